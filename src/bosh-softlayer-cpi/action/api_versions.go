@@ -0,0 +1,38 @@
+package action
+
+// MaxSupportedApiVersion is the highest CPI contract version advertised by `info`.
+const MaxSupportedApiVersion = 2
+
+// ApiVersions are the API versions negotiated for a single CPI request.
+type ApiVersions struct {
+	// CPI contract version used to shape action results
+	Contract int
+
+	// Agent API version of the stemcell the VM is (or will be) running
+	Stemcell int
+}
+
+// NewApiVersions returns the versions to use for a request. Missing versions
+// default to 1 and the contract version is capped to the highest supported one.
+func NewApiVersions(contract int, stemcell int) ApiVersions {
+	if contract < 1 {
+		contract = 1
+	}
+	if contract > MaxSupportedApiVersion {
+		contract = MaxSupportedApiVersion
+	}
+	if stemcell < 1 {
+		stemcell = 1
+	}
+
+	return ApiVersions{
+		Contract: contract,
+		Stemcell: stemcell,
+	}
+}
+
+// UseRegistry reports whether agent settings must be stored in the registry.
+// Stemcells with API v2 read their settings from the SoftLayer user data.
+func (v ApiVersions) UseRegistry() bool {
+	return v.Stemcell < 2
+}
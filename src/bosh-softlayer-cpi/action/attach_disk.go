@@ -1,6 +1,8 @@
 package action
 
 import (
+	"encoding/json"
+
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 
 	"bosh-softlayer-cpi/api"
@@ -14,17 +16,20 @@ type AttachDisk struct {
 	diskService    disk.Service
 	vmService      instance.Service
 	registryClient registry.Client
+	apiVersions    ApiVersions
 }
 
 func NewAttachDisk(
 	diskService disk.Service,
 	vmService instance.Service,
 	registryClient registry.Client,
+	apiVersions ApiVersions,
 ) AttachDisk {
 	return AttachDisk{
 		diskService:    diskService,
 		vmService:      vmService,
 		registryClient: registryClient,
+		apiVersions:    apiVersions,
 	}
 }
 
@@ -47,17 +52,29 @@ func (ad AttachDisk) Run(vmCID VMCID, diskCID DiskCID) (interface{}, error) {
 		return nil, bosherr.WrapErrorf(err, "Attaching disk '%s' to vm '%s", diskCID, vmCID)
 	}
 
-	// Read VM agent settings
-	agentSettings, err := ad.registryClient.Fetch(vmCID.String())
-	if err != nil {
-		return nil, bosherr.WrapErrorf(err, "Attaching disk '%s' to vm '%s'", diskCID, vmCID)
+	if ad.apiVersions.UseRegistry() {
+		// Read VM agent settings
+		agentSettings, err := ad.registryClient.Fetch(vmCID.String())
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Attaching disk '%s' to vm '%s'", diskCID, vmCID)
+		}
+
+		// Update VM agent settings
+		newAgentSettings, err := agentSettings.AttachPersistentDisk(diskCID.String(), persistentSetting)
+		if err = ad.registryClient.Update(vmCID.String(), newAgentSettings); err != nil {
+			return nil, bosherr.WrapErrorf(err, "Attaching disk '%s' to vm '%s'", diskCID, vmCID)
+		}
+	}
+
+	if ad.apiVersions.Contract < 2 {
+		return nil, nil
 	}
 
-	// Update VM agent settings
-	newAgentSettings, err := agentSettings.AttachPersistentDisk(diskCID.String(), persistentSetting)
-	if err = ad.registryClient.Update(vmCID.String(), newAgentSettings); err != nil {
-		return nil, bosherr.WrapErrorf(err, "Attaching disk '%s' to vm '%s'", diskCID, vmCID)
+	// Return the disk hint so that the director passes it to the agent
+	var diskHint registry.PersistentSettings
+	if err = json.Unmarshal(persistentSetting, &diskHint); err != nil {
+		return nil, bosherr.WrapErrorf(err, "Unmarshalling disk hint of disk '%s'", diskCID)
 	}
 
-	return nil, nil
+	return diskHint, nil
 }
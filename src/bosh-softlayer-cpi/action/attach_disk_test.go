@@ -32,7 +32,7 @@ var _ = Describe("AttachDisk", func() {
 		diskService = &diskfakes.FakeService{}
 		vmService = &instancefakes.FakeService{}
 		registryClient = &registryfakes.FakeClient{}
-		attachDisk = NewAttachDisk(diskService, vmService, registryClient)
+		attachDisk = NewAttachDisk(diskService, vmService, registryClient, NewApiVersions(1, 1))
 	})
 
 	Describe("Run", func() {
@@ -83,6 +83,25 @@ var _ = Describe("AttachDisk", func() {
 			Expect(registryClient.UpdateSettings).To(Equal(expectedAgentSettings))
 		})
 
+		It("returns the disk hint without using the registry with api version 2", func() {
+			attachDisk = NewAttachDisk(diskService, vmService, registryClient, NewApiVersions(2, 2))
+
+			diskHint, err := attachDisk.Run(vmCID, diskCID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskHint).To(Equal(expectedAgentSettings.Disks.Persistent["25667635"]))
+			Expect(registryClient.FetchCalled).To(BeFalse())
+			Expect(registryClient.UpdateCalled).To(BeFalse())
+		})
+
+		It("returns the disk hint and updates the registry with api version 2 and a stemcell api version 1", func() {
+			attachDisk = NewAttachDisk(diskService, vmService, registryClient, NewApiVersions(2, 1))
+
+			diskHint, err := attachDisk.Run(vmCID, diskCID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskHint).To(Equal(expectedAgentSettings.Disks.Persistent["25667635"]))
+			Expect(registryClient.UpdateSettings).To(Equal(expectedAgentSettings))
+		})
+
 		It("returns an error if diskService find call returns an error", func() {
 			diskService.FindReturns(
 				&datatypes.Network_Storage{},
@@ -0,0 +1,39 @@
+package action
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	instance "bosh-softlayer-cpi/softlayer/virtual_guest_service"
+)
+
+type VMResources struct {
+	Cpu               int `json:"cpu"`
+	Ram               int `json:"ram"`
+	EphemeralDiskSize int `json:"ephemeral_disk_size"`
+}
+
+type CalculateVMCloudProperties struct {
+	vmService instance.Service
+}
+
+func NewCalculateVMCloudProperties(
+	vmService instance.Service,
+) CalculateVMCloudProperties {
+	return CalculateVMCloudProperties{
+		vmService: vmService,
+	}
+}
+
+func (cv CalculateVMCloudProperties) Run(vmResources VMResources) (VMCloudProperties, error) {
+	resources, err := cv.vmService.CalculateResources(vmResources.Cpu, vmResources.Ram, vmResources.EphemeralDiskSize)
+	if err != nil {
+		return VMCloudProperties{}, bosherr.WrapErrorf(err, "Calculating VM cloud properties for '%+v'", vmResources)
+	}
+
+	return VMCloudProperties{
+		FlavorKeyName:     resources.FlavorKeyName,
+		Cpu:               resources.Cpu,
+		Memory:            resources.Memory,
+		EphemeralDiskSize: resources.EphemeralDiskSize,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package action_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "bosh-softlayer-cpi/action"
+
+	instance "bosh-softlayer-cpi/softlayer/virtual_guest_service"
+	instancefakes "bosh-softlayer-cpi/softlayer/virtual_guest_service/fakes"
+)
+
+var _ = Describe("CalculateVMCloudProperties", func() {
+	var (
+		err         error
+		vmResources VMResources
+
+		vmService                  *instancefakes.FakeService
+		calculateVMCloudProperties CalculateVMCloudProperties
+	)
+
+	BeforeEach(func() {
+		vmResources = VMResources{
+			Cpu:               2,
+			Ram:               4096,
+			EphemeralDiskSize: 30720,
+		}
+
+		vmService = &instancefakes.FakeService{}
+		calculateVMCloudProperties = NewCalculateVMCloudProperties(vmService)
+	})
+
+	Describe("Run", func() {
+		It("returns the flavor when one fits", func() {
+			vmService.CalculateResourcesReturns(
+				instance.Resources{
+					FlavorKeyName:     "B1_2X4X25",
+					EphemeralDiskSize: 100,
+				},
+				nil,
+			)
+
+			cloudProps, err := calculateVMCloudProperties.Run(vmResources)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cloudProps).To(Equal(VMCloudProperties{
+				FlavorKeyName:     "B1_2X4X25",
+				EphemeralDiskSize: 100,
+			}))
+
+			cpu, memory, ephemeralDiskSize := vmService.CalculateResourcesArgsForCall(0)
+			Expect(cpu).To(Equal(2))
+			Expect(memory).To(Equal(4096))
+			Expect(ephemeralDiskSize).To(Equal(30720))
+		})
+
+		It("returns cpu and memory when no flavor fits", func() {
+			vmService.CalculateResourcesReturns(
+				instance.Resources{
+					Cpu:    2,
+					Memory: 4096,
+				},
+				nil,
+			)
+
+			cloudProps, err := calculateVMCloudProperties.Run(vmResources)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cloudProps.FlavorKeyName).To(BeEmpty())
+			Expect(cloudProps.Cpu).To(Equal(2))
+			Expect(cloudProps.Memory).To(Equal(4096))
+		})
+
+		It("returns an error if vmService calculate resources call returns an error", func() {
+			vmService.CalculateResourcesReturns(
+				instance.Resources{},
+				errors.New("fake-vm-service-error"),
+			)
+
+			_, err = calculateVMCloudProperties.Run(vmResources)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-vm-service-error"))
+			Expect(vmService.CalculateResourcesCallCount()).To(Equal(1))
+		})
+	})
+})
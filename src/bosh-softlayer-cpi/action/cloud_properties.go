@@ -1,32 +1,217 @@
 package action
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	boslc "bosh-softlayer-cpi/softlayer/client"
+	"bosh-softlayer-cpi/softlayer/disk_service"
+	instance "bosh-softlayer-cpi/softlayer/virtual_guest_service"
 )
 
 type DiskCloudProperties struct {
-	DiskType      string `json:"type,omitempty"`
-	DataCenter    string `json:"datacenter,omitempty"`
-	Iops          int    `json:"iops,omitempty"`
-	SnapshotSpace int    `json:"snapshot_space,omitempty"`
+	DiskType      string  `json:"type,omitempty"`
+	DataCenter    string  `json:"datacenter,omitempty"`
+	Iops          int     `json:"iops,omitempty"`
+	Tier          float64 `json:"tier,omitempty"`
+	SnapshotSpace int     `json:"snapshot_space,omitempty"`
+	Encrypted     bool    `json:"encrypted,omitempty"`
+
+	// Nil leaves the snapshot schedules of existing disks alone, empty disables them
+	SnapshotSchedule []disk.SnapshotSchedule `json:"snapshot_schedule,omitempty"`
+
+	// How many snapshots snapshot_disk keeps, 0 for all
+	SnapshotRetention int `json:"snapshot_retention,omitempty"`
+
+	// A disk created from a snapshot duplicates the disk the snapshot was taken of
+	SnapshotId   int `json:"snapshot_id,omitempty"`
+	SourceDiskId int `json:"source_disk_id,omitempty"`
+
+	// New disks get a replicant in another datacenter
+	Replication *disk.Replication `json:"replication,omitempty"`
+}
+
+// Validate checks that the type, iops and tier of a disk are consistent.
+func (diskProps DiskCloudProperties) Validate() error {
+	diskType := strings.ToLower(diskProps.DiskType)
+	if diskType != "" && diskType != disk.PerformanceDiskType && diskType != disk.EnduranceDiskType {
+		return bosherr.Errorf("The property 'type' must be '%s' or '%s'", disk.PerformanceDiskType, disk.EnduranceDiskType)
+	}
+	if diskProps.Iops < 0 {
+		return bosherr.Error("The property 'iops' must be positive")
+	}
+	if diskProps.Iops != 0 && (diskProps.Tier != 0 || diskType == disk.EnduranceDiskType) {
+		return bosherr.Error("The property 'iops' is only supported by performance disks")
+	}
+	if diskProps.Iops%100 != 0 {
+		return bosherr.Error("The property 'iops' must be a multiple of 100")
+	}
+	// A duplicate keeps the tier of its source disk
+	if diskType == disk.EnduranceDiskType && diskProps.Tier == 0 && diskProps.SnapshotId == 0 {
+		return bosherr.Error("The property 'tier' is required by endurance disks")
+	}
+	if diskProps.Tier != 0 {
+		if diskType == disk.PerformanceDiskType {
+			return bosherr.Error("The property 'tier' is only supported by endurance disks")
+		}
+		if _, err := boslc.EnduranceTierLevel(diskProps.Tier); err != nil {
+			return err
+		}
+	}
+	if diskProps.SourceDiskId != 0 && diskProps.SnapshotId == 0 {
+		return bosherr.Error("The property 'source_disk_id' requires the 'snapshot_id' of the disk to create the disk from")
+	}
+	if diskProps.Encrypted && diskProps.SnapshotId != 0 {
+		return bosherr.Error("The property 'encrypted' is not supported with 'snapshot_id', disks created from a snapshot are encrypted like their source disk")
+	}
+	if diskProps.SnapshotRetention < 0 {
+		return bosherr.Error("The property 'snapshot_retention' must be positive")
+	}
+	if len(diskProps.SnapshotSchedule) > 0 && diskProps.SnapshotSpace == 0 {
+		return bosherr.Error("The property 'snapshot_schedule' requires 'snapshot_space'")
+	}
+	if err := validateSnapshotSchedules(diskProps.SnapshotSchedule); err != nil {
+		return err
+	}
+	if diskProps.Replication != nil {
+		return validateReplication(*diskProps.Replication, diskProps.SnapshotSchedule)
+	}
+	return nil
+}
+
+// IOPS ranges of performance disks from the minimum size in GB they apply to
+var performanceIopsRanges = []struct {
+	minSize int
+	minIops int
+	maxIops int
+}{
+	{20, 100, 1000},
+	{40, 100, 2000},
+	{80, 100, 4000},
+	{100, 100, 6000},
+	{500, 100, 10000},
+	{1000, 100, 20000},
+	{2000, 200, 40000},
+	{3000, 300, 48000},
+	{8000, 500, 48000},
+	{10000, 1000, 48000},
+}
+
+// Endurance disks of 10 IOPS per GB are limited to 4000 GB
+const maxTier10DiskSize = 4000
+
+// ValidateSize checks that a disk of size MB can be ordered with the iops or tier of the disk.
+func (diskProps DiskCloudProperties) ValidateSize(size int) error {
+	sizeGb := disk.SoftLayerDiskSize(size)
+
+	if diskProps.Tier == 10 && sizeGb > maxTier10DiskSize {
+		return bosherr.Errorf("Endurance disks of tier 10 can not be larger than %d GB, not '%d' GB", maxTier10DiskSize, sizeGb)
+	}
+
+	if diskProps.Iops == 0 {
+		return nil
+	}
+	minIops, maxIops := 0, 0
+	for _, iopsRange := range performanceIopsRanges {
+		if sizeGb >= iopsRange.minSize {
+			minIops, maxIops = iopsRange.minIops, iopsRange.maxIops
+		}
+	}
+	if diskProps.Iops < minIops || diskProps.Iops > maxIops {
+		return bosherr.Errorf("The property 'iops' of a %d GB performance disk must be between %d and %d", sizeGb, minIops, maxIops)
+	}
+	return nil
+}
+
+var snapshotDaysOfWeek = []string{"SUNDAY", "MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY"}
+
+func validateSnapshotSchedules(schedules []disk.SnapshotSchedule) error {
+	types := map[string]bool{}
+	for _, schedule := range schedules {
+		scheduleType := strings.ToUpper(schedule.Type)
+		if scheduleType != "HOURLY" && scheduleType != "DAILY" && scheduleType != "WEEKLY" {
+			return bosherr.Errorf("The 'type' of snapshot schedule '%s' must be 'HOURLY', 'DAILY' or 'WEEKLY'", schedule.Type)
+		}
+		if types[scheduleType] {
+			return bosherr.Errorf("Only one %s snapshot schedule is supported", strings.ToLower(scheduleType))
+		}
+		types[scheduleType] = true
+
+		if schedule.Retention < 1 {
+			return bosherr.Errorf("The 'retention' of snapshot schedule '%s' must be at least 1", schedule.Type)
+		}
+		if schedule.Minute < 0 || schedule.Minute > 59 {
+			return bosherr.Errorf("The 'minute' of snapshot schedule '%s' must be between 0 and 59", schedule.Type)
+		}
+		if schedule.Hour < 0 || schedule.Hour > 23 {
+			return bosherr.Errorf("The 'hour' of snapshot schedule '%s' must be between 0 and 23", schedule.Type)
+		}
+		if schedule.DayOfWeek != "" && !containsString(snapshotDaysOfWeek, strings.ToUpper(schedule.DayOfWeek)) {
+			return bosherr.Errorf("The 'day_of_week' of snapshot schedule '%s' must be a day like 'SUNDAY'", schedule.Type)
+		}
+	}
+	return nil
+}
+
+// validateReplication checks that the disk has the snapshot schedule its replicant is synchronized at.
+func validateReplication(replication disk.Replication, schedules []disk.SnapshotSchedule) error {
+	if replication.Datacenter == "" {
+		return bosherr.Error("The property 'datacenter' of replication must be set")
+	}
+	for _, schedule := range schedules {
+		if strings.ToUpper(schedule.Type) == strings.ToUpper(replication.Schedule) {
+			return nil
+		}
+	}
+	return bosherr.Errorf("The 'schedule' of replication must be the type of a 'snapshot_schedule' of the disk, not '%s'", replication.Schedule)
 }
 
 type Environment map[string]interface{}
 
 type NetworkCloudProperties struct {
-	SubnetIds           []int `json:"subnet_ids,omitempty"`
-	VlanIds             []int `json:"vlan_ids,omitempty"`
-	SourcePolicyRouting bool  `json:"source_policy_routing,omitempty"`
+	SubnetIds           []int          `json:"subnet_ids,omitempty"`
+	VlanIds             []int          `json:"vlan_ids,omitempty"`
+	SourcePolicyRouting bool           `json:"source_policy_routing,omitempty"`
+	SecurityGroups      SecurityGroups `json:"security_groups,omitempty"`
+}
+
+// SecurityGroups refer to SoftLayer security groups by ID or by name.
+type SecurityGroups []string
+
+func (groups *SecurityGroups) UnmarshalJSON(data []byte) error {
+	var refs []interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&refs); err != nil {
+		return err
+	}
+
+	*groups = SecurityGroups{}
+	for _, ref := range refs {
+		switch ref := ref.(type) {
+		case string:
+			*groups = append(*groups, ref)
+		case json.Number:
+			*groups = append(*groups, ref.String())
+		default:
+			return bosherr.Errorf("Security group '%v' must be an ID or a name", ref)
+		}
+	}
+
+	return nil
 }
 
 type SnapshotMetadata struct {
 	Deployment string      `json:"deployment,omitempty"`
 	Job        string      `json:"job,omitempty"`
 	Index      json.Number `json:"index,omitempty"`
+	AgentID    string      `json:"agent_id,omitempty"`
 }
 
 type StemcellCloudProperties struct {
@@ -51,9 +236,11 @@ type VMCloudProperties struct {
 	Hostname          string `json:"hostname,omitempty"`
 	Domain            string `json:"domain,omitempty"`
 	FlavorKeyName     string `json:"flavor_key_name,omitempty"`
+	Gpu               string `json:"gpu,omitempty"`
+	RootDiskSize      int    `json:"root_disk_size,omitempty"`
 	Cpu               int    `json:"cpu,omitempty"`
 	Memory            int    `json:"memory,omitempty"`
-	Datacenter        string `json:"datacenter"`
+	Datacenter        string `json:"datacenter,omitempty"`
 	EphemeralDiskSize int    `json:"ephemeral_disk_size,omitempty"`
 	SshKey            int    `json:"ssh_key,omitempty"`
 
@@ -62,11 +249,35 @@ type VMCloudProperties struct {
 	DedicatedAccountHostOnlyFlag bool `json:"dedicated_account_host_only_flag,omitempty"`
 	DedicatedHostId              int  `json:"dedicated_host_id,omitempty"`
 
+	DedicatedHostIds       []int  `json:"dedicated_host_ids,omitempty"`
+	DedicatedHostGroup     string `json:"dedicated_host_group,omitempty"`
+	DedicatedHostPlacement string `json:"dedicated_host_placement,omitempty"`
+
+	PlacementGroup string `json:"placement_group,omitempty"`
+
+	Transient          bool `json:"transient,omitempty"`
+	ReservedCapacityId int  `json:"reserved_capacity_id,omitempty"`
+
 	DeployedByBoshCLI bool `json:"deployed_by_boshcli,omitempty"`
 
 	MaxNetworkSpeed int `json:"max_network_speed,omitempty"`
+
+	LoadBalancers []LoadBalancer `json:"load_balancers,omitempty"`
+
+	SecurityGroups SecurityGroups `json:"security_groups,omitempty"`
+}
+
+// LoadBalancer is a SoftLayer/IBM Cloud local load balancer the VM joins as member.
+type LoadBalancer struct {
+	UUID   string `json:"uuid"`
+	Weight int    `json:"weight,omitempty"`
+
+	// Port is rejected, a member serves all pools of the load balancer on their backend ports
+	Port int `json:"port,omitempty"`
 }
 
+const defaultLoadBalancerWeight = 50
+
 func (vmProps *VMCloudProperties) Validate() error {
 	if vmProps.HostnamePrefix == "" {
 		return bosherr.Error("The property 'hostname_prefix' must be set to create an instance")
@@ -78,7 +289,11 @@ func (vmProps *VMCloudProperties) Validate() error {
 		return bosherr.Error("The property 'datacenter' must be set to create an instance")
 	}
 
-	if vmProps.FlavorKeyName != "" && (vmProps.Memory != 0 || vmProps.Cpu != 0) {
+	if vmProps.Gpu != "" {
+		if vmProps.FlavorKeyName != "" || vmProps.Memory != 0 || vmProps.Cpu != 0 {
+			return bosherr.Error("The property 'gpu' can not be set with 'flavor_key_name/memory/cpu'")
+		}
+	} else if vmProps.FlavorKeyName != "" && (vmProps.Memory != 0 || vmProps.Cpu != 0) {
 		return bosherr.Error("The property 'flavor_key_name' can not be set with 'memory/cpu'")
 	} else {
 		if vmProps.Memory == 0 {
@@ -89,10 +304,70 @@ func (vmProps *VMCloudProperties) Validate() error {
 		}
 	}
 
+	// Flavors come with their root disk
+	if vmProps.RootDiskSize != 0 && (vmProps.FlavorKeyName != "" || vmProps.Gpu != "") {
+		return bosherr.Error("The property 'root_disk_size' can not be set with 'flavor_key_name/gpu'")
+	}
+	if vmProps.RootDiskSize < 0 {
+		return bosherr.Error("The property 'root_disk_size' must be a positive size in GB")
+	}
+
+	if len(vmProps.DedicatedHostIds) > 0 || vmProps.DedicatedHostGroup != "" {
+		if vmProps.DedicatedHostId != 0 || vmProps.DedicatedAccountHostOnlyFlag {
+			return bosherr.Error("The properties 'dedicated_host_ids/dedicated_host_group' can not be set with 'dedicated_host_id/dedicated_account_host_only_flag'")
+		}
+		if len(vmProps.DedicatedHostIds) > 0 && vmProps.DedicatedHostGroup != "" {
+			return bosherr.Error("The property 'dedicated_host_ids' can not be set with 'dedicated_host_group'")
+		}
+	}
+	if vmProps.PlacementGroup != "" && (vmProps.DedicatedHostId != 0 || len(vmProps.DedicatedHostIds) > 0 || vmProps.DedicatedHostGroup != "") {
+		return bosherr.Error("The property 'placement_group' can not be set with dedicated hosts")
+	}
+	if vmProps.Transient || vmProps.ReservedCapacityId != 0 {
+		property := "transient"
+		if vmProps.ReservedCapacityId != 0 {
+			property = "reserved_capacity_id"
+		}
+		if vmProps.Transient && vmProps.ReservedCapacityId != 0 {
+			return bosherr.Error("The property 'transient' can not be set with 'reserved_capacity_id'")
+		}
+		if vmProps.DedicatedHostId != 0 || len(vmProps.DedicatedHostIds) > 0 || vmProps.DedicatedHostGroup != "" || vmProps.DedicatedAccountHostOnlyFlag {
+			return bosherr.Errorf("The property '%s' can not be set with dedicated hosts", property)
+		}
+		if vmProps.LocalDiskFlag {
+			return bosherr.Errorf("The property '%s' can not be set with 'local_disk_flag'", property)
+		}
+		if vmProps.ReservedCapacityId != 0 && vmProps.FlavorKeyName == "" {
+			return bosherr.Error("The property 'reserved_capacity_id' requires the 'flavor_key_name' of the reserved capacity group")
+		}
+		// Transient and reserved capacity guests are only billed hourly
+		vmProps.HourlyBillingFlag = true
+	}
+	switch vmProps.DedicatedHostPlacement {
+	case "", instance.DedicatedHostStrategySpread, instance.DedicatedHostStrategyPack:
+	default:
+		return bosherr.Errorf("The property 'dedicated_host_placement' must be '%s' or '%s'", instance.DedicatedHostStrategySpread, instance.DedicatedHostStrategyPack)
+	}
+
 	if vmProps.MaxNetworkSpeed == 0 {
 		vmProps.MaxNetworkSpeed = 1000
 	}
 
+	for i, loadBalancer := range vmProps.LoadBalancers {
+		if loadBalancer.UUID == "" {
+			return bosherr.Error("The property 'uuid' must be set for every entry of 'load_balancers'")
+		}
+		if loadBalancer.Port != 0 {
+			return bosherr.Errorf("The property 'port' of load balancer '%s' is not supported, the VM serves all pools of the load balancer on their backend ports", loadBalancer.UUID)
+		}
+		if loadBalancer.Weight < 0 || loadBalancer.Weight > 100 {
+			return bosherr.Errorf("The 'weight' of load balancer '%s' must be between 0 and 100, 0 uses the default of %d", loadBalancer.UUID, defaultLoadBalancerWeight)
+		}
+		if loadBalancer.Weight == 0 {
+			vmProps.LoadBalancers[i].Weight = defaultLoadBalancerWeight
+		}
+	}
+
 	return nil
 }
 
@@ -111,6 +386,14 @@ func (vmProps *VMCloudProperties) AsInstanceProperties() *VMCloudProperties {
 	return vmProps
 }
 
+// flavor returns the flavor the VM is ordered with, its GPU flavor if it has one.
+func (vmProps VMCloudProperties) flavor() string {
+	if vmProps.Gpu != "" {
+		return vmProps.Gpu
+	}
+	return vmProps.FlavorKeyName
+}
+
 func (vmProps VMCloudProperties) updateHostNameInCloudProps(cloudProps *VMCloudProperties, timeStampPostfix string) string {
 	if len(timeStampPostfix) == 0 {
 		return cloudProps.HostnamePrefix
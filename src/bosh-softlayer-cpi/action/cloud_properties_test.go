@@ -1,7 +1,10 @@
 package action_test
 
 import (
+	"encoding/json"
+
 	. "bosh-softlayer-cpi/action"
+	"bosh-softlayer-cpi/softlayer/disk_service"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -77,5 +80,309 @@ var _ = Describe("ConcreteFactoryOptions", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("The property 'datacenter' must be set to create an instance"))
 		})
+
+		Context("when dedicated host placement is set", func() {
+			It("accepts a list of dedicated hosts with a strategy", func() {
+				cloudProps.DedicatedHostIds = []int{11111, 22222}
+				cloudProps.DedicatedHostPlacement = "pack"
+
+				err := cloudProps.Validate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns error if a single dedicated host is set too", func() {
+				cloudProps.DedicatedHostIds = []int{11111, 22222}
+				cloudProps.DedicatedHostId = 11111
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("can not be set with 'dedicated_host_id/dedicated_account_host_only_flag'"))
+			})
+
+			It("returns error if both dedicated host ids and group are set", func() {
+				cloudProps.DedicatedHostIds = []int{11111}
+				cloudProps.DedicatedHostGroup = "cf-dh"
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'dedicated_host_ids' can not be set with 'dedicated_host_group'"))
+			})
+
+			It("returns error if the strategy is unknown", func() {
+				cloudProps.DedicatedHostGroup = "cf-dh"
+				cloudProps.DedicatedHostPlacement = "random"
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'dedicated_host_placement' must be 'spread' or 'pack'"))
+			})
+
+			It("returns error if placement group is set", func() {
+				cloudProps.DedicatedHostGroup = "cf-dh"
+				cloudProps.PlacementGroup = "auto"
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'placement_group' can not be set with dedicated hosts"))
+			})
+		})
+
+		Context("when root disk size or gpu is set", func() {
+			It("does not default cpu and memory of a gpu flavor", func() {
+				cloudProps.Cpu = 0
+				cloudProps.Memory = 0
+				cloudProps.Gpu = "AC1_8X60X25"
+
+				err := cloudProps.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cloudProps.Cpu).To(Equal(0))
+				Expect(cloudProps.Memory).To(Equal(0))
+			})
+
+			It("returns error if gpu is set with cpu and memory", func() {
+				cloudProps.Gpu = "AC1_8X60X25"
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'gpu' can not be set with 'flavor_key_name/memory/cpu'"))
+			})
+
+			It("returns error if root disk size is set with a flavor", func() {
+				cloudProps.Cpu = 0
+				cloudProps.Memory = 0
+				cloudProps.FlavorKeyName = "B1_2X8X25"
+				cloudProps.RootDiskSize = 100
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'root_disk_size' can not be set with 'flavor_key_name/gpu'"))
+			})
+		})
+
+		Context("when transient or reserved capacity is set", func() {
+			It("orders transient guests hourly", func() {
+				cloudProps.Transient = true
+
+				err := cloudProps.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cloudProps.HourlyBillingFlag).To(BeTrue())
+			})
+
+			It("returns error if both transient and reserved capacity are set", func() {
+				cloudProps.Transient = true
+				cloudProps.ReservedCapacityId = 3456
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'transient' can not be set with 'reserved_capacity_id'"))
+			})
+
+			It("returns error if a dedicated host is set", func() {
+				cloudProps.Transient = true
+				cloudProps.DedicatedHostId = 11111
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'transient' can not be set with dedicated hosts"))
+			})
+
+			It("returns error if local disk is set", func() {
+				cloudProps.ReservedCapacityId = 3456
+				cloudProps.FlavorKeyName = "B1_2X8X25"
+				cloudProps.Cpu = 0
+				cloudProps.Memory = 0
+				cloudProps.LocalDiskFlag = true
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'reserved_capacity_id' can not be set with 'local_disk_flag'"))
+			})
+
+			It("returns error if reserved capacity is set without flavor", func() {
+				cloudProps.ReservedCapacityId = 3456
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("requires the 'flavor_key_name'"))
+			})
+		})
+
+		Context("when load balancers are set", func() {
+			It("defaults the weight of load balancers", func() {
+				cloudProps.LoadBalancers = []LoadBalancer{{UUID: "fake-lb-uuid"}}
+
+				err := cloudProps.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cloudProps.LoadBalancers[0].Weight).To(Equal(50))
+			})
+
+			It("returns error if the uuid of a load balancer is not set", func() {
+				cloudProps.LoadBalancers = []LoadBalancer{{Weight: 20}}
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'uuid' must be set for every entry of 'load_balancers'"))
+			})
+
+			It("returns error if the port of a load balancer is set", func() {
+				cloudProps.LoadBalancers = []LoadBalancer{{UUID: "fake-lb-uuid", Port: 8080}}
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'port' of load balancer 'fake-lb-uuid' is not supported"))
+			})
+
+			It("returns error if the weight of a load balancer is out of range", func() {
+				cloudProps.LoadBalancers = []LoadBalancer{{UUID: "fake-lb-uuid", Weight: 101}}
+
+				err := cloudProps.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The 'weight' of load balancer 'fake-lb-uuid' must be between 0 and 100, 0 uses the default of 50"))
+			})
+		})
+	})
+
+	Describe("DiskCloudProperties Validate", func() {
+		It("accepts performance and endurance disks", func() {
+			Expect(DiskCloudProperties{Iops: 1000}.Validate()).To(Succeed())
+			Expect(DiskCloudProperties{DiskType: "Performance", Iops: 1000}.Validate()).To(Succeed())
+			Expect(DiskCloudProperties{DiskType: "endurance", Tier: 0.25}.Validate()).To(Succeed())
+		})
+
+		It("returns error if the type is unknown", func() {
+			err := DiskCloudProperties{DiskType: "fake-type"}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The property 'type' must be 'performance' or 'endurance'"))
+		})
+
+		It("returns error if iops are set with a tier", func() {
+			err := DiskCloudProperties{Iops: 1000, Tier: 2}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The property 'iops' is only supported by performance disks"))
+		})
+
+		It("returns error if an endurance disk has no tier", func() {
+			err := DiskCloudProperties{DiskType: "endurance"}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The property 'tier' is required by endurance disks"))
+		})
+
+		It("accepts an endurance disk without tier duplicated from a snapshot", func() {
+			Expect(DiskCloudProperties{DiskType: "endurance", SnapshotId: 32345678}.Validate()).To(Succeed())
+		})
+
+		It("returns error if a tier is set on a performance disk", func() {
+			err := DiskCloudProperties{DiskType: "performance", Tier: 2}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The property 'tier' is only supported by endurance disks"))
+		})
+
+		It("returns error if the iops are not a multiple of 100", func() {
+			err := DiskCloudProperties{Iops: 1050}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The property 'iops' must be a multiple of 100"))
+		})
+
+		It("accepts iops in the range of the size", func() {
+			Expect(DiskCloudProperties{Iops: 6000}.ValidateSize(250 * 1024)).To(Succeed())
+			Expect(DiskCloudProperties{Iops: 48000}.ValidateSize(4000 * 1024)).To(Succeed())
+			Expect(DiskCloudProperties{DiskType: "endurance", Tier: 10}.ValidateSize(4000 * 1024)).To(Succeed())
+		})
+
+		It("returns error if the iops are out of the range of the size", func() {
+			err := DiskCloudProperties{Iops: 900}.ValidateSize(12000 * 1024)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The property 'iops' of a 12000 GB performance disk must be between 1000 and 48000"))
+
+			err = DiskCloudProperties{Iops: 2000}.ValidateSize(20 * 1024)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The property 'iops' of a 20 GB performance disk must be between 100 and 1000"))
+		})
+
+		It("returns error if an endurance disk of tier 10 is larger than 4000 GB", func() {
+			err := DiskCloudProperties{Tier: 10}.ValidateSize(4001 * 1024)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Endurance disks of tier 10 can not be larger than 4000 GB"))
+		})
+
+		It("returns error if the snapshot retention is negative", func() {
+			err := DiskCloudProperties{SnapshotRetention: -1}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The property 'snapshot_retention' must be positive"))
+		})
+
+		It("returns error if the replication has no datacenter", func() {
+			err := DiskCloudProperties{
+				SnapshotSpace:    20,
+				SnapshotSchedule: []disk.SnapshotSchedule{{Type: "DAILY", Retention: 7}},
+				Replication:      &disk.Replication{Schedule: "DAILY"},
+			}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The property 'datacenter' of replication must be set"))
+		})
+
+		It("accepts a replication at a snapshot schedule of the disk", func() {
+			err := DiskCloudProperties{
+				SnapshotSpace:    20,
+				SnapshotSchedule: []disk.SnapshotSchedule{{Type: "DAILY", Retention: 7}},
+				Replication:      &disk.Replication{Datacenter: "wdc07", Schedule: "daily"},
+			}.Validate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns error if the replication schedule is not a snapshot schedule of the disk", func() {
+			err := DiskCloudProperties{Replication: &disk.Replication{Datacenter: "wdc07", Schedule: "DAILY"}}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The 'schedule' of replication must be the type of a 'snapshot_schedule' of the disk, not 'DAILY'"))
+		})
+
+		It("returns error if snapshot schedules are set without snapshot space", func() {
+			err := DiskCloudProperties{SnapshotSchedule: []disk.SnapshotSchedule{{Type: "HOURLY", Retention: 24}}}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The property 'snapshot_schedule' requires 'snapshot_space'"))
+		})
+
+		It("returns error if a snapshot schedule type is set twice", func() {
+			err := DiskCloudProperties{
+				SnapshotSpace: 20,
+				SnapshotSchedule: []disk.SnapshotSchedule{
+					{Type: "hourly", Retention: 24},
+					{Type: "HOURLY", Retention: 12},
+				},
+			}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Only one hourly snapshot schedule is supported"))
+		})
+
+		It("returns error if the day of the week of a snapshot schedule is unknown", func() {
+			err := DiskCloudProperties{
+				SnapshotSpace:    20,
+				SnapshotSchedule: []disk.SnapshotSchedule{{Type: "WEEKLY", Retention: 4, Hour: 3, DayOfWeek: "SUN"}},
+			}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The 'day_of_week' of snapshot schedule 'WEEKLY' must be a day like 'SUNDAY'"))
+		})
+
+		It("returns error if the tier is not an endurance tier", func() {
+			err := DiskCloudProperties{DiskType: "endurance", Tier: 3}.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Invalid endurance tier '3'"))
+		})
+	})
+
+	Describe("SecurityGroups", func() {
+		It("accepts security group IDs and names", func() {
+			var props NetworkCloudProperties
+			err := json.Unmarshal([]byte(`{"security_groups": [21345, "allow_ssh"]}`), &props)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(props.SecurityGroups).To(Equal(SecurityGroups{"21345", "allow_ssh"}))
+		})
+
+		It("returns error if a security group is neither an ID nor a name", func() {
+			var props VMCloudProperties
+			err := json.Unmarshal([]byte(`{"security_groups": [{"id": 21345}]}`), &props)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be an ID or a name"))
+		})
 	})
 })
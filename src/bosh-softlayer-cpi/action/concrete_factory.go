@@ -1,10 +1,14 @@
 package action
 
 import (
+	"time"
+
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
 	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
 
 	"bosh-softlayer-cpi/config"
+	"bosh-softlayer-cpi/journal"
 	"bosh-softlayer-cpi/softlayer/client"
 	"bosh-softlayer-cpi/softlayer/disk_service"
 	"bosh-softlayer-cpi/softlayer/snapshot_service"
@@ -17,6 +21,9 @@ import (
 
 type concreteFactory struct {
 	availableActions map[string]Action
+
+	// Actions whose behaviour depends on the API versions of the request
+	versionedActions map[string]func(ApiVersions) Action
 }
 
 func NewConcreteFactory(
@@ -48,13 +55,19 @@ func NewConcreteFactory(
 		softlayerClient,
 		uuidGen,
 		logger,
-	)
+	).WithLoadBalancerDrainPeriod(time.Duration(cfg.Cloud.Properties.SoftLayer.LoadBalancerDrainSeconds) * time.Second)
 
 	snapshotService := snapshot.NewSoftlayerSnapshotService(
 		softlayerClient,
 		logger,
 	)
 
+	createVMJournal := journal.NewFileJournal(
+		cfg.Cloud.Properties.Journal.Dir,
+		boshsys.NewOsFileSystem(logger.GetBoshLogger()),
+		logger,
+	)
+
 	return concreteFactory{
 		availableActions: map[string]Action{
 			// Stemcell management
@@ -62,27 +75,18 @@ func NewConcreteFactory(
 			"delete_stemcell": NewDeleteStemcell(stemcellService),
 
 			// VM management
-			"create_vm": NewCreateVM(
-				stemcellService,
-				vmService,
-				registryClient,
-				cfg.Cloud.Properties.Registry,
-				cfg.Cloud.Properties.Agent,
-				cfg.Cloud.Properties.SoftLayer,
-				localDNSConfigFile,
-			),
-			"delete_vm":          NewDeleteVM(vmService, registryClient, cfg.Cloud.Properties.SoftLayer),
-			"has_vm":             NewHasVM(vmService),
-			"reboot_vm":          NewRebootVM(vmService),
-			"set_vm_metadata":    NewSetVMMetadata(vmService),
-			"configure_networks": NewConfigureNetworks(vmService, registryClient),
+			"has_vm":                        NewHasVM(vmService),
+			"reboot_vm":                     NewRebootVM(vmService),
+			"set_vm_metadata":               NewSetVMMetadata(vmService),
+			"configure_networks":            NewConfigureNetworks(vmService),
+			"calculate_vm_cloud_properties": NewCalculateVMCloudProperties(vmService),
 
 			// Disk management
 			"has_disk":          NewHasDisk(diskService),
 			"create_disk":       NewCreateDisk(diskService, vmService),
 			"delete_disk":       NewDeleteDisk(diskService),
-			"attach_disk":       NewAttachDisk(diskService, vmService, registryClient),
-			"detach_disk":       NewDetachDisk(vmService, registryClient),
+			"resize_disk":       NewResizeDisk(diskService),
+			"update_disk":       NewUpdateDisk(diskService),
 			"get_disks":         NewGetDisks(vmService),
 			"set_disk_metadata": NewSetDiskMetadata(diskService),
 
@@ -96,12 +100,46 @@ func NewConcreteFactory(
 
 			// Not implemented (others):
 			//   current_vm_id
-			//   calculate_vm_cloud_properties
+		},
+		versionedActions: map[string]func(ApiVersions) Action{
+			// VM management
+			"create_vm": func(apiVersions ApiVersions) Action {
+				createVM := NewCreateVM(
+					stemcellService,
+					vmService,
+					registryClient,
+					cfg.Cloud.Properties.Registry,
+					cfg.Cloud.Properties.Agent,
+					cfg.Cloud.Properties.SoftLayer,
+					localDNSConfigFile,
+					apiVersions,
+					createVMJournal,
+				)
+				if apiVersions.Contract >= 2 {
+					return NewCreateVMV2(createVM)
+				}
+				return createVM
+			},
+			"delete_vm": func(apiVersions ApiVersions) Action {
+				return NewDeleteVM(vmService, registryClient, cfg.Cloud.Properties.SoftLayer, apiVersions)
+			},
+
+			// Disk management
+			"attach_disk": func(apiVersions ApiVersions) Action {
+				return NewAttachDisk(diskService, vmService, registryClient, apiVersions)
+			},
+			"detach_disk": func(apiVersions ApiVersions) Action {
+				return NewDetachDisk(vmService, registryClient, apiVersions)
+			},
 		},
 	}
 }
 
-func (f concreteFactory) Create(method string) (Action, error) {
+func (f concreteFactory) Create(method string, apiVersions ApiVersions) (Action, error) {
+	if newAction, found := f.versionedActions[method]; found {
+		return newAction(apiVersions), nil
+	}
+
 	action, found := f.availableActions[method]
 	if !found {
 		return nil, bosherr.Errorf("Could not create action with method %s", method)
@@ -2,6 +2,7 @@ package action_test
 
 import (
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
 	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -9,6 +10,7 @@ import (
 	. "bosh-softlayer-cpi/action"
 
 	"bosh-softlayer-cpi/config"
+	"bosh-softlayer-cpi/journal"
 	cpiLog "bosh-softlayer-cpi/logger"
 	"bosh-softlayer-cpi/registry"
 	bosl "bosh-softlayer-cpi/softlayer/client"
@@ -26,7 +28,8 @@ var _ = Describe("ConcreteFactory", func() {
 
 		cfg = config.Config{}
 
-		factory Factory
+		factory     Factory
+		apiVersions ApiVersions
 	)
 
 	var (
@@ -41,6 +44,7 @@ var _ = Describe("ConcreteFactory", func() {
 
 	BeforeEach(func() {
 		uuidGen = &fakeuuid.FakeGenerator{}
+		apiVersions = NewApiVersions(1, 1)
 		logger = cpiLog.NewLogger(boshlog.LevelNone, "")
 		cfg = config.Config{
 			Cloud: config.Cloud{
@@ -96,13 +100,13 @@ var _ = Describe("ConcreteFactory", func() {
 	})
 
 	It("returns error if action cannot be created", func() {
-		action, err := factory.Create("fake-unknown-action")
+		action, err := factory.Create("fake-unknown-action", apiVersions)
 		Expect(err).To(HaveOccurred())
 		Expect(action).To(BeNil())
 	})
 
 	It("create_disk", func() {
-		action, err := factory.Create("create_disk")
+		action, err := factory.Create("create_disk", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewCreateDisk(
 			diskService,
@@ -111,37 +115,49 @@ var _ = Describe("ConcreteFactory", func() {
 	})
 
 	It("delete_disk", func() {
-		action, err := factory.Create("delete_disk")
+		action, err := factory.Create("delete_disk", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewDeleteDisk(diskService)))
 	})
 
+	It("resize_disk", func() {
+		action, err := factory.Create("resize_disk", apiVersions)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(action).To(Equal(NewResizeDisk(diskService)))
+	})
+
+	It("update_disk", func() {
+		action, err := factory.Create("update_disk", apiVersions)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(action).To(Equal(NewUpdateDisk(diskService)))
+	})
+
 	It("attach_disk", func() {
-		action, err := factory.Create("attach_disk")
+		action, err := factory.Create("attach_disk", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
-		Expect(action).To(Equal(NewAttachDisk(diskService, vmService, registryClient)))
+		Expect(action).To(Equal(NewAttachDisk(diskService, vmService, registryClient, apiVersions)))
 	})
 
 	It("detach_disk", func() {
-		action, err := factory.Create("detach_disk")
+		action, err := factory.Create("detach_disk", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
-		Expect(action).To(Equal(NewDetachDisk(vmService, registryClient)))
+		Expect(action).To(Equal(NewDetachDisk(vmService, registryClient, apiVersions)))
 	})
 
 	It("create_stemcell", func() {
-		action, err := factory.Create("create_stemcell")
+		action, err := factory.Create("create_stemcell", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewCreateStemcell(imageService)))
 	})
 
 	It("delete_stemcell", func() {
-		action, err := factory.Create("delete_stemcell")
+		action, err := factory.Create("delete_stemcell", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewDeleteStemcell(imageService)))
 	})
 
 	It("create_vm", func() {
-		action, err := factory.Create("create_vm")
+		action, err := factory.Create("create_vm", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewCreateVM(
 			imageService,
@@ -151,71 +167,96 @@ var _ = Describe("ConcreteFactory", func() {
 			agentOptions,
 			softlayerOptions,
 			"/etc/hosts",
+			apiVersions,
+			journal.NewFileJournal("", boshsys.NewOsFileSystem(logger.GetBoshLogger()), logger),
 		)))
 	})
 
+	It("create_vm with api version 2", func() {
+		apiVersions = NewApiVersions(2, 2)
+		action, err := factory.Create("create_vm", apiVersions)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(action).To(Equal(NewCreateVMV2(NewCreateVM(
+			imageService,
+			vmService,
+			registryClient,
+			registryOptions,
+			agentOptions,
+			softlayerOptions,
+			"/etc/hosts",
+			apiVersions,
+			journal.NewFileJournal("", boshsys.NewOsFileSystem(logger.GetBoshLogger()), logger),
+		))))
+	})
+
 	It("configure_networks", func() {
-		action, err := factory.Create("configure_networks")
+		action, err := factory.Create("configure_networks", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
-		Expect(action).To(Equal(NewConfigureNetworks(vmService, registryClient)))
+		Expect(action).To(Equal(NewConfigureNetworks(vmService)))
 	})
 
 	It("delete_vm", func() {
-		action, err := factory.Create("delete_vm")
+		action, err := factory.Create("delete_vm", apiVersions)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(action).To(Equal(NewDeleteVM(vmService, registryClient, softlayerOptions, apiVersions)))
+	})
+
+	It("calculate_vm_cloud_properties", func() {
+		action, err := factory.Create("calculate_vm_cloud_properties", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
-		Expect(action).To(Equal(NewDeleteVM(vmService, registryClient, softlayerOptions)))
+		Expect(action).To(Equal(NewCalculateVMCloudProperties(vmService)))
 	})
 
 	It("reboot_vm", func() {
-		action, err := factory.Create("reboot_vm")
+		action, err := factory.Create("reboot_vm", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewRebootVM(vmService)))
 	})
 
 	It("set_vm_metadata", func() {
-		action, err := factory.Create("set_vm_metadata")
+		action, err := factory.Create("set_vm_metadata", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewSetVMMetadata(vmService)))
 	})
 
 	It("has_vm", func() {
-		action, err := factory.Create("has_vm")
+		action, err := factory.Create("has_vm", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewHasVM(vmService)))
 	})
 
 	It("get_disks", func() {
-		action, err := factory.Create("get_disks")
+		action, err := factory.Create("get_disks", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewGetDisks(vmService)))
 	})
 
 	It("set_disk_metadata", func() {
-		action, err := factory.Create("set_disk_metadata")
+		action, err := factory.Create("set_disk_metadata", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewSetDiskMetadata(diskService)))
 	})
 
 	It("info", func() {
-		action, err := factory.Create("info")
+		action, err := factory.Create("info", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewInfo()))
 	})
 
 	It("ping", func() {
-		action, err := factory.Create("ping")
+		action, err := factory.Create("ping", apiVersions)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(action).To(Equal(NewPing()))
 	})
 
 	It("when action is current_vm_id returns an error because this CPI does not implement the method", func() {
-		action, err := factory.Create("current_vm_id")
+		action, err := factory.Create("current_vm_id", apiVersions)
 		Expect(err).To(HaveOccurred())
 		Expect(action).To(BeNil())
 	})
 
 	It("when action is wrong returns an error because it is not an official CPI method", func() {
-		action, err := factory.Create("wrong")
+		action, err := factory.Create("wrong", apiVersions)
 		Expect(err).To(HaveOccurred())
 		Expect(action).To(BeNil())
 	})
@@ -1,27 +1,52 @@
 package action
 
 import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
 	"bosh-softlayer-cpi/api"
 	instance "bosh-softlayer-cpi/softlayer/virtual_guest_service"
-
-	"bosh-softlayer-cpi/registry"
 )
 
 type ConfigureNetworks struct {
-	vmService      instance.Service
-	registryClient registry.Client
+	vmService instance.Service
 }
 
 func NewConfigureNetworks(
 	vmService instance.Service,
-	registryClient registry.Client,
 ) ConfigureNetworks {
 	return ConfigureNetworks{
-		vmService:      vmService,
-		registryClient: registryClient,
+		vmService: vmService,
 	}
 }
 
+// Run reconciles the security groups of the network components of the VM with those of the networks
+// and of the VM cloud properties recorded on the VM by create_vm: groups bound but configured neither
+// way are detached. Any other change needs the director to recreate the VM, as does a VM without a
+// record of its networks.
 func (rv ConfigureNetworks) Run(vmCID VMCID, networks Networks) (interface{}, error) {
-	return nil, api.NotSupportedError{}
+	record, found, err := rv.vmService.RecordedNetworks(vmCID.Int())
+	if err != nil {
+		if _, ok := err.(api.CloudError); ok {
+			return nil, err
+		}
+		return nil, bosherr.WrapErrorf(err, "Getting networks record of VM with id '%d'", vmCID.Int())
+	}
+
+	if !found || record.Digest != networks.Digest() {
+		return nil, api.NotSupportedError{}
+	}
+
+	securityGroups, err := withNetworkSecurityGroups(rv.vmService, record.SecurityGroups, networks)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Getting security groups from networks settings")
+	}
+
+	if err = rv.vmService.ConfigureSecurityGroups(vmCID.Int(), securityGroups, true); err != nil {
+		if _, ok := err.(api.CloudError); ok {
+			return nil, err
+		}
+		return nil, bosherr.WrapErrorf(err, "Configuring security groups of VM with id '%d'", vmCID.Int())
+	}
+
+	return nil, nil
 }
@@ -1,11 +1,17 @@
 package action_test
 
 import (
+	"errors"
+
 	. "bosh-softlayer-cpi/action"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/api"
 
-	registryfakes "bosh-softlayer-cpi/registry/fakes"
+	instance "bosh-softlayer-cpi/softlayer/virtual_guest_service"
 	instancefakes "bosh-softlayer-cpi/softlayer/virtual_guest_service/fakes"
 )
 
@@ -14,21 +20,20 @@ var _ = Describe("ConfigureNetworks", func() {
 		err      error
 		networks Networks
 
-		vmService      *instancefakes.FakeService
-		registryClient *registryfakes.FakeClient
+		vmService *instancefakes.FakeService
 
 		configureNetworks ConfigureNetworks
 	)
 
 	BeforeEach(func() {
 		vmService = &instancefakes.FakeService{}
-		registryClient = &registryfakes.FakeClient{}
-		configureNetworks = NewConfigureNetworks(vmService, registryClient)
+		configureNetworks = NewConfigureNetworks(vmService)
 	})
 
 	Describe("Run", func() {
 		var (
-			vmCID VMCID
+			vmCID          VMCID
+			recordedDigest string
 		)
 		BeforeEach(func() {
 			vmCID = VMCID(12345678)
@@ -47,11 +52,165 @@ var _ = Describe("ConfigureNetworks", func() {
 					},
 				},
 			}
+			recordedDigest = networks.Digest()
 		})
 
-		It("returns an error because method is deprecated", func() {
+		It("returns a not supported error if the VM has no record of the networks", func() {
+			vmService.RecordedNetworksReturns(instance.NetworksRecord{}, false, nil)
+
+			_, err = configureNetworks.Run(vmCID, networks)
+			Expect(err).To(BeAssignableToTypeOf(api.NotSupportedError{}))
+			Expect(vmService.ConfigureSecurityGroupsCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if getting the record of the networks fails", func() {
+			vmService.RecordedNetworksReturns(instance.NetworksRecord{}, false, errors.New("fake-vm-service-error"))
+
 			_, err = configureNetworks.Run(vmCID, networks)
 			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Getting networks record of VM with id '12345678'"))
+		})
+
+		Context("when the VM has a record of the networks", func() {
+			BeforeEach(func() {
+				vmService.RecordedNetworksStub = func(id int) (instance.NetworksRecord, bool, error) {
+					return instance.NetworksRecord{
+						Digest:         recordedDigest,
+						SecurityGroups: instance.SecurityGroups{Private: []int{21345}},
+					}, true, nil
+				}
+
+				network := networks["fake-network-name"]
+				network.CloudProperties.SecurityGroups = SecurityGroups{"allow_https"}
+				networks["fake-network-name"] = network
+
+				vmService.GetVlanReturns(
+					&datatypes.Network_Vlan{
+						Id:           sl.Int(42345678),
+						NetworkSpace: sl.String("PRIVATE"),
+					},
+					nil,
+				)
+				vmService.FindSecurityGroupsStub = func(refs []string) ([]int, error) {
+					ids := []int{}
+					for _, ref := range refs {
+						switch ref {
+						case "allow_ssh":
+							ids = append(ids, 21345)
+						case "allow_https":
+							ids = append(ids, 21346)
+						}
+					}
+					return ids, nil
+				}
+			})
+
+			It("reconciles the security groups of the VM and of the networks", func() {
+				_, err = configureNetworks.Run(vmCID, networks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmService.ConfigureSecurityGroupsCallCount()).To(Equal(1))
+
+				id, groups, detachOthers := vmService.ConfigureSecurityGroupsArgsForCall(0)
+				Expect(id).To(Equal(12345678))
+				Expect(groups.Public).To(BeEmpty())
+				Expect(groups.Private).To(Equal([]int{21345, 21346}))
+				Expect(detachOthers).To(BeTrue())
+			})
+
+			It("keeps only the security groups of the VM when those of the networks are removed", func() {
+				network := networks["fake-network-name"]
+				network.CloudProperties.SecurityGroups = nil
+				networks["fake-network-name"] = network
+
+				_, err = configureNetworks.Run(vmCID, networks)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, groups, detachOthers := vmService.ConfigureSecurityGroupsArgsForCall(0)
+				Expect(groups.Private).To(Equal([]int{21345}))
+				Expect(detachOthers).To(BeTrue())
+			})
+
+			It("reconciles the security groups when the director sends the address a dynamic network got", func() {
+				network := networks["fake-network-name"]
+				network.IP = "10.112.10.20"
+				networks["fake-network-name"] = network
+
+				_, err = configureNetworks.Run(vmCID, networks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmService.ConfigureSecurityGroupsCallCount()).To(Equal(1))
+			})
+
+			It("reconciles the security groups of a VM with VIP and networks with several subnets", func() {
+				networks["fake-vip-network"] = Network{Type: "vip", IP: "169.50.10.20"}
+				networks["fake-multi-network"] = Network{
+					Type: "manual",
+					IP:   "10.112.10.21",
+					CloudProperties: NetworkCloudProperties{
+						SubnetIds: []int{1234, 5678},
+					},
+				}
+
+				recordedDigest = networks.Digest()
+
+				_, err = configureNetworks.Run(vmCID, networks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmService.ConfigureSecurityGroupsCallCount()).To(Equal(1))
+			})
+
+			It("returns a not supported error if the vlans of a network changed", func() {
+				network := networks["fake-network-name"]
+				network.CloudProperties.VlanIds = []int{42345679}
+				networks["fake-network-name"] = network
+
+				_, err = configureNetworks.Run(vmCID, networks)
+				Expect(err).To(BeAssignableToTypeOf(api.NotSupportedError{}))
+				Expect(vmService.ConfigureSecurityGroupsCallCount()).To(Equal(0))
+			})
+
+			It("returns a not supported error if the dns of a network changed", func() {
+				network := networks["fake-network-name"]
+				network.DNS = []string{"fake-network-dns", "fake-network-dns2"}
+				networks["fake-network-name"] = network
+
+				_, err = configureNetworks.Run(vmCID, networks)
+				Expect(err).To(BeAssignableToTypeOf(api.NotSupportedError{}))
+				Expect(vmService.ConfigureSecurityGroupsCallCount()).To(Equal(0))
+			})
+
+			It("returns a not supported error if the type of a network changed", func() {
+				network := networks["fake-network-name"]
+				network.Type = "manual"
+				networks["fake-network-name"] = network
+
+				_, err = configureNetworks.Run(vmCID, networks)
+				Expect(err).To(BeAssignableToTypeOf(api.NotSupportedError{}))
+				Expect(vmService.ConfigureSecurityGroupsCallCount()).To(Equal(0))
+			})
+
+			It("returns a not supported error if a network is added", func() {
+				networks["fake-network-name2"] = Network{Type: "manual", IP: "10.112.10.21"}
+
+				_, err = configureNetworks.Run(vmCID, networks)
+				Expect(err).To(BeAssignableToTypeOf(api.NotSupportedError{}))
+				Expect(vmService.ConfigureSecurityGroupsCallCount()).To(Equal(0))
+			})
+
+			It("returns an error if getting the vlan fails", func() {
+				vmService.GetVlanReturns(&datatypes.Network_Vlan{}, errors.New("fake-vm-service-error"))
+
+				_, err = configureNetworks.Run(vmCID, networks)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-vm-service-error"))
+				Expect(vmService.ConfigureSecurityGroupsCallCount()).To(Equal(0))
+			})
+
+			It("returns an error if configuring the security groups fails", func() {
+				vmService.ConfigureSecurityGroupsReturns(errors.New("fake-vm-service-error"))
+
+				_, err = configureNetworks.Run(vmCID, networks)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Configuring security groups of VM with id '12345678'"))
+			})
 		})
 	})
 })
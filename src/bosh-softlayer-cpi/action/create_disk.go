@@ -24,6 +24,14 @@ func NewCreateDisk(
 }
 
 func (cd CreateDisk) Run(size int, cloudProps DiskCloudProperties, vmCID VMCID) (string, error) {
+	err := cloudProps.Validate()
+	if err == nil {
+		err = cloudProps.ValidateSize(size)
+	}
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Creating disk with size '%d'", size)
+	}
+
 	// Find the VM (if provided) so we can create the disk in the same datacenter
 	var location string
 	if vmCID != 0 {
@@ -36,18 +44,52 @@ func (cd CreateDisk) Run(size int, cloudProps DiskCloudProperties, vmCID VMCID)
 		}
 		location = *vm.Datacenter.Name
 	} else {
-		if len(cloudProps.DataCenter) > 0 {
-			location = cloudProps.DataCenter
-		} else {
-			return "", bosherr.Errorf("Creating disk with size '%d': Invalid datacenter name specified.", size)
+		location = cloudProps.DataCenter
+	}
+
+	// Duplicates are created in the datacenter of their source disk
+	if cloudProps.SnapshotId != 0 {
+		disk, err := cd.diskService.Duplicate(size, cloudProps.Iops, cloudProps.Tier, cloudProps.SnapshotSpace, cloudProps.SourceDiskId, cloudProps.SnapshotId, location)
+		if err != nil {
+			return "", bosherr.WrapErrorf(err, "Creating disk with size '%d' from snapshot '%d'", size, cloudProps.SnapshotId)
 		}
+
+		return cd.configure(disk, size, cloudProps)
+	}
+
+	if location == "" {
+		return "", bosherr.Errorf("Creating disk with size '%d': Invalid datacenter name specified.", size)
 	}
 
 	// Create the Disk
-	disk, err := cd.diskService.Create(size, cloudProps.Iops, location, cloudProps.SnapshotSpace)
+	disk, err := cd.diskService.Create(size, cloudProps.DiskType, cloudProps.Iops, cloudProps.Tier, cloudProps.Encrypted, location, cloudProps.SnapshotSpace)
 	if err != nil {
 		return "", bosherr.WrapErrorf(err, "Creating disk with size '%d'", size)
 	}
 
-	return DiskCID(disk).String(), nil
+	return cd.configure(disk, size, cloudProps)
+}
+
+// configure enables the snapshot schedules, records the snapshot retention and orders the replicant
+// of a new disk, deleting the disk if that fails since the director would not know about it.
+func (cd CreateDisk) configure(diskID int, size int, cloudProps DiskCloudProperties) (string, error) {
+	var err error
+	if len(cloudProps.SnapshotSchedule) > 0 {
+		err = cd.diskService.SetSnapshotSchedules(diskID, cloudProps.SnapshotSchedule)
+	}
+	if err == nil && cloudProps.SnapshotRetention > 0 {
+		err = cd.diskService.SetSnapshotRetention(diskID, cloudProps.SnapshotRetention)
+	}
+	if err == nil && cloudProps.Replication != nil {
+		_, err = cd.diskService.Replicate(diskID, *cloudProps.Replication)
+	}
+
+	if err != nil {
+		if deleteErr := cd.diskService.Delete(diskID); deleteErr != nil {
+			return "", bosherr.WrapErrorf(err, "Creating disk with size '%d' (deleting disk '%d' failed: %s)", size, diskID, deleteErr)
+		}
+		return "", bosherr.WrapErrorf(err, "Creating disk with size '%d'", size)
+	}
+
+	return DiskCID(diskID).String(), nil
 }
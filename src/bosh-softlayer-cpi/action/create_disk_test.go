@@ -9,6 +9,7 @@ import (
 	. "bosh-softlayer-cpi/action"
 
 	"bosh-softlayer-cpi/api"
+	"bosh-softlayer-cpi/softlayer/disk_service"
 	diskfakes "bosh-softlayer-cpi/softlayer/disk_service/fakes"
 	instancefakes "bosh-softlayer-cpi/softlayer/virtual_guest_service/fakes"
 	"github.com/softlayer/softlayer-go/datatypes"
@@ -71,12 +72,35 @@ var _ = Describe("CreateDisk", func() {
 				actualCid := vmService.FindArgsForCall(0)
 				Expect(actualCid).To(Equal(12345678))
 				Expect(diskService.CreateCallCount()).To(Equal(1))
-				actualSize, _, actualLocation, _ := diskService.CreateArgsForCall(0)
+				actualSize, _, _, _, _, actualLocation, _ := diskService.CreateArgsForCall(0)
 				Expect(actualSize).To(Equal(size))
 				Expect(actualLocation).To(Equal("fake-datacenter-name"))
 				Expect(diskCID).To(Equal("22345678"))
 			})
 
+			It("creates an encrypted endurance disk", func() {
+				cloudProps.DiskType = "endurance"
+				cloudProps.Tier = 10
+				cloudProps.Encrypted = true
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).NotTo(HaveOccurred())
+				_, actualType, actualIops, actualTier, actualEncrypted, _, _ := diskService.CreateArgsForCall(0)
+				Expect(actualType).To(Equal("endurance"))
+				Expect(actualIops).To(Equal(0))
+				Expect(actualTier).To(Equal(float64(10)))
+				Expect(actualEncrypted).To(BeTrue())
+			})
+
+			It("returns an error if the iops are not supported by the size", func() {
+				cloudProps.Iops = 5000
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'iops' of a 40 GB performance disk must be between 100 and 2000"))
+				Expect(diskService.CreateCallCount()).To(Equal(0))
+			})
+
 			It("returns an error if vmService find call returns an error", func() {
 				vmService.FindReturns(
 					&datatypes.Virtual_Guest{},
@@ -126,5 +150,180 @@ var _ = Describe("CreateDisk", func() {
 				Expect(diskService.CreateCallCount()).To(Equal(1))
 			})
 		})
+
+		Context("when snapshot_id is set", func() {
+			BeforeEach(func() {
+				cloudProps = DiskCloudProperties{
+					SnapshotId: 32345678,
+					Iops:       1000,
+				}
+				diskService.DuplicateReturns(22345678, nil)
+			})
+
+			It("creates the disk from the snapshot", func() {
+				diskCID, err := createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diskCID).To(Equal("22345678"))
+				Expect(diskService.CreateCallCount()).To(Equal(0))
+				Expect(diskService.DuplicateCallCount()).To(Equal(1))
+				actualSize, actualIops, actualTier, actualSnapshotSpace, actualSourceID, actualSnapshotID, actualLocation := diskService.DuplicateArgsForCall(0)
+				Expect(actualSize).To(Equal(size))
+				Expect(actualIops).To(Equal(1000))
+				Expect(actualTier).To(Equal(float64(0)))
+				Expect(actualSnapshotSpace).To(Equal(0))
+				Expect(actualSourceID).To(Equal(0))
+				Expect(actualSnapshotID).To(Equal(32345678))
+				Expect(actualLocation).To(Equal(""))
+			})
+
+			It("creates the disk from the snapshot of the source disk in the datacenter of the VM", func() {
+				vmCID = VMCID(12345678)
+				cloudProps.SourceDiskId = 12345678
+				vmService.FindReturns(
+					&datatypes.Virtual_Guest{
+						Id: sl.Int(12345678),
+						Datacenter: &datatypes.Location{
+							Name: sl.String("fake-datacenter-name"),
+						},
+					},
+					nil,
+				)
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).NotTo(HaveOccurred())
+				_, _, _, _, actualSourceID, _, actualLocation := diskService.DuplicateArgsForCall(0)
+				Expect(actualSourceID).To(Equal(12345678))
+				Expect(actualLocation).To(Equal("fake-datacenter-name"))
+			})
+
+			It("returns an error if source_disk_id is set without snapshot_id", func() {
+				cloudProps = DiskCloudProperties{SourceDiskId: 12345678}
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'source_disk_id' requires the 'snapshot_id'"))
+				Expect(diskService.DuplicateCallCount()).To(Equal(0))
+				Expect(diskService.CreateCallCount()).To(Equal(0))
+			})
+
+			It("returns an error if the iops do not fit the size", func() {
+				cloudProps.Iops = 3000
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'iops' of a 40 GB performance disk must be between 100 and 2000"))
+				Expect(diskService.DuplicateCallCount()).To(Equal(0))
+			})
+
+			It("returns an error if encrypted is set", func() {
+				cloudProps.Encrypted = true
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'encrypted' is not supported with 'snapshot_id'"))
+				Expect(diskService.DuplicateCallCount()).To(Equal(0))
+			})
+
+			It("returns an error if diskService duplicate call returns an error", func() {
+				diskService.DuplicateReturns(0, errors.New("fake-disk-service-error"))
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Creating disk with size '32768' from snapshot '32345678'"))
+				Expect(err.Error()).To(ContainSubstring("fake-disk-service-error"))
+			})
+		})
+
+		Context("when snapshot_schedule is set", func() {
+			BeforeEach(func() {
+				cloudProps = DiskCloudProperties{
+					DataCenter:       "fake-datacenter-name",
+					SnapshotSpace:    20,
+					SnapshotSchedule: []disk.SnapshotSchedule{{Type: "DAILY", Retention: 7, Hour: 2}},
+				}
+				diskService.CreateReturns(22345678, nil)
+			})
+
+			It("sets the snapshot schedules of the new disk", func() {
+				diskCID, err := createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diskCID).To(Equal("22345678"))
+				Expect(diskService.SetSnapshotSchedulesCallCount()).To(Equal(1))
+				actualID, actualSchedules := diskService.SetSnapshotSchedulesArgsForCall(0)
+				Expect(actualID).To(Equal(22345678))
+				Expect(actualSchedules).To(Equal(cloudProps.SnapshotSchedule))
+			})
+
+			It("returns an error if snapshot_space is not set", func() {
+				cloudProps.SnapshotSpace = 0
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The property 'snapshot_schedule' requires 'snapshot_space'"))
+				Expect(diskService.CreateCallCount()).To(Equal(0))
+			})
+
+			It("records the snapshot retention of the new disk", func() {
+				cloudProps.SnapshotRetention = 7
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diskService.SetSnapshotRetentionCallCount()).To(Equal(1))
+				actualID, actualRetention := diskService.SetSnapshotRetentionArgsForCall(0)
+				Expect(actualID).To(Equal(22345678))
+				Expect(actualRetention).To(Equal(7))
+			})
+
+			It("deletes the new disk if diskService set snapshot retention call returns an error", func() {
+				cloudProps.SnapshotRetention = 7
+				diskService.SetSnapshotRetentionReturns(errors.New("fake-disk-service-error"))
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).To(HaveOccurred())
+				Expect(diskService.DeleteCallCount()).To(Equal(1))
+			})
+
+			It("orders a replicant of the new disk after enabling its snapshot schedules", func() {
+				cloudProps.Replication = &disk.Replication{Datacenter: "wdc07", Schedule: "DAILY"}
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diskService.SetSnapshotSchedulesCallCount()).To(Equal(1))
+				Expect(diskService.ReplicateCallCount()).To(Equal(1))
+				actualID, actualReplication := diskService.ReplicateArgsForCall(0)
+				Expect(actualID).To(Equal(22345678))
+				Expect(actualReplication).To(Equal(disk.Replication{Datacenter: "wdc07", Schedule: "DAILY"}))
+			})
+
+			It("deletes the new disk if diskService replicate call returns an error", func() {
+				cloudProps.Replication = &disk.Replication{Datacenter: "wdc07", Schedule: "DAILY"}
+				diskService.ReplicateReturns(0, errors.New("fake-disk-service-error"))
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-disk-service-error"))
+				Expect(diskService.DeleteCallCount()).To(Equal(1))
+				Expect(diskService.DeleteArgsForCall(0)).To(Equal(22345678))
+			})
+
+			It("returns an error if the replication schedule is not a snapshot schedule of the disk", func() {
+				cloudProps.Replication = &disk.Replication{Datacenter: "wdc07", Schedule: "WEEKLY"}
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("The 'schedule' of replication must be the type of a 'snapshot_schedule' of the disk"))
+				Expect(diskService.CreateCallCount()).To(Equal(0))
+			})
+
+			It("deletes the new disk if diskService set snapshot schedules call returns an error", func() {
+				diskService.SetSnapshotSchedulesReturns(errors.New("fake-disk-service-error"))
+
+				_, err = createDisk.Run(size, cloudProps, vmCID)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-disk-service-error"))
+				Expect(diskService.DeleteCallCount()).To(Equal(1))
+				Expect(diskService.DeleteArgsForCall(0)).To(Equal(22345678))
+			})
+		})
 	})
 })
@@ -2,11 +2,14 @@ package action
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/bluebosh/goodhosts"
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
@@ -14,6 +17,7 @@ import (
 	"github.com/softlayer/softlayer-go/sl"
 
 	"bosh-softlayer-cpi/api"
+	"bosh-softlayer-cpi/journal"
 	"bosh-softlayer-cpi/registry"
 	boslc "bosh-softlayer-cpi/softlayer/client"
 	boslconfig "bosh-softlayer-cpi/softlayer/config"
@@ -21,6 +25,15 @@ import (
 	"bosh-softlayer-cpi/softlayer/virtual_guest_service"
 )
 
+const createVMJournalAction = "create_vm"
+
+// placementGroupAuto lets the CPI manage a placement group per deployment and instance group
+const placementGroupAuto = "auto"
+
+// dedicatedHostRootDiskSize is the size in GB of the root disk the guests take on a dedicated host
+// when root_disk_size is not set
+const dedicatedHostRootDiskSize = 25
+
 type CreateVM struct {
 	stemcellService     stemcell.Service
 	virtualGuestService instance.Service
@@ -29,6 +42,8 @@ type CreateVM struct {
 	agentOptions        registry.AgentOptions
 	softlayerOptions    boslconfig.Config
 	localDNSConfigFile  string
+	apiVersions         ApiVersions
+	journal             journal.Journal
 }
 
 func NewCreateVM(
@@ -39,6 +54,8 @@ func NewCreateVM(
 	agentOptions registry.AgentOptions,
 	softlayerOptions boslconfig.Config,
 	localDNSConfigurationFile string,
+	apiVersions ApiVersions,
+	journal journal.Journal,
 ) (action CreateVM) {
 	action.stemcellService = stemcellService
 	action.virtualGuestService = virtualGuestService
@@ -47,45 +64,127 @@ func NewCreateVM(
 	action.agentOptions = agentOptions
 	action.softlayerOptions = softlayerOptions
 	action.localDNSConfigFile = localDNSConfigurationFile
+	action.apiVersions = apiVersions
+	action.journal = journal
 	return
 }
 
+// CreateVMV2 is the create_vm action of CPI API v2, which also returns the VM networks.
+type CreateVMV2 struct {
+	createVM CreateVM
+}
+
+func NewCreateVMV2(createVM CreateVM) CreateVMV2 {
+	return CreateVMV2{createVM: createVM}
+}
+
+func (cv CreateVMV2) Run(agentID string, stemcellCID StemcellCID, cloudProps VMCloudProperties, networks Networks, diskIDs []DiskCID, env Environment) ([]interface{}, error) {
+	instanceID, agentNetworks, err := cv.createVM.create(agentID, stemcellCID, cloudProps, networks, env)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{instanceID, agentNetworks}, nil
+}
+
 func (cv CreateVM) Run(agentID string, stemcellCID StemcellCID, cloudProps VMCloudProperties, networks Networks, diskIDs []DiskCID, env Environment) (string, error) {
+	instanceID, _, err := cv.create(agentID, stemcellCID, cloudProps, networks, env)
+	return instanceID, err
+}
+
+func (cv CreateVM) create(agentID string, stemcellCID StemcellCID, cloudProps VMCloudProperties, networks Networks, env Environment) (string, registry.NetworksSettings, error) {
 	// Validate VM properties
 	if err := cloudProps.Validate(); err != nil {
-		return "", bosherr.WrapError(err, "Creating VM")
+		return "", nil, bosherr.WrapError(err, "Creating VM")
+	}
+
+	// Check the root disk and GPU can be ordered in the datacenter, and round the ephemeral disk
+	// up to a size that can be ordered with the VM
+	orderItems, err := cv.virtualGuestService.ResolveOrderItems(instance.OrderItems{
+		Datacenter:        cloudProps.Datacenter,
+		LocalDisk:         cloudProps.LocalDiskFlag,
+		RootDiskSize:      cloudProps.RootDiskSize,
+		EphemeralDiskSize: cloudProps.EphemeralDiskSize,
+		GpuFlavor:         cloudProps.Gpu,
+	})
+	if err != nil {
+		return "", nil, bosherr.WrapError(err, "Creating VM")
 	}
+	cloudProps.EphemeralDiskSize = orderItems.EphemeralDiskSize
 
 	// Find stemcell uuid
 	stemcellUuid, err := cv.stemcellService.Find(int(stemcellCID))
 	if err != nil {
 		if _, ok := err.(api.CloudError); ok {
-			return "", err
+			return "", nil, err
 		}
-		return "", bosherr.WrapErrorf(err, "Finding stemcell uuid with id '%d'", stemcellCID.Int())
+		return "", nil, bosherr.WrapErrorf(err, "Finding stemcell uuid with id '%d'", stemcellCID.Int())
+	}
+
+	// Cancel what interrupted calls left behind, then journal this one
+	cv.journal.Reconcile(createVMJournalAction, cv.cleanUp)
+
+	entry, err := cv.journal.Begin(createVMJournalAction, agentID)
+	if err != nil {
+		return "", nil, bosherr.WrapError(err, "Recording VM creation in journal")
 	}
 
+	// If any of the below code fails, we must delete what was created. The journal entry
+	// is kept when that fails too, so the next call retries once this one is released.
+	succeeded := false
+	defer func() {
+		defer cv.journal.Release(entry)
+		if succeeded {
+			return
+		}
+		if cleanUpErr := cv.cleanUp(*entry); cleanUpErr == nil {
+			// The error of the call is returned, a failure to finish is logged by the journal
+			_ = cv.journal.Finish(entry)
+		}
+	}()
+
 	// Set public key
 	var sshKey int
 	if len(cv.softlayerOptions.PublicKey) > 0 {
-		sshKey, err = cv.virtualGuestService.CreateSshKey("bosh_cpi", cv.softlayerOptions.PublicKey, cv.softlayerOptions.PublicKeyFingerPrint)
+		var created bool
+		sshKey, created, err = cv.virtualGuestService.CreateSshKey("bosh_cpi", cv.softlayerOptions.PublicKey, cv.softlayerOptions.PublicKeyFingerPrint)
 		if err != nil {
-			return "", bosherr.WrapErrorf(err, "Creating Public Key with content '%s'", cv.softlayerOptions.PublicKey)
+			return "", nil, bosherr.WrapErrorf(err, "Creating Public Key with content '%s'", cv.softlayerOptions.PublicKey)
 		}
 		cloudProps.SshKey = sshKey
+
+		entry.SshKeyID = sshKey
+		entry.SshKeyCreated = created
+		if err = cv.journal.Record(entry, "ssh_key_created"); err != nil {
+			return "", nil, bosherr.WrapError(err, "Recording SSH key in journal")
+		}
 	}
 
 	// Set userData without server name
 	userData, err := cv.createUserDataForInstance(&cv.registryOptions, cloudProps.DeployedByBoshCLI)
 	if err != nil {
-		return "", bosherr.WrapError(err, "Creating VM UserData")
+		return "", nil, bosherr.WrapError(err, "Creating VM UserData")
 	}
 
 	// Inspect networks to get NetworkComponents
 	publicNetworkComponent, privateNetworkComponent, err := cv.getNetworkComponents(networks)
 	if err != nil {
-		return "", bosherr.WrapError(err, "Getting NetworkComponents from networks settings")
+		return "", nil, bosherr.WrapError(err, "Getting NetworkComponents from networks settings")
+	}
+
+	// Bind security groups to the network components
+	vmGroups, err := vmSecurityGroups(cv.virtualGuestService, cloudProps.SecurityGroups, publicNetworkComponent != nil)
+	if err != nil {
+		return "", nil, bosherr.WrapError(err, "Getting security groups from cloud properties")
+	}
+	securityGroups, err := withNetworkSecurityGroups(cv.virtualGuestService, vmGroups, networks)
+	if err != nil {
+		return "", nil, bosherr.WrapError(err, "Getting security groups from cloud properties")
+	}
+	if publicNetworkComponent != nil {
+		publicNetworkComponent.SecurityGroupBindings = securityGroupBindings(securityGroups.Public)
 	}
+	privateNetworkComponent.SecurityGroupBindings = securityGroupBindings(securityGroups.Private)
 
 	// Create Virtual Guest template
 	virtualGuestTemplate := cv.createVirtualGuestTemplate(stemcellUuid, *cloudProps.AsInstanceProperties(), publicNetworkComponent, privateNetworkComponent)
@@ -99,7 +198,7 @@ func (cv CreateVM) Run(agentID string, stemcellCID StemcellCID, cloudProps VMClo
 	}
 
 	if err = instanceNetworks.Validate(); err != nil {
-		return "", bosherr.WrapError(err, "Creating VM")
+		return "", nil, bosherr.WrapError(err, "Creating VM")
 	}
 
 	if boshenv, ok := env["bosh"]; ok {
@@ -111,44 +210,132 @@ func (cv CreateVM) Run(agentID string, stemcellCID StemcellCID, cloudProps VMClo
 		}
 	}
 
+	// Without a registry the agent reads its settings from the user data when the VM boots, so they
+	// are ordered with the VM and completed once it is configured
+	if !cv.apiVersions.UseRegistry() {
+		agentSettings := registry.NewAgentSettings(agentID, "", instanceNetworks.AsRegistryNetworks(), registry.EnvSettings(env), cv.agentOptions)
+		if cloudProps.EphemeralDiskSize > 0 {
+			agentSettings = agentSettings.AttachEphemeralDisk(registry.DefaultEphemeralDisk)
+		}
+		userData.Settings = &agentSettings
+	}
+
 	// CID for returned VM
 	cid := 0
-	osReloaded := false
+
+	// Ordered VMs get the ephemeral disk with the order, VPS VMs and OS reloaded VMs with too small a
+	// disk are upgraded after boot
+	upgradeEphemeralDisk := cloudProps.EphemeralDiskSize > 0 && cv.softlayerOptions.EnableVps
 
 	if !cv.softlayerOptions.DisableOsReload {
-		cid, err = cv.createByOsReload(stemcellCID, virtualGuestTemplate, instanceNetworks, userData)
+		var upgradeReloadedDisk bool
+		cid, upgradeReloadedDisk, err = cv.createByOsReload(stemcellCID, virtualGuestTemplate, orderItems, instanceNetworks, userData, entry)
 		if err != nil {
-			return "", bosherr.WrapError(err, "OS reloading VM")
+			if _, ok := err.(api.CloudError); ok {
+				return "", nil, err
+			}
+			return "", nil, bosherr.WrapError(err, "OS reloading VM")
+		}
+		if cid != 0 {
+			upgradeEphemeralDisk = upgradeReloadedDisk
 		}
-
-		osReloaded = true
 	}
 
 	if cid == 0 {
+		if len(cloudProps.DedicatedHostIds) > 0 || cloudProps.DedicatedHostGroup != "" {
+			deployment, instanceGroup, _ := boshGroups(env)
+			hostID, err := cv.virtualGuestService.SelectDedicatedHost(instance.DedicatedHostPlacement{
+				HostIDs:        cloudProps.DedicatedHostIds,
+				Group:          cloudProps.DedicatedHostGroup,
+				Datacenter:     cloudProps.Datacenter,
+				Strategy:       cloudProps.DedicatedHostPlacement,
+				Cpu:            cloudProps.Cpu,
+				Memory:         cloudProps.Memory,
+				Flavor:         cloudProps.flavor(),
+				Disk:           dedicatedHostDiskSize(cloudProps.RootDiskSize, cloudProps.EphemeralDiskSize),
+				Deployment:     deployment,
+				InstanceGroup:  instanceGroup,
+				HostnamePrefix: cloudProps.HostnamePrefix,
+			})
+			if err != nil {
+				if _, ok := err.(api.CloudError); ok {
+					return "", nil, err
+				}
+				return "", nil, bosherr.WrapError(err, "Selecting dedicated host")
+			}
+			virtualGuestTemplate.DedicatedHost = &datatypes.Virtual_DedicatedHost{Id: sl.Int(hostID)}
+		}
+
+		orderOptions := boslc.InstanceOrderOptions{
+			TransientGuestFlag: cloudProps.Transient,
+			ReservedCapacityId: cloudProps.ReservedCapacityId,
+		}
+		if cloudProps.PlacementGroup != "" {
+			orderOptions.PlacementGroupId, err = cv.placementGroup(cloudProps.PlacementGroup, env, privateNetworkComponent)
+			if err != nil {
+				return "", nil, bosherr.WrapErrorf(err, "Getting placement group '%s'", cloudProps.PlacementGroup)
+			}
+		}
+
+		// The guest is journaled once ordered, it takes long to be ready
+		orderOptions.Ordered = func(id int) error {
+			entry.GuestID = id
+			return cv.journal.Record(entry, "ordered")
+		}
+
 		// Create VM
-		cid, err = cv.virtualGuestService.Create(virtualGuestTemplate, cv.softlayerOptions.EnableVps, stemcellCID.Int(), []int{cloudProps.SshKey}, userData)
+		cid, err = cv.virtualGuestService.Create(virtualGuestTemplate, cv.softlayerOptions.EnableVps, stemcellCID.Int(), []int{cloudProps.SshKey}, userData, orderOptions)
 		if err != nil {
 			if _, ok := err.(api.CloudError); ok {
-				return "", err
+				return "", nil, err
 			}
-			return "", bosherr.WrapError(err, "Creating VM")
+			return "", nil, bosherr.WrapError(err, "Creating VM")
 		}
-	}
 
-	instanceID := VMCID(cid).String()
+		entry.GuestID = cid
+		if err = cv.journal.Record(entry, "created"); err != nil {
+			return "", nil, bosherr.WrapError(err, "Recording VM in journal")
+		}
+	}
 
-	// If any of the below code fails, we must delete the created VM
-	defer func() {
-		if err != nil && !osReloaded {
-			// #nosec G104
-			cv.virtualGuestService.CleanUp(cid)
+	// An OS reloaded VM keeps the security groups of its earlier deployment, reconcile them even
+	// when none are configured
+	if entry.OsReload {
+		if err = cv.virtualGuestService.ConfigureSecurityGroups(cid, securityGroups, true); err != nil {
+			return "", nil, bosherr.WrapErrorf(err, "Configuring security groups of VM with id '%d'", cid)
 		}
-	}()
+	}
+
+	instanceID := VMCID(cid).String()
 
 	// Config VM network settings
 	instanceNetworks, err = cv.virtualGuestService.ConfigureNetworks(cid, instanceNetworks)
 	if err != nil {
-		return "", bosherr.WrapError(err, "Configuring VM networks")
+		return "", nil, bosherr.WrapError(err, "Configuring VM networks")
+	}
+	// configure_networks tells from the record what changed in the networks, without the registry
+	record := instance.NetworksRecord{Digest: networks.Digest(), SecurityGroups: vmGroups}
+	if err = cv.virtualGuestService.RecordNetworks(cid, record); err != nil {
+		return "", nil, bosherr.WrapErrorf(err, "Recording networks of VM with id '%d'", cid)
+	}
+	if err = cv.journal.Record(entry, "networks_configured"); err != nil {
+		return "", nil, bosherr.WrapError(err, "Recording VM networks in journal")
+	}
+
+	// Join load balancers, members added by an earlier try are kept
+	for _, loadBalancer := range cloudProps.LoadBalancers {
+		err = cv.virtualGuestService.AddLoadBalancerMember(cid, instance.LoadBalancerMember{
+			UUID:   loadBalancer.UUID,
+			Weight: loadBalancer.Weight,
+		})
+		if err != nil {
+			return "", nil, bosherr.WrapErrorf(err, "Adding VM with id '%d' to load balancer '%s'", cid, loadBalancer.UUID)
+		}
+	}
+	if len(cloudProps.LoadBalancers) > 0 {
+		if err = cv.journal.Record(entry, "load_balancers_joined"); err != nil {
+			return "", nil, bosherr.WrapError(err, "Recording load balancers in journal")
+		}
 	}
 
 	// Create VM agent settings
@@ -157,30 +344,67 @@ func (cv CreateVM) Run(agentID string, stemcellCID StemcellCID, cloudProps VMClo
 	// Get object details of new VM
 	virtualGuest, err := cv.virtualGuestService.Find(cid)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	if err = cv.updateHost(cid, cloudProps.DeployedByBoshCLI, networks.HasManualNetwork(), *virtualGuest.PrimaryBackendIpAddress, *virtualGuest.FullyQualifiedDomainName); err != nil {
-		return "", bosherr.WrapError(err, "Post config")
+		return "", nil, bosherr.WrapError(err, "Post config")
 	}
 
 	agentSettings := registry.NewAgentSettings(agentID, instanceID, agentNetworks, registry.EnvSettings(env), cv.agentOptions)
 
 	// Attach Ephemeral Disk
-	if cloudProps.EphemeralDiskSize > 0 {
+	if upgradeEphemeralDisk {
 		err = cv.virtualGuestService.AttachEphemeralDisk(cid, cloudProps.EphemeralDiskSize)
 		if err != nil {
-			return "", bosherr.WrapErrorf(err, "Attaching ephemeral disk to VM with id '%d'", cid)
+			return "", nil, bosherr.WrapErrorf(err, "Attaching ephemeral disk to VM with id '%d'", cid)
+		}
+		if err = cv.journal.Record(entry, "ephemeral_disk_attached"); err != nil {
+			return "", nil, bosherr.WrapError(err, "Recording ephemeral disk in journal")
 		}
+	}
+	if cloudProps.EphemeralDiskSize > 0 {
 		// Update VM agent settings
 		agentSettings = agentSettings.AttachEphemeralDisk(registry.DefaultEphemeralDisk)
 	}
 
-	if err = cv.registryClient.Update(instanceID, agentSettings); err != nil {
-		return "", bosherr.WrapError(err, "Updating registryClient")
+	if cv.apiVersions.UseRegistry() {
+		if err = cv.registryClient.Update(instanceID, agentSettings); err != nil {
+			return "", nil, bosherr.WrapError(err, "Updating registryClient")
+		}
+	} else {
+		if err = cv.updateUserDataSettings(cid, userData, agentSettings); err != nil {
+			return "", nil, bosherr.WrapError(err, "Updating VM UserData with agent settings")
+		}
+	}
+
+	// A journal entry left behind would have the VM cancelled by the next create_vm
+	if err = cv.journal.Finish(entry); err != nil {
+		return "", nil, bosherr.WrapError(err, "Finishing VM creation in journal")
 	}
+	succeeded = true
 
-	return instanceID, nil
+	return instanceID, agentNetworks, nil
+}
+
+// cleanUp cancels the VM ordered by a failed or interrupted create_vm and deletes the SSH key
+// it created. OS reloaded VMs are kept, they were ordered by an earlier create_vm.
+func (cv CreateVM) cleanUp(entry journal.Entry) error {
+	if !entry.OsReload && entry.GuestID != 0 {
+		if err := cv.virtualGuestService.CleanUp(entry.GuestID); err != nil {
+			return err
+		}
+	}
+
+	// An SSH key of the same label and fingerprint may be used by other VMs
+	if entry.SshKeyID != 0 && entry.SshKeyCreated {
+		err := cv.virtualGuestService.DeleteSshKey(entry.SshKeyID)
+		if err != nil && !strings.Contains(err.Error(), boslc.SOFTLAYER_OBJECTNOTFOUND_EXCEPTION) {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (cv CreateVM) createVirtualGuestTemplate(stemcellUuid string, cloudProps VMCloudProperties,
@@ -214,7 +438,11 @@ func (cv CreateVM) createVirtualGuestTemplate(stemcellUuid string, cloudProps VM
 		PrimaryBackendNetworkComponent: privateNetworkComponent,
 	}
 
-	if cloudProps.FlavorKeyName != "" {
+	if cloudProps.Gpu != "" {
+		virtualGuestTemplate.SupplementalCreateObjectOptions = &datatypes.Virtual_Guest_SupplementalCreateObjectOptions{
+			FlavorKeyName: sl.String(cloudProps.Gpu),
+		}
+	} else if cloudProps.FlavorKeyName != "" {
 		virtualGuestTemplate.SupplementalCreateObjectOptions = &datatypes.Virtual_Guest_SupplementalCreateObjectOptions{
 			FlavorKeyName: sl.String(cloudProps.FlavorKeyName),
 		}
@@ -223,6 +451,24 @@ func (cv CreateVM) createVirtualGuestTemplate(stemcellUuid string, cloudProps VM
 		virtualGuestTemplate.MaxMemory = sl.Int(cloudProps.Memory)
 	}
 
+	// The root disk is ordered as guest_disk0 instead of the size of the stemcell
+	if cloudProps.RootDiskSize != 0 {
+		virtualGuestTemplate.BlockDevices = []datatypes.Virtual_Guest_Block_Device{
+			{
+				Device:    sl.String("0"),
+				DiskImage: &datatypes.Virtual_Disk_Image{Capacity: sl.Int(cloudProps.RootDiskSize)},
+			},
+		}
+	}
+
+	// The ephemeral disk is ordered as guest_disk1 on the storage of LocalDiskFlag, device 1 is swap
+	if cloudProps.EphemeralDiskSize > 0 && !cv.softlayerOptions.EnableVps {
+		virtualGuestTemplate.BlockDevices = append(virtualGuestTemplate.BlockDevices, datatypes.Virtual_Guest_Block_Device{
+			Device:    sl.String("2"),
+			DiskImage: &datatypes.Virtual_Disk_Image{Capacity: sl.Int(cloudProps.EphemeralDiskSize)},
+		})
+	}
+
 	if cloudProps.DedicatedAccountHostOnlyFlag {
 		virtualGuestTemplate.DedicatedAccountHostOnlyFlag = sl.Bool(cloudProps.DedicatedAccountHostOnlyFlag)
 	} else if cloudProps.DedicatedHostId != 0 {
@@ -319,6 +565,58 @@ func (cv CreateVM) getNetworkComponents(networks Networks) (*datatypes.Virtual_G
 	return publicNetworkComponent, privateNetworkComponent, nil
 }
 
+// placementGroup returns the ID of the placement group referred to by ref. With 'auto', it is the group of
+// the deployment and instance group of the VM, created behind the backend router of its private VLAN.
+func (cv CreateVM) placementGroup(ref string, env Environment, privateNetworkComponent *datatypes.Virtual_Guest_Network_Component) (int, error) {
+	if ref != placementGroupAuto {
+		return cv.virtualGuestService.FindPlacementGroup(ref)
+	}
+
+	deployment, instanceGroup, ok := boshGroups(env)
+	if !ok {
+		return 0, bosherr.Error("The deployment and instance group of the VM are not in 'env.bosh.groups'")
+	}
+	name := instance.AutoPlacementGroupName(deployment, instanceGroup)
+
+	vlanID := sl.Get(privateNetworkComponent.NetworkVlan.Id, 0).(int)
+	if vlanID == 0 {
+		subnet, err := cv.virtualGuestService.GetSubnet(*privateNetworkComponent.NetworkVlan.PrimarySubnetId, boslc.NETWORK_DEFAULT_SUBNET_MASK)
+		if err != nil {
+			return 0, bosherr.WrapErrorf(err, "Getting subnet info with id '%d'", *privateNetworkComponent.NetworkVlan.PrimarySubnetId)
+		}
+		vlanID = *subnet.NetworkVlanId
+	}
+
+	return cv.virtualGuestService.EnsurePlacementGroup(name, vlanID)
+}
+
+// boshGroups returns the deployment and instance group of the VM. The groups of the bosh env are
+// director, deployment and instance group names, then their combinations.
+func boshGroups(env Environment) (string, string, bool) {
+	var groups []interface{}
+	if boshenv, ok := env["bosh"].(map[string]interface{}); ok {
+		groups, _ = boshenv["groups"].([]interface{})
+	}
+	if len(groups) < 3 {
+		return "", "", false
+	}
+	return fmt.Sprint(groups[1]), fmt.Sprint(groups[2]), true
+}
+
+func securityGroupBindings(groupIDs []int) []datatypes.Virtual_Network_SecurityGroup_NetworkComponentBinding {
+	if len(groupIDs) == 0 {
+		return nil
+	}
+
+	bindings := []datatypes.Virtual_Network_SecurityGroup_NetworkComponentBinding{}
+	for _, groupID := range groupIDs {
+		bindings = append(bindings, datatypes.Virtual_Network_SecurityGroup_NetworkComponentBinding{
+			SecurityGroup: &datatypes.Network_SecurityGroup{Id: sl.Int(groupID)},
+		})
+	}
+	return bindings
+}
+
 func (cv CreateVM) createNetworkComponentsBySubnetId(subnetId int) (*datatypes.Virtual_Guest_Network_Component, error) {
 	subnet, err := cv.virtualGuestService.GetSubnet(subnetId, boslc.NETWORK_DEFAULT_SUBNET_MASK)
 	if err != nil {
@@ -353,8 +651,9 @@ func (cv CreateVM) createNetworkComponentsByVlanId(vlanId int) (*datatypes.Virtu
 	}, nil
 }
 
-func (cv CreateVM) createByOsReload(stemcellCID StemcellCID, template *datatypes.Virtual_Guest, instanceNetworks instance.Networks, userData *registry.SoftlayerUserData) (int, error) {
+func (cv CreateVM) createByOsReload(stemcellCID StemcellCID, template *datatypes.Virtual_Guest, orderItems instance.OrderItems, instanceNetworks instance.Networks, userData *registry.SoftlayerUserData, entry *journal.Entry) (int, bool, error) {
 	cid := 0
+	upgradeEphemeralDisk := false
 	for _, network := range instanceNetworks {
 		switch network.Type {
 		case "dynamic":
@@ -373,11 +672,24 @@ func (cv CreateVM) createByOsReload(stemcellCID StemcellCID, template *datatypes
 					vm, err = cv.virtualGuestService.FindByPrimaryIp(network.IP)
 				}
 				if err != nil {
-					return cid, err
+					return cid, false, err
 				}
 
-				if err := cv.registryClient.Delete(strconv.Itoa(*vm.Id)); err != nil {
-					return cid, bosherr.WrapErrorf(err, "Cleaning registry record '%d' before os_reload", *vm.Id)
+				// Neither an OS reload nor an upgrade changes the root disk or adds a GPU
+				if err = checkOsReloadItems(vm, orderItems); err != nil {
+					return cid, false, err
+				}
+
+				entry.GuestID = *vm.Id
+				entry.OsReload = true
+				if err = cv.journal.Record(entry, "os_reloading"); err != nil {
+					return cid, false, bosherr.WrapError(err, "Recording OS reloaded VM in journal")
+				}
+
+				if cv.apiVersions.UseRegistry() {
+					if err := cv.registryClient.Delete(strconv.Itoa(*vm.Id)); err != nil {
+						return cid, false, bosherr.WrapErrorf(err, "Cleaning registry record '%d' before os_reload", *vm.Id)
+					}
 				}
 
 				if (template.SupplementalCreateObjectOptions == nil || *template.SupplementalCreateObjectOptions.FlavorKeyName == "") &&
@@ -393,7 +705,7 @@ func (cv CreateVM) createByOsReload(stemcellCID StemcellCID, template *datatypes
 					if desiredCpu != 0 || desiredMemory != 0 {
 						err = cv.virtualGuestService.UpgradeInstance(*vm.Id, desiredCpu, desiredMemory, 0, *vm.DedicatedAccountHostOnlyFlag, vm.DedicatedHost != nil)
 						if err != nil {
-							return cid, bosherr.WrapError(err, "Upgrading VM")
+							return cid, false, bosherr.WrapError(err, "Upgrading VM")
 						}
 					}
 				}
@@ -405,25 +717,79 @@ func (cv CreateVM) createByOsReload(stemcellCID StemcellCID, template *datatypes
 				userData.Server = registry.SoftlayerUserDataServerName{
 					Name: strconv.Itoa(*vm.Id),
 				}
+				if userData.Settings != nil {
+					userData.Settings.VM.Name = strconv.Itoa(*vm.Id)
+				}
 
 				err = cv.virtualGuestService.ReloadOS(*vm.Id, stemcellCID.Int(), []int{sshKey}, *template.Hostname, *template.Domain, userData)
 				if err != nil {
-					return cid, err
+					return cid, false, err
 				}
 
 				cid = *vm.Id
+				upgradeEphemeralDisk = orderItems.EphemeralDiskSize > ephemeralDiskSize(vm)
 			}
-		case "vip":
-			return cid, bosherr.Error("SoftLayer Not Support VIP Networking")
 		default:
 			continue
 		}
 	}
 
-	return cid, nil
+	return cid, upgradeEphemeralDisk, nil
+}
+
+// checkOsReloadItems returns a VMCreationFailedError, not to be retried, if the VM found for an OS
+// reload has a smaller root disk or another flavor than the GPU flavor of orderItems.
+func checkOsReloadItems(vm *datatypes.Virtual_Guest, orderItems instance.OrderItems) error {
+	if orderItems.GpuFlavor != "" {
+		flavor := ""
+		if vm.BillingItem != nil && vm.BillingItem.OrderItem != nil && vm.BillingItem.OrderItem.Preset != nil {
+			flavor = sl.Get(vm.BillingItem.OrderItem.Preset.KeyName, "").(string)
+		}
+		if flavor != orderItems.GpuFlavor {
+			return api.NewVMCreationFailedError(fmt.Sprintf("VM '%d' of flavor '%s' can not be OS reloaded with GPU flavor '%s'", *vm.Id, flavor, orderItems.GpuFlavor), false)
+		}
+	}
+
+	if orderItems.RootDiskSize != 0 {
+		rootDiskSize := 0
+		for _, device := range vm.BlockDevices {
+			if sl.Get(device.Device, "").(string) == "0" && device.DiskImage != nil {
+				rootDiskSize = sl.Get(device.DiskImage.Capacity, 0).(int)
+			}
+		}
+		if rootDiskSize < orderItems.RootDiskSize {
+			return api.NewVMCreationFailedError(fmt.Sprintf("VM '%d' with a root disk of %d GB can not be OS reloaded with a root disk of %d GB", *vm.Id, rootDiskSize, orderItems.RootDiskSize), false)
+		}
+	}
+
+	return nil
+}
+
+// dedicatedHostDiskSize returns the disk in GB a guest with a root disk of rootDiskSize GB, 0 for the
+// default, and an ephemeral disk of ephemeralDiskSize GB takes on a dedicated host.
+func dedicatedHostDiskSize(rootDiskSize int, ephemeralDiskSize int) int {
+	if rootDiskSize == 0 {
+		rootDiskSize = dedicatedHostRootDiskSize
+	}
+	return rootDiskSize + ephemeralDiskSize
+}
+
+// ephemeralDiskSize returns the size in GB of the ephemeral disk of the VM, 0 if it has none.
+func ephemeralDiskSize(vm *datatypes.Virtual_Guest) int {
+	for _, device := range vm.BlockDevices {
+		if sl.Get(device.Device, "").(string) == "2" && device.DiskImage != nil {
+			return sl.Get(device.DiskImage.Capacity, 0).(int)
+		}
+	}
+	return 0
 }
 
 func (cv CreateVM) createUserDataForInstance(registryOptions *registry.ClientOptions, deployedByBoshCLI bool) (*registry.SoftlayerUserData, error) {
+	// Agent settings are written to the user data instead of the registry
+	if !cv.apiVersions.UseRegistry() {
+		return &registry.SoftlayerUserData{}, nil
+	}
+
 	var directorIP string
 	var err error
 	if deployedByBoshCLI {
@@ -448,6 +814,21 @@ func (cv CreateVM) createUserDataForInstance(registryOptions *registry.ClientOpt
 	return &userDataContents, nil
 }
 
+func (cv CreateVM) updateUserDataSettings(cid int, userData *registry.SoftlayerUserData, agentSettings registry.AgentSettings) error {
+	userData.Server = registry.SoftlayerUserDataServerName{
+		Name: strconv.Itoa(cid),
+	}
+	userData.Settings = &agentSettings
+
+	contentsBytes, err := json.Marshal(userData)
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling user data")
+	}
+
+	encodedUserData := base64.RawURLEncoding.EncodeToString(contentsBytes)
+	return cv.virtualGuestService.UpdateInstanceUserData(cid, &encodedUserData)
+}
+
 func (cv CreateVM) updateHostsFile(newIpAddress string, targetHostname string) (err error) {
 	err = os.Setenv("HOSTS_PATH", cv.localDNSConfigFile)
 	if err != nil {
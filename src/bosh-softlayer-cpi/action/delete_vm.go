@@ -13,16 +13,19 @@ type DeleteVMAction struct {
 	vmService        vgs.Service
 	registryClient   registry.Client
 	softlayerOptions boslconfig.Config
+	apiVersions      ApiVersions
 }
 
 func NewDeleteVM(
 	vmDeleterProvider vgs.Service,
 	registryClient registry.Client,
 	softlayerOptions boslconfig.Config,
+	apiVersions ApiVersions,
 ) (action DeleteVMAction) {
 	action.vmService = vmDeleterProvider
 	action.registryClient = registryClient
 	action.softlayerOptions = softlayerOptions
+	action.apiVersions = apiVersions
 	return
 }
 
@@ -36,8 +39,10 @@ func (dv DeleteVMAction) Run(vmCID VMCID) (interface{}, error) {
 	}
 
 	// Delete the VM agent settings
-	if err := dv.registryClient.Delete(vmCID.String()); err != nil {
-		return nil, bosherr.WrapErrorf(err, "Deleting vm '%s'", vmCID)
+	if dv.apiVersions.UseRegistry() {
+		if err := dv.registryClient.Delete(vmCID.String()); err != nil {
+			return nil, bosherr.WrapErrorf(err, "Deleting vm '%s'", vmCID)
+		}
 	}
 
 	return nil, nil
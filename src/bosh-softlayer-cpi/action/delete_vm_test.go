@@ -39,7 +39,7 @@ var _ = Describe("DeleteVM", func() {
 			DisableOsReload: false,
 		}
 
-		deleteVM = NewDeleteVM(vmService, registryClient, softlayerOptions)
+		deleteVM = NewDeleteVM(vmService, registryClient, softlayerOptions, NewApiVersions(1, 1))
 	})
 
 	Describe("Run", func() {
@@ -50,6 +50,15 @@ var _ = Describe("DeleteVM", func() {
 			Expect(registryClient.DeleteCalled).To(BeTrue())
 		})
 
+		It("does not delete the registry record with stemcell api version 2", func() {
+			deleteVM = NewDeleteVM(vmService, registryClient, softlayerOptions, NewApiVersions(2, 2))
+
+			_, err = deleteVM.Run(vmCID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vmService.DeleteCallCount()).To(Equal(1))
+			Expect(registryClient.DeleteCalled).To(BeFalse())
+		})
+
 		It("returns an error if vmService delete call returns an error", func() {
 			vmService.DeleteReturns(
 				errors.New("fake-vm-service-error"),
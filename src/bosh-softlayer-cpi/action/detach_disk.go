@@ -12,15 +12,18 @@ import (
 type DetachDisk struct {
 	vmService      instance.Service
 	registryClient registry.Client
+	apiVersions    ApiVersions
 }
 
 func NewDetachDisk(
 	vmService instance.Service,
 	registryClient registry.Client,
+	apiVersions ApiVersions,
 ) DetachDisk {
 	return DetachDisk{
 		vmService:      vmService,
 		registryClient: registryClient,
+		apiVersions:    apiVersions,
 	}
 }
 
@@ -33,6 +36,10 @@ func (dd DetachDisk) Run(vmCID VMCID, diskCID DiskCID) (interface{}, error) {
 		return nil, bosherr.WrapErrorf(err, "Detaching disk '%s' from vm '%s", diskCID, vmCID)
 	}
 
+	if !dd.apiVersions.UseRegistry() {
+		return nil, nil
+	}
+
 	exist, err := dd.registryClient.IsExist(vmCID.String())
 	if err != nil {
 		return nil, bosherr.WrapErrorf(err, "Checking if instance '%s' exists", vmCID)
@@ -26,7 +26,7 @@ var _ = Describe("DetachDisk", func() {
 	BeforeEach(func() {
 		vmService = &instancefakes.FakeService{}
 		registryClient = &registryfakes.FakeClient{}
-		detachDisk = NewDetachDisk(vmService, registryClient)
+		detachDisk = NewDetachDisk(vmService, registryClient, NewApiVersions(1, 1))
 	})
 
 	Describe("Run", func() {
@@ -73,6 +73,16 @@ var _ = Describe("DetachDisk", func() {
 			Expect(registryClient.UpdateSettings).To(Equal(expectedAgentSettings))
 		})
 
+		It("does not use the registry with stemcell api version 2", func() {
+			detachDisk = NewDetachDisk(vmService, registryClient, NewApiVersions(2, 2))
+
+			_, err = detachDisk.Run(vmCID, diskCID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vmService.DetachDiskCallCount()).To(Equal(1))
+			Expect(registryClient.FetchCalled).To(BeFalse())
+			Expect(registryClient.UpdateCalled).To(BeFalse())
+		})
+
 		It("returns an error if vmService detach disk call returns an error", func() {
 			vmService.DetachDiskReturns(
 				errors.New("fake-vm-service-error"),
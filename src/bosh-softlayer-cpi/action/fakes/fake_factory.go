@@ -10,6 +10,8 @@ import (
 type FakeFactory struct {
 	registeredActions    map[string]*FakeAction
 	registeredActionErrs map[string]error
+
+	CreateApiVersions bgcaction.ApiVersions
 }
 
 func NewFakeFactory() *FakeFactory {
@@ -19,7 +21,8 @@ func NewFakeFactory() *FakeFactory {
 	}
 }
 
-func (f *FakeFactory) Create(method string) (bgcaction.Action, error) {
+func (f *FakeFactory) Create(method string, apiVersions bgcaction.ApiVersions) (bgcaction.Action, error) {
+	f.CreateApiVersions = apiVersions
 	if err := f.registeredActionErrs[method]; err != nil {
 		return nil, err
 	}
@@ -15,6 +15,12 @@ var _ = Describe("Info", func() {
 	})
 
 	Describe("Run", func() {
+		It("advertises api_version 2", func() {
+			response, err := info.Run()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.ApiVersion).To(Equal(2))
+		})
+
 		Context("stemcell_formats", func() {
 			var response InfoResult
 
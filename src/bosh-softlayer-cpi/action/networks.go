@@ -3,13 +3,18 @@ package action
 import (
 	"bosh-softlayer-cpi/registry"
 
+	boslc "bosh-softlayer-cpi/softlayer/client"
 	"bosh-softlayer-cpi/softlayer/virtual_guest_service"
+	"crypto/sha1"
+	"encoding/json"
 	"fmt"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	"github.com/softlayer/softlayer-go/datatypes"
 )
 
 const (
 	NetworkTypeManual string = "manual"
+	NetworkTypeVip    string = "vip"
 )
 
 type Networks map[string]Network
@@ -48,11 +53,134 @@ func (ns Networks) HasManualNetwork() bool {
 	return false
 }
 
+// securityGroupsBySpace returns the security groups of the networks by the network component they
+// apply to, the public one for networks on a PUBLIC VLAN or subnet, else the private one.
+func (ns Networks) securityGroupsBySpace(vmService instance.Service) (SecurityGroups, SecurityGroups, error) {
+	public, private := SecurityGroups{}, SecurityGroups{}
+
+	for name, nw := range ns {
+		if nw.Type == NetworkTypeVip || len(nw.CloudProperties.SecurityGroups) == 0 {
+			continue
+		}
+
+		spaces := []string{}
+		for _, subnetId := range nw.CloudProperties.SubnetIds {
+			subnet, err := vmService.GetSubnet(subnetId, boslc.NETWORK_DEFAULT_SUBNET_MASK)
+			if err != nil {
+				return public, private, bosherr.WrapErrorf(err, "Network: %s, subnet id: %d", name, subnetId)
+			}
+			spaces = append(spaces, *subnet.AddressSpace)
+		}
+		for _, vlanId := range nw.CloudProperties.VlanIds {
+			vlan, err := vmService.GetVlan(vlanId, boslc.NETWORK_DEFAULT_VLAN_MASK)
+			if err != nil {
+				return public, private, bosherr.WrapErrorf(err, "Network: %s, vlan id: %d", name, vlanId)
+			}
+			spaces = append(spaces, *vlan.NetworkSpace)
+		}
+
+		for _, space := range spaces {
+			if space == "PUBLIC" {
+				public = append(public, nw.CloudProperties.SecurityGroups...)
+			} else {
+				private = append(private, nw.CloudProperties.SecurityGroups...)
+			}
+		}
+	}
+
+	return public, private, nil
+}
+
+// vmSecurityGroups returns the IDs of the security groups of the VM cloud properties by the network
+// component they are bound to, all of them to the private one and to the public one if the VM has a
+// public network.
+func vmSecurityGroups(vmService instance.Service, groups SecurityGroups, hasPublicNetwork bool) (instance.SecurityGroups, error) {
+	ids, err := vmService.FindSecurityGroups(groups)
+	if err != nil {
+		return instance.SecurityGroups{}, bosherr.WrapError(err, "Finding security groups of the VM")
+	}
+
+	vmGroups := instance.SecurityGroups{Private: ids}
+	if hasPublicNetwork {
+		vmGroups.Public = ids
+	}
+	return vmGroups, nil
+}
+
+// withNetworkSecurityGroups returns the security groups of the VM and those of the networks by
+// network component, resolved to IDs.
+func withNetworkSecurityGroups(vmService instance.Service, vmGroups instance.SecurityGroups, networks Networks) (instance.SecurityGroups, error) {
+	public, private, err := networks.securityGroupsBySpace(vmService)
+	if err != nil {
+		return instance.SecurityGroups{}, err
+	}
+
+	publicIDs, err := vmService.FindSecurityGroups(public)
+	if err != nil {
+		return instance.SecurityGroups{}, bosherr.WrapError(err, "Finding security groups of the public network component")
+	}
+
+	privateIDs, err := vmService.FindSecurityGroups(private)
+	if err != nil {
+		return instance.SecurityGroups{}, bosherr.WrapError(err, "Finding security groups of the private network component")
+	}
+
+	return instance.SecurityGroups{
+		Public:  appendMissingIDs(vmGroups.Public, publicIDs),
+		Private: appendMissingIDs(vmGroups.Private, privateIDs),
+	}, nil
+}
+
+func appendMissingIDs(ids []int, others []int) []int {
+	merged := append([]int{}, ids...)
+	for _, other := range others {
+		missing := true
+		for _, id := range merged {
+			if id == other {
+				missing = false
+				break
+			}
+		}
+		if missing {
+			merged = append(merged, other)
+		}
+	}
+	return merged
+}
+
+// Digest returns a digest of the networks as the director sent them but their security groups.
+// create_vm records it on the VM, configure_networks compares it to tell whether anything else changed.
+func (ns Networks) Digest() string {
+	networks := Networks{}
+	for name, network := range ns {
+		network.CloudProperties.SecurityGroups = nil
+		// The director sends the address a dynamic network got, when it knows it
+		if network.Type == "dynamic" {
+			network.IP = ""
+		}
+		networks[name] = network
+	}
+
+	// Maps are marshalled with sorted keys, networks always are
+	content, _ := json.Marshal(networks)
+	return fmt.Sprintf("%x", sha1.Sum(content))
+}
+
 func (n Network) isManual() bool {
 	return n.Type == NetworkTypeManual
 }
 
 func parseCloudProperties(networks instance.Networks, netName string, network Network, publicNetworkVlan *datatypes.Network_Vlan) {
+	// VIPs are routed to the public IP of the VM, whichever VLAN it is on
+	if network.Type == NetworkTypeVip {
+		networks[netName] = instance.Network{
+			Type: network.Type,
+			IP:   network.IP,
+			DNS:  network.DNS,
+		}
+		return
+	}
+
 	if len(network.CloudProperties.SubnetIds) > 0 {
 		for index, subnetId := range network.CloudProperties.SubnetIds {
 			var newNetName string
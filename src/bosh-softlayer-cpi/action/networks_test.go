@@ -75,6 +75,19 @@ var _ = Describe("Networks", func() {
 			Expect(ret).To(BeTrue())
 		})
 
+		It("Generate InstanceServiceNetworks with a vip network", func() {
+			networks := Networks{
+				"fake-vip": Network{
+					Type: "vip",
+					IP:   "169.55.61.215",
+				},
+			}
+			instanceNetworks := networks.AsInstanceServiceNetworks(publicVlan)
+			Expect(instanceNetworks).To(HaveKey("fake-vip"))
+			Expect(instanceNetworks["fake-vip"].Type).To(Equal("vip"))
+			Expect(instanceNetworks["fake-vip"].IP).To(Equal("169.55.61.215"))
+		})
+
 		It("Generate InstanceServiceNetworks with two vlans", func() {
 			networks := Networks{
 				"fake-network-name": Network{
@@ -1,7 +1,8 @@
 package action
 
 import (
-	"fmt"
+	"sort"
+	"time"
 
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 
@@ -25,22 +26,65 @@ func NewSnapshotDisk(
 }
 
 func (sd SnapshotDisk) Run(diskCID DiskCID, metadata SnapshotMetadata) (string, error) {
-	var note string
 	// Find the disk
-	_, err := sd.diskService.Find(diskCID.Int())
+	volume, err := sd.diskService.Find(diskCID.Int())
 	if err != nil {
 		return "", bosherr.WrapErrorf(err, "Failed to find disk '%s'", diskCID)
 	}
 
 	// Create the disk snapshot
-	if metadata.Deployment != "" && metadata.Job != "" && metadata.Index != "" {
-		note = fmt.Sprintf("%s_%s_%s", metadata.Deployment, metadata.Job, metadata.Index)
+	snapshotMetadata := snapshot.Metadata{
+		Deployment: metadata.Deployment,
+		Job:        metadata.Job,
+		Index:      metadata.Index.String(),
+		AgentID:    metadata.AgentID,
+		CreatedAt:  time.Now().UTC(),
 	}
-
-	snapshot, err := sd.snapshotService.Create(diskCID.Int(), note)
+	snapshotID, err := sd.snapshotService.Create(diskCID.Int(), snapshotMetadata)
 	if err != nil {
 		return "", bosherr.WrapErrorf(err, "Creating snapshot for disk '%s'", diskCID)
 	}
 
-	return DiskCID(snapshot).String(), nil
+	// The director does not know the new snapshot when pruning fails, it is deleted. The snapshots a
+	// failed pruning left are pruned by the next call.
+	if retention := disk.SnapshotRetention(volume); retention > 0 {
+		err = sd.pruneSnapshots(diskCID.Int(), retention, snapshotID, snapshot.LegacyNotes(snapshotMetadata))
+		if err != nil {
+			if deleteErr := sd.snapshotService.Delete(snapshotID); deleteErr != nil {
+				return "", bosherr.WrapErrorf(err, "Pruning snapshots of disk '%s' (deleting snapshot '%d' failed: %s)", diskCID, snapshotID, deleteErr)
+			}
+			return "", bosherr.WrapErrorf(err, "Pruning snapshots of disk '%s'", diskCID)
+		}
+	}
+
+	return SnapshotCID(snapshotID).String(), nil
+}
+
+// pruneSnapshots deletes the oldest snapshots of a disk the CPI created, keeping retention
+// snapshots and the snapshot snapshotID. Snapshots with legacyNotes were created by older CPI
+// versions for the same instance.
+func (sd SnapshotDisk) pruneSnapshots(diskID int, retention int, snapshotID int, legacyNotes string) error {
+	snapshots, err := sd.snapshotService.List(diskID)
+	if err != nil {
+		return err
+	}
+
+	managed := []snapshot.Snapshot{}
+	for _, s := range snapshots {
+		if (s.ManagedByBOSH || s.Notes == legacyNotes) && s.ID != snapshotID {
+			managed = append(managed, s)
+		}
+	}
+	sort.SliceStable(managed, func(i, j int) bool {
+		return managed[i].CreatedAt.Before(managed[j].CreatedAt)
+	})
+
+	// The new snapshot counts towards the retention
+	for i := 0; i < len(managed)-(retention-1); i++ {
+		if err = sd.snapshotService.Delete(managed[i].ID); err != nil {
+			return bosherr.WrapErrorf(err, "Deleting snapshot '%d'", managed[i].ID)
+		}
+	}
+
+	return nil
 }
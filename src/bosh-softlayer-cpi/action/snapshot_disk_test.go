@@ -2,6 +2,7 @@ package action_test
 
 import (
 	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -9,6 +10,7 @@ import (
 	. "bosh-softlayer-cpi/action"
 
 	diskfakes "bosh-softlayer-cpi/softlayer/disk_service/fakes"
+	"bosh-softlayer-cpi/softlayer/snapshot_service"
 	snapshotfakes "bosh-softlayer-cpi/softlayer/snapshot_service/fakes"
 
 	"github.com/softlayer/softlayer-go/datatypes"
@@ -48,7 +50,7 @@ var _ = Describe("SnapshotDisk", func() {
 				nil,
 			)
 			snapshotService.CreateReturns(snapshotCID.Int(), nil)
-			metadata = SnapshotMetadata{Deployment: "fake-deployment", Job: "fake-job", Index: "fake-index"}
+			metadata = SnapshotMetadata{Deployment: "fake-deployment", Job: "fake-job", Index: "fake-index", AgentID: "fake-agent-id"}
 		})
 
 		Context("creates a snaphot", func() {
@@ -58,6 +60,14 @@ var _ = Describe("SnapshotDisk", func() {
 				Expect(diskService.FindCallCount()).To(Equal(1))
 				Expect(snapshotService.CreateCallCount()).To(Equal(1))
 				Expect(snapshotID).To(Equal("1234567"))
+				actualDiskID, actualMetadata := snapshotService.CreateArgsForCall(0)
+				Expect(actualDiskID).To(Equal(diskCID.Int()))
+				Expect(actualMetadata.Deployment).To(Equal("fake-deployment"))
+				Expect(actualMetadata.Job).To(Equal("fake-job"))
+				Expect(actualMetadata.Index).To(Equal("fake-index"))
+				Expect(actualMetadata.AgentID).To(Equal("fake-agent-id"))
+				Expect(actualMetadata.CreatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+				Expect(snapshotService.ListCallCount()).To(Equal(0))
 			})
 
 		})
@@ -81,5 +91,80 @@ var _ = Describe("SnapshotDisk", func() {
 			Expect(diskService.FindCallCount()).To(Equal(1))
 			Expect(snapshotService.CreateCallCount()).To(Equal(1))
 		})
+
+		Context("when the disk has a snapshot retention", func() {
+			BeforeEach(func() {
+				diskService.FindReturns(
+					&datatypes.Network_Storage{
+						Id:    sl.Int(diskCID.Int()),
+						Notes: sl.String("deployment:fake-deployment,snapshot_retention:2"),
+					},
+					nil,
+				)
+				now := time.Now()
+				snapshotService.ListReturns([]snapshot.Snapshot{
+					{ID: 1234564, CreatedAt: now.Add(-2 * time.Hour), ManagedByBOSH: true},
+					{ID: 1234565, CreatedAt: now.Add(-3 * time.Hour), ManagedByBOSH: true},
+					{ID: 1234566, CreatedAt: now.Add(-4 * time.Hour)},
+					{ID: 1234567, CreatedAt: now, ManagedByBOSH: true},
+					{ID: 1234563, CreatedAt: now.Add(-1 * time.Hour), ManagedByBOSH: true},
+				}, nil)
+			})
+
+			It("deletes the oldest snapshots created by the CPI", func() {
+				snapshotID, err = snapshotDisk.Run(diskCID, metadata)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(snapshotID).To(Equal("1234567"))
+				Expect(snapshotService.ListArgsForCall(0)).To(Equal(diskCID.Int()))
+				Expect(snapshotService.DeleteCallCount()).To(Equal(2))
+				Expect(snapshotService.DeleteArgsForCall(0)).To(Equal(1234565))
+				Expect(snapshotService.DeleteArgsForCall(1)).To(Equal(1234564))
+			})
+
+			It("deletes the oldest snapshots older CPI versions created for the instance", func() {
+				now := time.Now()
+				snapshotService.ListReturns([]snapshot.Snapshot{
+					{ID: 1234564, CreatedAt: now.Add(-2 * time.Hour), Notes: "fake-deployment_fake-job_fake-index"},
+					{ID: 1234565, CreatedAt: now.Add(-3 * time.Hour), Notes: "fake-deployment_other-job_fake-index"},
+					{ID: 1234566, CreatedAt: now.Add(-4 * time.Hour), Notes: "fake-deployment_fake-job_fake-index"},
+					{ID: 1234567, CreatedAt: now, ManagedByBOSH: true},
+					{ID: 1234563, CreatedAt: now.Add(-1 * time.Hour), ManagedByBOSH: true},
+				}, nil)
+
+				_, err = snapshotDisk.Run(diskCID, metadata)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(snapshotService.DeleteCallCount()).To(Equal(2))
+				Expect(snapshotService.DeleteArgsForCall(0)).To(Equal(1234566))
+				Expect(snapshotService.DeleteArgsForCall(1)).To(Equal(1234564))
+			})
+
+			It("returns an error if snapshotService list call returns an error", func() {
+				snapshotService.ListReturns(nil, errors.New("fake-snapshot-service-error"))
+
+				_, err = snapshotDisk.Run(diskCID, metadata)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Pruning snapshots of disk '2345678'"))
+				Expect(snapshotService.DeleteCallCount()).To(Equal(1))
+				Expect(snapshotService.DeleteArgsForCall(0)).To(Equal(1234567))
+			})
+
+			It("returns an error and deletes the new snapshot if snapshotService delete call returns an error", func() {
+				snapshotService.DeleteReturnsOnCall(0, errors.New("fake-snapshot-service-error"))
+
+				_, err = snapshotDisk.Run(diskCID, metadata)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Deleting snapshot '1234565'"))
+				Expect(snapshotService.DeleteCallCount()).To(Equal(2))
+				Expect(snapshotService.DeleteArgsForCall(1)).To(Equal(1234567))
+			})
+
+			It("returns an error if deleting the new snapshot fails too", func() {
+				snapshotService.DeleteReturns(errors.New("fake-snapshot-service-error"))
+
+				_, err = snapshotDisk.Run(diskCID, metadata)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("deleting snapshot '1234567' failed"))
+			})
+		})
 	})
 })
@@ -0,0 +1,99 @@
+package action
+
+import (
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/api"
+	"bosh-softlayer-cpi/softlayer/disk_service"
+)
+
+type UpdateDisk struct {
+	diskService disk.Service
+}
+
+func NewUpdateDisk(
+	diskService disk.Service,
+) UpdateDisk {
+	return UpdateDisk{
+		diskService: diskService,
+	}
+}
+
+func (ud UpdateDisk) Run(diskCID DiskCID, newSize int, cloudProps DiskCloudProperties) (string, error) {
+	err := cloudProps.Validate()
+	if err == nil {
+		err = cloudProps.ValidateSize(newSize)
+	}
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Updating disk '%s'", diskCID)
+	}
+
+	// Disks can not be encrypted in place, the director migrates them to new disks instead
+	if cloudProps.Encrypted {
+		volume, err := ud.diskService.Find(diskCID.Int())
+		if err != nil {
+			if _, ok := err.(api.CloudError); ok {
+				return "", err
+			}
+			return "", bosherr.WrapErrorf(err, "Finding disk '%s'", diskCID)
+		}
+		if !sl.Get(volume.HasEncryptionAtRest, false).(bool) {
+			return "", api.NotSupportedError{}
+		}
+	}
+
+	err = ud.diskService.Update(diskCID.Int(), newSize, cloudProps.DiskType, cloudProps.DataCenter, cloudProps.Iops, cloudProps.Tier, cloudProps.SnapshotSpace)
+	if err != nil {
+		if _, ok := err.(api.CloudError); ok {
+			return "", err
+		}
+		return "", bosherr.WrapErrorf(err, "Updating disk '%s' with size '%d' and cloud properties '%+v'", diskCID, newSize, cloudProps)
+	}
+
+	err = ud.diskService.SetSnapshotRetention(diskCID.Int(), cloudProps.SnapshotRetention)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Updating snapshot retention of disk '%s'", diskCID)
+	}
+
+	if cloudProps.SnapshotSchedule != nil {
+		err = ud.diskService.SetSnapshotSchedules(diskCID.Int(), cloudProps.SnapshotSchedule)
+		if err != nil {
+			return "", bosherr.WrapErrorf(err, "Updating snapshot schedules of disk '%s'", diskCID)
+		}
+	}
+
+	if cloudProps.Replication != nil {
+		if err = ud.replicate(diskCID, *cloudProps.Replication); err != nil {
+			return "", err
+		}
+	}
+
+	return diskCID.String(), nil
+}
+
+// replicate orders a replicant of the disk unless it has one in the datacenter of replication already.
+// Replicants in other datacenters are kept until the disk is deleted.
+func (ud UpdateDisk) replicate(diskCID DiskCID, replication disk.Replication) error {
+	state, err := ud.diskService.GetReplicationState(diskCID.Int())
+	if err != nil {
+		if _, ok := err.(api.CloudError); ok {
+			return err
+		}
+		return bosherr.WrapErrorf(err, "Getting replicants of disk '%s'", diskCID)
+	}
+
+	for _, replicant := range state.Replicants {
+		if strings.EqualFold(replicant.Datacenter, replication.Datacenter) {
+			return nil
+		}
+	}
+
+	if _, err = ud.diskService.Replicate(diskCID.Int(), replication); err != nil {
+		return bosherr.WrapErrorf(err, "Replicating disk '%s' to datacenter '%s'", diskCID, replication.Datacenter)
+	}
+
+	return nil
+}
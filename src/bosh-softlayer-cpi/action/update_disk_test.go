@@ -0,0 +1,207 @@
+package action_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	. "bosh-softlayer-cpi/action"
+	"bosh-softlayer-cpi/api"
+	"bosh-softlayer-cpi/softlayer/disk_service"
+	diskfakes "bosh-softlayer-cpi/softlayer/disk_service/fakes"
+)
+
+var _ = Describe("UpdateDisk", func() {
+	var (
+		err        error
+		diskCID    DiskCID
+		cloudProps DiskCloudProperties
+
+		diskService *diskfakes.FakeService
+
+		updateDisk UpdateDisk
+	)
+
+	BeforeEach(func() {
+		diskCID = DiskCID(22345678)
+		cloudProps = DiskCloudProperties{
+			DataCenter:    "dal10",
+			DiskType:      "endurance",
+			Tier:          4,
+			SnapshotSpace: 20,
+		}
+		diskService = &diskfakes.FakeService{}
+		updateDisk = NewUpdateDisk(diskService)
+	})
+
+	Describe("Run", func() {
+		It("updates the disk in place", func() {
+			diskCIDString, err := updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskCIDString).To(Equal("22345678"))
+			Expect(diskService.UpdateCallCount()).To(Equal(1))
+			actualID, actualSize, actualType, actualDatacenter, actualIops, actualTier, actualSnapshotSpace := diskService.UpdateArgsForCall(0)
+			Expect(actualID).To(Equal(22345678))
+			Expect(actualSize).To(Equal(512000))
+			Expect(actualType).To(Equal("endurance"))
+			Expect(actualDatacenter).To(Equal("dal10"))
+			Expect(actualIops).To(Equal(0))
+			Expect(actualTier).To(Equal(float64(4)))
+			Expect(actualSnapshotSpace).To(Equal(20))
+		})
+
+		It("returns a not supported error if diskService update call returns it", func() {
+			diskService.UpdateReturns(api.NotSupportedError{})
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+		})
+
+		It("returns an error if diskService update call returns an error", func() {
+			diskService.UpdateReturns(errors.New("fake-disk-service-error"))
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-disk-service-error"))
+		})
+
+		It("returns a not supported error if the disk is not encrypted", func() {
+			cloudProps.Encrypted = true
+			diskService.FindReturns(&datatypes.Network_Storage{Id: sl.Int(22345678)}, nil)
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+			Expect(diskService.UpdateCallCount()).To(Equal(0))
+		})
+
+		It("updates an encrypted disk in place", func() {
+			cloudProps.Encrypted = true
+			diskService.FindReturns(&datatypes.Network_Storage{Id: sl.Int(22345678), HasEncryptionAtRest: sl.Bool(true)}, nil)
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskService.FindArgsForCall(0)).To(Equal(22345678))
+			Expect(diskService.UpdateCallCount()).To(Equal(1))
+		})
+
+		It("returns an error if the new size is not supported by the tier", func() {
+			cloudProps.Tier = 10
+
+			_, err = updateDisk.Run(diskCID, 8000*1024, cloudProps)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Endurance disks of tier 10 can not be larger than 4000 GB, not '8000' GB"))
+			Expect(diskService.UpdateCallCount()).To(Equal(0))
+		})
+
+		It("does not change the snapshot schedules without snapshot_schedule", func() {
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskService.SetSnapshotSchedulesCallCount()).To(Equal(0))
+		})
+
+		It("sets the snapshot schedules of the disk", func() {
+			cloudProps.SnapshotSchedule = []disk.SnapshotSchedule{{Type: "WEEKLY", Retention: 4, DayOfWeek: "SATURDAY"}}
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskService.SetSnapshotSchedulesCallCount()).To(Equal(1))
+			actualID, actualSchedules := diskService.SetSnapshotSchedulesArgsForCall(0)
+			Expect(actualID).To(Equal(22345678))
+			Expect(actualSchedules).To(Equal(cloudProps.SnapshotSchedule))
+		})
+
+		It("sets the snapshot retention of the disk", func() {
+			cloudProps.SnapshotRetention = 7
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskService.SetSnapshotRetentionCallCount()).To(Equal(1))
+			actualID, actualRetention := diskService.SetSnapshotRetentionArgsForCall(0)
+			Expect(actualID).To(Equal(22345678))
+			Expect(actualRetention).To(Equal(7))
+		})
+
+		It("returns an error if diskService set snapshot retention call returns an error", func() {
+			diskService.SetSnapshotRetentionReturns(errors.New("fake-disk-service-error"))
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Updating snapshot retention of disk '22345678'"))
+		})
+
+		It("returns an error if the cloud properties are invalid", func() {
+			cloudProps.SnapshotSchedule = []disk.SnapshotSchedule{{Type: "WEEKLY", Retention: 0}}
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The 'retention' of snapshot schedule 'WEEKLY' must be at least 1"))
+			Expect(diskService.UpdateCallCount()).To(Equal(0))
+		})
+
+		It("does not replicate the disk without replication", func() {
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskService.GetReplicationStateCallCount()).To(Equal(0))
+			Expect(diskService.ReplicateCallCount()).To(Equal(0))
+		})
+
+		It("orders a replicant when the disk has none", func() {
+			cloudProps.SnapshotSchedule = []disk.SnapshotSchedule{{Type: "DAILY", Retention: 7}}
+			cloudProps.Replication = &disk.Replication{Datacenter: "wdc07", Schedule: "DAILY"}
+			diskService.GetReplicationStateReturns(disk.ReplicationState{Replicants: []disk.Replicant{}}, nil)
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskService.GetReplicationStateArgsForCall(0)).To(Equal(22345678))
+			Expect(diskService.ReplicateCallCount()).To(Equal(1))
+			actualID, actualReplication := diskService.ReplicateArgsForCall(0)
+			Expect(actualID).To(Equal(22345678))
+			Expect(actualReplication).To(Equal(disk.Replication{Datacenter: "wdc07", Schedule: "DAILY"}))
+		})
+
+		It("orders a replicant when the datacenter of the replication changes", func() {
+			cloudProps.SnapshotSchedule = []disk.SnapshotSchedule{{Type: "DAILY", Retention: 7}}
+			cloudProps.Replication = &disk.Replication{Datacenter: "wdc07", Schedule: "DAILY"}
+			diskService.GetReplicationStateReturns(disk.ReplicationState{Replicants: []disk.Replicant{{ID: 32345678, Datacenter: "dal13", Schedule: "DAILY"}}}, nil)
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskService.ReplicateCallCount()).To(Equal(1))
+			_, actualReplication := diskService.ReplicateArgsForCall(0)
+			Expect(actualReplication.Datacenter).To(Equal("wdc07"))
+		})
+
+		It("does not order a replicant when the disk has one in the datacenter", func() {
+			cloudProps.SnapshotSchedule = []disk.SnapshotSchedule{{Type: "DAILY", Retention: 7}}
+			cloudProps.Replication = &disk.Replication{Datacenter: "wdc07", Schedule: "DAILY"}
+			diskService.GetReplicationStateReturns(disk.ReplicationState{Replicants: []disk.Replicant{{ID: 32345678, Datacenter: "wdc07", Schedule: "DAILY"}}}, nil)
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diskService.ReplicateCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if diskService replicate call returns an error", func() {
+			cloudProps.SnapshotSchedule = []disk.SnapshotSchedule{{Type: "DAILY", Retention: 7}}
+			cloudProps.Replication = &disk.Replication{Datacenter: "wdc07", Schedule: "DAILY"}
+			diskService.ReplicateReturns(0, errors.New("fake-disk-service-error"))
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Replicating disk '22345678' to datacenter 'wdc07'"))
+		})
+
+		It("returns an error if diskService set snapshot schedules call returns an error", func() {
+			cloudProps.SnapshotSchedule = []disk.SnapshotSchedule{}
+			diskService.SetSnapshotSchedulesReturns(errors.New("fake-disk-service-error"))
+
+			_, err = updateDisk.Run(diskCID, 512000, cloudProps)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Updating snapshot schedules of disk '22345678'"))
+		})
+	})
+})
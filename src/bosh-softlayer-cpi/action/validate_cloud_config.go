@@ -0,0 +1,379 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+	"gopkg.in/yaml.v2"
+
+	"bosh-softlayer-cpi/softlayer/disk_service"
+	instance "bosh-softlayer-cpi/softlayer/virtual_guest_service"
+)
+
+const (
+	validateVlanMask   = "id,networkSpace,primaryRouter[datacenter[name]]"
+	validateSubnetMask = "id,networkVlanId,addressSpace,datacenter[name]"
+)
+
+// CloudConfig is the part of a BOSH cloud config the CPI orders from.
+type CloudConfig struct {
+	AZs       []CloudConfigAZ       `json:"azs,omitempty"`
+	VMTypes   []CloudConfigVMType   `json:"vm_types,omitempty"`
+	DiskTypes []CloudConfigDiskType `json:"disk_types,omitempty"`
+	Networks  []CloudConfigNetwork  `json:"networks,omitempty"`
+}
+
+type CloudConfigAZ struct {
+	Name            string                 `json:"name"`
+	CloudProperties map[string]interface{} `json:"cloud_properties,omitempty"`
+}
+
+type CloudConfigVMType struct {
+	Name            string                 `json:"name"`
+	CloudProperties map[string]interface{} `json:"cloud_properties,omitempty"`
+}
+
+type CloudConfigDiskType struct {
+	Name            string              `json:"name"`
+	DiskSize        int                 `json:"disk_size"`
+	CloudProperties DiskCloudProperties `json:"cloud_properties,omitempty"`
+}
+
+// CloudConfigNetwork is a network of a cloud config, dynamic networks may have their cloud
+// properties on the network instead of on subnets.
+type CloudConfigNetwork struct {
+	Name            string                 `json:"name"`
+	Type            string                 `json:"type"`
+	CloudProperties NetworkCloudProperties `json:"cloud_properties,omitempty"`
+	Subnets         []CloudConfigSubnet    `json:"subnets,omitempty"`
+}
+
+type CloudConfigSubnet struct {
+	AZ              string                 `json:"az,omitempty"`
+	AZs             []string               `json:"azs,omitempty"`
+	CloudProperties NetworkCloudProperties `json:"cloud_properties,omitempty"`
+}
+
+// NewCloudConfigFromBytes parses a cloud config in YAML or JSON.
+func NewCloudConfigFromBytes(content []byte) (CloudConfig, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return CloudConfig{}, bosherr.WrapError(err, "Parsing cloud config")
+	}
+
+	jsonContent, err := json.Marshal(jsonCompatible(raw))
+	if err != nil {
+		return CloudConfig{}, bosherr.WrapError(err, "Converting cloud config to JSON")
+	}
+
+	var cloudConfig CloudConfig
+	if err = json.Unmarshal(jsonContent, &cloudConfig); err != nil {
+		return CloudConfig{}, bosherr.WrapError(err, "Unmarshalling cloud config")
+	}
+
+	return cloudConfig, nil
+}
+
+// jsonCompatible converts the maps decoded from YAML, whose keys are interface{}, to maps with string keys.
+func jsonCompatible(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for key, val := range v {
+			m[fmt.Sprint(key)] = jsonCompatible(val)
+		}
+		return m
+	case []interface{}:
+		for i := range v {
+			v[i] = jsonCompatible(v[i])
+		}
+		return v
+	}
+	return value
+}
+
+// ValidationResult is the outcome of resolving one entry of a cloud config, Err is nil if it resolved.
+type ValidationResult struct {
+	Entry string
+	Err   error
+}
+
+func (r ValidationResult) String() string {
+	if r.Err == nil {
+		return fmt.Sprintf("%s: ok", r.Entry)
+	}
+	return fmt.Sprintf("%s: %s", r.Entry, r.Err)
+}
+
+// ValidateCloudConfig resolves the VM types, disk types and networks of a cloud config against the
+// SoftLayer account, without ordering anything.
+type ValidateCloudConfig struct {
+	vmService   instance.Service
+	diskService disk.Service
+}
+
+func NewValidateCloudConfig(
+	vmService instance.Service,
+	diskService disk.Service,
+) ValidateCloudConfig {
+	return ValidateCloudConfig{
+		vmService:   vmService,
+		diskService: diskService,
+	}
+}
+
+// Run returns a result per AZ, per VM type and disk type in every datacenter they are used in, and
+// per network subnet.
+func (v ValidateCloudConfig) Run(cloudConfig CloudConfig) []ValidationResult {
+	results := []ValidationResult{}
+
+	azDatacenters := map[string]string{}
+	datacenters := []string{}
+	for _, az := range cloudConfig.AZs {
+		datacenter, _ := az.CloudProperties["datacenter"].(string)
+		azDatacenters[az.Name] = datacenter
+
+		var err error
+		if datacenter == "" {
+			err = bosherr.Error("The property 'datacenter' must be set")
+		} else if !containsString(datacenters, datacenter) {
+			datacenters = append(datacenters, datacenter)
+		}
+		results = append(results, ValidationResult{Entry: "azs/" + az.Name, Err: err})
+	}
+
+	for _, vmType := range cloudConfig.VMTypes {
+		if len(cloudConfig.AZs) == 0 {
+			results = append(results, ValidationResult{
+				Entry: "vm_types/" + vmType.Name,
+				Err:   v.validateVMType(vmType.CloudProperties),
+			})
+			continue
+		}
+
+		// VM type cloud properties take precedence over the ones of the AZ
+		for _, az := range cloudConfig.AZs {
+			cloudProperties := map[string]interface{}{}
+			for key, value := range az.CloudProperties {
+				cloudProperties[key] = value
+			}
+			for key, value := range vmType.CloudProperties {
+				cloudProperties[key] = value
+			}
+			results = append(results, ValidationResult{
+				Entry: fmt.Sprintf("vm_types/%s (az %s)", vmType.Name, az.Name),
+				Err:   v.validateVMType(cloudProperties),
+			})
+		}
+	}
+
+	for _, diskType := range cloudConfig.DiskTypes {
+		diskDatacenters := datacenters
+		if diskType.CloudProperties.DataCenter != "" {
+			diskDatacenters = []string{diskType.CloudProperties.DataCenter}
+		}
+
+		if len(diskDatacenters) == 0 {
+			results = append(results, ValidationResult{
+				Entry: "disk_types/" + diskType.Name,
+				Err:   v.validateDiskType(diskType, ""),
+			})
+			continue
+		}
+		for _, datacenter := range diskDatacenters {
+			results = append(results, ValidationResult{
+				Entry: fmt.Sprintf("disk_types/%s (datacenter %s)", diskType.Name, datacenter),
+				Err:   v.validateDiskType(diskType, datacenter),
+			})
+		}
+	}
+
+	for _, network := range cloudConfig.Networks {
+		if network.Type == NetworkTypeVip {
+			results = append(results, ValidationResult{Entry: "networks/" + network.Name})
+			continue
+		}
+
+		subnets := network.Subnets
+		if len(subnets) == 0 {
+			subnets = []CloudConfigSubnet{{CloudProperties: network.CloudProperties}}
+		}
+		for i, subnet := range subnets {
+			subnetDatacenters := []string{}
+			for _, az := range append([]string{subnet.AZ}, subnet.AZs...) {
+				if datacenter := azDatacenters[az]; datacenter != "" && !containsString(subnetDatacenters, datacenter) {
+					subnetDatacenters = append(subnetDatacenters, datacenter)
+				}
+			}
+			results = append(results, ValidationResult{
+				Entry: fmt.Sprintf("networks/%s (subnet %d)", network.Name, i),
+				Err:   v.validateSubnet(network.Type, subnet.CloudProperties, subnetDatacenters),
+			})
+		}
+	}
+
+	return results
+}
+
+func (v ValidateCloudConfig) validateVMType(properties map[string]interface{}) error {
+	var cloudProps VMCloudProperties
+	content, err := json.Marshal(properties)
+	if err == nil {
+		err = json.Unmarshal(content, &cloudProps)
+	}
+	if err != nil {
+		return bosherr.WrapError(err, "Parsing cloud properties")
+	}
+
+	if err = cloudProps.Validate(); err != nil {
+		return err
+	}
+
+	orderItems, err := v.vmService.ResolveOrderItems(instance.OrderItems{
+		Datacenter:        cloudProps.Datacenter,
+		LocalDisk:         cloudProps.LocalDiskFlag,
+		RootDiskSize:      cloudProps.RootDiskSize,
+		EphemeralDiskSize: cloudProps.EphemeralDiskSize,
+		Flavor:            cloudProps.FlavorKeyName,
+		GpuFlavor:         cloudProps.Gpu,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(cloudProps.SecurityGroups) > 0 {
+		if _, err = v.vmService.FindSecurityGroups(cloudProps.SecurityGroups); err != nil {
+			return bosherr.WrapError(err, "Finding security groups")
+		}
+	}
+
+	if cloudProps.PlacementGroup != "" && cloudProps.PlacementGroup != placementGroupAuto {
+		if _, err = v.vmService.FindPlacementGroup(cloudProps.PlacementGroup); err != nil {
+			return bosherr.WrapErrorf(err, "Finding placement group '%s'", cloudProps.PlacementGroup)
+		}
+	}
+
+	if len(cloudProps.DedicatedHostIds) > 0 || cloudProps.DedicatedHostGroup != "" {
+		_, err = v.vmService.SelectDedicatedHost(instance.DedicatedHostPlacement{
+			HostIDs:    cloudProps.DedicatedHostIds,
+			Group:      cloudProps.DedicatedHostGroup,
+			Datacenter: cloudProps.Datacenter,
+			Strategy:   cloudProps.DedicatedHostPlacement,
+			Cpu:        cloudProps.Cpu,
+			Memory:     cloudProps.Memory,
+			Flavor:     cloudProps.flavor(),
+			Disk:       dedicatedHostDiskSize(orderItems.RootDiskSize, orderItems.EphemeralDiskSize),
+		})
+		if err != nil {
+			return bosherr.WrapError(err, "Selecting dedicated host")
+		}
+	}
+
+	return nil
+}
+
+// validateDiskType verifies the order of a performance disk of the disk type in datacenter, endurance and
+// encrypted disk types and disk types without datacenter are only checked for consistency.
+func (v ValidateCloudConfig) validateDiskType(diskType CloudConfigDiskType, datacenter string) error {
+	if diskType.DiskSize <= 0 {
+		return bosherr.Error("The property 'disk_size' must be a positive size in MB")
+	}
+
+	if err := diskType.CloudProperties.Validate(); err != nil {
+		return err
+	}
+	if err := diskType.CloudProperties.ValidateSize(diskType.DiskSize); err != nil {
+		return err
+	}
+
+	if datacenter == "" || diskType.CloudProperties.Tier != 0 || diskType.CloudProperties.Encrypted {
+		return nil
+	}
+
+	return v.diskService.ValidateOrder(diskType.DiskSize, diskType.CloudProperties.Iops, datacenter)
+}
+
+// validateSubnet resolves the VLANs and subnets of a network subnet, which must be in the datacenters
+// of its AZs and have at most one private and one public VLAN.
+func (v ValidateCloudConfig) validateSubnet(networkType string, cloudProps NetworkCloudProperties, datacenters []string) error {
+	vlansBySpace := map[string]int{}
+
+	addVlan := func(vlanID int, space string, datacenter string) error {
+		if space != "PRIVATE" && space != "PUBLIC" {
+			return bosherr.Errorf("VLAN '%d' has unknown network space '%s'", vlanID, space)
+		}
+		if other, ok := vlansBySpace[space]; ok && other != vlanID {
+			return bosherr.Errorf("Only one %s VLAN is supported, found VLANs '%d' and '%d'", strings.ToLower(space), other, vlanID)
+		}
+		vlansBySpace[space] = vlanID
+
+		for _, azDatacenter := range datacenters {
+			if datacenter != "" && datacenter != azDatacenter {
+				return bosherr.Errorf("VLAN '%d' is in datacenter '%s', not in datacenter '%s' of the AZ", vlanID, datacenter, azDatacenter)
+			}
+		}
+		return nil
+	}
+
+	for _, subnetID := range cloudProps.SubnetIds {
+		subnet, err := v.vmService.GetSubnet(subnetID, validateSubnetMask)
+		if err != nil {
+			return err
+		}
+
+		datacenter := ""
+		if subnet.Datacenter != nil {
+			datacenter = sl.Get(subnet.Datacenter.Name, "").(string)
+		}
+		err = addVlan(sl.Get(subnet.NetworkVlanId, 0).(int), sl.Get(subnet.AddressSpace, "").(string), datacenter)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Subnet '%d'", subnetID)
+		}
+	}
+
+	for _, vlanID := range cloudProps.VlanIds {
+		vlan, err := v.vmService.GetVlan(vlanID, validateVlanMask)
+		if err != nil {
+			return err
+		}
+
+		if err = addVlan(vlanID, sl.Get(vlan.NetworkSpace, "").(string), vlanDatacenter(vlan)); err != nil {
+			return err
+		}
+	}
+
+	// VMs on manual networks get their network components from the dynamic network
+	if networkType != NetworkTypeManual {
+		if _, ok := vlansBySpace["PRIVATE"]; !ok {
+			return bosherr.Error("A private network is required. Please check vlan_ids")
+		}
+	}
+
+	if len(cloudProps.SecurityGroups) > 0 {
+		if _, err := v.vmService.FindSecurityGroups(cloudProps.SecurityGroups); err != nil {
+			return bosherr.WrapError(err, "Finding security groups")
+		}
+	}
+
+	return nil
+}
+
+func vlanDatacenter(vlan *datatypes.Network_Vlan) string {
+	if vlan.PrimaryRouter == nil || vlan.PrimaryRouter.Datacenter == nil {
+		return ""
+	}
+	return sl.Get(vlan.PrimaryRouter.Datacenter.Name, "").(string)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
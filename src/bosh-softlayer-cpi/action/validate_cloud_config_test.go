@@ -0,0 +1,250 @@
+package action_test
+
+import (
+	"errors"
+
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "bosh-softlayer-cpi/action"
+	diskfakes "bosh-softlayer-cpi/softlayer/disk_service/fakes"
+	instance "bosh-softlayer-cpi/softlayer/virtual_guest_service"
+	instancefakes "bosh-softlayer-cpi/softlayer/virtual_guest_service/fakes"
+)
+
+var _ = Describe("ValidateCloudConfig", func() {
+	var (
+		vmService   *instancefakes.FakeService
+		diskService *diskfakes.FakeService
+
+		cloudConfig CloudConfig
+
+		validateCloudConfig ValidateCloudConfig
+	)
+
+	BeforeEach(func() {
+		vmService = &instancefakes.FakeService{}
+		diskService = &diskfakes.FakeService{}
+
+		vmService.ResolveOrderItemsStub = func(items instance.OrderItems) (instance.OrderItems, error) {
+			return items, nil
+		}
+		vmService.GetVlanStub = func(id int, mask string) (*datatypes.Network_Vlan, error) {
+			space := "PRIVATE"
+			if id == 1234567 {
+				space = "PUBLIC"
+			}
+			return &datatypes.Network_Vlan{
+				Id:           sl.Int(id),
+				NetworkSpace: sl.String(space),
+				PrimaryRouter: &datatypes.Hardware_Router{
+					Hardware_Switch: datatypes.Hardware_Switch{
+						Hardware: datatypes.Hardware{
+							Datacenter: &datatypes.Location{Name: sl.String("lon02")},
+						},
+					},
+				},
+			}, nil
+		}
+
+		var err error
+		cloudConfig, err = NewCloudConfigFromBytes([]byte(`
+azs:
+- name: z1
+  cloud_properties:
+    datacenter: lon02
+vm_types:
+- name: default
+  cloud_properties:
+    hostname_prefix: fake-hostname
+    cpu: 2
+    memory: 4096
+    ephemeral_disk_size: 100
+    security_groups: [allow_ssh]
+disk_types:
+- name: default
+  disk_size: 20480
+  cloud_properties:
+    iops: 1000
+networks:
+- name: default
+  type: dynamic
+  subnets:
+  - az: z1
+    cloud_properties:
+      vlan_ids: [1234567, 1234568]
+- name: vip
+  type: vip
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		validateCloudConfig = NewValidateCloudConfig(vmService, diskService)
+	})
+
+	Describe("NewCloudConfigFromBytes", func() {
+		It("parses the entries of the cloud config", func() {
+			Expect(cloudConfig.AZs).To(HaveLen(1))
+			Expect(cloudConfig.AZs[0].CloudProperties).To(HaveKeyWithValue("datacenter", "lon02"))
+			Expect(cloudConfig.VMTypes[0].CloudProperties).To(HaveKeyWithValue("hostname_prefix", "fake-hostname"))
+			Expect(cloudConfig.DiskTypes[0].DiskSize).To(Equal(20480))
+			Expect(cloudConfig.DiskTypes[0].CloudProperties.Iops).To(Equal(1000))
+			Expect(cloudConfig.Networks[0].Subnets[0].CloudProperties.VlanIds).To(Equal([]int{1234567, 1234568}))
+		})
+
+		It("returns error if the cloud config is not YAML", func() {
+			_, err := NewCloudConfigFromBytes([]byte("azs: ["))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Parsing cloud config"))
+		})
+	})
+
+	Describe("Run", func() {
+		It("reports every entry as ok if it resolves", func() {
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results).To(Equal([]ValidationResult{
+				{Entry: "azs/z1"},
+				{Entry: "vm_types/default (az z1)"},
+				{Entry: "disk_types/default (datacenter lon02)"},
+				{Entry: "networks/default (subnet 0)"},
+				{Entry: "networks/vip"},
+			}))
+			Expect(results[0].String()).To(Equal("azs/z1: ok"))
+
+			Expect(vmService.ResolveOrderItemsCallCount()).To(Equal(1))
+			orderItems := vmService.ResolveOrderItemsArgsForCall(0)
+			Expect(orderItems.Datacenter).To(Equal("lon02"))
+			Expect(orderItems.EphemeralDiskSize).To(Equal(100))
+			Expect(vmService.FindSecurityGroupsCallCount()).To(Equal(1))
+
+			Expect(diskService.ValidateOrderCallCount()).To(Equal(1))
+			size, iops, location := diskService.ValidateOrderArgsForCall(0)
+			Expect(size).To(Equal(20480))
+			Expect(iops).To(Equal(1000))
+			Expect(location).To(Equal("lon02"))
+
+			Expect(vmService.GetVlanCallCount()).To(Equal(2))
+		})
+
+		It("does not order anything", func() {
+			validateCloudConfig.Run(cloudConfig)
+			Expect(vmService.CreateCallCount()).To(Equal(0))
+			Expect(diskService.CreateCallCount()).To(Equal(0))
+		})
+
+		It("reports an AZ without datacenter", func() {
+			cloudConfig.AZs[0].CloudProperties = map[string]interface{}{}
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results[0].Err).To(HaveOccurred())
+			Expect(results[0].String()).To(ContainSubstring("azs/z1: The property 'datacenter' must be set"))
+		})
+
+		It("reports a VM type whose flavor is not available", func() {
+			delete(cloudConfig.VMTypes[0].CloudProperties, "cpu")
+			delete(cloudConfig.VMTypes[0].CloudProperties, "memory")
+			cloudConfig.VMTypes[0].CloudProperties["flavor_key_name"] = "B1_2X8X100"
+			vmService.ResolveOrderItemsReturns(instance.OrderItems{}, errors.New("Flavor 'B1_2X8X100' does not exist"))
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(vmService.ResolveOrderItemsArgsForCall(0).Flavor).To(Equal("B1_2X8X100"))
+			Expect(results[1].Err).To(MatchError("Flavor 'B1_2X8X100' does not exist"))
+		})
+
+		It("selects a dedicated host with room for the flavor of a VM type", func() {
+			delete(cloudConfig.VMTypes[0].CloudProperties, "cpu")
+			delete(cloudConfig.VMTypes[0].CloudProperties, "memory")
+			cloudConfig.VMTypes[0].CloudProperties["flavor_key_name"] = "B1_2X8X100"
+			cloudConfig.VMTypes[0].CloudProperties["dedicated_host_group"] = "cf-dh"
+			vmService.SelectDedicatedHostReturns(0, errors.New("fake-vm-service-error"))
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(vmService.SelectDedicatedHostCallCount()).To(Equal(1))
+			placement := vmService.SelectDedicatedHostArgsForCall(0)
+			Expect(placement.Group).To(Equal("cf-dh"))
+			Expect(placement.Flavor).To(Equal("B1_2X8X100"))
+			Expect(results[1].Err).To(HaveOccurred())
+			Expect(results[1].Err.Error()).To(ContainSubstring("Selecting dedicated host"))
+		})
+
+		It("reports a VM type with invalid cloud properties", func() {
+			cloudConfig.VMTypes[0].CloudProperties["flavor_key_name"] = "B1_2X8X100"
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results[1].Err).To(MatchError("The property 'flavor_key_name' can not be set with 'memory/cpu'"))
+			Expect(vmService.ResolveOrderItemsCallCount()).To(Equal(0))
+		})
+
+		It("reports a disk type whose order is rejected", func() {
+			diskService.ValidateOrderReturns(errors.New("fake-disk-service-error"))
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results[2].Err).To(MatchError("fake-disk-service-error"))
+		})
+
+		It("does not verify the order of endurance disk types", func() {
+			cloudConfig.DiskTypes[0].CloudProperties = DiskCloudProperties{DiskType: "endurance", Tier: 4}
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results[2].Err).NotTo(HaveOccurred())
+			Expect(diskService.ValidateOrderCallCount()).To(Equal(0))
+		})
+
+		It("reports an endurance disk type without tier", func() {
+			cloudConfig.DiskTypes[0].CloudProperties = DiskCloudProperties{DiskType: "endurance"}
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results[2].Err).To(MatchError("The property 'tier' is required by endurance disks"))
+			Expect(diskService.ValidateOrderCallCount()).To(Equal(0))
+		})
+
+		It("does not verify the order of encrypted disk types", func() {
+			cloudConfig.DiskTypes[0].CloudProperties.Encrypted = true
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results[2].Err).NotTo(HaveOccurred())
+			Expect(diskService.ValidateOrderCallCount()).To(Equal(0))
+		})
+
+		It("reports a disk type whose iops are not supported by its size", func() {
+			cloudConfig.DiskTypes[0].CloudProperties.Iops = 2000
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results[2].Err).To(MatchError("The property 'iops' of a 20 GB performance disk must be between 100 and 1000"))
+			Expect(diskService.ValidateOrderCallCount()).To(Equal(0))
+		})
+
+		It("reports a VLAN in another datacenter than the AZ", func() {
+			cloudConfig.AZs[0].CloudProperties["datacenter"] = "dal10"
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results[3].Err).To(HaveOccurred())
+			Expect(results[3].Err.Error()).To(ContainSubstring("VLAN '1234567' is in datacenter 'lon02', not in datacenter 'dal10' of the AZ"))
+		})
+
+		It("reports a network with two private VLANs", func() {
+			cloudConfig.Networks[0].Subnets[0].CloudProperties.VlanIds = []int{1234568, 1234569}
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results[3].Err).To(HaveOccurred())
+			Expect(results[3].Err.Error()).To(ContainSubstring("Only one private VLAN is supported"))
+		})
+
+		It("reports a dynamic network without private VLAN", func() {
+			cloudConfig.Networks[0].Subnets[0].CloudProperties.VlanIds = []int{1234567}
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results[3].Err).To(MatchError("A private network is required. Please check vlan_ids"))
+		})
+
+		It("reports a subnet that does not exist", func() {
+			cloudConfig.Networks[0].Subnets[0].CloudProperties.SubnetIds = []int{2345678}
+			vmService.GetSubnetReturns(nil, errors.New("fake-vm-service-error"))
+
+			results := validateCloudConfig.Run(cloudConfig)
+			Expect(results[3].Err).To(MatchError("fake-vm-service-error"))
+		})
+	})
+})
@@ -3,12 +3,16 @@ package dispatcher
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"regexp"
 
 	bslaction "bosh-softlayer-cpi/action"
 	bslapi "bosh-softlayer-cpi/api"
+	boslconfig "bosh-softlayer-cpi/softlayer/config"
 )
 
+var logFieldPattern = regexp.MustCompile(`"log":"[^^]*"`)
+
 const (
 	jsonLogTag = "json"
 
@@ -21,7 +25,64 @@ type Request struct {
 	Method    string        `json:"method"`
 	Arguments []interface{} `json:"arguments"`
 
-	// context key is ignored
+	// CPI contract version requested by the director
+	ApiVersion int `json:"api_version"`
+
+	Context RequestContext `json:"context"`
+}
+
+type RequestContext struct {
+	DirectorUUID string `json:"director_uuid"`
+
+	// ID of the director request, also used as log tag prefix
+	RequestID string `json:"request_id"`
+
+	// SoftLayer account of the CPI config the request is for (multi-CPI),
+	// the static configuration is used when they are empty
+	Username    string `json:"username"`
+	ApiKey      string `json:"api_key"`
+	ApiEndpoint string `json:"api_endpoint"`
+
+	// Swift user of the SoftLayer account, Swift is not used for an account without one
+	SwiftUsername string `json:"swift_username"`
+
+	VM RequestContextVM `json:"vm"`
+}
+
+type RequestContextVM struct {
+	Stemcell RequestContextStemcell `json:"stemcell"`
+}
+
+type RequestContextStemcell struct {
+	// Agent API version of the stemcell
+	ApiVersion int `json:"api_version"`
+}
+
+// HasSoftLayerAccount returns true if the request context overrides the SoftLayer account or endpoint.
+func (c RequestContext) HasSoftLayerAccount() bool {
+	return c.Username != "" || c.ApiKey != "" || c.ApiEndpoint != ""
+}
+
+// SoftLayerConfig returns config with the SoftLayer account and endpoint of the request context.
+func (c RequestContext) SoftLayerConfig(config boslconfig.Config) boslconfig.Config {
+	if c.Username != "" || c.ApiKey != "" {
+		config.Username = c.Username
+		config.ApiKey = c.ApiKey
+
+		// The Swift user of the static config belongs to another account
+		config.SwiftUsername = c.SwiftUsername
+		if c.SwiftUsername == "" {
+			config.SwiftEndpoint = ""
+		}
+	}
+	if c.ApiEndpoint != "" {
+		config.ApiEndpoint = c.ApiEndpoint
+	}
+	return config
+}
+
+func (r Request) ApiVersions() bslaction.ApiVersions {
+	return bslaction.NewApiVersions(r.ApiVersion, r.Context.VM.Stemcell.ApiVersion)
 }
 
 type Response struct {
@@ -42,52 +103,88 @@ func (r ResponseError) Error() string {
 	return r.Message
 }
 
+// ActionFactoryBuilder builds the action factory of a request whose context carries
+// its own SoftLayer account.
+type ActionFactoryBuilder func(context RequestContext) (bslaction.Factory, error)
+
+// ServedHook is called with the method of every served request before its response is serialized,
+// so what it logs is part of the log of the response.
+type ServedHook func(method string)
+
 type JSON struct {
-	actionFactory bslaction.Factory
-	caller        Caller
-	logger        bslapi.MultiLogger
+	actionFactory        bslaction.Factory
+	actionFactoryBuilder ActionFactoryBuilder
+	caller               Caller
+	logger               bslapi.MultiLogger
+	servedHook           ServedHook
 }
 
+// NewJSON returns a dispatcher creating actions with actionFactory. The actionFactoryBuilder is
+// optional, without it the SoftLayer account of the request context is ignored.
 func NewJSON(
 	actionFactory bslaction.Factory,
+	actionFactoryBuilder ActionFactoryBuilder,
 	caller Caller,
 	logger bslapi.MultiLogger,
 ) JSON {
 	return JSON{
-		actionFactory: actionFactory,
-		caller:        caller,
-		logger:        logger,
+		actionFactory:        actionFactory,
+		actionFactoryBuilder: actionFactoryBuilder,
+		caller:               caller,
+		logger:               logger,
 	}
 }
 
+// WithServedHook returns a dispatcher calling hook for every request with a method.
+func (c JSON) WithServedHook(hook ServedHook) JSON {
+	c.servedHook = hook
+	return c
+}
+
 func (c JSON) Dispatch(reqBytes []byte) []byte {
 	var req Request
 
-	c.logger.DebugWithDetails(jsonLogTag, "Request bytes", redact(reqBytes))
+	c.logger.DebugWithDetails(jsonLogTag, "Request bytes", withoutLog(reqBytes))
 	digitalDecoder := json.NewDecoder(bytes.NewReader(reqBytes))
 	digitalDecoder.UseNumber()
-	if err := digitalDecoder.Decode(&req); err != nil {
-		return c.buildCpiError("Must provide valid JSON payload")
+	err := digitalDecoder.Decode(&req)
+	if err != nil {
+		return c.buildCpiError("", "Must provide valid JSON payload")
 	}
 
+	c.logger.SetRequestID(req.Context.RequestID)
+	c.logger.SetMethod(req.Method)
+	c.logger.Debug(jsonLogTag, "Serving method '%s' of director '%s' for request '%s'", req.Method, req.Context.DirectorUUID, req.Context.RequestID)
+
 	if req.Method == "" {
-		return c.buildCpiError("Must provide method key")
+		return c.buildCpiError(req.Method, "Must provide method key")
 	}
 
 	if req.Arguments == nil {
-		return c.buildCpiError("Must provide arguments key")
+		return c.buildCpiError(req.Method, "Must provide arguments key")
+	}
+
+	actionFactory := c.actionFactory
+	if c.actionFactoryBuilder != nil && req.Context.HasSoftLayerAccount() {
+		c.logger.Debug(jsonLogTag, "Using SoftLayer account '%s' of the request context", req.Context.Username)
+
+		actionFactory, err = c.actionFactoryBuilder(req.Context)
+		if err != nil {
+			return c.buildCpiError(req.Method, fmt.Sprintf("Building actions for the SoftLayer account of the request context: %s", err))
+		}
 	}
 
-	action, err := c.actionFactory.Create(req.Method)
+	action, err := actionFactory.Create(req.Method, req.ApiVersions())
 	if err != nil {
-		return c.buildNotImplementedError()
+		return c.buildNotImplementedError(req.Method)
 	}
 
 	result, err := c.caller.Call(action, req.Arguments)
 	if err != nil {
-		return c.buildCloudError(err)
+		return c.buildCloudError(req.Method, err)
 	}
 
+	c.served(req.Method)
 	resp := Response{
 		Result: result,
 		Log:    c.logger.LogBuff.String(),
@@ -95,15 +192,24 @@ func (c JSON) Dispatch(reqBytes []byte) []byte {
 
 	respBytes, err := json.Marshal(resp)
 	if err != nil {
-		return c.buildCpiError("Failed to serialize result")
+		// The request is reported already
+		return c.buildCpiError("", "Failed to serialize result")
 	}
 
-	c.logger.DebugWithDetails(jsonLogTag, "Response bytes", redact(respBytes))
+	c.logger.DebugWithDetails(jsonLogTag, "Response bytes", withoutLog(respBytes))
 
 	return respBytes
 }
 
-func (c JSON) buildCloudError(err error) []byte {
+// served calls the served hook for requests with a method.
+func (c JSON) served(method string) {
+	if c.servedHook != nil && method != "" {
+		c.servedHook(method)
+	}
+}
+
+func (c JSON) buildCloudError(method string, err error) []byte {
+	c.served(method)
 	respErr := Response{
 		Log:   c.logger.LogBuff.String(),
 		Error: &ResponseError{},
@@ -126,12 +232,13 @@ func (c JSON) buildCloudError(err error) []byte {
 		panic(err)
 	}
 
-	c.logger.DebugWithDetails(jsonLogTag, "CloudError response bytes", redact(respErrBytes))
+	c.logger.DebugWithDetails(jsonLogTag, "CloudError response bytes", withoutLog(respErrBytes))
 
 	return respErrBytes
 }
 
-func (c JSON) buildCpiError(message string) []byte {
+func (c JSON) buildCpiError(method string, message string) []byte {
+	c.served(method)
 	respErr := Response{
 		Log: c.logger.LogBuff.String(),
 		Error: &ResponseError{
@@ -145,12 +252,13 @@ func (c JSON) buildCpiError(message string) []byte {
 		panic(err)
 	}
 
-	c.logger.DebugWithDetails(jsonLogTag, "CpiError response bytes", redact(respErrBytes))
+	c.logger.DebugWithDetails(jsonLogTag, "CpiError response bytes", withoutLog(respErrBytes))
 
 	return respErrBytes
 }
 
-func (c JSON) buildNotImplementedError() []byte {
+func (c JSON) buildNotImplementedError(method string) []byte {
+	c.served(method)
 	respErr := Response{
 		Log: c.logger.LogBuff.String(),
 		Error: &ResponseError{
@@ -164,25 +272,12 @@ func (c JSON) buildNotImplementedError() []byte {
 		panic(err)
 	}
 
-	c.logger.DebugWithDetails(jsonLogTag, "NotImplementedError response bytes", redact(respErrBytes))
+	c.logger.DebugWithDetails(jsonLogTag, "NotImplementedError response bytes", withoutLog(respErrBytes))
 
 	return respErrBytes
 }
 
-func redact(bs []byte) []byte {
-	s := string(bs)
-
-	hiddenStr1 := "\"password\":\"<redact>\""
-	r1 := regexp.MustCompile(`"password":"[^"]*"`)
-	s1 := r1.ReplaceAllString(s, hiddenStr1)
-
-	hiddenStr2 := "\"cert\":<redact>"
-	r2 := regexp.MustCompile(`"cert":\{([^}]*)\}`)
-	s2 := r2.ReplaceAllString(s1, hiddenStr2)
-
-	hiddenStr3 := "\"log\":\"\""
-	r3 := regexp.MustCompile(`"log":"[^^]*"`)
-	s3 := r3.ReplaceAllString(s2, hiddenStr3)
-
-	return []byte(s3)
+// withoutLog drops the log of a response, which is logged already.
+func withoutLog(bs []byte) []byte {
+	return logFieldPattern.ReplaceAll(bs, []byte(`"log":""`))
 }
@@ -7,6 +7,7 @@ import (
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 
 	bslcaction "bosh-softlayer-cpi/action"
+	bslapi "bosh-softlayer-cpi/api"
 )
 
 type Caller interface {
@@ -123,8 +124,13 @@ func (r JSONCaller) getMethodArgType(methodType reflect.Type, index int) (argTyp
 func (r JSONCaller) extractReturns(values []reflect.Value) (value interface{}, err error) {
 	errValue := values[1]
 	if !errValue.IsNil() {
-		errorValues := errValue.MethodByName("Error").Call([]reflect.Value{})
-		err = bosherr.Error(errorValues[0].String())
+		// Keep cloud errors intact so that the dispatcher can report their type
+		if cloudErr, ok := errValue.Interface().(bslapi.CloudError); ok {
+			err = cloudErr
+		} else {
+			errorValues := errValue.MethodByName("Error").Call([]reflect.Value{})
+			err = bosherr.Error(errorValues[0].String())
+		}
 	}
 
 	value = values[0].Interface()
@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"bosh-softlayer-cpi/api"
 	. "bosh-softlayer-cpi/api/dispatcher"
 )
 
@@ -102,6 +103,13 @@ var _ = Describe("JSONCaller", func() {
 			Expect(action.SliceArgs).To(Equal([]string{"a", "b", "c"}))
 		})
 
+		It("keeps the type of cloud errors returned by action", func() {
+			action := &actionWithOptionalRunArgument{Err: api.NotSupportedError{}}
+
+			_, err := caller.Call(action, []interface{}{"setup"})
+			Expect(err).To(Equal(api.NotSupportedError{}))
+		})
+
 		It("returns error if actions not enough arguments", func() {
 			expectedValue := valueType{ID: 13, Success: true}
 
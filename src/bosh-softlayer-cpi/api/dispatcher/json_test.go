@@ -12,11 +12,13 @@ import (
 
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 
+	bgcaction "bosh-softlayer-cpi/action"
 	fakeaction "bosh-softlayer-cpi/action/fakes"
 	bgcapi "bosh-softlayer-cpi/api"
 	fakedisp "bosh-softlayer-cpi/api/dispatcher/fakes"
 	fakeapi "bosh-softlayer-cpi/api/fakes"
 	cpiLog "bosh-softlayer-cpi/logger"
+	boslconfig "bosh-softlayer-cpi/softlayer/config"
 )
 
 var _ = Describe("JSON", func() {
@@ -31,7 +33,7 @@ var _ = Describe("JSON", func() {
 		actionFactory = fakeaction.NewFakeFactory()
 		caller = &fakedisp.FakeCaller{}
 		logger = cpiLog.NewLogger(boshlog.LevelNone, "")
-		dispatcher = NewJSON(actionFactory, caller, bgcapi.MultiLogger{Logger: logger, LogBuff: &bytes.Buffer{}})
+		dispatcher = NewJSON(actionFactory, nil, caller, bgcapi.MultiLogger{Logger: logger, LogBuff: &bytes.Buffer{}})
 	})
 
 	Describe("Dispatch", func() {
@@ -68,6 +70,88 @@ var _ = Describe("JSON", func() {
 				}))
 			})
 
+			It("creates action with api versions from request", func() {
+				dispatcher.Dispatch([]byte(`{
+          "method":"fake-action",
+          "arguments":["fake-arg"],
+          "api_version":2,
+          "context":{"director_uuid":"fake-uuid","vm":{"stemcell":{"api_version":2}}}
+        }`))
+				Expect(actionFactory.CreateApiVersions).To(Equal(bgcaction.ApiVersions{Contract: 2, Stemcell: 2}))
+			})
+
+			It("tags logs with the request id from the request context", func() {
+				dispatcher.Dispatch([]byte(`{
+          "method":"fake-action",
+          "arguments":["fake-arg"],
+          "context":{"director_uuid":"fake-uuid","request_id":"fake-request-id"}
+        }`))
+				Expect(logger.GetRequestID()).To(Equal("fake-request-id"))
+				Expect(logger.GetSerialTagPrefix()).To(Equal("fake-request-id"))
+			})
+
+			Context("when request context carries a SoftLayer account", func() {
+				var (
+					accountFactory *fakeaction.FakeFactory
+					builtContext   RequestContext
+					buildErr       error
+				)
+
+				BeforeEach(func() {
+					accountFactory = fakeaction.NewFakeFactory()
+					accountFactory.RegisterAction("fake-action", action)
+					buildErr = nil
+
+					dispatcher = NewJSON(actionFactory, func(context RequestContext) (bgcaction.Factory, error) {
+						builtContext = context
+						return accountFactory, buildErr
+					}, caller, bgcapi.MultiLogger{Logger: logger, LogBuff: &bytes.Buffer{}})
+				})
+
+				It("creates action with the action factory of the account", func() {
+					dispatcher.Dispatch([]byte(`{
+          "method":"fake-action",
+          "arguments":["fake-arg"],
+          "api_version":2,
+          "context":{"director_uuid":"fake-uuid","username":"fake-username","api_key":"fake-api-key"}
+        }`))
+					Expect(builtContext.Username).To(Equal("fake-username"))
+					Expect(builtContext.ApiKey).To(Equal("fake-api-key"))
+					Expect(accountFactory.CreateApiVersions).To(Equal(bgcaction.ApiVersions{Contract: 2, Stemcell: 1}))
+					Expect(actionFactory.CreateApiVersions).To(Equal(bgcaction.ApiVersions{}))
+				})
+
+				It("creates action with the static action factory if the context has no account", func() {
+					dispatcher.Dispatch([]byte(`{"method":"fake-action","arguments":["fake-arg"],"context":{"director_uuid":"fake-uuid"}}`))
+					Expect(actionFactory.CreateApiVersions).To(Equal(bgcaction.ApiVersions{Contract: 1, Stemcell: 1}))
+					Expect(accountFactory.CreateApiVersions).To(Equal(bgcaction.ApiVersions{}))
+				})
+
+				It("responds with Bosh::Clouds::CpiError if building the action factory fails", func() {
+					buildErr = errors.New("fake-build-err")
+
+					response := dispatcher.Dispatch([]byte(`{
+          "method":"fake-action",
+          "arguments":["fake-arg"],
+          "context":{"username":"fake-username"}
+        }`))
+					Expect(response).To(MatchJSON(`{
+						"result": null,
+						"error": {
+							"type":"Bosh::Clouds::CpiError",
+							"message":"Building actions for the SoftLayer account of the request context: fake-build-err",
+							"ok_to_retry": false
+						},
+						"log": ""
+					}`))
+				})
+			})
+
+			It("creates action with api version 1 when request does not specify versions", func() {
+				dispatcher.Dispatch([]byte(`{"method":"fake-action","arguments":["fake-arg"]}`))
+				Expect(actionFactory.CreateApiVersions).To(Equal(bgcaction.ApiVersions{Contract: 1, Stemcell: 1}))
+			})
+
 			Context("when running action succeeds", func() {
 				Context("when result can be serialized", func() {
 					BeforeEach(func() {
@@ -244,3 +328,55 @@ var _ = Describe("JSON", func() {
 		})
 	})
 })
+
+var _ = Describe("RequestContext", func() {
+	var (
+		staticConfig boslconfig.Config
+	)
+
+	BeforeEach(func() {
+		staticConfig = boslconfig.Config{
+			Username:      "fake-static-username",
+			ApiKey:        "fake-static-api-key",
+			ApiEndpoint:   "fake-static-api-endpoint",
+			EnableVps:     true,
+			SwiftUsername: "fake-static-swift-username",
+			SwiftEndpoint: "fake-static-swift-endpoint",
+		}
+	})
+
+	Describe("SoftLayerConfig", func() {
+		It("overrides the account and the endpoint of the static config", func() {
+			context := RequestContext{Username: "fake-username", ApiKey: "fake-api-key", ApiEndpoint: "fake-api-endpoint"}
+			Expect(context.HasSoftLayerAccount()).To(BeTrue())
+
+			config := context.SoftLayerConfig(staticConfig)
+			Expect(config.Username).To(Equal("fake-username"))
+			Expect(config.ApiKey).To(Equal("fake-api-key"))
+			Expect(config.ApiEndpoint).To(Equal("fake-api-endpoint"))
+			Expect(config.EnableVps).To(BeTrue())
+			Expect(config.SwiftUsername).To(BeEmpty())
+			Expect(config.SwiftEndpoint).To(BeEmpty())
+		})
+
+		It("uses the swift user of the request context with the static swift endpoint", func() {
+			context := RequestContext{Username: "fake-username", ApiKey: "fake-api-key", SwiftUsername: "fake-swift-username"}
+
+			config := context.SoftLayerConfig(staticConfig)
+			Expect(config.SwiftUsername).To(Equal("fake-swift-username"))
+			Expect(config.SwiftEndpoint).To(Equal("fake-static-swift-endpoint"))
+		})
+
+		It("falls back to the static config", func() {
+			context := RequestContext{ApiEndpoint: "fake-api-endpoint"}
+
+			config := context.SoftLayerConfig(staticConfig)
+			Expect(config.Username).To(Equal("fake-static-username"))
+			Expect(config.ApiKey).To(Equal("fake-static-api-key"))
+			Expect(config.ApiEndpoint).To(Equal("fake-api-endpoint"))
+
+			Expect(RequestContext{}.HasSoftLayerAccount()).To(BeFalse())
+			Expect(RequestContext{}.SoftLayerConfig(staticConfig)).To(Equal(staticConfig))
+		})
+	})
+})
@@ -0,0 +1,107 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	bslcdisp "bosh-softlayer-cpi/api/dispatcher"
+	"bosh-softlayer-cpi/logger"
+)
+
+const (
+	forwardingDispatcherLogTag = "ForwardingDispatcher"
+
+	forwardingCpiErrorType = "Bosh::Clouds::CpiError"
+)
+
+// ForwardingDispatcher hands requests over to a CPI server. When the server cannot be
+// reached the request is served by the local dispatcher instead, which is only built then.
+type ForwardingDispatcher struct {
+	network  string
+	address  string
+	newLocal func() bslcdisp.Dispatcher
+	logger   logger.Logger
+}
+
+func NewForwardingDispatcher(
+	network string,
+	address string,
+	newLocal func() bslcdisp.Dispatcher,
+	logger logger.Logger,
+) ForwardingDispatcher {
+	return ForwardingDispatcher{
+		network:  network,
+		address:  address,
+		newLocal: newLocal,
+		logger:   logger,
+	}
+}
+
+func (d ForwardingDispatcher) Dispatch(reqBytes []byte) []byte {
+	conn, err := net.Dial(d.network, d.address)
+	if err != nil {
+		d.logger.Warn(forwardingDispatcherLogTag, "Server on '%s' is not reachable, serving request locally: %s", d.address, err)
+		return d.newLocal().Dispatch(reqBytes)
+	}
+	defer conn.Close()
+
+	// Once the request is sent the server may act on it, so it must not be served again locally
+	respBytes, err := d.forward(conn, reqBytes)
+	if err != nil {
+		d.logger.Error(forwardingDispatcherLogTag, "Failed forwarding request to '%s': %s", d.address, err)
+		return d.buildCpiError(err.Error())
+	}
+
+	return respBytes
+}
+
+func (d ForwardingDispatcher) forward(conn net.Conn, reqBytes []byte) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodPost, "http://localhost/", bytes.NewReader(reqBytes))
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Building request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if err = req.Write(conn); err != nil {
+		return nil, bosherr.WrapError(err, "Writing request")
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Reading response")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, bosherr.Errorf("Server responded with status '%s'", resp.Status)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Reading response body")
+	}
+
+	return respBytes, nil
+}
+
+func (d ForwardingDispatcher) buildCpiError(message string) []byte {
+	respErr := bslcdisp.Response{
+		Error: &bslcdisp.ResponseError{
+			Type:    forwardingCpiErrorType,
+			Message: message,
+		},
+	}
+
+	respErrBytes, err := json.Marshal(respErr)
+	if err != nil {
+		panic(err)
+	}
+
+	return respErrBytes
+}
@@ -0,0 +1,83 @@
+package transport_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "bosh-softlayer-cpi/api/transport"
+
+	bslcdisp "bosh-softlayer-cpi/api/dispatcher"
+	fakedisp "bosh-softlayer-cpi/api/dispatcher/fakes"
+	cpilog "bosh-softlayer-cpi/logger"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+)
+
+var _ = Describe("ForwardingDispatcher", func() {
+	var (
+		tmpDir     string
+		socketPath string
+		local      *fakedisp.FakeDispatcher
+		localBuilt bool
+		logger     cpilog.Logger
+		forwarder  ForwardingDispatcher
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "cpi-forwarder")
+		Expect(err).ToNot(HaveOccurred())
+		socketPath = filepath.Join(tmpDir, "cpi.sock")
+
+		local = &fakedisp.FakeDispatcher{}
+		logger = cpilog.NewLogger(boshlog.LevelNone, "")
+		localBuilt = false
+		forwarder = NewForwardingDispatcher("unix", socketPath, func() bslcdisp.Dispatcher {
+			localBuilt = true
+			return local
+		}, logger)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	Describe("Dispatch", func() {
+		It("serves the request locally if the server is not reachable", func() {
+			local.DispatchRespBytes = []byte("fake-local-bytes-out")
+
+			respBytes := forwarder.Dispatch([]byte("fake-bytes-in"))
+			Expect(respBytes).To(Equal([]byte("fake-local-bytes-out")))
+			Expect(local.DispatchReqBytes).To(Equal([]byte("fake-bytes-in")))
+			Expect(localBuilt).To(BeTrue())
+		})
+
+		It("returns a cpi error without serving the request locally if the server fails", func() {
+			listener, err := net.Listen("unix", socketPath)
+			Expect(err).ToNot(HaveOccurred())
+			httpServer := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "fake-server-error", http.StatusInternalServerError)
+			})}
+			go httpServer.Serve(listener)
+			defer httpServer.Close()
+
+			respBytes := forwarder.Dispatch([]byte("fake-bytes-in"))
+			Expect(respBytes).To(MatchJSON(`{
+				"result": null,
+				"error": {
+					"type": "Bosh::Clouds::CpiError",
+					"message": "Server responded with status '500 Internal Server Error'",
+					"ok_to_retry": false
+				},
+				"log": ""
+			}`))
+			Expect(local.DispatchReqBytes).To(BeNil())
+			Expect(localBuilt).To(BeFalse())
+		})
+	})
+})
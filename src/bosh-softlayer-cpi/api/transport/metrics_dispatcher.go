@@ -0,0 +1,47 @@
+package transport
+
+import (
+	bslcdisp "bosh-softlayer-cpi/api/dispatcher"
+	"bosh-softlayer-cpi/logger"
+	"bosh-softlayer-cpi/metrics"
+)
+
+const metricsDispatcherLogTag = "MetricsDispatcher"
+
+// MetricsDispatcher reports the SoftLayer API calls made while serving a request, before its
+// response is serialized so the summary is part of the response log.
+type MetricsDispatcher struct {
+	dispatcher bslcdisp.Dispatcher
+	collector  *metrics.Collector
+	reporters  []metrics.Reporter
+	logger     logger.Logger
+}
+
+func NewMetricsDispatcher(
+	dispatcher bslcdisp.JSON,
+	collector *metrics.Collector,
+	reporters []metrics.Reporter,
+	logger logger.Logger,
+) MetricsDispatcher {
+	d := MetricsDispatcher{
+		collector: collector,
+		reporters: reporters,
+		logger:    logger,
+	}
+	d.dispatcher = dispatcher.WithServedHook(d.report)
+	return d
+}
+
+func (d MetricsDispatcher) Dispatch(reqBytes []byte) []byte {
+	return d.dispatcher.Dispatch(reqBytes)
+}
+
+func (d MetricsDispatcher) report(method string) {
+	stats := d.collector.Stats()
+	for _, reporter := range d.reporters {
+		// Metrics are best effort, they never fail the request
+		if err := reporter.Report(method, stats); err != nil {
+			d.logger.Warn(metricsDispatcherLogTag, "Reporting SoftLayer API call metrics: %s", err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package transport_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "bosh-softlayer-cpi/api/transport"
+
+	fakeaction "bosh-softlayer-cpi/action/fakes"
+	"bosh-softlayer-cpi/api"
+	"bosh-softlayer-cpi/api/dispatcher"
+	fakedisp "bosh-softlayer-cpi/api/dispatcher/fakes"
+	cpilog "bosh-softlayer-cpi/logger"
+	"bosh-softlayer-cpi/metrics"
+	fakemetrics "bosh-softlayer-cpi/metrics/fakes"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+)
+
+var _ = Describe("MetricsDispatcher", func() {
+	var (
+		caller        *fakedisp.FakeCaller
+		logBuff       *bytes.Buffer
+		collector     *metrics.Collector
+		reporter      *fakemetrics.FakeReporter
+		otherReporter *fakemetrics.FakeReporter
+		metricsDisp   MetricsDispatcher
+	)
+
+	BeforeEach(func() {
+		actionFactory := fakeaction.NewFakeFactory()
+		actionFactory.RegisterAction("create_vm", &fakeaction.FakeAction{})
+		actionFactory.RegisterAction("delete_vm", &fakeaction.FakeAction{})
+		caller = &fakedisp.FakeCaller{CallResult: "fake-result"}
+		logBuff = &bytes.Buffer{}
+		logger := cpilog.NewLogger(boshlog.LevelNone, "")
+		jsonDisp := dispatcher.NewJSON(actionFactory, nil, caller, api.MultiLogger{Logger: logger, LogBuff: logBuff})
+
+		collector = metrics.NewCollector()
+		reporter = &fakemetrics.FakeReporter{}
+		otherReporter = &fakemetrics.FakeReporter{}
+		metricsDisp = NewMetricsDispatcher(jsonDisp, collector, []metrics.Reporter{reporter, otherReporter}, logger)
+	})
+
+	Describe("Dispatch", func() {
+		It("reports the SoftLayer API calls of the request to every reporter", func() {
+			collector.Record("SoftLayer_Virtual_Guest", "getObject", time.Second, nil)
+
+			respBytes := metricsDisp.Dispatch([]byte(`{"method":"create_vm","arguments":[]}`))
+			Expect(respBytes).To(ContainSubstring(`"result":"fake-result"`))
+
+			Expect(reporter.ReportCallCount()).To(Equal(1))
+			cpiMethod, stats := reporter.ReportArgsForCall(0)
+			Expect(cpiMethod).To(Equal("create_vm"))
+			Expect(stats).To(HaveLen(1))
+			Expect(stats[0].Method).To(Equal("getObject"))
+			Expect(otherReporter.ReportCallCount()).To(Equal(1))
+		})
+
+		It("reports before the response is serialized", func() {
+			reporter.ReportStub = func(cpiMethod string, stats []metrics.CallStats) error {
+				logBuff.WriteString("fake-metrics-summary")
+				return nil
+			}
+
+			respBytes := metricsDisp.Dispatch([]byte(`{"method":"create_vm","arguments":[]}`))
+
+			var resp dispatcher.Response
+			Expect(json.Unmarshal(respBytes, &resp)).To(Succeed())
+			Expect(resp.Log).To(ContainSubstring("fake-metrics-summary"))
+		})
+
+		It("reports failed requests", func() {
+			caller.CallErr = errors.New("fake-call-error")
+
+			respBytes := metricsDisp.Dispatch([]byte(`{"method":"delete_vm","arguments":[]}`))
+			Expect(respBytes).To(ContainSubstring("fake-call-error"))
+			Expect(reporter.ReportCallCount()).To(Equal(1))
+		})
+
+		It("returns the response if a reporter fails", func() {
+			reporter.ReportReturns(errors.New("fake-report-error"))
+
+			respBytes := metricsDisp.Dispatch([]byte(`{"method":"delete_vm","arguments":[]}`))
+			Expect(respBytes).To(ContainSubstring(`"result":"fake-result"`))
+			Expect(otherReporter.ReportCallCount()).To(Equal(1))
+		})
+
+		It("does not report requests without method", func() {
+			respBytes := metricsDisp.Dispatch([]byte("fake-bytes-in"))
+			Expect(respBytes).To(ContainSubstring("Must provide valid JSON payload"))
+			Expect(reporter.ReportCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package transport
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	bslcdisp "bosh-softlayer-cpi/api/dispatcher"
+	"bosh-softlayer-cpi/logger"
+)
+
+const serverLogTag = "Server"
+
+// DispatcherFactory builds the dispatcher of a single request, so every request gets
+// its own logger while sharing the SoftLayer client of the server.
+type DispatcherFactory func() bslcdisp.Dispatcher
+
+type Server struct {
+	listener          net.Listener
+	dispatcherFactory DispatcherFactory
+	logger            logger.Logger
+	httpServer        *http.Server
+}
+
+func NewServer(
+	listener net.Listener,
+	dispatcherFactory DispatcherFactory,
+	logger logger.Logger,
+) Server {
+	server := Server{
+		listener:          listener,
+		dispatcherFactory: dispatcherFactory,
+		logger:            logger,
+		httpServer:        &http.Server{},
+	}
+	server.httpServer.Handler = server
+
+	return server
+}
+
+// Listen listens on network and address. A socket left behind by a server that is no
+// longer running is removed first, and a new socket is only accessible by its owner.
+func Listen(network string, address string) (net.Listener, error) {
+	if network == "unix" {
+		if _, err := os.Stat(address); err == nil {
+			conn, err := net.Dial(network, address)
+			if err == nil {
+				conn.Close()
+				return nil, bosherr.Errorf("Another server is listening on '%s'", address)
+			}
+
+			if err = os.Remove(address); err != nil {
+				return nil, bosherr.WrapErrorf(err, "Removing stale socket '%s'", address)
+			}
+		}
+	}
+
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Listening on '%s'", address)
+	}
+
+	if network == "unix" {
+		if err = os.Chmod(address, 0600); err != nil {
+			listener.Close()
+			return nil, bosherr.WrapErrorf(err, "Changing mode of socket '%s'", address)
+		}
+	}
+
+	return listener, nil
+}
+
+// Serve dispatches requests concurrently until the server is shut down.
+func (s Server) Serve() error {
+	s.logger.Info(serverLogTag, "Serving requests on '%s'", s.listener.Addr())
+
+	err := s.httpServer.Serve(s.listener)
+	if err != nil && err != http.ErrServerClosed {
+		return bosherr.WrapError(err, "Serving requests")
+	}
+
+	return nil
+}
+
+// Shutdown stops accepting requests and waits for the requests in flight.
+func (s Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST requests are served", http.StatusMethodNotAllowed)
+		return
+	}
+
+	reqBytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.logger.Error(serverLogTag, "Failed reading request: %s", err)
+		http.Error(w, "Reading request", http.StatusBadRequest)
+		return
+	}
+
+	respBytes := s.dispatcherFactory().Dispatch(reqBytes)
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(respBytes)
+	if err != nil {
+		s.logger.Error(serverLogTag, "Failed writing response: %s", err)
+	}
+}
@@ -0,0 +1,163 @@
+package transport_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "bosh-softlayer-cpi/api/transport"
+
+	bslcdisp "bosh-softlayer-cpi/api/dispatcher"
+	fakedisp "bosh-softlayer-cpi/api/dispatcher/fakes"
+	cpilog "bosh-softlayer-cpi/logger"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+)
+
+type blockingDispatcher struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (d blockingDispatcher) Dispatch(reqBytes []byte) []byte {
+	d.started <- struct{}{}
+	<-d.release
+	return reqBytes
+}
+
+var _ = Describe("Server", func() {
+	var (
+		tmpDir     string
+		socketPath string
+		dispatcher *fakedisp.FakeDispatcher
+		logger     cpilog.Logger
+		listener   net.Listener
+		server     Server
+		served     chan error
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "cpi-server")
+		Expect(err).ToNot(HaveOccurred())
+		socketPath = filepath.Join(tmpDir, "cpi.sock")
+
+		dispatcher = &fakedisp.FakeDispatcher{}
+		logger = cpilog.NewLogger(boshlog.LevelNone, "")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	serve := func(dispatcherFactory DispatcherFactory) {
+		var err error
+		listener, err = Listen("unix", socketPath)
+		Expect(err).ToNot(HaveOccurred())
+
+		server = NewServer(listener, dispatcherFactory, logger)
+		served = make(chan error, 1)
+		go func() { served <- server.Serve() }()
+	}
+
+	shutdown := func() {
+		Expect(server.Shutdown(context.Background())).To(Succeed())
+		Expect(<-served).ToNot(HaveOccurred())
+	}
+
+	Describe("Serve", func() {
+		It("dispatches forwarded requests and returns their responses", func() {
+			dispatcher.DispatchRespBytes = []byte("fake-bytes-out")
+			serve(func() bslcdisp.Dispatcher { return dispatcher })
+			defer shutdown()
+
+			forwarder := NewForwardingDispatcher("unix", socketPath, func() bslcdisp.Dispatcher { return &fakedisp.FakeDispatcher{} }, logger)
+			respBytes := forwarder.Dispatch([]byte("fake-bytes-in"))
+
+			Expect(respBytes).To(Equal([]byte("fake-bytes-out")))
+			Expect(dispatcher.DispatchReqBytes).To(Equal([]byte("fake-bytes-in")))
+		})
+
+		It("dispatches requests concurrently", func() {
+			blocking := blockingDispatcher{started: make(chan struct{}), release: make(chan struct{})}
+			serve(func() bslcdisp.Dispatcher { return blocking })
+			defer shutdown()
+
+			forwarder := NewForwardingDispatcher("unix", socketPath, func() bslcdisp.Dispatcher { return &fakedisp.FakeDispatcher{} }, logger)
+			responses := make(chan []byte, 2)
+			wg := sync.WaitGroup{}
+			for _, req := range []string{"fake-request-1", "fake-request-2"} {
+				wg.Add(1)
+				go func(req string) {
+					defer wg.Done()
+					responses <- forwarder.Dispatch([]byte(req))
+				}(req)
+			}
+
+			<-blocking.started
+			<-blocking.started
+			close(blocking.release)
+			wg.Wait()
+
+			Expect(string(<-responses)).To(HavePrefix("fake-request-"))
+			Expect(string(<-responses)).To(HavePrefix("fake-request-"))
+		})
+	})
+
+	Describe("ServeHTTP", func() {
+		It("rejects requests other than POST", func() {
+			server = NewServer(nil, func() bslcdisp.Dispatcher { return dispatcher }, logger)
+
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(dispatcher.DispatchReqBytes).To(BeNil())
+		})
+
+		It("dispatches the request body", func() {
+			dispatcher.DispatchRespBytes = []byte("fake-bytes-out")
+			server = NewServer(nil, func() bslcdisp.Dispatcher { return dispatcher }, logger)
+
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("fake-bytes-in")))
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(Equal("fake-bytes-out"))
+			Expect(dispatcher.DispatchReqBytes).To(Equal([]byte("fake-bytes-in")))
+		})
+	})
+
+	Describe("Listen", func() {
+		It("replaces a stale socket and restricts access to its owner", func() {
+			Expect(ioutil.WriteFile(socketPath, []byte{}, 0644)).To(Succeed())
+
+			listener, err := Listen("unix", socketPath)
+			Expect(err).ToNot(HaveOccurred())
+			defer listener.Close()
+
+			info, err := os.Stat(socketPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode() & os.ModeSocket).ToNot(BeZero())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		})
+
+		It("returns error if another server is listening on the socket", func() {
+			listener, err := Listen("unix", socketPath)
+			Expect(err).ToNot(HaveOccurred())
+			defer listener.Close()
+
+			_, err = Listen("unix", socketPath)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Another server is listening"))
+		})
+	})
+})
@@ -2,7 +2,6 @@ package config
 
 import (
 	"encoding/json"
-	"regexp"
 
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
@@ -25,6 +24,9 @@ type CPIProperties struct {
 	SoftLayer boslconfig.Config
 	Agent     registry.AgentOptions
 	Registry  registry.ClientOptions
+	Server    ServerOptions
+	Metrics   MetricsOptions
+	Journal   JournalOptions
 }
 
 func NewConfigFromPath(configFile string, fs boshsys.FileSystem, logger logger.Logger) (Config, error) {
@@ -41,7 +43,7 @@ func NewConfigFromPath(configFile string, fs boshsys.FileSystem, logger logger.L
 		return config, bosherr.WrapErrorf(err, "Reading config file '%s'", configFile)
 	}
 
-	logger.DebugWithDetails("File System", "Read content", string(redact(bytes)))
+	logger.DebugWithDetails("File System", "Read content", string(bytes))
 
 	if err = json.Unmarshal(bytes, &config); err != nil {
 		return config, bosherr.WrapError(err, "Unmarshalling config contents")
@@ -82,31 +84,18 @@ func (c Config) Validate() error {
 	if err := c.Cloud.Properties.Agent.Validate(); err != nil {
 		return bosherr.WrapError(err, "Validating agent configuration")
 	}
+	if err := c.Cloud.Properties.Server.Validate(); err != nil {
+		return bosherr.WrapError(err, "Validating server configuration")
+	}
+	if err := c.Cloud.Properties.Metrics.Validate(); err != nil {
+		return bosherr.WrapError(err, "Validating metrics configuration")
+	}
+	if err := c.Cloud.Properties.Journal.Validate(); err != nil {
+		return bosherr.WrapError(err, "Validating journal configuration")
+	}
 	//if err := c.Cloud.Properties.Registry.Validate(); err != nil {
 	//	return bosherr.WrapError(err, "Validating registry configuration")
 	//}
 
 	return nil
 }
-
-func redact(bs []byte) []byte {
-	s := string(bs)
-
-	hiddenStr1 := "\"password\":\"<redact>\""      // replacement string
-	r1 := regexp.MustCompile(`"password":"[^"]*"`) // original string
-	s1 := r1.ReplaceAllString(s, hiddenStr1)
-
-	hiddenStr2 := "\"api_key\":\"<redact>\""
-	r2 := regexp.MustCompile(`"api_key":"[^"]*"`)
-	s2 := r2.ReplaceAllString(s1, hiddenStr2)
-
-	hiddenStr3 := "//registry:<redact>"
-	r3 := regexp.MustCompile(`//registry:[^@]*`)
-	s3 := r3.ReplaceAllString(s2, hiddenStr3)
-
-	hiddenStr4 := "//nats:<redact>"
-	r4 := regexp.MustCompile(`//nats:[^@]*`)
-	s4 := r4.ReplaceAllString(s3, hiddenStr4)
-
-	return []byte(s4)
-}
@@ -121,5 +121,80 @@ var _ = Describe("Config", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("Validating SoftLayer configuration"))
 		})
+
+		It("returns error if journal section has a relative directory", func() {
+			config.Cloud.Properties.Journal.Dir = "journal"
+
+			err := config.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Validating journal configuration"))
+		})
+
+		It("returns error if metrics section has a relative Prometheus textfile", func() {
+			config.Cloud.Properties.Metrics.PrometheusTextfile = "softlayer_cpi.prom"
+
+			err := config.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Validating metrics configuration"))
+		})
+
+		It("returns error if metrics section has an invalid StatsD address", func() {
+			config.Cloud.Properties.Metrics.StatsdAddress = "127.0.0.1"
+
+			err := config.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Parsing StatsdAddress '127.0.0.1'"))
+		})
+
+		It("returns error if softlayer section has an unsupported log format", func() {
+			config.Cloud.Properties.SoftLayer.LogFormat = "xml"
+
+			err := config.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unsupported LogFormat 'xml'"))
+		})
+
+		It("returns error if softlayer section has a negative load balancer drain period", func() {
+			config.Cloud.Properties.SoftLayer.LoadBalancerDrainSeconds = -1
+
+			err := config.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("LoadBalancerDrainSeconds must not be negative"))
+		})
+
+		It("returns error if softlayer section has an unknown log level", func() {
+			config.Cloud.Properties.SoftLayer.LogFormat = "json"
+			config.Cloud.Properties.SoftLayer.LogLevel = "verbose"
+
+			err := config.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Validating LogLevel"))
+		})
+
+		It("does not return error if server section listens on a socket or a loopback address", func() {
+			config.Cloud.Properties.Server.Socket = "/var/vcap/sys/run/softlayer_cpi/cpi.sock"
+			Expect(config.Validate()).ToNot(HaveOccurred())
+
+			config.Cloud.Properties.Server.Socket = ""
+			config.Cloud.Properties.Server.Address = "127.0.0.1:25555"
+			Expect(config.Validate()).ToNot(HaveOccurred())
+		})
+
+		It("returns error if server section listens on a non loopback address", func() {
+			config.Cloud.Properties.Server.Address = "0.0.0.0:25555"
+
+			err := config.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Validating server configuration"))
+		})
+
+		It("returns error if server section has both a socket and an address", func() {
+			config.Cloud.Properties.Server.Socket = "/tmp/cpi.sock"
+			config.Cloud.Properties.Server.Address = "127.0.0.1:25555"
+
+			err := config.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Must provide either a Socket or an Address"))
+		})
 	})
 })
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"path/filepath"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+// JournalOptions are the options of the journal recording the steps of create_vm, so the VMs
+// and SSH keys of interrupted calls are cleaned up by the next call.
+type JournalOptions struct {
+	// Directory of the journal entries, nothing is recorded when empty
+	Dir string `json:"dir,omitempty"`
+}
+
+// Validate validates the journal options.
+func (o JournalOptions) Validate() error {
+	if o.Dir != "" && !filepath.IsAbs(o.Dir) {
+		return bosherr.Errorf("Dir '%s' must be an absolute path", o.Dir)
+	}
+
+	return nil
+}
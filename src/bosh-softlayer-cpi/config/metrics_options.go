@@ -0,0 +1,33 @@
+package config
+
+import (
+	"net"
+	"path/filepath"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+// MetricsOptions are where the SoftLayer API call metrics of every request are published,
+// in addition to the CPI log.
+type MetricsOptions struct {
+	// File the metrics of the last request are written to, for the Prometheus node exporter textfile collector
+	PrometheusTextfile string `json:"prometheus_textfile,omitempty"`
+
+	// host:port of the StatsD server the metrics are sent to over UDP
+	StatsdAddress string `json:"statsd_address,omitempty"`
+}
+
+// Validate validates the metrics options.
+func (o MetricsOptions) Validate() error {
+	if o.PrometheusTextfile != "" && !filepath.IsAbs(o.PrometheusTextfile) {
+		return bosherr.Errorf("PrometheusTextfile '%s' must be an absolute path", o.PrometheusTextfile)
+	}
+
+	if o.StatsdAddress != "" {
+		if _, _, err := net.SplitHostPort(o.StatsdAddress); err != nil {
+			return bosherr.WrapErrorf(err, "Parsing StatsdAddress '%s'", o.StatsdAddress)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"net"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+// ServerOptions are the options of the long running CPI server (cpi -server).
+// When set, the cpi command forwards its request to the server instead of serving it itself.
+type ServerOptions struct {
+	// Path of the Unix socket the server listens on
+	Socket string `json:"socket,omitempty"`
+
+	// Loopback host:port the server listens on when no socket is given
+	Address string `json:"address,omitempty"`
+}
+
+// Enabled returns true if a socket or an address is configured.
+func (o ServerOptions) Enabled() bool {
+	return o.Socket != "" || o.Address != ""
+}
+
+// Network returns the network to listen on or dial, as expected by the net package.
+func (o ServerOptions) Network() string {
+	if o.Socket != "" {
+		return "unix"
+	}
+	return "tcp"
+}
+
+// ListenAddress returns the socket path or the host:port to listen on or dial.
+func (o ServerOptions) ListenAddress() string {
+	if o.Socket != "" {
+		return o.Socket
+	}
+	return o.Address
+}
+
+// Validate validates the server options.
+func (o ServerOptions) Validate() error {
+	if o.Socket != "" && o.Address != "" {
+		return bosherr.Error("Must provide either a Socket or an Address")
+	}
+
+	if o.Address != "" {
+		host, _, err := net.SplitHostPort(o.Address)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Parsing Address '%s'", o.Address)
+		}
+
+		ip := net.ParseIP(host)
+		if host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return bosherr.Errorf("Address '%s' must be a loopback address", o.Address)
+		}
+	}
+
+	return nil
+}
@@ -91,7 +91,7 @@ func execCPI(request string) (disp.Response, error) {
 	)
 
 	caller := disp.NewJSONCaller()
-	dispatcher := disp.NewJSON(actionFactory, caller, multiLogger)
+	dispatcher := disp.NewJSON(actionFactory, nil, caller, multiLogger)
 
 	_, err = in.WriteString(request)
 	if err != nil {
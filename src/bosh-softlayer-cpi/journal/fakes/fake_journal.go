@@ -0,0 +1,289 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"bosh-softlayer-cpi/journal"
+)
+
+type FakeJournal struct {
+	BeginStub        func(action string, id string) (*journal.Entry, error)
+	beginMutex       sync.RWMutex
+	beginArgsForCall []struct {
+		action string
+		id     string
+	}
+	beginReturns struct {
+		result1 *journal.Entry
+		result2 error
+	}
+	beginReturnsOnCall map[int]struct {
+		result1 *journal.Entry
+		result2 error
+	}
+	RecordStub        func(entry *journal.Entry, step string) error
+	recordMutex       sync.RWMutex
+	recordArgsForCall []struct {
+		entry *journal.Entry
+		step  string
+	}
+	recordReturns struct {
+		result1 error
+	}
+	recordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FinishStub        func(entry *journal.Entry) error
+	finishMutex       sync.RWMutex
+	finishArgsForCall []struct {
+		entry *journal.Entry
+	}
+	finishReturns struct {
+		result1 error
+	}
+	finishReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReleaseStub        func(entry *journal.Entry)
+	releaseMutex       sync.RWMutex
+	releaseArgsForCall []struct {
+		entry *journal.Entry
+	}
+	ReconcileStub        func(action string, reconcile func(entry journal.Entry) error)
+	reconcileMutex       sync.RWMutex
+	reconcileArgsForCall []struct {
+		action    string
+		reconcile func(entry journal.Entry) error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeJournal) Begin(action string, id string) (*journal.Entry, error) {
+	fake.beginMutex.Lock()
+	ret, specificReturn := fake.beginReturnsOnCall[len(fake.beginArgsForCall)]
+	fake.beginArgsForCall = append(fake.beginArgsForCall, struct {
+		action string
+		id     string
+	}{action, id})
+	fake.recordInvocation("Begin", []interface{}{action, id})
+	fake.beginMutex.Unlock()
+	if fake.BeginStub != nil {
+		return fake.BeginStub(action, id)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.beginReturns.result1, fake.beginReturns.result2
+}
+
+func (fake *FakeJournal) BeginCallCount() int {
+	fake.beginMutex.RLock()
+	defer fake.beginMutex.RUnlock()
+	return len(fake.beginArgsForCall)
+}
+
+func (fake *FakeJournal) BeginArgsForCall(i int) (string, string) {
+	fake.beginMutex.RLock()
+	defer fake.beginMutex.RUnlock()
+	return fake.beginArgsForCall[i].action, fake.beginArgsForCall[i].id
+}
+
+func (fake *FakeJournal) BeginReturns(result1 *journal.Entry, result2 error) {
+	fake.BeginStub = nil
+	fake.beginReturns = struct {
+		result1 *journal.Entry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJournal) BeginReturnsOnCall(i int, result1 *journal.Entry, result2 error) {
+	fake.BeginStub = nil
+	if fake.beginReturnsOnCall == nil {
+		fake.beginReturnsOnCall = make(map[int]struct {
+			result1 *journal.Entry
+			result2 error
+		})
+	}
+	fake.beginReturnsOnCall[i] = struct {
+		result1 *journal.Entry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJournal) Record(entry *journal.Entry, step string) error {
+	fake.recordMutex.Lock()
+	ret, specificReturn := fake.recordReturnsOnCall[len(fake.recordArgsForCall)]
+	fake.recordArgsForCall = append(fake.recordArgsForCall, struct {
+		entry *journal.Entry
+		step  string
+	}{entry, step})
+	fake.recordInvocation("Record", []interface{}{entry, step})
+	fake.recordMutex.Unlock()
+	if fake.RecordStub != nil {
+		return fake.RecordStub(entry, step)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.recordReturns.result1
+}
+
+func (fake *FakeJournal) RecordCallCount() int {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return len(fake.recordArgsForCall)
+}
+
+func (fake *FakeJournal) RecordArgsForCall(i int) (*journal.Entry, string) {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return fake.recordArgsForCall[i].entry, fake.recordArgsForCall[i].step
+}
+
+func (fake *FakeJournal) RecordReturns(result1 error) {
+	fake.RecordStub = nil
+	fake.recordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) RecordReturnsOnCall(i int, result1 error) {
+	fake.RecordStub = nil
+	if fake.recordReturnsOnCall == nil {
+		fake.recordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) Finish(entry *journal.Entry) error {
+	fake.finishMutex.Lock()
+	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
+	fake.finishArgsForCall = append(fake.finishArgsForCall, struct {
+		entry *journal.Entry
+	}{entry})
+	fake.recordInvocation("Finish", []interface{}{entry})
+	fake.finishMutex.Unlock()
+	if fake.FinishStub != nil {
+		return fake.FinishStub(entry)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.finishReturns.result1
+}
+
+func (fake *FakeJournal) FinishCallCount() int {
+	fake.finishMutex.RLock()
+	defer fake.finishMutex.RUnlock()
+	return len(fake.finishArgsForCall)
+}
+
+func (fake *FakeJournal) FinishArgsForCall(i int) *journal.Entry {
+	fake.finishMutex.RLock()
+	defer fake.finishMutex.RUnlock()
+	return fake.finishArgsForCall[i].entry
+}
+
+func (fake *FakeJournal) FinishReturns(result1 error) {
+	fake.FinishStub = nil
+	fake.finishReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) FinishReturnsOnCall(i int, result1 error) {
+	fake.FinishStub = nil
+	if fake.finishReturnsOnCall == nil {
+		fake.finishReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.finishReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJournal) Release(entry *journal.Entry) {
+	fake.releaseMutex.Lock()
+	fake.releaseArgsForCall = append(fake.releaseArgsForCall, struct {
+		entry *journal.Entry
+	}{entry})
+	fake.recordInvocation("Release", []interface{}{entry})
+	fake.releaseMutex.Unlock()
+	if fake.ReleaseStub != nil {
+		fake.ReleaseStub(entry)
+	}
+}
+
+func (fake *FakeJournal) ReleaseCallCount() int {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return len(fake.releaseArgsForCall)
+}
+
+func (fake *FakeJournal) ReleaseArgsForCall(i int) *journal.Entry {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return fake.releaseArgsForCall[i].entry
+}
+
+func (fake *FakeJournal) Reconcile(action string, reconcile func(entry journal.Entry) error) {
+	fake.reconcileMutex.Lock()
+	fake.reconcileArgsForCall = append(fake.reconcileArgsForCall, struct {
+		action    string
+		reconcile func(entry journal.Entry) error
+	}{action, reconcile})
+	fake.recordInvocation("Reconcile", []interface{}{action, reconcile})
+	fake.reconcileMutex.Unlock()
+	if fake.ReconcileStub != nil {
+		fake.ReconcileStub(action, reconcile)
+	}
+}
+
+func (fake *FakeJournal) ReconcileCallCount() int {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	return len(fake.reconcileArgsForCall)
+}
+
+func (fake *FakeJournal) ReconcileArgsForCall(i int) (string, func(entry journal.Entry) error) {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	return fake.reconcileArgsForCall[i].action, fake.reconcileArgsForCall[i].reconcile
+}
+
+func (fake *FakeJournal) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.beginMutex.RLock()
+	defer fake.beginMutex.RUnlock()
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	fake.finishMutex.RLock()
+	defer fake.finishMutex.RUnlock()
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeJournal) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ journal.Journal = new(FakeJournal)
@@ -0,0 +1,351 @@
+package journal
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	"bosh-softlayer-cpi/logger"
+)
+
+const journalLogTag = "Journal"
+
+// staleTmpAge is the age of a temporary entry file after which it is left by a process that died
+// while writing it, the file is written and renamed at once.
+const staleTmpAge = 10 * time.Minute
+
+//go:generate counterfeiter -o fakes/fake_journal.go . Journal
+
+// Journal records the steps of actions and the SoftLayer objects they created, so what an
+// interrupted action leaves behind can be reconciled by a later one.
+type Journal interface {
+	// Begin records the start of action for id, e.g. the agent ID of a create_vm.
+	Begin(action string, id string) (*Entry, error)
+
+	// Record records step and the SoftLayer IDs set on entry so far. Failures are logged.
+	Record(entry *Entry, step string) error
+
+	// Finish removes entry, nothing is left to reconcile. Failures are logged.
+	Finish(entry *Entry) error
+
+	// Release ends the call of entry. An entry that is not finished is reconciled by a later action.
+	Release(entry *Entry)
+
+	// Reconcile calls reconcile with every unfinished entry of action whose call is gone, and
+	// finishes the entries reconcile succeeds for.
+	Reconcile(action string, reconcile func(entry Entry) error)
+}
+
+// Entry is the journal of one action.
+type Entry struct {
+	Action    string    `json:"action"`
+	ID        string    `json:"id"`
+	Pid       int       `json:"pid"`
+	StartedAt time.Time `json:"started_at"`
+	Steps     []Step    `json:"steps"`
+
+	// Token identifies the call, the CPI server runs many calls in one process
+	Token string `json:"token,omitempty"`
+
+	// PidStartTime is the start time of the process in clock ticks after boot, which tells it
+	// from a later process reusing its pid. Zero where /proc is not available.
+	PidStartTime uint64 `json:"pid_start_time,omitempty"`
+
+	// SoftLayer objects involved
+	GuestID  int  `json:"guest_id,omitempty"`
+	SshKeyID int  `json:"ssh_key_id,omitempty"`
+	OsReload bool `json:"os_reload,omitempty"`
+
+	// SshKeyCreated is false for an SSH key of the account reused by the action
+	SshKeyCreated bool `json:"ssh_key_created,omitempty"`
+}
+
+type Step struct {
+	Name string    `json:"name"`
+	Time time.Time `json:"time"`
+}
+
+// LastStep returns the name of the last recorded step.
+func (e Entry) LastStep() string {
+	if len(e.Steps) == 0 {
+		return ""
+	}
+	return e.Steps[len(e.Steps)-1].Name
+}
+
+func (e Entry) fileName() string {
+	return fmt.Sprintf("%s-%s-%s.json", e.Action, e.ID, e.Token)
+}
+
+// running holds the tokens of the calls of this process that are not released.
+var running = struct {
+	sync.Mutex
+	tokens map[string]bool
+}{tokens: map[string]bool{}}
+
+func setRunning(token string, isRunning bool) {
+	running.Lock()
+	defer running.Unlock()
+	if isRunning {
+		running.tokens[token] = true
+	} else {
+		delete(running.tokens, token)
+	}
+}
+
+func isRunning(token string) bool {
+	running.Lock()
+	defer running.Unlock()
+	return running.tokens[token]
+}
+
+type fileJournal struct {
+	dir    string
+	fs     boshsys.FileSystem
+	logger logger.Logger
+}
+
+// NewFileJournal returns a journal keeping one JSON file per entry in dir. With an empty dir
+// nothing is recorded.
+func NewFileJournal(dir string, fs boshsys.FileSystem, logger logger.Logger) Journal {
+	return fileJournal{dir: dir, fs: fs, logger: logger}
+}
+
+func (j fileJournal) Begin(action string, id string) (*Entry, error) {
+	entry := &Entry{
+		Action:    action,
+		ID:        id,
+		StartedAt: time.Now().UTC(),
+	}
+	if err := j.own(entry); err != nil {
+		return nil, err
+	}
+
+	if j.dir != "" {
+		if err := j.fs.MkdirAll(j.dir, os.FileMode(0700)); err != nil {
+			j.Release(entry)
+			return nil, bosherr.WrapErrorf(err, "Creating journal directory '%s'", j.dir)
+		}
+	}
+
+	if err := j.Record(entry, "started"); err != nil {
+		j.Release(entry)
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// own makes entry one of a call of this process, with a new token.
+func (j fileJournal) own(entry *Entry) error {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return bosherr.WrapError(err, "Generating journal token")
+	}
+	entry.Token = hex.EncodeToString(token)
+	entry.Pid = os.Getpid()
+
+	startTime, err := processStartTime(entry.Pid)
+	if err != nil {
+		j.logger.Warn(journalLogTag, "Reading start time of process '%d', a process reusing its pid keeps %s '%s' from being reconciled: %s", entry.Pid, entry.Action, entry.ID, err)
+	}
+	entry.PidStartTime = startTime
+
+	setRunning(entry.Token, true)
+	return nil
+}
+
+func (j fileJournal) Record(entry *Entry, step string) error {
+	entry.Steps = append(entry.Steps, Step{Name: step, Time: time.Now().UTC()})
+	if j.dir == "" {
+		return nil
+	}
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling journal entry")
+	}
+
+	// A partially written entry would be lost to reconciliation, so it is replaced at once
+	path := filepath.Join(j.dir, entry.fileName())
+	err = j.fs.WriteFile(path+".tmp", content)
+	if err == nil {
+		err = j.fs.Rename(path+".tmp", path)
+	}
+	if err != nil {
+		j.logger.Warn(journalLogTag, "Recording step '%s' of %s '%s': %s", step, entry.Action, entry.ID, err)
+		return bosherr.WrapErrorf(err, "Writing journal entry '%s'", path)
+	}
+
+	return nil
+}
+
+func (j fileJournal) Finish(entry *Entry) error {
+	if j.dir == "" {
+		return nil
+	}
+
+	path := filepath.Join(j.dir, entry.fileName())
+	if err := j.fs.RemoveAll(path); err != nil {
+		j.logger.Warn(journalLogTag, "Finishing %s '%s': %s", entry.Action, entry.ID, err)
+		return bosherr.WrapErrorf(err, "Removing journal entry '%s'", path)
+	}
+
+	return nil
+}
+
+func (j fileJournal) Release(entry *Entry) {
+	setRunning(entry.Token, false)
+}
+
+// Reconcile does not resume the calls it finds, whatever step they reached reconcile undoes them.
+// An entry is claimed before it is reconciled: it is renamed after a token of this call, only one
+// of the calls reconciling concurrently succeeds. An entry whose claiming call is gone in turn is
+// claimed again by a later call.
+func (j fileJournal) Reconcile(action string, reconcile func(entry Entry) error) {
+	if j.dir == "" {
+		return
+	}
+
+	j.removeStaleTmpFiles(action)
+
+	paths, err := j.fs.Glob(filepath.Join(j.dir, action+"-*.json"))
+	if err != nil {
+		j.logger.Warn(journalLogTag, "Listing journal entries of '%s': %s", action, err)
+		return
+	}
+
+	for _, path := range paths {
+		content, err := j.fs.ReadFile(path)
+		if err != nil {
+			// Claimed and renamed by another call meanwhile
+			if !j.fs.FileExists(path) {
+				continue
+			}
+			j.logger.Warn(journalLogTag, "Reading journal entry '%s': %s", path, err)
+			continue
+		}
+
+		var entry Entry
+		if err = json.Unmarshal(content, &entry); err != nil {
+			j.logger.Warn(journalLogTag, "Unmarshalling journal entry '%s': %s", path, err)
+			continue
+		}
+
+		// The action may still be running in another CPI process or in the CPI server
+		if callAlive(entry) {
+			continue
+		}
+
+		if err = j.own(&entry); err != nil {
+			j.logger.Warn(journalLogTag, "Claiming %s '%s': %s", entry.Action, entry.ID, err)
+			continue
+		}
+		claimedPath := filepath.Join(j.dir, entry.fileName())
+		if err = j.fs.Rename(path, claimedPath); err != nil {
+			j.Release(&entry)
+			j.logger.Debug(journalLogTag, "Skipping %s '%s', it is claimed by another call: %s", entry.Action, entry.ID, err)
+			continue
+		}
+
+		j.logger.Info(journalLogTag, "Reconciling %s '%s' interrupted after step '%s'", entry.Action, entry.ID, entry.LastStep())
+		// A failure is logged, the claimed entry is reconciled by a later call once this one is gone
+		_ = j.Record(&entry, "reconciling")
+		if err = reconcile(entry); err != nil {
+			j.logger.Warn(journalLogTag, "Reconciling %s '%s', it is retried by the next %s: %s", entry.Action, entry.ID, entry.Action, err)
+			j.Release(&entry)
+			continue
+		}
+
+		// A failure is logged, the entry is reconciled again by the next action
+		_ = j.Finish(&entry)
+		j.Release(&entry)
+	}
+}
+
+// removeStaleTmpFiles removes the temporary entry files of action left by processes that died while
+// writing them.
+func (j fileJournal) removeStaleTmpFiles(action string) {
+	paths, err := j.fs.Glob(filepath.Join(j.dir, action+"-*.json.tmp"))
+	if err != nil {
+		j.logger.Warn(journalLogTag, "Listing temporary journal entries of '%s': %s", action, err)
+		return
+	}
+
+	for _, path := range paths {
+		info, err := j.fs.Stat(path)
+		if err != nil || time.Since(info.ModTime()) < staleTmpAge {
+			continue
+		}
+		if err = j.fs.RemoveAll(path); err != nil {
+			j.logger.Warn(journalLogTag, "Removing temporary journal entry '%s': %s", path, err)
+		}
+	}
+}
+
+// callAlive returns true if the call of entry is running. Calls of this process are told by their
+// token, the others by their process.
+func callAlive(entry Entry) bool {
+	if entry.Pid == os.Getpid() {
+		startTime, err := processStartTime(entry.Pid)
+		if entry.PidStartTime == 0 || err != nil || startTime == entry.PidStartTime {
+			return isRunning(entry.Token)
+		}
+		return false
+	}
+
+	return processAlive(entry.Pid, entry.PidStartTime)
+}
+
+// processAlive returns true if the process pid started at startTime is running. Without a
+// start time any process with pid counts.
+func processAlive(pid int, startTime uint64) bool {
+	if pid <= 0 {
+		return false
+	}
+
+	// Signal 0 only checks the process exists, EPERM means it exists as another user
+	err := syscall.Kill(pid, syscall.Signal(0))
+	if err != nil && err != syscall.EPERM {
+		return false
+	}
+
+	if startTime == 0 {
+		return true
+	}
+
+	// A different start time means the pid was reused. If it cannot be read the process is
+	// assumed alive, the entry is reconciled later.
+	currentStartTime, err := processStartTime(pid)
+	return err != nil || currentStartTime == startTime
+}
+
+// processStartTime returns the start time of process pid in clock ticks after boot, the 22nd
+// field of /proc/<pid>/stat.
+func processStartTime(pid int) (uint64, error) {
+	content, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, err
+	}
+
+	// The command name, the 2nd field, may contain spaces and parentheses
+	stat := string(content)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	if len(fields) < 20 {
+		return 0, fmt.Errorf("Unexpected format of process stat '%s'", stat)
+	}
+
+	return strconv.ParseUint(fields[19], 10, 64)
+}
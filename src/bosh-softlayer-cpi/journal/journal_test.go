@@ -0,0 +1,234 @@
+package journal_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	. "bosh-softlayer-cpi/journal"
+	cpilog "bosh-softlayer-cpi/logger"
+)
+
+var _ = Describe("FileJournal", func() {
+	var (
+		dir     string
+		journal Journal
+	)
+
+	// A pid above the kernel's pid_max, its process is always gone
+	const deadPid = 99999999
+
+	writeEntry := func(entry Entry) {
+		content, err := json.Marshal(entry)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ioutil.WriteFile(filepath.Join(dir, entry.Action+"-"+entry.ID+".json"), content, 0600)).To(Succeed())
+	}
+
+	entries := func() []string {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		Expect(err).ToNot(HaveOccurred())
+		return paths
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "cpi-journal")
+		Expect(err).ToNot(HaveOccurred())
+
+		logger := cpilog.NewLogger(boshlog.LevelNone, "")
+		journal = NewFileJournal(filepath.Join(dir, "journal"), boshsys.NewOsFileSystem(logger.GetBoshLogger()), logger)
+		dir = filepath.Join(dir, "journal")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(filepath.Dir(dir))
+	})
+
+	Describe("Begin and Record", func() {
+		It("writes the entry with its steps and SoftLayer IDs", func() {
+			entry, err := journal.Begin("create_vm", "fake-agent-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entry.Pid).To(Equal(os.Getpid()))
+			Expect(entry.PidStartTime).ToNot(BeZero())
+			Expect(entry.Token).ToNot(BeEmpty())
+
+			entry.GuestID = 12345678
+			Expect(journal.Record(entry, "ordered")).To(Succeed())
+
+			content, err := ioutil.ReadFile(filepath.Join(dir, "create_vm-fake-agent-id-"+entry.Token+".json"))
+			Expect(err).ToNot(HaveOccurred())
+
+			var written Entry
+			Expect(json.Unmarshal(content, &written)).To(Succeed())
+			Expect(written.Action).To(Equal("create_vm"))
+			Expect(written.ID).To(Equal("fake-agent-id"))
+			Expect(written.GuestID).To(Equal(12345678))
+			Expect(written.Steps).To(HaveLen(2))
+			Expect(written.LastStep()).To(Equal("ordered"))
+		})
+	})
+
+	Describe("Finish", func() {
+		It("removes the entry", func() {
+			entry, err := journal.Begin("create_vm", "fake-agent-id")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(journal.Finish(entry)).To(Succeed())
+			Expect(entries()).To(BeEmpty())
+		})
+	})
+
+	Describe("Reconcile", func() {
+		var (
+			reconciled []Entry
+			reconcile  func(Entry) error
+		)
+
+		BeforeEach(func() {
+			Expect(os.MkdirAll(dir, 0700)).To(Succeed())
+			reconciled = []Entry{}
+			reconcile = func(entry Entry) error {
+				reconciled = append(reconciled, entry)
+				return nil
+			}
+		})
+
+		It("reconciles and finishes entries of processes which are gone", func() {
+			writeEntry(Entry{Action: "create_vm", ID: "fake-agent-id", Pid: deadPid, GuestID: 12345678})
+
+			journal.Reconcile("create_vm", reconcile)
+			Expect(reconciled).To(HaveLen(1))
+			Expect(reconciled[0].GuestID).To(Equal(12345678))
+			Expect(entries()).To(BeEmpty())
+		})
+
+		It("skips entries of other running processes", func() {
+			// init is always running
+			writeEntry(Entry{Action: "create_vm", ID: "fake-agent-id", Pid: 1})
+
+			journal.Reconcile("create_vm", reconcile)
+			Expect(reconciled).To(BeEmpty())
+			Expect(filepath.Join(dir, "create_vm-fake-agent-id.json")).To(BeAnExistingFile())
+		})
+
+		It("skips entries of running calls of this process", func() {
+			entry, err := journal.Begin("create_vm", "fake-agent-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entry.PidStartTime).ToNot(BeZero())
+
+			journal.Reconcile("create_vm", reconcile)
+			Expect(reconciled).To(BeEmpty())
+			Expect(entries()).To(HaveLen(1))
+		})
+
+		It("reconciles entries of released calls of this process, as in the CPI server", func() {
+			entry, err := journal.Begin("create_vm", "fake-agent-id")
+			Expect(err).ToNot(HaveOccurred())
+			journal.Release(entry)
+
+			journal.Reconcile("create_vm", reconcile)
+			Expect(reconciled).To(HaveLen(1))
+			Expect(entries()).To(BeEmpty())
+		})
+
+		It("reconciles entries of processes whose pid was reused", func() {
+			entry, err := journal.Begin("create_vm", "fake-agent-id")
+			Expect(err).ToNot(HaveOccurred())
+			entry.PidStartTime++
+			Expect(journal.Record(entry, "ordered")).To(Succeed())
+
+			journal.Reconcile("create_vm", reconcile)
+			Expect(reconciled).To(HaveLen(1))
+			Expect(entries()).To(BeEmpty())
+		})
+
+		It("claims entries before reconciling them", func() {
+			writeEntry(Entry{Action: "create_vm", ID: "fake-agent-id", Pid: deadPid, Token: "fake-token"})
+
+			journal.Reconcile("create_vm", func(entry Entry) error {
+				Expect(entry.Token).ToNot(Equal("fake-token"))
+				Expect(entry.Pid).To(Equal(os.Getpid()))
+				Expect(entries()).To(Equal([]string{filepath.Join(dir, "create_vm-fake-agent-id-"+entry.Token+".json")}))
+				return reconcile(entry)
+			})
+			Expect(reconciled).To(HaveLen(1))
+		})
+
+		It("reconciles an entry once when reconciling concurrently", func() {
+			writeEntry(Entry{Action: "create_vm", ID: "fake-agent-id", Pid: deadPid})
+
+			var mutex sync.Mutex
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					journal.Reconcile("create_vm", func(entry Entry) error {
+						mutex.Lock()
+						defer mutex.Unlock()
+						return reconcile(entry)
+					})
+				}()
+			}
+			wg.Wait()
+
+			Expect(reconciled).To(HaveLen(1))
+			Expect(entries()).To(BeEmpty())
+		})
+
+		It("skips entries of other actions", func() {
+			writeEntry(Entry{Action: "create_disk", ID: "fake-disk", Pid: deadPid})
+
+			journal.Reconcile("create_vm", reconcile)
+			Expect(reconciled).To(BeEmpty())
+		})
+
+		It("keeps entries failing to reconcile for the next call", func() {
+			writeEntry(Entry{Action: "create_vm", ID: "fake-agent-id", Pid: deadPid})
+
+			journal.Reconcile("create_vm", func(Entry) error { return errors.New("fake-reconcile-error") })
+			Expect(entries()).To(HaveLen(1))
+
+			journal.Reconcile("create_vm", reconcile)
+			Expect(reconciled).To(HaveLen(1))
+			Expect(entries()).To(BeEmpty())
+		})
+
+		It("removes temporary entry files left by processes which are gone", func() {
+			stale := filepath.Join(dir, "create_vm-fake-agent-id-fake-token.json.tmp")
+			Expect(ioutil.WriteFile(stale, []byte(`{"action":`), 0600)).To(Succeed())
+			old := time.Now().Add(-time.Hour)
+			Expect(os.Chtimes(stale, old, old)).To(Succeed())
+			fresh := filepath.Join(dir, "create_vm-fake-agent-id-fake-token2.json.tmp")
+			Expect(ioutil.WriteFile(fresh, []byte(`{"action":`), 0600)).To(Succeed())
+
+			journal.Reconcile("create_vm", reconcile)
+			Expect(stale).ToNot(BeAnExistingFile())
+			Expect(fresh).To(BeAnExistingFile())
+		})
+	})
+
+	Context("without directory", func() {
+		It("records nothing", func() {
+			logger := cpilog.NewLogger(boshlog.LevelNone, "")
+			journal = NewFileJournal("", boshsys.NewOsFileSystem(logger.GetBoshLogger()), logger)
+
+			entry, err := journal.Begin("create_vm", "fake-agent-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(journal.Record(entry, "ordered")).To(Succeed())
+			Expect(journal.Finish(entry)).To(Succeed())
+			Expect(dir).ToNot(BeADirectory())
+		})
+	})
+})
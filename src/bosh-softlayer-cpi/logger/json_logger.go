@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+)
+
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+type jsonRecord struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Tag       string `json:"tag"`
+	RequestID string `json:"request_id,omitempty"`
+	Method    string `json:"method,omitempty"`
+	Message   string `json:"message"`
+	Details   string `json:"details,omitempty"`
+
+	SoftLayerService string  `json:"sl_service,omitempty"`
+	SoftLayerMethod  string  `json:"sl_method,omitempty"`
+	DurationMs       float64 `json:"duration_ms,omitempty"`
+	Error            string  `json:"error,omitempty"`
+}
+
+// jsonLogger writes one JSON record per line. It implements boshlog.Logger, so the
+// bosh-utils retry strategies and system packages log records with the request fields too.
+type jsonLogger struct {
+	level       boshlog.LogLevel
+	out         io.Writer
+	outMu       sync.Mutex
+	forcedDebug bool
+	parent      *logger
+}
+
+func (l *jsonLogger) Debug(tag, msg string, args ...interface{}) {
+	l.log(boshlog.LevelDebug, jsonRecord{Tag: tag, Message: fmt.Sprintf(msg, args...)})
+}
+
+func (l *jsonLogger) DebugWithDetails(tag, msg string, args ...interface{}) {
+	l.log(boshlog.LevelDebug, detailedRecord(tag, msg, args...))
+}
+
+func (l *jsonLogger) Info(tag, msg string, args ...interface{}) {
+	l.log(boshlog.LevelInfo, jsonRecord{Tag: tag, Message: fmt.Sprintf(msg, args...)})
+}
+
+func (l *jsonLogger) Warn(tag, msg string, args ...interface{}) {
+	l.log(boshlog.LevelWarn, jsonRecord{Tag: tag, Message: fmt.Sprintf(msg, args...)})
+}
+
+func (l *jsonLogger) Error(tag, msg string, args ...interface{}) {
+	l.log(boshlog.LevelError, jsonRecord{Tag: tag, Message: fmt.Sprintf(msg, args...)})
+}
+
+func (l *jsonLogger) ErrorWithDetails(tag, msg string, args ...interface{}) {
+	l.log(boshlog.LevelError, detailedRecord(tag, msg, args...))
+}
+
+func (l *jsonLogger) HandlePanic(tag string) {
+	if e := recover(); e != nil {
+		l.ErrorWithDetails(tag, "Panic: %v", e, debug.Stack())
+		os.Exit(2)
+	}
+}
+
+func (l *jsonLogger) ToggleForcedDebug() {
+	l.forcedDebug = !l.forcedDebug
+}
+
+func (l *jsonLogger) Flush() error { return nil }
+
+func (l *jsonLogger) FlushTimeout(_ time.Duration) error { return nil }
+
+func (l *jsonLogger) call(tag, service, method string, duration time.Duration, err error) {
+	record := jsonRecord{
+		Tag:              tag,
+		Message:          fmt.Sprintf("Called %s::%s", service, method),
+		SoftLayerService: service,
+		SoftLayerMethod:  method,
+		DurationMs:       float64(duration) / float64(time.Millisecond),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	l.log(boshlog.LevelDebug, record)
+}
+
+func (l *jsonLogger) log(level boshlog.LogLevel, record jsonRecord) {
+	if l.level > level && !l.forcedDebug {
+		return
+	}
+
+	record.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	record.Level = levelNames[level]
+	record.Message = RedactString(record.Message)
+	record.Details = RedactString(record.Details)
+	record.Error = RedactString(record.Error)
+	if l.parent != nil {
+		record.RequestID = l.parent.threadPrefix
+		record.Method = l.parent.method
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		line = []byte(fmt.Sprintf(`{"level":"ERROR","message":"Marshalling log record: %s"}`, err))
+	}
+
+	l.outMu.Lock()
+	l.out.Write(append(line, '\n')) // #nosec G104
+	l.outMu.Unlock()
+}
+
+var levelNames = map[boshlog.LogLevel]string{
+	boshlog.LevelDebug: "DEBUG",
+	boshlog.LevelInfo:  "INFO",
+	boshlog.LevelWarn:  "WARN",
+	boshlog.LevelError: "ERROR",
+}
+
+// detailedRecord splits the arguments of a *WithDetails call, whose last argument is the details.
+func detailedRecord(tag, msg string, args ...interface{}) jsonRecord {
+	if len(args) == 0 {
+		return jsonRecord{Tag: tag, Message: msg}
+	}
+
+	return jsonRecord{
+		Tag:     tag,
+		Message: fmt.Sprintf(msg, args[:len(args)-1]...),
+		Details: fmt.Sprintf("%s", args[len(args)-1]),
+	}
+}
+
+type recordWriter struct {
+	logger Logger
+	tag    string
+}
+
+// NewRecordWriter returns a writer logging every line written as a debug record of logger,
+// so libraries logging to a *log.Logger do not break the JSON log format.
+func NewRecordWriter(logger Logger, tag string) io.Writer {
+	return recordWriter{logger: logger, tag: tag}
+}
+
+func (w recordWriter) Write(p []byte) (int, error) {
+	w.logger.Debug(w.tag, "%s", strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
@@ -0,0 +1,103 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+
+	. "bosh-softlayer-cpi/logger"
+)
+
+var _ = Describe("JSON logger", func() {
+	var (
+		out    bytes.Buffer
+		logger Logger
+	)
+
+	records := func() []map[string]interface{} {
+		result := []map[string]interface{}{}
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			record := map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(line), &record)).To(Succeed())
+			result = append(result, record)
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		out.Reset()
+		logger = NewJSON(boshlog.LevelDebug, "fake-tag-prefix", &out)
+	})
+
+	It("writes one record per line with time, level, tag and request fields", func() {
+		logger.SetRequestID("fake-request-id")
+		logger.SetMethod("create_vm")
+		logger.Info("LoggerUnitTest", "Creating vm '%d'", 1234)
+
+		record := records()[0]
+		Expect(record["time"]).ToNot(BeEmpty())
+		Expect(record["level"]).To(Equal("INFO"))
+		Expect(record["tag"]).To(Equal("LoggerUnitTest"))
+		Expect(record["request_id"]).To(Equal("fake-request-id"))
+		Expect(record["method"]).To(Equal("create_vm"))
+		Expect(record["message"]).To(Equal("Creating vm '1234'"))
+	})
+
+	It("uses the serial tag prefix as request id until the request id is known", func() {
+		logger.Debug("LoggerUnitTest", "It is from cpi logger.")
+		Expect(records()[0]["request_id"]).To(Equal("fake-tag-prefix"))
+	})
+
+	It("writes details and redacts them", func() {
+		logger.DebugWithDetails("LoggerUnitTest", "Request bytes", []byte(`{"api_key":"fake-api-key"}`))
+
+		record := records()[0]
+		Expect(record["message"]).To(Equal("Request bytes"))
+		Expect(record["details"]).To(Equal(`{"api_key":"<redact>"}`))
+	})
+
+	It("writes SoftLayer calls with service, method and duration", func() {
+		logger.SoftLayerCall("SoftLayer_Virtual_Guest", "getObject", 250*time.Millisecond, errors.New("fake-sl-error"))
+
+		record := records()[0]
+		Expect(record["sl_service"]).To(Equal("SoftLayer_Virtual_Guest"))
+		Expect(record["sl_method"]).To(Equal("getObject"))
+		Expect(record["duration_ms"]).To(BeNumerically("==", 250))
+		Expect(record["error"]).To(Equal("fake-sl-error"))
+	})
+
+	It("writes records of the bosh logger with the request fields", func() {
+		logger.SetRequestID("fake-request-id")
+		logger.GetBoshLogger().Warn("RetryStrategy", "Retrying")
+
+		record := records()[0]
+		Expect(record["level"]).To(Equal("WARN"))
+		Expect(record["request_id"]).To(Equal("fake-request-id"))
+	})
+
+	It("skips records below the log level", func() {
+		logger = NewJSON(boshlog.LevelWarn, "fake-tag-prefix", &out)
+		logger.Debug("LoggerUnitTest", "It is from cpi logger.")
+		logger.Info("LoggerUnitTest", "It is from cpi logger.")
+		Expect(out.String()).To(BeEmpty())
+
+		logger.Error("LoggerUnitTest", "It is from cpi logger.")
+		Expect(records()[0]["level"]).To(Equal("ERROR"))
+	})
+
+	It("writes the lines of a record writer as records", func() {
+		_, err := NewRecordWriter(logger, "softlayerGo").Write([]byte("[softlayerGo] GET https://api.softlayer.com\n"))
+		Expect(err).ToNot(HaveOccurred())
+
+		record := records()[0]
+		Expect(record["tag"]).To(Equal("softlayerGo"))
+		Expect(record["message"]).To(Equal("[softlayerGo] GET https://api.softlayer.com"))
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"time"
 	"unsafe"
 
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
@@ -23,12 +24,21 @@ type Logger interface {
 	Flush() error
 	GetBoshLogger() boshlog.Logger
 	GetSerialTagPrefix() string
+	SetRequestID(requestID string)
+	GetRequestID() string
+	SetMethod(method string)
+	SoftLayerCall(service, method string, duration time.Duration, err error)
 	ChangeRetryStrategyLogTag(retryStrategy *boshretry.RetryStrategy) error
 }
 
+const softLayerCallLogTag = "SoftLayerCall"
+
 type logger struct {
 	boshlogger   boshlog.Logger
+	jsonLogger   *jsonLogger
 	threadPrefix string
+	requestID    string
+	method       string
 }
 
 func New(level boshlog.LogLevel, serialTagPrefix string, out, err *log.Logger) Logger {
@@ -39,6 +49,17 @@ func New(level boshlog.LogLevel, serialTagPrefix string, out, err *log.Logger) L
 	}
 }
 
+// NewJSON returns a logger writing one JSON record per line to out, with the request ID
+// (or the serial tag prefix until it is known) and the CPI method as fields instead of tag prefixes.
+func NewJSON(level boshlog.LogLevel, serialTagPrefix string, out io.Writer) Logger {
+	l := &logger{
+		threadPrefix: serialTagPrefix,
+	}
+	l.jsonLogger = &jsonLogger{level: level, out: out, parent: l}
+	l.boshlogger = l.jsonLogger
+	return l
+}
+
 func NewLogger(level boshlog.LogLevel, serialTagPrefix string) Logger {
 	return NewWriterLogger(level, serialTagPrefix, os.Stdout, os.Stderr)
 }
@@ -60,45 +81,104 @@ func (l *logger) GetSerialTagPrefix() string {
 	return l.threadPrefix
 }
 
+// SetRequestID tags the following log lines with the director request ID instead of
+// the serial tag prefix, so they can be tied back to a director task.
+func (l *logger) SetRequestID(requestID string) {
+	if requestID == "" {
+		return
+	}
+	l.requestID = requestID
+	l.threadPrefix = requestID
+}
+
+// GetRequestID returns the director request ID, or an empty string if the director did not send one.
+func (l *logger) GetRequestID() string {
+	return l.requestID
+}
+
+// SetMethod adds the CPI method being served to the following JSON records.
+func (l *logger) SetMethod(method string) {
+	l.method = method
+}
+
+// SoftLayerCall logs a call to the SoftLayer API and how long it took.
+func (l *logger) SoftLayerCall(service, method string, duration time.Duration, err error) {
+	if l.jsonLogger != nil {
+		l.jsonLogger.call(softLayerCallLogTag, service, method, duration, err)
+		return
+	}
+
+	if err != nil {
+		l.Debug(softLayerCallLogTag, "Called %s::%s in %s with error: %s", service, method, duration, err)
+		return
+	}
+	l.Debug(softLayerCallLogTag, "Called %s::%s in %s", service, method, duration)
+}
+
 func (l *logger) Debug(tag, msg string, args ...interface{}) {
-	tag = fmt.Sprintf("%s:%s", l.threadPrefix, tag)
-	l.boshlogger.Debug(tag, msg, args...)
+	l.boshlogger.Debug(l.tag(tag), "%s", l.message(msg, args...))
 }
 
 func (l *logger) DebugWithDetails(tag, msg string, args ...interface{}) {
+	if l.jsonLogger != nil {
+		l.jsonLogger.DebugWithDetails(tag, msg, args...)
+		return
+	}
+
 	msg = msg + "\n********************\n%s\n********************"
 	l.Debug(tag, msg, args...)
 }
 
 func (l *logger) Info(tag, msg string, args ...interface{}) {
-	tag = fmt.Sprintf("%s:%s", l.threadPrefix, tag)
-	l.boshlogger.Info(tag, msg, args...)
+	l.boshlogger.Info(l.tag(tag), "%s", l.message(msg, args...))
 }
 
 func (l *logger) Warn(tag, msg string, args ...interface{}) {
-	tag = fmt.Sprintf("%s:%s", l.threadPrefix, tag)
-	l.boshlogger.Warn(tag, msg, args...)
+	l.boshlogger.Warn(l.tag(tag), "%s", l.message(msg, args...))
 }
 
 func (l *logger) Error(tag, msg string, args ...interface{}) {
-	tag = fmt.Sprintf("%s:%s", l.threadPrefix, tag)
-	l.boshlogger.Error(tag, msg, args...)
+	l.boshlogger.Error(l.tag(tag), "%s", l.message(msg, args...))
 }
 
 func (l *logger) ErrorWithDetails(tag, msg string, args ...interface{}) {
+	if l.jsonLogger != nil {
+		l.jsonLogger.ErrorWithDetails(tag, msg, args...)
+		return
+	}
+
 	msg = msg + "\n********************\n%s\n********************"
 	l.Error(tag, msg, args...)
 }
 
 func (l *logger) HandlePanic(tag string) {
-	tag = fmt.Sprintf("%s:%s", l.threadPrefix, tag)
-	l.boshlogger.HandlePanic(tag)
+	l.boshlogger.HandlePanic(l.tag(tag))
+}
+
+// tag prefixes text log tags with the request ID or the serial tag prefix,
+// JSON records carry them as fields instead.
+func (l *logger) tag(tag string) string {
+	if l.jsonLogger != nil {
+		return tag
+	}
+	return fmt.Sprintf("%s:%s", l.threadPrefix, tag)
+}
+
+func (l *logger) message(msg string, args ...interface{}) string {
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return RedactString(msg)
 }
 
 func (l *logger) Flush() error { return l.boshlogger.Flush() }
 
 // it's unfriendly to change RetryStrategy().logtag
 func (l *logger) ChangeRetryStrategyLogTag(retryStrategy *boshretry.RetryStrategy) error {
+	if l.jsonLogger != nil {
+		return nil
+	}
+
 	defer func() {
 		if err := recover(); err != nil {
 			l.Warn("cpiLogger", fmt.Sprintf("Recovered from panic when ChangeRetryStrategyLogTag: %s", err))
@@ -84,6 +84,22 @@ var _ = Describe("Logger", func() {
 		Expect(serialTagPrefix).To(ContainSubstring("fake-tag-prefix"))
 	})
 
+	It("tags log lines with the request id once it is set", func() {
+		Expect(logger.GetRequestID()).To(BeEmpty())
+
+		logger.SetRequestID("fake-request-id")
+		Expect(logger.GetRequestID()).To(Equal("fake-request-id"))
+		Expect(logger.GetSerialTagPrefix()).To(Equal("fake-request-id"))
+
+		logger.Info("LoggerUnitTest", "It is from cpi logger.")
+		Expect(out.String()).To(MatchRegexp("\\[fake-request-id:LoggerUnitTest\\]"))
+	})
+
+	It("keeps the serial tag prefix if the request id is empty", func() {
+		logger.SetRequestID("")
+		Expect(logger.GetSerialTagPrefix()).To(Equal("fake-tag-prefix"))
+	})
+
 	It("call to print DEBUG log", func() {
 		logger.Debug("LoggerUnitTest", "It is from cpi logger.")
 		Expect(out.String()).To(ContainSubstring("DEBUG"))
@@ -100,6 +116,18 @@ var _ = Describe("Logger", func() {
 		Expect(out.String()).To(ContainSubstring("DEBUG"))
 	})
 
+	It("redacts credentials from log lines", func() {
+		logger.Debug("LoggerUnitTest", "Request %s", `{"api_key":"fake-api-key"}`)
+		Expect(out.String()).To(ContainSubstring(`{"api_key":"<redact>"}`))
+		Expect(out.String()).ToNot(ContainSubstring("fake-api-key"))
+	})
+
+	It("call to print SoftLayerCall log", func() {
+		logger.SoftLayerCall("SoftLayer_Account", "getObject", 1500*time.Millisecond, nil)
+		Expect(out.String()).To(ContainSubstring("[fake-tag-prefix:SoftLayerCall]"))
+		Expect(out.String()).To(ContainSubstring("Called SoftLayer_Account::getObject in 1.5s"))
+	})
+
 	It("call to print WARN log", func() {
 		logger.Warn("LoggerUnitTest", "It is from cpi logger.")
 		Expect(out.String()).To(ContainSubstring("WARN"))
@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"io"
+	"regexp"
+)
+
+type redaction struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// redactions hide credentials from logged requests, configurations, agent settings and URLs:
+// SoftLayer API keys, registry, NATS and blobstore passwords, iSCSI CHAP credentials and certificates.
+var redactions = []redaction{
+	{
+		pattern:     regexp.MustCompile(`"(password|api_key|apiKey|secret_access_key|private_key|vcappassword)"\s*:\s*"[^"]*"`),
+		replacement: `"$1":"<redact>"`,
+	},
+	{
+		pattern:     regexp.MustCompile(`"cert"\s*:\s*\{[^}]*\}`),
+		replacement: `"cert":<redact>`,
+	},
+	{
+		pattern:     regexp.MustCompile(`//([^/:"@\s]+):[^/"@\s]+@`),
+		replacement: `//$1:<redact>@`,
+	},
+}
+
+// Redact returns bs with all known credentials replaced by <redact>.
+func Redact(bs []byte) []byte {
+	for _, r := range redactions {
+		bs = r.pattern.ReplaceAll(bs, []byte(r.replacement))
+	}
+	return bs
+}
+
+// RedactString returns s with all known credentials replaced by <redact>.
+func RedactString(s string) string {
+	return string(Redact([]byte(s)))
+}
+
+type redactingWriter struct {
+	out io.Writer
+}
+
+// NewRedactingWriter returns a writer redacting credentials before writing to out,
+// for libraries logging to a *log.Logger.
+func NewRedactingWriter(out io.Writer) io.Writer {
+	return redactingWriter{out: out}
+}
+
+func (w redactingWriter) Write(p []byte) (int, error) {
+	if _, err := w.out.Write(Redact(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -2,14 +2,18 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
-	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
 	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
 	httptransport "github.com/go-openapi/runtime/client"
@@ -22,21 +26,29 @@ import (
 	"bosh-softlayer-cpi/api/transport"
 	"bosh-softlayer-cpi/config"
 	cpiLog "bosh-softlayer-cpi/logger"
+	"bosh-softlayer-cpi/metrics"
 	"bosh-softlayer-cpi/softlayer/client"
+	"bosh-softlayer-cpi/softlayer/disk_service"
+	instance "bosh-softlayer-cpi/softlayer/virtual_guest_service"
 	vpsClient "bosh-softlayer-cpi/softlayer/vps_service/client"
 	"bosh-softlayer-cpi/softlayer/vps_service/client/vm"
 )
 
-const logTagMain = "main"
+const (
+	logTagMain = "main"
+
+	// How long the server keeps product packages and datacenter ids
+	serverCacheTTL = 1 * time.Hour
+)
 
 var (
 	configPathOpt = flag.String("configFile", "", "Path to configuration file")
+	serverOpt     = flag.Bool("server", false, "Serve requests on the socket or address of the server configuration")
 )
 
 func main() {
-	logger, fs, uuid, outLogger := basicDeps()
-	cmdRunner := boshsys.NewExecCmdRunner(logger.GetBoshLogger())
-	defer logger.HandlePanic("Main")
+	logger, fs, uuid, outLogger := basicDeps(config.Config{}, fmt.Sprintf("%09d", time.Now().Nanosecond()), &bytes.Buffer{})
+	defer func() { logger.HandlePanic("Main") }()
 
 	flag.Parse()
 
@@ -46,7 +58,32 @@ func main() {
 		os.Exit(1)
 	}
 
-	dispatch := buildDispatcher(cfg, logger, outLogger, uuid, cmdRunner)
+	// Switch to the configured log level and format, keeping what is logged so far
+	logger, fs, uuid, outLogger = basicDeps(cfg, logger.GetSerialTagPrefix(), logger.LogBuff)
+
+	if flag.Arg(0) == "validate" {
+		os.Exit(validate(cfg, flag.Args()[1:], fs, logger, outLogger, uuid))
+	}
+
+	if *serverOpt {
+		err = serve(cfg, logger, uuid)
+		if err != nil {
+			logger.Error(logTagMain, "Serving %s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// The shim forwards to the server and only builds the local dispatcher when the server is down
+	var dispatch dispatcher.Dispatcher
+	serverOptions := cfg.Cloud.Properties.Server
+	if serverOptions.Enabled() {
+		dispatch = transport.NewForwardingDispatcher(serverOptions.Network(), serverOptions.ListenAddress(), func() dispatcher.Dispatcher {
+			return buildDispatcher(cfg, logger, outLogger, uuid)
+		}, logger)
+	} else {
+		dispatch = buildDispatcher(cfg, logger, outLogger, uuid)
+	}
 
 	cli := transport.NewCLI(os.Stdin, os.Stdout, dispatch, logger)
 
@@ -57,31 +94,149 @@ func main() {
 	}
 }
 
-func basicDeps() (api.MultiLogger, boshsys.FileSystem, boshuuid.Generator, *log.Logger) {
-	var logBuff bytes.Buffer
-	multiWriter := io.MultiWriter(os.Stderr, &logBuff)
-	nanos := fmt.Sprintf("%09d", time.Now().Nanosecond())
+// serve keeps one SoftLayer client manager with warm caches and dispatches every request
+// with a copy of it that logs to the request's own log buffer.
+func serve(cfg config.Config, logger api.MultiLogger, uuidGen boshuuid.Generator) error {
+	serverOptions := cfg.Cloud.Properties.Server
+	if !serverOptions.Enabled() {
+		return bosherr.Error("Server mode requires a socket or an address in the server configuration")
+	}
+
+	// softlayer-go logs through a package level logger, keep it out of the request log buffers
+	level, _ := cfg.Cloud.Properties.SoftLayer.LoggerLevel()
+	outLogger := newClientLogger(cfg, cpiLog.NewJSON(level, logger.GetSerialTagPrefix(), os.Stderr), os.Stderr, "")
+	clientManager := buildClientManager(cfg, logger, outLogger)
+	clientManager.EnableCache(serverCacheTTL)
+	accountClientManagers := newClientManagers(serverCacheTTL)
+
+	listener, err := transport.Listen(serverOptions.Network(), serverOptions.ListenAddress())
+	if err != nil {
+		return err
+	}
+
+	server := transport.NewServer(listener, func() dispatcher.Dispatcher {
+		requestLogger, _ := newLoggers(cfg, fmt.Sprintf("%09d", time.Now().Nanosecond()), &bytes.Buffer{})
+		requestClientManager := clientManager.WithLogger(requestLogger).WithMetrics(metrics.NewCollector())
+		return buildActionDispatcher(requestClientManager, accountClientManagers, cfg, requestLogger, outLogger, uuidGen)
+	}, logger)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		logger.Info(logTagMain, "Received %s, shutting down server", sig)
+		if err := server.Shutdown(context.Background()); err != nil {
+			logger.Error(logTagMain, "Shutting down server %s", err)
+		}
+	}()
+
+	return server.Serve()
+}
+
+// validate resolves the cloud config of the -cloudConfig flag in args against the SoftLayer account,
+// prints a line per entry and returns the exit code, 1 if any entry does not resolve.
+func validate(
+	cfg config.Config,
+	args []string,
+	fs boshsys.FileSystem,
+	logger api.MultiLogger,
+	outLogger *log.Logger,
+	uuidGen boshuuid.Generator,
+) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	cloudConfigPath := flags.String("cloudConfig", "", "Path to the cloud config, in YAML or JSON")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *cloudConfigPath == "" {
+		logger.Error(logTagMain, "Validating requires the path of a cloud config in -cloudConfig")
+		return 2
+	}
+
+	content, err := fs.ReadFile(*cloudConfigPath)
+	if err != nil {
+		logger.Error(logTagMain, "Reading cloud config %s", err)
+		return 1
+	}
 
-	clientLogger := log.New(multiWriter, nanos, log.LstdFlags) // For softlayer_client
-	outLogger := log.New(multiWriter, "", log.LstdFlags)
-	errLogger := log.New(os.Stderr, "", log.LstdFlags)
+	cloudConfig, err := action.NewCloudConfigFromBytes(content)
+	if err != nil {
+		logger.Error(logTagMain, "Loading cloud config %s", err)
+		return 1
+	}
 
-	cpiLogger := cpiLog.New(boshlog.LevelDebug, nanos, outLogger, errLogger)
-	multiLogger := api.MultiLogger{Logger: cpiLogger, LogBuff: &logBuff}
-	fs := boshsys.NewOsFileSystem(cpiLogger.GetBoshLogger())
+	softlayerClient := client.NewClientFactory(buildClientManager(cfg, logger, outLogger)).CreateClient()
+	validator := action.NewValidateCloudConfig(
+		instance.NewSoftLayerVirtualGuestService(softlayerClient, uuidGen, logger),
+		disk.NewSoftlayerDiskService(softlayerClient, logger),
+	)
+
+	exitCode := 0
+	for _, result := range validator.Run(cloudConfig) {
+		if result.Err != nil {
+			exitCode = 1
+		}
+		fmt.Fprintln(os.Stdout, result)
+	}
+
+	return exitCode
+}
+
+// basicDeps returns the loggers with the log level and format of cfg and the dependencies logging to them.
+func basicDeps(cfg config.Config, serialTagPrefix string, logBuff *bytes.Buffer) (api.MultiLogger, boshsys.FileSystem, boshuuid.Generator, *log.Logger) {
+	multiLogger, clientLogger := newLoggers(cfg, serialTagPrefix, logBuff)
+	fs := boshsys.NewOsFileSystem(multiLogger.GetBoshLogger())
 
 	uuidGen := boshuuid.NewGenerator()
 
 	return multiLogger, fs, uuidGen, clientLogger
 }
 
+// newLoggers returns the CPI logger and the softlayer-go logger, both writing to stderr and
+// logBuff with the log level and format of cfg.
+func newLoggers(cfg config.Config, serialTagPrefix string, logBuff *bytes.Buffer) (api.MultiLogger, *log.Logger) {
+	multiWriter := io.MultiWriter(os.Stderr, logBuff)
+
+	// The level is validated with the configuration
+	level, _ := cfg.Cloud.Properties.SoftLayer.LoggerLevel()
+
+	var cpiLogger cpiLog.Logger
+	if cfg.Cloud.Properties.SoftLayer.LogFormat == cpiLog.JSONFormat {
+		cpiLogger = cpiLog.NewJSON(level, serialTagPrefix, multiWriter)
+	} else {
+		outLogger := log.New(multiWriter, "", log.LstdFlags)
+		errLogger := log.New(os.Stderr, "", log.LstdFlags)
+		cpiLogger = cpiLog.New(level, serialTagPrefix, outLogger, errLogger)
+	}
+
+	clientLogger := newClientLogger(cfg, cpiLogger, multiWriter, serialTagPrefix) // For softlayer_client
+
+	return api.MultiLogger{Logger: cpiLogger, LogBuff: logBuff}, clientLogger
+}
+
+// newClientLogger returns the logger of softlayer-go, whose lines become records of cpiLogger
+// with the JSON log format.
+func newClientLogger(cfg config.Config, cpiLogger cpiLog.Logger, out io.Writer, prefix string) *log.Logger {
+	if cfg.Cloud.Properties.SoftLayer.LogFormat == cpiLog.JSONFormat {
+		return log.New(cpiLog.NewRecordWriter(cpiLogger, client.SoftlayerGoLogTag), "", 0)
+	}
+	return log.New(cpiLog.NewRedactingWriter(out), prefix, log.LstdFlags)
+}
+
 func buildDispatcher(
 	config config.Config,
 	logger api.MultiLogger,
 	outLogger *log.Logger,
 	uuidGen boshuuid.Generator,
-	cmdRunner boshsys.CmdRunner,
 ) dispatcher.Dispatcher {
+	return buildActionDispatcher(buildClientManager(config, logger, outLogger), newClientManagers(0), config, logger, outLogger, uuidGen)
+}
+
+func buildClientManager(
+	config config.Config,
+	logger api.MultiLogger,
+	outLogger *log.Logger,
+) *client.ClientManager {
 	var softlayerAPIEndpoint string
 	if config.Cloud.Properties.SoftLayer.ApiEndpoint != "" {
 		softlayerAPIEndpoint = config.Cloud.Properties.SoftLayer.ApiEndpoint
@@ -103,17 +258,118 @@ func buildDispatcher(
 		swiftClient = client.NewSwiftClient(config.Cloud.Properties.SoftLayer.SwiftEndpoint, config.Cloud.Properties.SoftLayer.SwiftUsername, config.Cloud.Properties.SoftLayer.ApiKey, 120, 3)
 	}
 
-	repClientFactory := client.NewClientFactory(client.NewSoftLayerClientManager(softLayerClient, vps, swiftClient, logger))
-	cli := repClientFactory.CreateClient()
+	return client.NewSoftLayerClientManager(softLayerClient, vps, swiftClient, logger)
+}
+
+// clientManagers keeps a client manager per SoftLayer account, so the requests of an account
+// share its session and cache.
+type clientManagers struct {
+	cacheTTL time.Duration
+
+	mutex    sync.Mutex
+	managers map[clientManagerKey]*client.ClientManager
+}
+
+type clientManagerKey struct {
+	apiEndpoint   string
+	username      string
+	apiKey        string
+	swiftEndpoint string
+	swiftUsername string
+}
+
+// newClientManagers returns the client managers by account, whose objects are cached for cacheTTL,
+// not cached if it is 0.
+func newClientManagers(cacheTTL time.Duration) *clientManagers {
+	return &clientManagers{
+		cacheTTL: cacheTTL,
+		managers: map[clientManagerKey]*client.ClientManager{},
+	}
+}
+
+// get returns the client manager of the SoftLayer account of config, built on first use.
+func (m *clientManagers) get(config config.Config, logger api.MultiLogger, outLogger *log.Logger) *client.ClientManager {
+	softLayerConfig := config.Cloud.Properties.SoftLayer
+	key := clientManagerKey{
+		apiEndpoint:   softLayerConfig.ApiEndpoint,
+		username:      softLayerConfig.Username,
+		apiKey:        softLayerConfig.ApiKey,
+		swiftEndpoint: softLayerConfig.SwiftEndpoint,
+		swiftUsername: softLayerConfig.SwiftUsername,
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 
+	clientManager, ok := m.managers[key]
+	if !ok {
+		clientManager = buildClientManager(config, logger, outLogger)
+		if m.cacheTTL > 0 {
+			clientManager.EnableCache(m.cacheTTL)
+		}
+		m.managers[key] = clientManager
+	}
+
+	return clientManager
+}
+
+// buildActionDispatcher returns the dispatcher of the actions using clientManager, or the one of
+// accountClientManagers for requests of another account, which reports the SoftLayer API calls of
+// every request.
+func buildActionDispatcher(
+	clientManager *client.ClientManager,
+	accountClientManagers *clientManagers,
+	config config.Config,
+	logger api.MultiLogger,
+	outLogger *log.Logger,
+	uuidGen boshuuid.Generator,
+) dispatcher.Dispatcher {
 	actionFactory := action.NewConcreteFactory(
-		cli,
+		client.NewClientFactory(clientManager).CreateClient(),
 		uuidGen,
 		config,
 		logger,
 	)
 
+	// Requests of other CPI configs carry their SoftLayer account in the context
+	actionFactoryBuilder := func(context dispatcher.RequestContext) (action.Factory, error) {
+		accountConfig := config
+		accountConfig.Cloud.Properties.SoftLayer = context.SoftLayerConfig(config.Cloud.Properties.SoftLayer)
+		if err := accountConfig.Cloud.Properties.SoftLayer.Validate(); err != nil {
+			return nil, err
+		}
+
+		accountClientManager := accountClientManagers.get(accountConfig, logger, outLogger).WithLogger(logger).WithMetrics(clientManager.Metrics())
+		accountClient := client.NewClientFactory(accountClientManager).CreateClient()
+
+		return action.NewConcreteFactory(
+			accountClient,
+			uuidGen,
+			accountConfig,
+			logger,
+		), nil
+	}
+
 	caller := dispatcher.NewJSONCaller()
 
-	return dispatcher.NewJSON(actionFactory, caller, logger)
+	return transport.NewMetricsDispatcher(
+		dispatcher.NewJSON(actionFactory, actionFactoryBuilder, caller, logger),
+		clientManager.Metrics(),
+		newMetricsReporters(config, logger),
+		logger,
+	)
+}
+
+func newMetricsReporters(config config.Config, logger api.MultiLogger) []metrics.Reporter {
+	reporters := []metrics.Reporter{metrics.NewLogReporter(logger)}
+
+	options := config.Cloud.Properties.Metrics
+	if options.PrometheusTextfile != "" {
+		reporters = append(reporters, metrics.NewTextfileReporter(options.PrometheusTextfile))
+	}
+	if options.StatsdAddress != "" {
+		reporters = append(reporters, metrics.NewStatsdReporter(options.StatsdAddress))
+	}
+
+	return reporters
 }
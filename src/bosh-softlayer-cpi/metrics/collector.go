@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+// CallStats are the statistics of the calls to one method of a SoftLayer service.
+type CallStats struct {
+	Service string
+	Method  string
+
+	// Number of HTTP attempts, retries included
+	Count   int
+	Errors  int
+	Retries int
+
+	// Number of errors per SoftLayer exception, e.g. SoftLayer_Exception_WebService_RateLimitExceeded
+	Exceptions map[string]int
+
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+
+	// Time waited before the retries, not part of the durations
+	RetryWait time.Duration
+}
+
+// AverageDuration returns the average duration of the calls.
+func (s CallStats) AverageDuration() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Count)
+}
+
+// Collector records the SoftLayer API calls of a CPI request.
+type Collector struct {
+	mutex sync.Mutex
+	stats map[string]*CallStats
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		stats: map[string]*CallStats{},
+	}
+}
+
+// Record records the first HTTP attempt of a call of method of service which took duration
+// and failed with err, if not nil.
+func (c *Collector) Record(service string, method string, duration time.Duration, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.record(service, method, duration, err)
+}
+
+// RecordRetry records an HTTP attempt of a call of method of service made after waiting wait
+// for a failed attempt, which took duration and failed with err, if not nil.
+func (c *Collector) RecordRetry(service string, method string, wait time.Duration, duration time.Duration, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := c.record(service, method, duration, err)
+	stats.Retries++
+	stats.RetryWait += wait
+}
+
+func (c *Collector) record(service string, method string, duration time.Duration, err error) *CallStats {
+	key := service + "::" + method
+	stats, found := c.stats[key]
+	if !found {
+		stats = &CallStats{Service: service, Method: method, Exceptions: map[string]int{}}
+		c.stats[key] = stats
+	}
+
+	stats.Count++
+	stats.TotalDuration += duration
+	if duration > stats.MaxDuration {
+		stats.MaxDuration = duration
+	}
+
+	if err != nil {
+		stats.Errors++
+		stats.Exceptions[exceptionOf(err)]++
+	}
+
+	return stats
+}
+
+// Stats returns the statistics of every called service method, the most time consuming first.
+func (c *Collector) Stats() []CallStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := make([]CallStats, 0, len(c.stats))
+	for _, s := range c.stats {
+		exceptions := make(map[string]int, len(s.Exceptions))
+		for exception, count := range s.Exceptions {
+			exceptions[exception] = count
+		}
+		copied := *s
+		copied.Exceptions = exceptions
+		stats = append(stats, copied)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].TotalDuration != stats[j].TotalDuration {
+			return stats[i].TotalDuration > stats[j].TotalDuration
+		}
+		return stats[i].Service+stats[i].Method < stats[j].Service+stats[j].Method
+	})
+
+	return stats
+}
+
+func exceptionOf(err error) string {
+	if slErr, ok := err.(sl.Error); ok {
+		if slErr.Exception != "" {
+			return slErr.Exception
+		}
+		if slErr.StatusCode != 0 {
+			return fmt.Sprintf("HTTP %d", slErr.StatusCode)
+		}
+	}
+	return "Error"
+}
@@ -0,0 +1,83 @@
+package metrics_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/metrics"
+)
+
+var _ = Describe("Collector", func() {
+	var (
+		collector *metrics.Collector
+	)
+
+	BeforeEach(func() {
+		collector = metrics.NewCollector()
+	})
+
+	It("counts the calls and durations per service method", func() {
+		collector.Record("SoftLayer_Virtual_Guest", "getObject", 100*time.Millisecond, nil)
+		collector.Record("SoftLayer_Virtual_Guest", "getObject", 300*time.Millisecond, nil)
+		collector.Record("SoftLayer_Product_Order", "placeOrder", 2*time.Second, nil)
+
+		stats := collector.Stats()
+		Expect(stats).To(HaveLen(2))
+
+		Expect(stats[0].Service).To(Equal("SoftLayer_Product_Order"))
+		Expect(stats[0].Method).To(Equal("placeOrder"))
+		Expect(stats[0].Count).To(Equal(1))
+
+		Expect(stats[1].Service).To(Equal("SoftLayer_Virtual_Guest"))
+		Expect(stats[1].Method).To(Equal("getObject"))
+		Expect(stats[1].Count).To(Equal(2))
+		Expect(stats[1].TotalDuration).To(Equal(400 * time.Millisecond))
+		Expect(stats[1].MaxDuration).To(Equal(300 * time.Millisecond))
+		Expect(stats[1].AverageDuration()).To(Equal(200 * time.Millisecond))
+	})
+
+	It("counts errors per exception", func() {
+		rateLimitErr := sl.Error{StatusCode: 429, Exception: "SoftLayer_Exception_WebService_RateLimitExceeded"}
+		collector.Record("SoftLayer_Virtual_Guest", "getObject", time.Millisecond, rateLimitErr)
+		collector.Record("SoftLayer_Virtual_Guest", "getObject", time.Millisecond, rateLimitErr)
+		collector.Record("SoftLayer_Virtual_Guest", "getObject", time.Millisecond, nil)
+		collector.Record("SoftLayer_Virtual_Guest", "getObject", time.Millisecond, sl.Error{StatusCode: 500})
+		collector.Record("SoftLayer_Virtual_Guest", "getObject", time.Millisecond, errors.New("fake-network-error"))
+
+		stats := collector.Stats()
+		Expect(stats).To(HaveLen(1))
+		Expect(stats[0].Count).To(Equal(5))
+		Expect(stats[0].Errors).To(Equal(4))
+		Expect(stats[0].Retries).To(Equal(0))
+		Expect(stats[0].Exceptions).To(Equal(map[string]int{
+			"SoftLayer_Exception_WebService_RateLimitExceeded": 2,
+			"HTTP 500": 1,
+			"Error":    1,
+		}))
+	})
+
+	It("counts retried attempts and the time waited before them", func() {
+		rateLimitErr := sl.Error{StatusCode: 429, Exception: "SoftLayer_Exception_WebService_RateLimitExceeded"}
+		collector.Record("SoftLayer_Virtual_Guest", "getObject", 100*time.Millisecond, rateLimitErr)
+		collector.RecordRetry("SoftLayer_Virtual_Guest", "getObject", time.Minute, 100*time.Millisecond, rateLimitErr)
+		collector.RecordRetry("SoftLayer_Virtual_Guest", "getObject", 2*time.Minute, 200*time.Millisecond, nil)
+
+		stats := collector.Stats()
+		Expect(stats).To(HaveLen(1))
+		Expect(stats[0].Count).To(Equal(3))
+		Expect(stats[0].Errors).To(Equal(2))
+		Expect(stats[0].Retries).To(Equal(2))
+		Expect(stats[0].Exceptions).To(Equal(map[string]int{"SoftLayer_Exception_WebService_RateLimitExceeded": 2}))
+		Expect(stats[0].TotalDuration).To(Equal(400 * time.Millisecond))
+		Expect(stats[0].RetryWait).To(Equal(3 * time.Minute))
+	})
+
+	It("returns no statistics without calls", func() {
+		Expect(collector.Stats()).To(BeEmpty())
+	})
+})
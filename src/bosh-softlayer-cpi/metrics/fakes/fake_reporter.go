@@ -0,0 +1,101 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"bosh-softlayer-cpi/metrics"
+)
+
+type FakeReporter struct {
+	ReportStub        func(cpiMethod string, stats []metrics.CallStats) error
+	reportMutex       sync.RWMutex
+	reportArgsForCall []struct {
+		cpiMethod string
+		stats     []metrics.CallStats
+	}
+	reportReturns struct {
+		result1 error
+	}
+	reportReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeReporter) Report(cpiMethod string, stats []metrics.CallStats) error {
+	var statsCopy []metrics.CallStats
+	if stats != nil {
+		statsCopy = make([]metrics.CallStats, len(stats))
+		copy(statsCopy, stats)
+	}
+	fake.reportMutex.Lock()
+	ret, specificReturn := fake.reportReturnsOnCall[len(fake.reportArgsForCall)]
+	fake.reportArgsForCall = append(fake.reportArgsForCall, struct {
+		cpiMethod string
+		stats     []metrics.CallStats
+	}{cpiMethod, statsCopy})
+	fake.recordInvocation("Report", []interface{}{cpiMethod, statsCopy})
+	fake.reportMutex.Unlock()
+	if fake.ReportStub != nil {
+		return fake.ReportStub(cpiMethod, stats)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.reportReturns.result1
+}
+
+func (fake *FakeReporter) ReportCallCount() int {
+	fake.reportMutex.RLock()
+	defer fake.reportMutex.RUnlock()
+	return len(fake.reportArgsForCall)
+}
+
+func (fake *FakeReporter) ReportArgsForCall(i int) (string, []metrics.CallStats) {
+	fake.reportMutex.RLock()
+	defer fake.reportMutex.RUnlock()
+	return fake.reportArgsForCall[i].cpiMethod, fake.reportArgsForCall[i].stats
+}
+
+func (fake *FakeReporter) ReportReturns(result1 error) {
+	fake.ReportStub = nil
+	fake.reportReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReporter) ReportReturnsOnCall(i int, result1 error) {
+	fake.ReportStub = nil
+	if fake.reportReturnsOnCall == nil {
+		fake.reportReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reportReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReporter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.reportMutex.RLock()
+	defer fake.reportMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeReporter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ metrics.Reporter = new(FakeReporter)
@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	"bosh-softlayer-cpi/logger"
+)
+
+const (
+	metricsLogTag = "SoftLayerCallMetrics"
+
+	metricPrefix = "bosh_softlayer_cpi"
+)
+
+//go:generate counterfeiter -o fakes/fake_reporter.go . Reporter
+
+// Reporter publishes the SoftLayer API call statistics of a CPI request.
+type Reporter interface {
+	Report(cpiMethod string, stats []CallStats) error
+}
+
+type logReporter struct {
+	logger logger.Logger
+}
+
+// NewLogReporter returns a reporter logging a summary line and one line per service method.
+func NewLogReporter(logger logger.Logger) Reporter {
+	return logReporter{logger: logger}
+}
+
+func (r logReporter) Report(cpiMethod string, stats []CallStats) error {
+	var count, errors, retries int
+	var duration, retryWait time.Duration
+	for _, s := range stats {
+		count += s.Count
+		errors += s.Errors
+		retries += s.Retries
+		duration += s.TotalDuration
+		retryWait += s.RetryWait
+	}
+
+	r.logger.Info(metricsLogTag, "Method '%s' made %d SoftLayer API calls in %s with %d errors and %d retries after %s", cpiMethod, count, duration, errors, retries, retryWait)
+	for _, s := range stats {
+		r.logger.Info(metricsLogTag, "%s::%s called %d times in %s (average %s, max %s) with %d errors and %d retries after %s%s",
+			s.Service, s.Method, s.Count, s.TotalDuration, s.AverageDuration(), s.MaxDuration, s.Errors, s.Retries, s.RetryWait, formatExceptions(s.Exceptions))
+	}
+
+	return nil
+}
+
+func formatExceptions(exceptions map[string]int) string {
+	if len(exceptions) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(exceptions))
+	for name := range exceptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	counts := make([]string, len(names))
+	for i, name := range names {
+		counts[i] = fmt.Sprintf("%s=%d", name, exceptions[name])
+	}
+	return ", exceptions: " + strings.Join(counts, ", ")
+}
+
+type textfileReporter struct {
+	path string
+}
+
+// NewTextfileReporter returns a reporter writing the statistics of the last request to path,
+// in the text format of the Prometheus node exporter textfile collector.
+func NewTextfileReporter(path string) Reporter {
+	return textfileReporter{path: path}
+}
+
+func (r textfileReporter) Report(cpiMethod string, stats []CallStats) error {
+	var buf bytes.Buffer
+
+	writeMetric := func(name string, help string, value func(CallStats) float64) {
+		fmt.Fprintf(&buf, "# HELP %s_%s %s\n# TYPE %s_%s gauge\n", metricPrefix, name, help, metricPrefix, name)
+		for _, s := range stats {
+			fmt.Fprintf(&buf, "%s_%s{cpi_method=%q,service=%q,method=%q} %g\n", metricPrefix, name, cpiMethod, s.Service, s.Method, value(s))
+		}
+	}
+
+	writeMetric("softlayer_calls", "SoftLayer API calls of the last CPI request.", func(s CallStats) float64 { return float64(s.Count) })
+	writeMetric("softlayer_call_errors", "Failed SoftLayer API calls of the last CPI request.", func(s CallStats) float64 { return float64(s.Errors) })
+	writeMetric("softlayer_call_retries", "Retried SoftLayer API calls of the last CPI request.", func(s CallStats) float64 { return float64(s.Retries) })
+	writeMetric("softlayer_call_retry_wait_seconds", "Time waited before retrying SoftLayer API calls of the last CPI request.", func(s CallStats) float64 { return s.RetryWait.Seconds() })
+	writeMetric("softlayer_call_duration_seconds", "Time spent in SoftLayer API calls of the last CPI request.", func(s CallStats) float64 { return s.TotalDuration.Seconds() })
+	writeMetric("softlayer_call_max_duration_seconds", "Longest SoftLayer API call of the last CPI request.", func(s CallStats) float64 { return s.MaxDuration.Seconds() })
+
+	name := metricPrefix + "_softlayer_call_exceptions"
+	fmt.Fprintf(&buf, "# HELP %s SoftLayer API exceptions of the last CPI request.\n# TYPE %s gauge\n", name, name)
+	for _, s := range stats {
+		for exception, count := range s.Exceptions {
+			fmt.Fprintf(&buf, "%s{cpi_method=%q,service=%q,method=%q,exception=%q} %d\n", name, cpiMethod, s.Service, s.Method, exception, count)
+		}
+	}
+
+	// The node exporter may read the file at any time, so it is replaced at once. Concurrent
+	// requests of the server each write their own temporary file.
+	tmpFile, err := ioutil.TempFile(filepath.Dir(r.path), "."+filepath.Base(r.path)+".")
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating temporary file for '%s'", r.path)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath) // #nosec G104
+
+	_, err = tmpFile.Write(buf.Bytes())
+	if err == nil {
+		err = tmpFile.Chmod(0644)
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing metrics to '%s'", tmpPath)
+	}
+
+	if err := os.Rename(tmpPath, r.path); err != nil {
+		return bosherr.WrapErrorf(err, "Renaming '%s' to '%s'", tmpPath, r.path)
+	}
+
+	return nil
+}
+
+type statsdReporter struct {
+	address string
+}
+
+// NewStatsdReporter returns a reporter sending counters and timers to the StatsD server on address (host:port).
+func NewStatsdReporter(address string) Reporter {
+	return statsdReporter{address: address}
+}
+
+func (r statsdReporter) Report(cpiMethod string, stats []CallStats) error {
+	conn, err := net.Dial("udp", r.address)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Connecting to StatsD on '%s'", r.address)
+	}
+	defer conn.Close()
+
+	for _, s := range stats {
+		prefix := strings.Join([]string{metricPrefix, cpiMethod, s.Service, s.Method}, ".")
+
+		lines := []string{
+			fmt.Sprintf("%s.calls:%d|c", prefix, s.Count),
+			fmt.Sprintf("%s.errors:%d|c", prefix, s.Errors),
+			fmt.Sprintf("%s.retries:%d|c", prefix, s.Retries),
+			fmt.Sprintf("%s.duration:%d|ms", prefix, int64(s.TotalDuration/time.Millisecond)),
+			fmt.Sprintf("%s.retry_wait:%d|ms", prefix, int64(s.RetryWait/time.Millisecond)),
+		}
+		for exception, count := range s.Exceptions {
+			lines = append(lines, fmt.Sprintf("%s.exceptions.%s:%d|c", prefix, strings.Replace(exception, " ", "_", -1), count))
+		}
+
+		// One datagram per service method keeps packets below the usual MTU
+		if _, err := conn.Write([]byte(strings.Join(lines, "\n"))); err != nil {
+			return bosherr.WrapErrorf(err, "Sending metrics to StatsD on '%s'", r.address)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package metrics_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+
+	cpilog "bosh-softlayer-cpi/logger"
+	"bosh-softlayer-cpi/metrics"
+)
+
+var _ = Describe("Reporter", func() {
+	var (
+		stats []metrics.CallStats
+	)
+
+	BeforeEach(func() {
+		stats = []metrics.CallStats{
+			{
+				Service:       "SoftLayer_Virtual_Guest",
+				Method:        "getObject",
+				Count:         3,
+				Errors:        1,
+				Retries:       1,
+				Exceptions:    map[string]int{"SoftLayer_Exception_WebService_RateLimitExceeded": 1},
+				TotalDuration: 1500 * time.Millisecond,
+				MaxDuration:   time.Second,
+				RetryWait:     time.Minute,
+			},
+		}
+	})
+
+	Describe("LogReporter", func() {
+		It("logs a summary and the statistics of every service method", func() {
+			var out bytes.Buffer
+			reporter := metrics.NewLogReporter(cpilog.NewJSON(boshlog.LevelDebug, "", &out))
+
+			err := reporter.Report("create_vm", stats)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out.String()).To(ContainSubstring("Method 'create_vm' made 3 SoftLayer API calls in 1.5s with 1 errors and 1 retries after 1m0s"))
+			Expect(out.String()).To(ContainSubstring("SoftLayer_Virtual_Guest::getObject called 3 times in 1.5s (average 500ms, max 1s) with 1 errors and 1 retries after 1m0s, exceptions: SoftLayer_Exception_WebService_RateLimitExceeded=1"))
+		})
+	})
+
+	Describe("TextfileReporter", func() {
+		var (
+			dir string
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "metrics")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("writes the statistics in the Prometheus text format", func() {
+			path := filepath.Join(dir, "softlayer_cpi.prom")
+			reporter := metrics.NewTextfileReporter(path)
+
+			err := reporter.Report("create_vm", stats)
+			Expect(err).ToNot(HaveOccurred())
+
+			bs, err := ioutil.ReadFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			content := string(bs)
+			Expect(content).To(ContainSubstring("# TYPE bosh_softlayer_cpi_softlayer_calls gauge\n"))
+			Expect(content).To(ContainSubstring(`bosh_softlayer_cpi_softlayer_calls{cpi_method="create_vm",service="SoftLayer_Virtual_Guest",method="getObject"} 3`))
+			Expect(content).To(ContainSubstring(`bosh_softlayer_cpi_softlayer_call_duration_seconds{cpi_method="create_vm",service="SoftLayer_Virtual_Guest",method="getObject"} 1.5`))
+			Expect(content).To(ContainSubstring(`bosh_softlayer_cpi_softlayer_call_retry_wait_seconds{cpi_method="create_vm",service="SoftLayer_Virtual_Guest",method="getObject"} 60`))
+			Expect(content).To(ContainSubstring(`bosh_softlayer_cpi_softlayer_call_exceptions{cpi_method="create_vm",service="SoftLayer_Virtual_Guest",method="getObject",exception="SoftLayer_Exception_WebService_RateLimitExceeded"} 1`))
+
+			files, err := ioutil.ReadDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+			Expect(files[0].Mode().Perm()).To(Equal(os.FileMode(0644)))
+		})
+
+		It("writes the statistics of concurrent requests", func() {
+			path := filepath.Join(dir, "softlayer_cpi.prom")
+			reporter := metrics.NewTextfileReporter(path)
+
+			errs := make(chan error, 10)
+			for i := 0; i < 10; i++ {
+				go func() { errs <- reporter.Report("create_vm", stats) }()
+			}
+			for i := 0; i < 10; i++ {
+				Expect(<-errs).ToNot(HaveOccurred())
+			}
+
+			files, err := ioutil.ReadDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+		})
+
+		It("returns error if writing the file fails", func() {
+			reporter := metrics.NewTextfileReporter(filepath.Join(dir, "missing", "softlayer_cpi.prom"))
+
+			err := reporter.Report("create_vm", stats)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Creating temporary file"))
+		})
+	})
+
+	Describe("StatsdReporter", func() {
+		It("sends counters and timers of every service method", func() {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+
+			reporter := metrics.NewStatsdReporter(conn.LocalAddr().String())
+			err = reporter.Report("create_vm", stats)
+			Expect(err).ToNot(HaveOccurred())
+
+			buf := make([]byte, 1024)
+			conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			n, _, err := conn.ReadFrom(buf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strings.Split(string(buf[:n]), "\n")).To(Equal([]string{
+				"bosh_softlayer_cpi.create_vm.SoftLayer_Virtual_Guest.getObject.calls:3|c",
+				"bosh_softlayer_cpi.create_vm.SoftLayer_Virtual_Guest.getObject.errors:1|c",
+				"bosh_softlayer_cpi.create_vm.SoftLayer_Virtual_Guest.getObject.retries:1|c",
+				"bosh_softlayer_cpi.create_vm.SoftLayer_Virtual_Guest.getObject.duration:1500|ms",
+				"bosh_softlayer_cpi.create_vm.SoftLayer_Virtual_Guest.getObject.retry_wait:60000|ms",
+				"bosh_softlayer_cpi.create_vm.SoftLayer_Virtual_Guest.getObject.exceptions.SoftLayer_Exception_WebService_RateLimitExceeded:1|c",
+			}))
+		})
+	})
+})
@@ -10,6 +10,9 @@ type SoftlayerUserData struct {
 	Server   SoftlayerUserDataServerName       `json:"server"`
 	Registry SoftlayerUserDataRegistryEndpoint `json:"registry"`
 	DNS      SoftlayerUserDataDNSItems         `json:"dns,omitempty"`
+
+	// Agent settings for stemcells which do not read them from the registry
+	Settings *AgentSettings `json:"agent_settings,omitempty"`
 }
 
 type SoftlayerUserDataServerName struct {
@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"regexp"
 	"time"
 
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
@@ -45,25 +44,25 @@ func (c HTTPClient) Delete(instanceID string) error {
 	} else {
 		endpoint = fmt.Sprintf("%s/instances/%s/settings", c.options.EndpointWithCredentials(), instanceID)
 	}
-	c.logger.Debug(httpClientLogTag, "Deleting agent settings from registry endpoint '%s'", redact([]byte(endpoint)))
+	c.logger.Debug(httpClientLogTag, "Deleting agent settings from registry endpoint '%s'", endpoint)
 
 	request, err := http.NewRequest("DELETE", endpoint, nil)
 	if err != nil {
-		return bosherr.WrapErrorf(err, "Creating DELETE request for registry endpoint '%s'", redact([]byte(endpoint)))
+		return bosherr.WrapErrorf(err, "Creating DELETE request for registry endpoint '%s'", logger.Redact([]byte(endpoint)))
 	}
 
 	httpResponse, err := c.doRequest(request)
 	if err != nil {
-		return bosherr.WrapErrorf(err, "Deleting agent settings from registry endpoint '%s'", redact([]byte(endpoint)))
+		return bosherr.WrapErrorf(err, "Deleting agent settings from registry endpoint '%s'", logger.Redact([]byte(endpoint)))
 	}
 
 	defer httpResponse.Body.Close()
 
 	if httpResponse.StatusCode != http.StatusOK {
-		return bosherr.Errorf("Received status code '%d' when deleting agent settings from registry endpoint '%s'", httpResponse.StatusCode, redact([]byte(endpoint)))
+		return bosherr.Errorf("Received status code '%d' when deleting agent settings from registry endpoint '%s'", httpResponse.StatusCode, logger.Redact([]byte(endpoint)))
 	}
 
-	c.logger.Debug(httpClientLogTag, "Deleted agent settings from registry endpoint '%s'", redact([]byte(endpoint)))
+	c.logger.Debug(httpClientLogTag, "Deleted agent settings from registry endpoint '%s'", endpoint)
 	return nil
 }
 
@@ -75,37 +74,37 @@ func (c HTTPClient) Fetch(instanceID string) (AgentSettings, error) {
 	} else {
 		endpoint = fmt.Sprintf("%s/instances/%s/settings", c.options.EndpointWithCredentials(), instanceID)
 	}
-	c.logger.Debug(httpClientLogTag, "Fetching agent settings from registry endpoint '%s'", redact([]byte(endpoint)))
+	c.logger.Debug(httpClientLogTag, "Fetching agent settings from registry endpoint '%s'", endpoint)
 
 	request, err := http.NewRequest("GET", endpoint, nil)
 	if err != nil {
-		return AgentSettings{}, bosherr.WrapErrorf(err, "Creating GET request for registry endpoint '%s'", redact([]byte(endpoint)))
+		return AgentSettings{}, bosherr.WrapErrorf(err, "Creating GET request for registry endpoint '%s'", logger.Redact([]byte(endpoint)))
 	}
 
 	httpResponse, err := c.doRequest(request)
 	if err != nil {
-		return AgentSettings{}, bosherr.WrapErrorf(err, "Fetching agent settings from registry endpoint '%s'", redact([]byte(endpoint)))
+		return AgentSettings{}, bosherr.WrapErrorf(err, "Fetching agent settings from registry endpoint '%s'", logger.Redact([]byte(endpoint)))
 	}
 
 	defer httpResponse.Body.Close()
 
 	if httpResponse.StatusCode != http.StatusOK {
-		return AgentSettings{}, bosherr.Errorf("Received status code '%d' when fetching agent settings from registry endpoint '%s'", httpResponse.StatusCode, redact([]byte(endpoint)))
+		return AgentSettings{}, bosherr.Errorf("Received status code '%d' when fetching agent settings from registry endpoint '%s'", httpResponse.StatusCode, logger.Redact([]byte(endpoint)))
 	}
 
 	httpBody, err := ioutil.ReadAll(httpResponse.Body)
 	if err != nil {
-		return AgentSettings{}, bosherr.WrapErrorf(err, "Reading agent settings response from registry endpoint '%s'", redact([]byte(endpoint)))
+		return AgentSettings{}, bosherr.WrapErrorf(err, "Reading agent settings response from registry endpoint '%s'", logger.Redact([]byte(endpoint)))
 	}
 
 	var settingsResponse agentSettingsResponse
 	if err = json.Unmarshal(httpBody, &settingsResponse); err != nil {
-		return AgentSettings{}, bosherr.WrapErrorf(err, "Unmarshalling agent settings response from registry endpoint '%s', contents: '%s'", redact([]byte(endpoint)), httpBody)
+		return AgentSettings{}, bosherr.WrapErrorf(err, "Unmarshalling agent settings response from registry endpoint '%s', contents: '%s'", logger.Redact([]byte(endpoint)), httpBody)
 	}
 
 	var agentSettings AgentSettings
 	if err = json.Unmarshal([]byte(settingsResponse.Settings), &agentSettings); err != nil {
-		return AgentSettings{}, bosherr.WrapErrorf(err, "Unmarshalling agent settings response from registry endpoint '%s', contents: '%s'", redact([]byte(endpoint)), httpBody)
+		return AgentSettings{}, bosherr.WrapErrorf(err, "Unmarshalling agent settings response from registry endpoint '%s', contents: '%s'", logger.Redact([]byte(endpoint)), httpBody)
 	}
 
 	settingsJSON, err := json.Marshal(agentSettings)
@@ -113,7 +112,7 @@ func (c HTTPClient) Fetch(instanceID string) (AgentSettings, error) {
 		return AgentSettings{}, bosherr.WrapErrorf(err, "Marshalling agent settings, contents: '%#v", agentSettings)
 	}
 
-	c.logger.Debug(httpClientLogTag, "Received agent settings from registry endpoint '%s', contents: '%s'", redact([]byte(endpoint)), redact(settingsJSON))
+	c.logger.Debug(httpClientLogTag, "Received agent settings from registry endpoint '%s', contents: '%s'", endpoint, settingsJSON)
 	return agentSettings, nil
 }
 
@@ -126,16 +125,16 @@ func (c HTTPClient) IsExist(instanceID string) (bool, error) {
 	} else {
 		endpoint = fmt.Sprintf("%s/instances/%s/settings", c.options.EndpointWithCredentials(), instanceID)
 	}
-	c.logger.Debug(httpClientLogTag, "Fetching agent settings from registry endpoint '%s'", redact([]byte(endpoint)))
+	c.logger.Debug(httpClientLogTag, "Fetching agent settings from registry endpoint '%s'", endpoint)
 
 	request, err := http.NewRequest("GET", endpoint, nil)
 	if err != nil {
-		return false, bosherr.WrapErrorf(err, "Creating GET request for registry endpoint '%s'", redact([]byte(endpoint)))
+		return false, bosherr.WrapErrorf(err, "Creating GET request for registry endpoint '%s'", logger.Redact([]byte(endpoint)))
 	}
 
 	httpResponse, err := c.doRequest(request)
 	if err != nil {
-		return false, bosherr.WrapErrorf(err, "Fetching agent settings from registry endpoint '%s'", redact([]byte(endpoint)))
+		return false, bosherr.WrapErrorf(err, "Fetching agent settings from registry endpoint '%s'", logger.Redact([]byte(endpoint)))
 	}
 
 	defer httpResponse.Body.Close()
@@ -144,7 +143,7 @@ func (c HTTPClient) IsExist(instanceID string) (bool, error) {
 		if httpResponse.StatusCode == 500 {
 			return false, nil
 		}
-		return false, bosherr.Errorf("Received status code '%d' when fetching agent settings from registry endpoint '%s'", httpResponse.StatusCode, redact([]byte(endpoint)))
+		return false, bosherr.Errorf("Received status code '%d' when fetching agent settings from registry endpoint '%s'", httpResponse.StatusCode, logger.Redact([]byte(endpoint)))
 	}
 
 	return true, nil
@@ -163,26 +162,26 @@ func (c HTTPClient) Update(instanceID string, agentSettings AgentSettings) error
 	} else {
 		endpoint = fmt.Sprintf("%s/instances/%s/settings", c.options.EndpointWithCredentials(), instanceID)
 	}
-	c.logger.Debug(httpClientLogTag, "Updating registry endpoint '%s' with agent settings '%s'", redact([]byte(endpoint)), redact(settingsJSON))
+	c.logger.Debug(httpClientLogTag, "Updating registry endpoint '%s' with agent settings '%s'", endpoint, settingsJSON)
 
 	putPayload := bytes.NewReader(settingsJSON)
 	request, err := http.NewRequest("PUT", endpoint, putPayload)
 	if err != nil {
-		return bosherr.WrapErrorf(err, "Creating PUT request for registry endpoint '%s' with agent settings '%s'", redact([]byte(endpoint)), redact(settingsJSON))
+		return bosherr.WrapErrorf(err, "Creating PUT request for registry endpoint '%s' with agent settings '%s'", logger.Redact([]byte(endpoint)), logger.Redact(settingsJSON))
 	}
 
 	httpResponse, err := c.doRequest(request)
 	if err != nil {
-		return bosherr.WrapErrorf(err, "Updating registry endpoint '%s' with agent settings: '%s'", redact([]byte(endpoint)), redact(settingsJSON))
+		return bosherr.WrapErrorf(err, "Updating registry endpoint '%s' with agent settings: '%s'", logger.Redact([]byte(endpoint)), logger.Redact(settingsJSON))
 	}
 
 	defer httpResponse.Body.Close()
 
 	if httpResponse.StatusCode != http.StatusOK && httpResponse.StatusCode != http.StatusCreated {
-		return bosherr.Errorf("Received status code '%d' when updating registry endpoint '%s' with agent settings: '%s'", httpResponse.StatusCode, redact([]byte(endpoint)), redact(settingsJSON))
+		return bosherr.Errorf("Received status code '%d' when updating registry endpoint '%s' with agent settings: '%s'", httpResponse.StatusCode, logger.Redact([]byte(endpoint)), logger.Redact(settingsJSON))
 	}
 
-	c.logger.Debug(httpClientLogTag, "Updated registry endpoint '%s' with agent settings '%s'", redact([]byte(endpoint)), redact(settingsJSON))
+	c.logger.Debug(httpClientLogTag, "Updated registry endpoint '%s' with agent settings '%s'", endpoint, settingsJSON)
 	return nil
 }
 
@@ -237,25 +236,3 @@ func (c HTTPClient) httpClient() (http.Client, error) {
 
 	return httpClient, nil
 }
-
-func redact(bs []byte) []byte {
-	s := string(bs)
-
-	hiddenStr1 := "\"password\":\"<redact>\""
-	r1 := regexp.MustCompile(`"password":"[^"]*"`)
-	s1 := r1.ReplaceAllString(s, hiddenStr1)
-
-	hiddenStr2 := "\"cert\":<redact>"
-	r2 := regexp.MustCompile(`"cert":\{([^}]*)\}`)
-	s2 := r2.ReplaceAllString(s1, hiddenStr2)
-
-	hiddenStr3 := "//registry:<redact>"
-	r3 := regexp.MustCompile(`//registry:[^@]*`)
-	s3 := r3.ReplaceAllString(s2, hiddenStr3)
-
-	hiddenStr4 := "//nats:<redact>"
-	r4 := regexp.MustCompile(`//nats:[^@]*`)
-	s4 := r4.ReplaceAllString(s3, hiddenStr4)
-
-	return []byte(s4)
-}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// objectCache keeps rarely changing SoftLayer objects (product packages, datacenter ids)
+// for a while. A nil cache loads every time, which is what a one-shot CPI call wants.
+type objectCache struct {
+	ttl     time.Duration
+	mutex   sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+func newObjectCache(ttl time.Duration) *objectCache {
+	return &objectCache{
+		ttl:     ttl,
+		entries: map[string]cacheEntry{},
+	}
+}
+
+func (c *objectCache) get(key string, load func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return load()
+	}
+
+	c.mutex.Lock()
+	entry, found := c.entries[key]
+	c.mutex.Unlock()
+	if found && time.Now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.entries[key] = cacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+	c.mutex.Unlock()
+
+	return value, nil
+}
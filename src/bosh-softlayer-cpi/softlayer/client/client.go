@@ -24,6 +24,7 @@ import (
 	"github.com/softlayer/softlayer-go/sl"
 
 	"bosh-softlayer-cpi/logger"
+	"bosh-softlayer-cpi/metrics"
 	"bosh-softlayer-cpi/registry"
 	vpsVm "bosh-softlayer-cpi/softlayer/vps_service/client/vm"
 	"bosh-softlayer-cpi/softlayer/vps_service/models"
@@ -38,7 +39,7 @@ const (
 		"powerState.name, activeTransaction, datacenter.name, account.id, " +
 		"maxCpu, maxMemory, primaryIpAddress, primaryBackendIpAddress, " +
 		"privateNetworkOnlyFlag, dedicatedAccountHostOnlyFlag, dedicatedHost, createDate, modifyDate, " +
-		"billingItem[orderItem.order.userRecord.username, recurringFee], notes, tagReferences.tag.name"
+		"billingItem[orderItem[order.userRecord.username, preset.keyName], recurringFee], blockDevices[device, diskImage.capacity], notes, tagReferences.tag.name"
 
 	INSTANCE_DETAIL_MASK = "id, globalIdentifier, hostname, domain, fullyQualifiedDomainName, status.name, " +
 		"powerState.name, activeTransaction, datacenter.name, " +
@@ -55,7 +56,14 @@ const (
 	NETWORK_DEFAULT_VLAN_MASK   = "id,primarySubnetId,networkSpace"
 	NETWORK_DEFAULT_SUBNET_MASK = "id,networkVlanId,addressSpace"
 
-	VOLUME_DEFAULT_MASK = "id,username,lunId,capacityGb,bytesUsed,serviceResource.datacenter.name,serviceResourceBackendIpAddress,activeTransactionCount,billingItem.orderItem.order[id,userRecord.username]"
+	GLOBAL_IP_DEFAULT_MASK = "id, ipAddressId, ipAddress.ipAddress, ipAddress.note, destinationIpAddress.ipAddress"
+
+	DEDICATED_HOST_DEFAULT_MASK = "id, name, cpuCount, memoryCapacity, diskCapacity, guestCount, datacenter.name, " +
+		"allocationStatus[cpuAvailable, memoryAvailable, diskAvailable, guestCount], tagReferences[tag[name]], guests[hostname, tagReferences[tag[name]]]"
+
+	LOAD_BALANCER_DEFAULT_MASK = "id, uuid, name, provisioningStatus, listeners[uuid, defaultPool[uuid, protocolPort]], members[uuid, address, weight]"
+
+	VOLUME_DEFAULT_MASK = "id,username,notes,lunId,capacityGb,bytesUsed,hasEncryptionAtRest,serviceResource.datacenter.name,serviceResourceBackendIpAddress,activeTransactionCount,billingItem.orderItem.order[id,userRecord.username]"
 
 	ALLOWD_HOST_DEFAULT_MASK = "id, name, credential[username, password]"
 
@@ -64,11 +72,27 @@ const (
 		"activeTransactions.transactionStatus.friendlyName,replicationPartnerCount,replicationStatus," +
 		"replicationPartners[id,username,serviceResourceBackendIpAddress,serviceResource.datacenter.name,replicationSchedule.type.keyname]"
 
+	SNAPSHOT_LIST_MASK = "id,notes,snapshotCreationTimestamp"
+
+	SNAPSHOT_SCHEDULE_MASK = "id,active,type.keyname,retentionCount,minute,hour,dayOfWeek"
+
+	VOLUME_UPGRADE_MASK = "id,capacityGb,snapshotCapacityGb,provisionedIops,storageTierLevel,staasVersion,storageType.keyName," +
+		"serviceResource.datacenter.name,activeTransactionCount"
+
 	IMAGE_DEFAULT_MASK = "id, name, globalIdentifier, imageType, accountId"
 
 	IMAGE_DETAIL_MASK = "id,globalIdentifier,name,datacenter.name,status.name,transaction.transactionStatus.name,accountId,publicFlag,imageType,flexImageFlag,note,createDate,blockDevicesDiskSpaceTotal,children[blockDevicesDiskSpaceTotal,datacenter.name]"
 
+	ROOT_DISK_CATEGORY_CODE      = "guest_disk0"
 	EPHEMERAL_DISK_CATEGORY_CODE = "guest_disk1"
+	GPU_CATEGORY_CODE            = "guest_pcie_device0"
+
+	VIRTUAL_SERVER_PACKAGE_TYPE = "VIRTUAL_SERVER_INSTANCE"
+
+	VIRTUAL_SERVER_PACKAGE_DEFAULT_MASK = "id,name,description,isActive,type.keyName"
+
+	VIRTUAL_SERVER_PACKAGE_PRICES_MASK = "id, activePresets[id, keyName, isActive, locations[name], prices[categories[categoryCode], item[keyName, description, capacity]]], " +
+		"itemPrices[id, locationGroupId, categories[categoryCode], item[keyName, description, capacity]]"
 
 	UPGRADE_VIRTUAL_SERVER_ORDER_TYPE = "SoftLayer_Container_Product_Order_Virtual_Guest_Upgrade"
 
@@ -80,6 +104,7 @@ const (
 	SOFTLAYER_BLOCKINGOPERATIONINPROGRESS_EXCEPTION = "SoftLayer_Exception_Network_Storage_BlockingOperationInProgress"
 	SOFTLAYER_GROUP_ACCESSCONTROLERROR_EXCEPTION    = "SoftLayer_Exception_Network_Storage_Group_AccessControlError"
 	SOFTLAYER_NOTIMPLEMENTED_EXCEPTION              = "SoftLayer_Exception_NotImplemented"
+	SOFTLAYER_RATELIMITEXCEEDED_EXCEPTION           = "SoftLayer_Exception_WebService_RateLimitExceeded"
 )
 
 // go:generate counterfeiter -o fakes/fake_client_factory.go . ClientFactory
@@ -100,6 +125,13 @@ func (factory *clientFactory) CreateClient() Client {
 }
 
 func NewSoftLayerClientManager(session *session.Session, vps *vpsVm.Client, swfitClient *swift.Connection, logger logger.Logger) *ClientManager {
+	return newClientManager(session, vps, swfitClient, logger, metrics.NewCollector())
+}
+
+func newClientManager(session *session.Session, vps *vpsVm.Client, swfitClient *swift.Connection, logger logger.Logger, collector *metrics.Collector) *ClientManager {
+	unloggedSession := session
+	session = withCallLogging(session, logger, collector)
+
 	return &ClientManager{
 		services.GetVirtualGuestService(session),
 		services.GetAccountService(session),
@@ -110,21 +142,56 @@ func NewSoftLayerClientManager(session *session.Session, vps *vpsVm.Client, swfi
 		services.GetLocationDatacenterService(session),
 		services.GetNetworkVlanService(session),
 		services.GetNetworkSubnetService(session),
+		services.GetNetworkSubnetIpAddressGlobalService(session),
+		services.GetNetworkSubnetIpAddressService(session),
+		services.GetNetworkLBaaSLoadBalancerService(session),
+		services.GetNetworkLBaaSMemberService(session),
 		services.GetVirtualGuestBlockDeviceTemplateGroupService(session),
 		services.GetSecuritySshKeyService(session),
+		services.GetNetworkSecurityGroupService(session),
 		services.GetBillingOrderService(session),
 		services.GetTicketService(session),
 		services.GetTicketSubjectService(session),
 		vps,
 		swfitClient,
 		logger,
+		nil,
+		unloggedSession,
+		collector,
 	}
 }
 
+// EnableCache keeps product packages and datacenter ids for ttl, so a long running CPI
+// server does not look them up again for every request.
+func (c *ClientManager) EnableCache(ttl time.Duration) {
+	c.cache = newObjectCache(ttl)
+}
+
+// WithLogger returns a client manager that logs to logger, including its SoftLayer API calls,
+// and shares the session and the cache of c.
+func (c *ClientManager) WithLogger(logger logger.Logger) *ClientManager {
+	clientManager := newClientManager(c.session, c.vpsService, c.swfitClient, logger, c.metrics)
+	clientManager.cache = c.cache
+	return clientManager
+}
+
+// WithMetrics returns a client manager that records its SoftLayer API calls in collector,
+// and shares the session, the logger and the cache of c.
+func (c *ClientManager) WithMetrics(collector *metrics.Collector) *ClientManager {
+	clientManager := newClientManager(c.session, c.vpsService, c.swfitClient, c.logger, collector)
+	clientManager.cache = c.cache
+	return clientManager
+}
+
+// Metrics returns the collector of the SoftLayer API calls of c.
+func (c *ClientManager) Metrics() *metrics.Collector {
+	return c.metrics
+}
+
 // go:generate counterfeiter -o fakes/fake_client.go . Client
 type Client interface {
 	CancelInstance(id int) error
-	CreateInstance(template *datatypes.Virtual_Guest, userData *registry.SoftlayerUserData) (*datatypes.Virtual_Guest, error)
+	CreateInstance(template *datatypes.Virtual_Guest, options InstanceOrderOptions, userData *registry.SoftlayerUserData) (*datatypes.Virtual_Guest, error)
 	EditInstance(id int, template *datatypes.Virtual_Guest) (bool, error)
 	GetInstance(id int, mask string) (*datatypes.Virtual_Guest, bool, error)
 	GetInstanceByPrimaryBackendIpAddress(ip string) (*datatypes.Virtual_Guest, bool, error)
@@ -133,6 +200,7 @@ type Client interface {
 	ReloadInstance(id int, stemcellId int, sshKeyIds []int, hostname string, domain string, userData *registry.SoftlayerUserData) error
 	UpgradeInstanceConfig(id int, cpu int, memory int, network int, privateCPU bool, dedicatedHost bool) error
 	UpgradeInstance(id int, cpu int, memory int, network int, privateCPU bool, dedicatedHost bool, secondDiskSize int) (int, error)
+	GetVirtualServerPackage(mask string) (datatypes.Product_Package, error)
 	WaitInstanceUntilReady(id int, until time.Time) error
 	WaitInstanceUntilReadyWithTicket(id int, until time.Time) error
 	WaitInstanceHasActiveTransaction(id int, until time.Time) error
@@ -144,9 +212,17 @@ type Client interface {
 	GetInstanceAllowedHost(id int) (*datatypes.Network_Storage_Allowed_Host, bool, error)
 	AuthorizeHostToVolume(instance *datatypes.Virtual_Guest, volumeId int, until time.Time) (bool, error)
 	DeauthorizeHostToVolume(instance *datatypes.Virtual_Guest, volumeId int, until time.Time) (bool, error)
-	CreateVolume(location string, size int, iops int, snapshotSpace int) (*datatypes.Network_Storage, error)
+	CreateVolume(location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error)
+	DuplicateVolume(originVolumeId int, snapshotId int, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error)
+	OrderReplicantVolume(originVolumeId int, originScheduleId int, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error)
 	OrderBlockVolume(storageType string, location string, size int, iops int) (*datatypes.Container_Product_Order_Receipt, error)
-	OrderBlockVolume2(storageType string, location string, size int, iops int, snapshotSpace int) (*datatypes.Container_Product_Order_Receipt, error)
+	OrderBlockVolume2(storageType string, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Container_Product_Order_Receipt, error)
+	VerifyVolumeOrder(location string, size int, iops int) error
+	ModifyVolume(volumeId int, size int, iops int, tier float64) error
+	UpgradeBlockVolume(volumeId int, size int, iops int, tier float64) (*datatypes.Container_Product_Order_Receipt, error)
+	WaitVolumeModificationCompleted(volumeId int, size int, iops int, tier float64, until time.Time) error
+	OrderSnapshotSpace(volumeId int, location string, size int, iops int, tier float64, upgrade bool) (*datatypes.Container_Product_Order_Receipt, error)
+	WaitSnapshotSpaceCompleted(volumeId int, size int, until time.Time) error
 	CancelBlockVolume(volumeId int, reason string, immediate bool) (bool, error)
 	GetBlockVolumeDetails(volumeId int, mask string) (*datatypes.Network_Storage, bool, error)
 	GetBlockVolumeDetailsBySoftLayerAccount(volumeId int, mask string) (datatypes.Network_Storage, error)
@@ -155,6 +231,30 @@ type Client interface {
 	GetImage(imageId int, mask string) (*datatypes.Virtual_Guest_Block_Device_Template_Group, bool, error)
 	GetVlan(id int, mask string) (*datatypes.Network_Vlan, bool, error)
 	GetSubnet(id int, mask string) (*datatypes.Network_Subnet, bool, error)
+	GetSubnetByIpAddress(ip string, mask string) (*datatypes.Network_Subnet, bool, error)
+	GetGlobalIp(ip string) (*datatypes.Network_Subnet_IpAddress_Global, bool, error)
+	GetGlobalIpsByDestination(ip string) ([]datatypes.Network_Subnet_IpAddress_Global, error)
+	RouteGlobalIp(id int, destinationIp string) error
+	UnrouteGlobalIp(id int) error
+	SetIpAddressNote(id int, note string) error
+
+	GetLoadBalancer(uuid string) (*datatypes.Network_LBaaS_LoadBalancer, bool, error)
+	AddLoadBalancerMember(uuid string, ip string, weight int) error
+	UpdateLoadBalancerMemberWeight(uuid string, memberUuid string, weight int) error
+	DeleteLoadBalancerMember(uuid string, memberUuid string) error
+	WaitLoadBalancerActive(uuid string, until time.Time) error
+
+	GetSecurityGroups() ([]datatypes.Network_SecurityGroup, error)
+	GetDedicatedHosts() ([]DedicatedHost, error)
+
+	GetPlacementGroups() ([]PlacementGroup, error)
+	GetPlacementGroup(id int) (*PlacementGroup, bool, error)
+	CreatePlacementGroup(name string, backendRouterId int) (*PlacementGroup, error)
+	DeletePlacementGroup(id int) error
+	GetInstancePlacementGroupId(id int) (int, error)
+	IsInstanceReclaimed(id int) (bool, error)
+	AttachSecurityGroupComponents(id int, componentIds []int) error
+	DetachSecurityGroupComponents(id int, componentIds []int) error
 	GetAllowedHostCredential(id int) (*datatypes.Network_Storage_Allowed_Host, bool, error)
 	GetAllowedNetworkStorage(id int) ([]string, bool, error)
 	CreateSshKey(label *string, key *string, fingerPrint *string) (*datatypes.Security_Ssh_Key, error)
@@ -165,6 +265,10 @@ type Client interface {
 
 	CreateSnapshot(volumeId int, notes string) (datatypes.Network_Storage, error)
 	DeleteSnapshot(snapshotId int) error
+	GetSnapshots(volumeId int) ([]datatypes.Network_Storage, error)
+	GetSnapshotSchedules(volumeId int) ([]datatypes.Network_Storage_Schedule, error)
+	EnableSnapshot(volumeId int, scheduleType string, retentionCount int, minute int, hour int, dayOfWeek string) error
+	DisableSnapshots(volumeId int, scheduleType string) error
 
 	CreateTicket(ticketSubject *string, ticketTitle *string, contents *string, attachmentId *int, attachmentType *string) error
 
@@ -186,14 +290,22 @@ type ClientManager struct {
 	LocationService       services.Location_Datacenter
 	NetworkVlanService    services.Network_Vlan
 	NetworkSubnetService  services.Network_Subnet
+	GlobalIpService       services.Network_Subnet_IpAddress_Global
+	IpAddressService      services.Network_Subnet_IpAddress
+	LBaaSService          services.Network_LBaaS_LoadBalancer
+	LBaaSMemberService    services.Network_LBaaS_Member
 	ImageService          services.Virtual_Guest_Block_Device_Template_Group
 	SecuritySshKeyService services.Security_Ssh_Key
+	SecurityGroupService  services.Network_SecurityGroup
 	BillingOrderService   services.Billing_Order
 	TicketService         services.Ticket
 	TicketSubjectSerivce  services.Ticket_Subject
 	vpsService            *vpsVm.Client
 	swfitClient           *swift.Connection
 	logger                logger.Logger
+	cache                 *objectCache
+	session               *session.Session
+	metrics               *metrics.Collector
 }
 
 func (c *ClientManager) GetInstance(id int, mask string) (*datatypes.Virtual_Guest, bool, error) {
@@ -247,6 +359,183 @@ func (c *ClientManager) GetSubnet(id int, mask string) (*datatypes.Network_Subne
 	return &vlan, true, err
 }
 
+// GetSubnetByIpAddress returns the subnet of the account the ip belongs to.
+func (c *ClientManager) GetSubnetByIpAddress(ip string, mask string) (*datatypes.Network_Subnet, bool, error) {
+	if mask == "" {
+		mask = NETWORK_DEFAULT_SUBNET_MASK
+	}
+	subnet, err := c.NetworkSubnetService.Mask(mask).GetSubnetForIpAddress(sl.String(ip))
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.Exception == SOFTLAYER_OBJECTNOTFOUND_EXCEPTION {
+			return &datatypes.Network_Subnet{}, false, nil
+		}
+		return &datatypes.Network_Subnet{}, false, err
+	}
+	if subnet.Id == nil {
+		return &datatypes.Network_Subnet{}, false, nil
+	}
+
+	return &subnet, true, nil
+}
+
+// GetGlobalIp returns the global IP record of the account with address ip.
+func (c *ClientManager) GetGlobalIp(ip string) (*datatypes.Network_Subnet_IpAddress_Global, bool, error) {
+	filters := filter.New()
+	filters = append(filters, filter.Path("globalIpRecords.ipAddress.ipAddress").Eq(ip))
+	globalIps, err := c.AccountService.Mask(GLOBAL_IP_DEFAULT_MASK).Filter(filters.Build()).GetGlobalIpRecords()
+	if err != nil {
+		return &datatypes.Network_Subnet_IpAddress_Global{}, false, err
+	}
+
+	for _, globalIp := range globalIps {
+		if globalIp.IpAddress != nil && globalIp.IpAddress.IpAddress != nil && *globalIp.IpAddress.IpAddress == ip {
+			return &globalIp, true, nil
+		}
+	}
+
+	return &datatypes.Network_Subnet_IpAddress_Global{}, false, nil
+}
+
+// GetGlobalIpsByDestination returns the global IP records of the account routed to ip.
+func (c *ClientManager) GetGlobalIpsByDestination(ip string) ([]datatypes.Network_Subnet_IpAddress_Global, error) {
+	filters := filter.New()
+	filters = append(filters, filter.Path("globalIpRecords.destinationIpAddress.ipAddress").Eq(ip))
+	globalIps, err := c.AccountService.Mask(GLOBAL_IP_DEFAULT_MASK).Filter(filters.Build()).GetGlobalIpRecords()
+	if err != nil {
+		return []datatypes.Network_Subnet_IpAddress_Global{}, err
+	}
+
+	routed := []datatypes.Network_Subnet_IpAddress_Global{}
+	for _, globalIp := range globalIps {
+		if globalIp.DestinationIpAddress != nil && globalIp.DestinationIpAddress.IpAddress != nil && *globalIp.DestinationIpAddress.IpAddress == ip {
+			routed = append(routed, globalIp)
+		}
+	}
+
+	return routed, nil
+}
+
+// RouteGlobalIp routes the global IP with id to destinationIp, moving it from its current destination.
+func (c *ClientManager) RouteGlobalIp(id int, destinationIp string) error {
+	_, err := c.GlobalIpService.Id(id).Route(sl.String(destinationIp))
+	return err
+}
+
+// UnrouteGlobalIp removes the route of the global IP with id, the IP stays on the account.
+func (c *ClientManager) UnrouteGlobalIp(id int) error {
+	_, err := c.GlobalIpService.Id(id).Unroute()
+	if apiErr, ok := err.(sl.Error); ok && apiErr.Exception == SOFTLAYER_OBJECTNOTFOUND_EXCEPTION {
+		return nil
+	}
+	return err
+}
+
+// SetIpAddressNote sets the note of the IP address with id, an empty note removes it.
+func (c *ClientManager) SetIpAddressNote(id int, note string) error {
+	_, err := c.IpAddressService.Id(id).EditObject(&datatypes.Network_Subnet_IpAddress{Note: sl.String(note)})
+	return err
+}
+
+// GetLoadBalancer returns the local load balancer with uuid, including its listeners and members.
+func (c *ClientManager) GetLoadBalancer(uuid string) (*datatypes.Network_LBaaS_LoadBalancer, bool, error) {
+	loadBalancer, err := c.LBaaSService.Mask(LOAD_BALANCER_DEFAULT_MASK).GetLoadBalancer(sl.String(uuid))
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && strings.Contains(apiErr.Exception, "ObjectNotFound") {
+			return &datatypes.Network_LBaaS_LoadBalancer{}, false, nil
+		}
+		return &datatypes.Network_LBaaS_LoadBalancer{}, false, err
+	}
+
+	return &loadBalancer, true, nil
+}
+
+// AddLoadBalancerMember adds the private ip as member with weight to the load balancer with uuid.
+func (c *ClientManager) AddLoadBalancerMember(uuid string, ip string, weight int) error {
+	_, err := c.LBaaSMemberService.AddLoadBalancerMembers(sl.String(uuid), []datatypes.Network_LBaaS_LoadBalancerServerInstanceInfo{
+		{
+			PrivateIpAddress: sl.String(ip),
+			Weight:           sl.Int(weight),
+		},
+	})
+	return err
+}
+
+// UpdateLoadBalancerMemberWeight sets the weight of the member memberUuid of the load balancer with uuid.
+func (c *ClientManager) UpdateLoadBalancerMemberWeight(uuid string, memberUuid string, weight int) error {
+	_, err := c.LBaaSMemberService.UpdateLoadBalancerMembers(sl.String(uuid), []datatypes.Network_LBaaS_Member{
+		{
+			Uuid:   sl.String(memberUuid),
+			Weight: sl.Int(weight),
+		},
+	})
+	return err
+}
+
+// DeleteLoadBalancerMember removes the member memberUuid from the load balancer with uuid.
+func (c *ClientManager) DeleteLoadBalancerMember(uuid string, memberUuid string) error {
+	_, err := c.LBaaSMemberService.DeleteLoadBalancerMembers(sl.String(uuid), []string{memberUuid})
+	return err
+}
+
+// WaitLoadBalancerActive waits until the load balancer with uuid applied its pending updates, it
+// rejects further updates until then.
+func (c *ClientManager) WaitLoadBalancerActive(uuid string, until time.Time) error {
+	for {
+		loadBalancer, err := c.LBaaSService.Mask("id, uuid, provisioningStatus").GetLoadBalancer(sl.String(uuid))
+		if err != nil {
+			return err
+		}
+
+		status := sl.Get(loadBalancer.ProvisioningStatus, "").(string)
+		switch status {
+		case "ACTIVE":
+			return nil
+		case "ERROR":
+			return bosherr.Errorf("Load balancer '%s' is in provisioning status '%s'", uuid, status)
+		}
+
+		now := time.Now()
+		if now.After(until) {
+			return bosherr.Errorf("Waiting load balancer '%s' to be active time out, provisioning status '%s'", uuid, status)
+		}
+
+		min := math.Min(float64(5.0), float64(until.Sub(now)))
+		time.Sleep(time.Duration(min) * time.Second)
+	}
+}
+
+// GetSecurityGroups returns the security groups of the account.
+func (c *ClientManager) GetSecurityGroups() ([]datatypes.Network_SecurityGroup, error) {
+	return c.AccountService.Mask("id, name").GetSecurityGroups()
+}
+
+// DedicatedHost is a SoftLayer_Virtual_DedicatedHost with its tags, which the vendored softlayer-go lacks.
+type DedicatedHost struct {
+	datatypes.Virtual_DedicatedHost
+
+	TagReferences []datatypes.Tag_Reference `json:"tagReferences,omitempty"`
+}
+
+// GetDedicatedHosts returns the dedicated hosts of the account with their tags, guests and free capacity.
+func (c *ClientManager) GetDedicatedHosts() ([]DedicatedHost, error) {
+	var hosts []DedicatedHost
+	options := sl.Options{Mask: "mask[" + DEDICATED_HOST_DEFAULT_MASK + "]"}
+	err := c.AccountService.Session.DoRequest("SoftLayer_Account", "getDedicatedHosts", nil, &options, &hosts)
+	return hosts, err
+}
+
+// AttachSecurityGroupComponents binds the network components with componentIds to the security group with id.
+func (c *ClientManager) AttachSecurityGroupComponents(id int, componentIds []int) error {
+	_, err := c.SecurityGroupService.Id(id).AttachNetworkComponents(componentIds)
+	return err
+}
+
+// DetachSecurityGroupComponents unbinds the network components with componentIds from the security group with id.
+func (c *ClientManager) DetachSecurityGroupComponents(id int, componentIds []int) error {
+	_, err := c.SecurityGroupService.Id(id).DetachNetworkComponents(componentIds)
+	return err
+}
+
 func (c *ClientManager) GetInstanceByPrimaryBackendIpAddress(ip string) (*datatypes.Virtual_Guest, bool, error) {
 	filters := filter.New()
 	filters = append(filters, filter.Path("virtualGuests.primaryBackendIpAddress").Eq(ip))
@@ -329,10 +618,21 @@ func (c *ClientManager) GetImage(imageId int, mask string) (*datatypes.Virtual_G
 	return &image, true, err
 }
 
-// Check the virtual server instance is ready for use
+// Check the virtual server instance is ready for use, polling backs off while the API rate limit is exceeded
 func (c *ClientManager) WaitInstanceUntilReady(id int, until time.Time) error {
+	interval := instanceReadyPollInterval
 	for {
 		virtualGuest, found, err := c.GetInstance(id, "id, lastOperatingSystemReload[id,modifyDate], activeTransaction[id,transactionStatus.name], provisionDate, powerState.keyName")
+		if isRateLimitExceeded(err) {
+			c.logger.Debug(softlayerClientLogTag, "Rate limit exceeded polling instance '%d', retrying in %s", id, interval)
+			if time.Now().Add(interval).After(until) {
+				return bosherr.Errorf("Power on virtual guest with id %d Time Out!", id)
+			}
+			time.Sleep(interval)
+			interval = backOff(interval)
+			continue
+		}
+		interval = instanceReadyPollInterval
 		if err != nil {
 			return err
 		}
@@ -385,6 +685,9 @@ func (c *ClientManager) WaitInstanceUntilReadyWithTicket(id int, until time.Time
 	if err != nil {
 		if strings.Contains(err.Error(), fmt.Sprintf("Power on virtual guest with id %d Time Out!", id)) {
 			contents := fmt.Sprintf("The power state of virtual guest '%d' is not 'RUNNING' after OS reload. The ticket generated by Bosh Softlayer CPI.", id)
+			if requestID := c.logger.GetRequestID(); requestID != "" {
+				contents = fmt.Sprintf("%s BOSH director request ID: %s.", contents, requestID)
+			}
 
 			c.logger.Debug(softlayerClientLogTag, fmt.Sprintf("Creating ticket for intance '%d' timeout.", id))
 			err = c.CreateTicket(sl.String("OS Reload Question"), sl.String("OS reload hung."),
@@ -492,20 +795,41 @@ func (c *ClientManager) WaitInstanceHasNoneActiveTransaction(id int, until time.
 	}
 }
 
-func (c *ClientManager) CreateInstance(template *datatypes.Virtual_Guest, userData *registry.SoftlayerUserData) (*datatypes.Virtual_Guest, error) {
-	virtualguest, err := c.VirtualGuestService.CreateObject(template)
+func (c *ClientManager) CreateInstance(template *datatypes.Virtual_Guest, options InstanceOrderOptions, userData *registry.SoftlayerUserData) (*datatypes.Virtual_Guest, error) {
+	// The user data is ordered with the instance, so that the agent finds its settings from the first boot.
+	// Its server name is only known once the instance is ordered.
+	encodedUserData, err := encodeUserData(userData)
+	if err != nil {
+		return &datatypes.Virtual_Guest{}, err
+	}
+	template.UserData = []datatypes.Virtual_Guest_Attribute{{Value: encodedUserData}}
+
+	if err := c.verifyInstanceOrder(template, options); err != nil {
+		return &datatypes.Virtual_Guest{}, bosherr.WrapError(err, "Verifying instance order")
+	}
+
+	virtualguest, err := c.createInstanceObject(template, options)
 	if err != nil {
 		return &datatypes.Virtual_Guest{}, bosherr.WrapError(err, "Creating instance")
 	}
+	if options.Ordered != nil {
+		if err := options.Ordered(*virtualguest.Id); err != nil {
+			return &datatypes.Virtual_Guest{}, bosherr.WrapErrorf(err, "Recording ordered instance '%d'", *virtualguest.Id)
+		}
+	}
+	c.stampInstance(*virtualguest.Id)
+
+	// Wait for instance provisioning
+	until := time.Now().Add(time.Duration(4) * time.Hour)
+	if err := c.waitInstanceProvisioning(*virtualguest.Id, until); err != nil {
+		return &datatypes.Virtual_Guest{}, bosherr.WrapError(err, "Waiting until instance is provisioning")
+	}
 
-	// Wait for instance ready
-	time.Sleep(90 * time.Second)
 	err = c.SetUserDataWithID(*virtualguest.Id, userData)
 	if err != nil {
 		return &datatypes.Virtual_Guest{}, bosherr.WrapErrorf(err, "Updating user data contents with instance '%d'", *virtualguest.Id)
 	}
 
-	until := time.Now().Add(time.Duration(4) * time.Hour)
 	if err := c.WaitInstanceUntilReady(*virtualguest.Id, until); err != nil {
 		return &datatypes.Virtual_Guest{}, bosherr.WrapError(err, "Waiting until instance is ready")
 	}
@@ -532,7 +856,7 @@ func (c *ClientManager) CreateInstanceFromVPS(template *datatypes.Virtual_Guest,
 			return &datatypes.Virtual_Guest{}, bosherr.WrapError(err, "Ordering vm from pool")
 		} else {
 			// From createBySoftlayer implement run in cpi action
-			virtualGuest, err := c.CreateInstance(template, userData)
+			virtualGuest, err := c.CreateInstance(template, InstanceOrderOptions{}, userData)
 			if err != nil {
 				return &datatypes.Virtual_Guest{}, bosherr.WrapError(err, "Creating VirtualGuest from SoftLayer client")
 			}
@@ -769,18 +1093,11 @@ func (c *ClientManager) UpgradeInstance(id int, cpu int, memory int, network int
 		c.logger.Debug(softlayerClientLogTag, fmt.Sprintf("Upgrade item price for 'port_speed/%d'", network))
 	}
 
-	packageType := "VIRTUAL_SERVER_INSTANCE"
-	productPackages, err := c.PackageService.
-		Mask("id,name,description,isActive,type.keyName").
-		Filter(filter.New(filter.Path("type.keyName").Eq(packageType)).Build()).
-		GetAllObjects()
+	productPackage, err := c.GetVirtualServerPackage(VIRTUAL_SERVER_PACKAGE_DEFAULT_MASK)
 	if err != nil {
 		return 0, err
 	}
-	if len(productPackages) == 0 {
-		return 0, bosherr.Errorf("No package found for type: %s", packageType)
-	}
-	packageID := *productPackages[0].Id
+	packageID := *productPackage.Id
 
 	var prices = make([]datatypes.Product_Item_Price, 0)
 
@@ -834,6 +1151,7 @@ func (c *ClientManager) UpgradeInstance(id int, cpu int, memory int, network int
 	if presetId != 0 {
 		order.PresetId = sl.Int(presetId)
 	}
+	c.stampOrder(&order)
 	upgradeOrder := datatypes.Container_Product_Order_Virtual_Guest_Upgrade{
 		Container_Product_Order_Virtual_Guest: datatypes.Container_Product_Order_Virtual_Guest{
 			Container_Product_Order_Hardware_Server: datatypes.Container_Product_Order_Hardware_Server{
@@ -884,6 +1202,28 @@ func (c *ClientManager) UpgradeInstance(id int, cpu int, memory int, network int
 	return orderId, nil
 }
 
+func (c *ClientManager) GetVirtualServerPackage(mask string) (datatypes.Product_Package, error) {
+	value, err := c.cache.get("virtual_server_package:"+mask, func() (interface{}, error) {
+		productPackages, err := c.PackageService.
+			Mask(mask).
+			Filter(filter.New(filter.Path("type.keyName").Eq(VIRTUAL_SERVER_PACKAGE_TYPE)).Build()).
+			GetAllObjects()
+		if err != nil {
+			return nil, err
+		}
+		if len(productPackages) == 0 {
+			return nil, bosherr.Errorf("No package found for type: %s", VIRTUAL_SERVER_PACKAGE_TYPE)
+		}
+
+		return productPackages[0], nil
+	})
+	if err != nil {
+		return datatypes.Product_Package{}, err
+	}
+
+	return value.(datatypes.Product_Package), nil
+}
+
 func (c *ClientManager) SetTags(id int, tags string) (bool, error) {
 	_, err := c.VirtualGuestService.Id(id).SetTags(&tags)
 	if err != nil {
@@ -1044,104 +1384,56 @@ func (c *ClientManager) GetNetworkStorageTarget(volumeId int, mask string) (stri
 }
 
 func (c *ClientManager) OrderBlockVolume(storageType string, location string, size int, iops int) (*datatypes.Container_Product_Order_Receipt, error) {
-	locationId, err := c.GetLocationId(location)
-	if err != nil {
-		return &datatypes.Container_Product_Order_Receipt{}, bosherr.Error("Invalid datacenter name specified. Please provide the lower case short name (e.g.: dal09)")
+	if storageType != "performance_storage_iscsi" {
+		return &datatypes.Container_Product_Order_Receipt{}, bosherr.Error("Block volume storage_type must be either Performance or Endurance")
 	}
-	baseTypeName := "SoftLayer_Container_Product_Order_Network_"
-	var prices = make([]datatypes.Product_Item_Price, 0)
 
-	if storageType == "performance_storage_iscsi" {
-		productPacakge, err := c.GetPerformanceIscsiPackage()
-		if err != nil {
-			return &datatypes.Container_Product_Order_Receipt{}, err
-		}
-		complexType := baseTypeName + "PerformanceStorage_Iscsi"
-		storagePrice, err := FindPerformancePrice(productPacakge, "performance_storage_iscsi")
-		if err != nil {
-			return &datatypes.Container_Product_Order_Receipt{}, err
-		}
-		prices = append(prices, storagePrice)
-		spacePrice, err := FindPerformanceSpacePrice(productPacakge, size)
-		if err != nil {
-			return &datatypes.Container_Product_Order_Receipt{}, err
-		}
-		prices = append(prices, spacePrice)
+	order, err := c.performanceVolumeOrder(location, size, iops)
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, err
+	}
 
-		var iopsPrice datatypes.Product_Item_Price
-		if iops == 0 {
-			switch size {
-			case 250:
-				iopsPrice, err = c.selectMaximunIopsItemPriceIdOnSize(1000)
-			case 500:
-				iopsPrice, err = c.selectMaximunIopsItemPriceIdOnSize(1000)
-			default:
-				iopsPrice, err = c.selectMaximunIopsItemPriceIdOnSize(size)
-			}
-			if err != nil {
-				return &datatypes.Container_Product_Order_Receipt{}, err
-			}
-		} else {
-			iopsPrice, err = FindPerformanceIOPSPrice(productPacakge, size, iops)
-			if err != nil {
-				return &datatypes.Container_Product_Order_Receipt{}, err
-			}
-		}
-		prices = append(prices, iopsPrice)
+	orderReceipt, err := c.OrderService.PlaceOrder(order, sl.Bool(false))
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, err
+	}
 
-		order := datatypes.Container_Product_Order_Network_PerformanceStorage_Iscsi{
-			OsFormatType: &datatypes.Network_Storage_Iscsi_OS_Type{
-				KeyName: sl.String("LINUX"),
-				Id:      sl.Int(12),
-			},
-			Container_Product_Order_Network_PerformanceStorage: datatypes.Container_Product_Order_Network_PerformanceStorage{
-				Container_Product_Order: datatypes.Container_Product_Order{
-					ComplexType: sl.String(complexType),
-					PackageId:   productPacakge.Id,
-					Prices:      prices,
-					Quantity:    sl.Int(1),
-					Location:    sl.String(strconv.Itoa(locationId)),
-				},
-			},
-		}
-		orderReceipt, err := c.OrderService.PlaceOrder(&order, sl.Bool(false))
-		if err != nil {
-			return &datatypes.Container_Product_Order_Receipt{}, err
-		}
+	return &orderReceipt, nil
+}
 
-		return &orderReceipt, nil
-	} else {
-		return &datatypes.Container_Product_Order_Receipt{}, bosherr.Error("Block volume storage_type must be either Performance or Endurance")
+// VerifyVolumeOrder dry-runs the order of a performance volume of size GB with iops in location,
+// without ordering anything.
+func (c *ClientManager) VerifyVolumeOrder(location string, size int, iops int) error {
+	order, err := c.performanceVolumeOrder(location, size, iops)
+	if err != nil {
+		return err
 	}
+
+	_, err = c.OrderService.VerifyOrder(order)
+	return err
 }
 
-func (c *ClientManager) OrderBlockVolume2(storageType string, location string, size int, iops int, snapshotSpace int) (*datatypes.Container_Product_Order_Receipt, error) {
+func (c *ClientManager) performanceVolumeOrder(location string, size int, iops int) (*datatypes.Container_Product_Order_Network_PerformanceStorage_Iscsi, error) {
 	locationId, err := c.GetLocationId(location)
 	if err != nil {
-		return &datatypes.Container_Product_Order_Receipt{}, bosherr.Error("Invalid datacenter name specified. Please provide the lower case short name (e.g.: dal09)")
+		return nil, bosherr.Error("Invalid datacenter name specified. Please provide the lower case short name (e.g.: dal09)")
 	}
+	baseTypeName := "SoftLayer_Container_Product_Order_Network_"
 	var prices = make([]datatypes.Product_Item_Price, 0)
 
-	productPacakge, err := c.GetStorageAsServicePackage()
+	productPacakge, err := c.GetPerformanceIscsiPackage()
 	if err != nil {
-		return &datatypes.Container_Product_Order_Receipt{}, err
+		return nil, err
 	}
-
-	storagePrice, err := FindSaaSPriceByCategory(productPacakge, "storage_as_a_service")
+	complexType := baseTypeName + "PerformanceStorage_Iscsi"
+	storagePrice, err := FindPerformancePrice(productPacakge, "performance_storage_iscsi")
 	if err != nil {
-		return &datatypes.Container_Product_Order_Receipt{}, err
+		return nil, err
 	}
 	prices = append(prices, storagePrice)
-
-	blockPrice, err := FindSaaSPriceByCategory(productPacakge, "storage_block")
-	if err != nil {
-		return &datatypes.Container_Product_Order_Receipt{}, err
-	}
-	prices = append(prices, blockPrice)
-
-	spacePrice, err := FindSaaSPerformSpacePrice(productPacakge, size)
+	spacePrice, err := FindPerformanceSpacePrice(productPacakge, size)
 	if err != nil {
-		return &datatypes.Container_Product_Order_Receipt{}, err
+		return nil, err
 	}
 	prices = append(prices, spacePrice)
 
@@ -1156,41 +1448,89 @@ func (c *ClientManager) OrderBlockVolume2(storageType string, location string, s
 			iopsPrice, err = c.selectMaximunIopsItemPriceIdOnSize(size)
 		}
 		if err != nil {
-			return &datatypes.Container_Product_Order_Receipt{}, err
+			return nil, err
 		}
 	} else {
-		iopsPrice, err = FindSaaSPerformIopsPrice(productPacakge, size, iops)
+		iopsPrice, err = FindPerformanceIOPSPrice(productPacakge, size, iops)
 		if err != nil {
-			return &datatypes.Container_Product_Order_Receipt{}, err
+			return nil, err
 		}
 	}
 	prices = append(prices, iopsPrice)
 
-	if snapshotSpace > 0 {
-		snapshotSpacePrice, err := FindSaaSSnapshotSpacePrice(productPacakge, snapshotSpace, iops)
-		if err != nil {
-			return &datatypes.Container_Product_Order_Receipt{}, err
+	order := datatypes.Container_Product_Order_Network_PerformanceStorage_Iscsi{
+		OsFormatType: &datatypes.Network_Storage_Iscsi_OS_Type{
+			KeyName: sl.String("LINUX"),
+			Id:      sl.Int(12),
+		},
+		Container_Product_Order_Network_PerformanceStorage: datatypes.Container_Product_Order_Network_PerformanceStorage{
+			Container_Product_Order: datatypes.Container_Product_Order{
+				ComplexType: sl.String(complexType),
+				PackageId:   productPacakge.Id,
+				Prices:      prices,
+				Quantity:    sl.Int(1),
+				Location:    sl.String(strconv.Itoa(locationId)),
+			},
+		},
+	}
+	c.stampOrder(&order.Container_Product_Order)
+
+	return &order, nil
+}
+
+// Orders a performance or endurance volume of the storage as a service package, the volumes of which are encrypted at rest.
+// storageType: "performance_storage_iscsi" or "storage_service_enterprise" for endurance volumes
+// location: The datacenter name of the volume
+// size: The capacity of the volume in GB
+// iops: The IOPS of a performance volume, 0 for the maximum IOPS of the size
+// tier: The IOPS per GB of an endurance volume, 0 for performance volumes
+// snapshotSpace: The snapshot space of the volume in GB, 0 for none
+func (c *ClientManager) OrderBlockVolume2(storageType string, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Container_Product_Order_Receipt, error) {
+	switch storageType {
+	case "performance_storage_iscsi":
+		if tier != 0 {
+			return &datatypes.Container_Product_Order_Receipt{}, bosherr.Error("Performance block volumes have no endurance tier")
 		}
-		prices = append(prices, snapshotSpacePrice)
+	case "storage_service_enterprise":
+		if tier == 0 || iops != 0 {
+			return &datatypes.Container_Product_Order_Receipt{}, bosherr.Error("Endurance block volumes require a tier and no iops")
+		}
+	default:
+		return &datatypes.Container_Product_Order_Receipt{}, bosherr.Error("Block volume storage_type must be either Performance or Endurance")
+	}
+
+	locationId, err := c.GetLocationId(location)
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, bosherr.Error("Invalid datacenter name specified. Please provide the lower case short name (e.g.: dal09)")
+	}
+
+	productPacakge, err := c.GetStorageAsServicePackage()
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, err
+	}
+
+	prices, iops, err := c.saasVolumePrices(productPacakge, size, iops, tier, snapshotSpace)
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, err
 	}
 
-	// baseTypeName := "SoftLayer_Container_Product_Order_Network_"
-	// complexType := baseTypeName + "Storage_AsAService"
 	order := datatypes.Container_Product_Order_Network_Storage_AsAService{
 		OsFormatType: &datatypes.Network_Storage_Iscsi_OS_Type{
 			KeyName: sl.String("LINUX"),
 			Id:      sl.Int(12),
 		},
 		Container_Product_Order: datatypes.Container_Product_Order{
-			ComplexType: sl.String(""),
-			PackageId:   productPacakge.Id,
-			Prices:      prices,
-			Quantity:    sl.Int(1),
-			Location:    sl.String(strconv.Itoa(locationId)),
+			PackageId: productPacakge.Id,
+			Prices:    prices,
+			Quantity:  sl.Int(1),
+			Location:  sl.String(strconv.Itoa(locationId)),
 		},
-		Iops:       sl.Int(iops),
 		VolumeSize: sl.Int(size),
 	}
+	if tier == 0 {
+		order.Iops = sl.Int(iops)
+	}
+	c.stampOrder(&order.Container_Product_Order)
 	orderReceipt, err := c.OrderService.PlaceOrder(&order, sl.Bool(false))
 	if err != nil {
 		return &datatypes.Container_Product_Order_Receipt{}, err
@@ -1199,49 +1539,438 @@ func (c *ClientManager) OrderBlockVolume2(storageType string, location string, s
 	return &orderReceipt, nil
 }
 
-func (c *ClientManager) CreateVolume(location string, size int, iops int, snapshotSpace int) (*datatypes.Network_Storage, error) {
-	var receipt *datatypes.Container_Product_Order_Receipt
-	var err error
-
-	if snapshotSpace == 0 {
-		receipt, err = c.OrderBlockVolume("performance_storage_iscsi", location, size, iops)
-	} else {
-		receipt, err = c.OrderBlockVolume2("performance_storage_iscsi", location, size, iops, snapshotSpace)
+// Orders a performance or endurance block volume of the storage as a service package, which can be modified in place,
+// and waits until it is provisioned.
+func (c *ClientManager) CreateVolume(location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error) {
+	storageType := "performance_storage_iscsi"
+	if tier > 0 {
+		storageType = "storage_service_enterprise"
 	}
 
+	receipt, err := c.OrderBlockVolume2(storageType, location, size, iops, tier, snapshotSpace)
 	if err != nil {
 		return &datatypes.Network_Storage{}, err
 	}
 
 	if receipt.OrderId == nil {
-		return &datatypes.Network_Storage{}, bosherr.Errorf("No order id returned after placing order with size of '%d', iops of '%d', location of `%s`", size, iops, location)
+		return &datatypes.Network_Storage{}, bosherr.Errorf("No order id returned after placing order with size of '%d', iops of '%d', tier of '%v', location of `%s`", size, iops, tier, location)
 	}
 
 	until := time.Now().Add(time.Duration(1) * time.Hour)
 	return c.WaitVolumeProvisioningWithOrderId(*receipt.OrderId, until)
 }
 
-// Creates a snapshot on the given block volume.
-// volumeId: The id of the volume
-// notes: The notes or "name" to assign the snapshot
-func (c *ClientManager) CreateSnapshot(volumeId int, notes string) (datatypes.Network_Storage, error) {
-	for {
-		networkStorage, err := c.StorageService.Id(volumeId).CreateSnapshot(sl.String((notes)))
-		if err != nil {
-			apiErr := err.(sl.Error)
-			if apiErr.Exception == SOFTLAYER_PUBLIC_EXCEPTION &&
-				strings.Contains(apiErr.Message, "please try again after Volume Provisioning is complete") {
-				continue
-			}
+// Orders a duplicate of the given block volume from one of its snapshots and waits until the duplicate is provisioned.
+// originVolumeId: The id of the volume to duplicate
+// snapshotId: The id of the snapshot of the origin volume the duplicate is created from
+// location: The datacenter name of the origin volume
+// size: The capacity of the duplicate in GB, at least the capacity of the origin volume
+// iops: The IOPS of a performance duplicate
+// tier: The IOPS per GB of an endurance duplicate, 0 for performance duplicates
+// snapshotSpace: The snapshot space of the duplicate in GB, 0 for none
+func (c *ClientManager) DuplicateVolume(originVolumeId int, snapshotId int, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error) {
+	locationId, err := c.GetLocationId(location)
+	if err != nil {
+		return &datatypes.Network_Storage{}, bosherr.Error("Invalid datacenter name specified. Please provide the lower case short name (e.g.: dal09)")
+	}
 
-			return datatypes.Network_Storage{}, err
-		}
+	productPacakge, err := c.GetStorageAsServicePackage()
+	if err != nil {
+		return &datatypes.Network_Storage{}, err
+	}
 
-		return networkStorage, nil
+	prices, iops, err := c.saasVolumePrices(productPacakge, size, iops, tier, snapshotSpace)
+	if err != nil {
+		return &datatypes.Network_Storage{}, err
 	}
-}
 
-// Deletes the specified snapshot object.
+	order := datatypes.Container_Product_Order_Network_Storage_AsAService{
+		OsFormatType: &datatypes.Network_Storage_Iscsi_OS_Type{
+			KeyName: sl.String("LINUX"),
+			Id:      sl.Int(12),
+		},
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: productPacakge.Id,
+			Prices:    prices,
+			Quantity:  sl.Int(1),
+			Location:  sl.String(strconv.Itoa(locationId)),
+		},
+		DuplicateOriginVolumeId:   sl.Int(originVolumeId),
+		DuplicateOriginSnapshotId: sl.Int(snapshotId),
+		VolumeSize:                sl.Int(size),
+	}
+	if tier == 0 {
+		order.Iops = sl.Int(iops)
+	}
+
+	c.stampOrder(&order.Container_Product_Order)
+
+	c.logger.Debug(softlayerClientLogTag, fmt.Sprintf("Place duplicate order for volume '%d' from snapshot '%d'", originVolumeId, snapshotId))
+	receipt, err := c.OrderService.PlaceOrder(&order, sl.Bool(false))
+	if err != nil {
+		return &datatypes.Network_Storage{}, err
+	}
+
+	if receipt.OrderId == nil {
+		return &datatypes.Network_Storage{}, bosherr.Errorf("No order id returned after placing duplicate order of volume '%d' from snapshot '%d'", originVolumeId, snapshotId)
+	}
+
+	until := time.Now().Add(time.Duration(1) * time.Hour)
+	return c.WaitVolumeProvisioningWithOrderId(*receipt.OrderId, until)
+}
+
+// Returns the prices of a performance or endurance volume of the storage as a service package, and the IOPS of a
+// performance volume, the maximum IOPS for the size when iops is 0.
+func (c *ClientManager) saasVolumePrices(productPackage datatypes.Product_Package, size int, iops int, tier float64, snapshotSpace int) ([]datatypes.Product_Item_Price, int, error) {
+	var prices = make([]datatypes.Product_Item_Price, 0)
+	for _, category := range []string{"storage_as_a_service", "storage_block"} {
+		price, err := FindSaaSPriceByCategory(productPackage, category)
+		if err != nil {
+			return nil, 0, err
+		}
+		prices = append(prices, price)
+	}
+
+	if tier > 0 {
+		tierPrice, err := FindSaaSEnduranceTierPrice(productPackage, tier)
+		if err != nil {
+			return nil, 0, err
+		}
+		prices = append(prices, tierPrice)
+
+		spacePrice, err := FindSaaSEnduranceSpacePrice(productPackage, size, tier)
+		if err != nil {
+			return nil, 0, err
+		}
+		prices = append(prices, spacePrice)
+	} else {
+		spacePrice, err := FindSaaSPerformSpacePrice(productPackage, size)
+		if err != nil {
+			return nil, 0, err
+		}
+		prices = append(prices, spacePrice)
+
+		var iopsPrice datatypes.Product_Item_Price
+		iopsPrice, iops, err = selectSaaSIopsPrice(productPackage, size, iops)
+		if err != nil {
+			return nil, 0, err
+		}
+		prices = append(prices, iopsPrice)
+	}
+
+	if snapshotSpace > 0 {
+		var snapshotSpacePrice datatypes.Product_Item_Price
+		var err error
+		if tier > 0 {
+			snapshotSpacePrice, err = FindSaaSEnduranceSnapshotSpacePrice(productPackage, snapshotSpace, tier)
+		} else {
+			snapshotSpacePrice, err = FindSaaSSnapshotSpacePrice(productPackage, snapshotSpace, iops)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		prices = append(prices, snapshotSpacePrice)
+	}
+
+	return prices, iops, nil
+}
+
+// Orders a replicant of the given block volume in another datacenter and waits until the replicant is provisioned.
+// originVolumeId: The id of the volume to replicate
+// originScheduleId: The id of the snapshot schedule of the origin volume the replicant is synchronized at
+// location: The datacenter name of the replicant
+// size: The capacity of the origin volume in GB
+// iops: The IOPS of a performance origin volume
+// tier: The IOPS per GB of an endurance origin volume, 0 for performance volumes
+// snapshotSpace: The snapshot space of the origin volume in GB
+func (c *ClientManager) OrderReplicantVolume(originVolumeId int, originScheduleId int, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error) {
+	locationId, err := c.GetLocationId(location)
+	if err != nil {
+		return &datatypes.Network_Storage{}, bosherr.Error("Invalid datacenter name specified. Please provide the lower case short name (e.g.: dal09)")
+	}
+
+	productPacakge, err := c.GetStorageAsServicePackage()
+	if err != nil {
+		return &datatypes.Network_Storage{}, err
+	}
+
+	prices, iops, err := c.saasVolumePrices(productPacakge, size, iops, tier, snapshotSpace)
+	if err != nil {
+		return &datatypes.Network_Storage{}, err
+	}
+
+	replicationPrice, err := FindSaaSReplicationPrice(productPacakge, iops, tier)
+	if err != nil {
+		return &datatypes.Network_Storage{}, err
+	}
+	prices = append(prices, replicationPrice)
+
+	order := datatypes.Container_Product_Order_Network_Storage_AsAService{
+		OsFormatType: &datatypes.Network_Storage_Iscsi_OS_Type{
+			KeyName: sl.String("LINUX"),
+			Id:      sl.Int(12),
+		},
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: productPacakge.Id,
+			Prices:    prices,
+			Quantity:  sl.Int(1),
+			Location:  sl.String(strconv.Itoa(locationId)),
+		},
+		OriginVolumeId:         sl.Int(originVolumeId),
+		OriginVolumeScheduleId: sl.Int(originScheduleId),
+		VolumeSize:             sl.Int(size),
+	}
+	if tier == 0 {
+		order.Iops = sl.Int(iops)
+	}
+
+	c.stampOrder(&order.Container_Product_Order)
+
+	c.logger.Debug(softlayerClientLogTag, fmt.Sprintf("Place replicant order for volume '%d' in datacenter '%s'", originVolumeId, location))
+	receipt, err := c.OrderService.PlaceOrder(&order, sl.Bool(false))
+	if err != nil {
+		return &datatypes.Network_Storage{}, err
+	}
+
+	if receipt.OrderId == nil {
+		return &datatypes.Network_Storage{}, bosherr.Errorf("No order id returned after placing replicant order of volume '%d' in datacenter '%s'", originVolumeId, location)
+	}
+
+	until := time.Now().Add(time.Duration(1) * time.Hour)
+	return c.WaitVolumeProvisioningWithOrderId(*receipt.OrderId, until)
+}
+
+// Modifies the size, IOPS or endurance tier of the given block volume in place and waits until the modification completed.
+// volumeId: The id of the volume
+// size: The capacity of the volume in GB after modification
+// iops: The IOPS of a performance volume after modification
+// tier: The IOPS per GB of an endurance volume after modification, 0 for performance volumes
+func (c *ClientManager) ModifyVolume(volumeId int, size int, iops int, tier float64) error {
+	receipt, err := c.UpgradeBlockVolume(volumeId, size, iops, tier)
+	if err != nil {
+		return err
+	}
+
+	if receipt.OrderId == nil {
+		return bosherr.Errorf("No order id returned after placing upgrade order with size of '%d', iops of '%d', tier of '%v' for volume '%d'", size, iops, tier, volumeId)
+	}
+
+	until := time.Now().Add(time.Duration(1) * time.Hour)
+	return c.WaitVolumeModificationCompleted(volumeId, size, iops, tier, until)
+}
+
+func (c *ClientManager) UpgradeBlockVolume(volumeId int, size int, iops int, tier float64) (*datatypes.Container_Product_Order_Receipt, error) {
+	productPacakge, err := c.GetStorageAsServicePackage()
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, err
+	}
+
+	var prices = make([]datatypes.Product_Item_Price, 0)
+
+	storagePrice, err := FindSaaSPriceByCategory(productPacakge, "storage_as_a_service")
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, err
+	}
+	prices = append(prices, storagePrice)
+
+	if tier > 0 {
+		tierPrice, err := FindSaaSEnduranceTierPrice(productPacakge, tier)
+		if err != nil {
+			return &datatypes.Container_Product_Order_Receipt{}, err
+		}
+		prices = append(prices, tierPrice)
+
+		spacePrice, err := FindSaaSEnduranceSpacePrice(productPacakge, size, tier)
+		if err != nil {
+			return &datatypes.Container_Product_Order_Receipt{}, err
+		}
+		prices = append(prices, spacePrice)
+	} else {
+		spacePrice, err := FindSaaSPerformSpacePrice(productPacakge, size)
+		if err != nil {
+			return &datatypes.Container_Product_Order_Receipt{}, err
+		}
+		prices = append(prices, spacePrice)
+
+		var iopsPrice datatypes.Product_Item_Price
+		iopsPrice, iops, err = selectSaaSIopsPrice(productPacakge, size, iops)
+		if err != nil {
+			return &datatypes.Container_Product_Order_Receipt{}, err
+		}
+		prices = append(prices, iopsPrice)
+	}
+
+	order := Container_Product_Order_Network_Storage_AsAService_Upgrade{
+		Container_Product_Order_Network_Storage_AsAService: datatypes.Container_Product_Order_Network_Storage_AsAService{
+			Container_Product_Order: datatypes.Container_Product_Order{
+				PackageId: productPacakge.Id,
+				Prices:    prices,
+				Quantity:  sl.Int(1),
+			},
+			VolumeSize: sl.Int(size),
+		},
+		Volume: &datatypes.Network_Storage{
+			Id: sl.Int(volumeId),
+		},
+	}
+	if tier == 0 {
+		order.Iops = sl.Int(iops)
+	}
+
+	c.stampOrder(&order.Container_Product_Order)
+
+	c.logger.Debug(softlayerClientLogTag, fmt.Sprintf("Place upgrade order for volume '%d'", volumeId))
+	orderReceipt, err := c.OrderService.PlaceOrder(&order, sl.Bool(false))
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, err
+	}
+
+	return &orderReceipt, nil
+}
+
+// Waits until the given block volume has at least the given size and the given IOPS or, for endurance volumes, the given tier.
+// The IOPS SoftLayer provisions for an endurance volume are rounded, so these volumes are compared by their storage tier level.
+func (c *ClientManager) WaitVolumeModificationCompleted(volumeId int, size int, iops int, tier float64, until time.Time) error {
+	for {
+		volume, found, err := c.GetBlockVolumeDetails(volumeId, VOLUME_UPGRADE_MASK)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Getting volume with id of '%d'", volumeId)
+		}
+		if !found {
+			return bosherr.Errorf("Volume with id of '%d' not found", volumeId)
+		}
+
+		performanceModified := iops == 0 || sl.Get(volume.ProvisionedIops, "").(string) == strconv.Itoa(iops)
+		if tier > 0 {
+			performanceModified = EnduranceTiersByStorageTierLevel[sl.Get(volume.StorageTierLevel, "").(string)] == tier
+		}
+
+		if sl.Get(volume.CapacityGb, 0).(int) >= size && performanceModified &&
+			sl.Get(volume.ActiveTransactionCount, uint(0)).(uint) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		if now.After(until) {
+			return bosherr.Errorf("Waiting volume modification with id of '%d' has time out", volumeId)
+		}
+
+		min := math.Min(float64(5.0), float64(until.Sub(now)))
+		time.Sleep(time.Duration(min) * time.Second)
+	}
+}
+
+// Orders snapshot space for the given block volume, as an upgrade if the volume already has snapshot space.
+// volumeId: The id of the volume
+// location: The datacenter name of the volume
+// size: The snapshot space in GB
+// iops: The IOPS of a performance volume
+// tier: The IOPS per GB of an endurance volume, 0 for performance volumes
+// upgrade: If the volume already has snapshot space
+func (c *ClientManager) OrderSnapshotSpace(volumeId int, location string, size int, iops int, tier float64, upgrade bool) (*datatypes.Container_Product_Order_Receipt, error) {
+	locationId, err := c.GetLocationId(location)
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, bosherr.Error("Invalid datacenter name specified. Please provide the lower case short name (e.g.: dal09)")
+	}
+
+	productPacakge, err := c.GetStorageAsServicePackage()
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, err
+	}
+
+	var snapshotSpacePrice datatypes.Product_Item_Price
+	if tier > 0 {
+		snapshotSpacePrice, err = FindSaaSEnduranceSnapshotSpacePrice(productPacakge, size, tier)
+	} else {
+		snapshotSpacePrice, err = FindSaaSSnapshotSpacePrice(productPacakge, size, iops)
+	}
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, err
+	}
+
+	order := datatypes.Container_Product_Order_Network_Storage_Enterprise_SnapshotSpace{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: productPacakge.Id,
+			Prices:    []datatypes.Product_Item_Price{snapshotSpacePrice},
+			Quantity:  sl.Int(1),
+			Location:  sl.String(strconv.Itoa(locationId)),
+		},
+		VolumeId: sl.Int(volumeId),
+	}
+	c.stampOrder(&order.Container_Product_Order)
+
+	c.logger.Debug(softlayerClientLogTag, fmt.Sprintf("Place snapshot space order for volume '%d'", volumeId))
+	var orderReceipt datatypes.Container_Product_Order_Receipt
+	if upgrade {
+		orderReceipt, err = c.OrderService.PlaceOrder(&datatypes.Container_Product_Order_Network_Storage_Enterprise_SnapshotSpace_Upgrade{
+			Container_Product_Order_Network_Storage_Enterprise_SnapshotSpace: order,
+		}, sl.Bool(false))
+	} else {
+		orderReceipt, err = c.OrderService.PlaceOrder(&order, sl.Bool(false))
+	}
+	if err != nil {
+		return &datatypes.Container_Product_Order_Receipt{}, err
+	}
+
+	return &orderReceipt, nil
+}
+
+// Waits until the given block volume has at least the given snapshot space in GB.
+func (c *ClientManager) WaitSnapshotSpaceCompleted(volumeId int, size int, until time.Time) error {
+	for {
+		volume, found, err := c.GetBlockVolumeDetails(volumeId, VOLUME_UPGRADE_MASK)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Getting volume with id of '%d'", volumeId)
+		}
+		if !found {
+			return bosherr.Errorf("Volume with id of '%d' not found", volumeId)
+		}
+
+		snapshotSpace, _ := strconv.Atoi(sl.Get(volume.SnapshotCapacityGb, "").(string))
+		if snapshotSpace >= size && sl.Get(volume.ActiveTransactionCount, uint(0)).(uint) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		if now.After(until) {
+			return bosherr.Errorf("Waiting snapshot space of volume with id of '%d' has time out", volumeId)
+		}
+
+		min := math.Min(float64(5.0), float64(until.Sub(now)))
+		time.Sleep(time.Duration(min) * time.Second)
+	}
+}
+
+// Container_Product_Order_Network_Storage_AsAService_Upgrade is missing from the vendored datatypes.
+// The type name matters: PlaceOrder derives the complexType of the order from it.
+type Container_Product_Order_Network_Storage_AsAService_Upgrade struct {
+	datatypes.Container_Product_Order_Network_Storage_AsAService
+
+	// The volume to be upgraded
+	Volume *datatypes.Network_Storage `json:"volume,omitempty"`
+}
+
+// Creates a snapshot on the given block volume.
+// volumeId: The id of the volume
+// notes: The notes or "name" to assign the snapshot
+func (c *ClientManager) CreateSnapshot(volumeId int, notes string) (datatypes.Network_Storage, error) {
+	for {
+		networkStorage, err := c.StorageService.Id(volumeId).CreateSnapshot(sl.String((notes)))
+		if err != nil {
+			apiErr := err.(sl.Error)
+			if apiErr.Exception == SOFTLAYER_PUBLIC_EXCEPTION &&
+				strings.Contains(apiErr.Message, "please try again after Volume Provisioning is complete") {
+				continue
+			}
+
+			return datatypes.Network_Storage{}, err
+		}
+
+		return networkStorage, nil
+	}
+}
+
+// Deletes the specified snapshot object.
 // snapshotId: The ID of the snapshot object to delete.
 func (c *ClientManager) DeleteSnapshot(snapshotId int) error {
 	_, err := c.StorageService.Id(snapshotId).DeleteObject()
@@ -1268,6 +1997,18 @@ func (c *ClientManager) DisableSnapshots(volumeId int, scheduleType string) erro
 	return err
 }
 
+// Returns the snapshots of a specific block volume.
+// volumeId: The id of the volume
+func (c *ClientManager) GetSnapshots(volumeId int) ([]datatypes.Network_Storage, error) {
+	return c.StorageService.Id(volumeId).Mask(SNAPSHOT_LIST_MASK).GetSnapshots()
+}
+
+// Returns the snapshot schedules of a specific block volume.
+// volumeId: The id of the volume
+func (c *ClientManager) GetSnapshotSchedules(volumeId int) ([]datatypes.Network_Storage_Schedule, error) {
+	return c.StorageService.Id(volumeId).Mask(SNAPSHOT_SCHEDULE_MASK).GetSchedules()
+}
+
 // Restores a specific volume from a snapshot.
 // volumeId: The id of the volume
 // snapshotId: The id of the restore point
@@ -1308,41 +2049,63 @@ func (c *ClientManager) getIscsiNetworkStorageWithOrderId(orderId int) ([]dataty
 }
 
 func (c *ClientManager) GetPackage(categoryCode string) (datatypes.Product_Package, error) {
-	filters := filter.New()
-	filters = append(filters, filter.Path("categories.categoryCode").Eq(categoryCode))
-	packages, err := c.PackageService.Mask("id,name,items[prices[categories],attributes]").Filter(filters.Build()).GetAllObjects()
+	value, err := c.cache.get("package:"+categoryCode, func() (interface{}, error) {
+		filters := filter.New()
+		filters = append(filters, filter.Path("categories.categoryCode").Eq(categoryCode))
+		packages, err := c.PackageService.Mask("id,name,items[prices[categories],attributes]").Filter(filters.Build()).GetAllObjects()
+		if err != nil {
+			return nil, err
+		}
+		if len(packages) == 0 {
+			return nil, bosherr.Errorf("No packages were found for %s ", categoryCode)
+		}
+		if len(packages) > 1 {
+			return nil, bosherr.Errorf("More than one packages were found for %s", categoryCode)
+		}
+		return packages[0], nil
+	})
 	if err != nil {
 		return datatypes.Product_Package{}, err
 	}
-	if len(packages) == 0 {
-		return datatypes.Product_Package{}, bosherr.Errorf("No packages were found for %s ", categoryCode)
-	}
-	if len(packages) > 1 {
-		return datatypes.Product_Package{}, bosherr.Errorf("More than one packages were found for %s", categoryCode)
-	}
-	return packages[0], nil
+	return value.(datatypes.Product_Package), nil
 }
 
 func (c *ClientManager) GetPerformanceIscsiPackage() (datatypes.Product_Package, error) {
-	return c.PackageService.Id(NETWORK_PERFORMANCE_STORAGE_PACKAGE_ID).Mask("id,name,items[prices[categories],attributes]").GetObject()
+	return c.getPackageById(NETWORK_PERFORMANCE_STORAGE_PACKAGE_ID)
 }
 
 func (c *ClientManager) GetStorageAsServicePackage() (datatypes.Product_Package, error) {
-	return c.PackageService.Id(NETWORK_STORAGE_AS_SERVICE_PACKAGE_ID).Mask("id,name,items[prices[categories],attributes]").GetObject()
+	return c.getPackageById(NETWORK_STORAGE_AS_SERVICE_PACKAGE_ID)
 }
 
-func (c *ClientManager) GetLocationId(location string) (int, error) {
-	reqFilter := filter.New(filter.Path("name").Eq(location))
-	datacenters, err := c.LocationService.Mask("longName,id,name").Filter(reqFilter.Build()).GetDatacenters()
+func (c *ClientManager) getPackageById(packageId int) (datatypes.Product_Package, error) {
+	value, err := c.cache.get(fmt.Sprintf("package:%d", packageId), func() (interface{}, error) {
+		return c.PackageService.Id(packageId).Mask("id,name,items[prices[categories],attributes]").GetObject()
+	})
 	if err != nil {
-		return 0, err
+		return datatypes.Product_Package{}, err
 	}
-	for _, datacenter := range datacenters {
-		if *datacenter.Name == location {
-			return *datacenter.Id, nil
+	return value.(datatypes.Product_Package), nil
+}
+
+func (c *ClientManager) GetLocationId(location string) (int, error) {
+	value, err := c.cache.get("location:"+location, func() (interface{}, error) {
+		reqFilter := filter.New(filter.Path("name").Eq(location))
+		datacenters, err := c.LocationService.Mask("longName,id,name").Filter(reqFilter.Build()).GetDatacenters()
+		if err != nil {
+			return nil, err
+		}
+		for _, datacenter := range datacenters {
+			if *datacenter.Name == location {
+				return *datacenter.Id, nil
+			}
 		}
+		return nil, bosherr.Error("Invalid datacenter name specified")
+	})
+	if err != nil {
+		return 0, err
 	}
-	return 0, bosherr.Error("Invalid datacenter name specified")
+	return value.(int), nil
 }
 
 func hasCategory(categories []datatypes.Product_Item_Category, categoryCode string) bool {
@@ -1460,6 +2223,55 @@ func FindSaaSPerformIopsPrice(productPackage datatypes.Product_Package, size int
 	return datatypes.Product_Item_Price{}, bosherr.Errorf("Unable to find price for storage space size: %d, iops: %d", size, iops)
 }
 
+// FindSaaSMaximumPerformIops returns the maximum IOPS of a performance volume of the given size in the storage as a service package.
+func FindSaaSMaximumPerformIops(productPackage datatypes.Product_Package, size int) (int, error) {
+	maximum := 0
+	for _, item := range productPackage.Items {
+		if item.ItemCategory == nil || item.ItemCategory.CategoryCode == nil || *item.ItemCategory.CategoryCode != "performance_storage_iops" {
+			continue
+		}
+		if item.CapacityMaximum == nil {
+			continue
+		}
+
+		capacityMax, err := strconv.Atoi(*item.CapacityMaximum)
+		if err != nil {
+			return 0, bosherr.WrapError(err, "Convert price capacity maximum")
+		}
+		if capacityMax <= maximum {
+			continue
+		}
+
+		for _, price := range item.Prices {
+			if price.LocationGroupId != nil || !hasCategory(price.Categories, "performance_storage_iops") {
+				continue
+			}
+			if price.CapacityRestrictionType == nil || *price.CapacityRestrictionType != "STORAGE_SPACE" ||
+				price.CapacityRestrictionMinimum == nil || price.CapacityRestrictionMaximum == nil {
+				continue
+			}
+
+			sizeMin, err := strconv.Atoi(*price.CapacityRestrictionMinimum)
+			if err != nil {
+				return 0, bosherr.WrapError(err, "Convert price capacity restriction minimum")
+			}
+			sizeMax, err := strconv.Atoi(*price.CapacityRestrictionMaximum)
+			if err != nil {
+				return 0, bosherr.WrapError(err, "Convert price capacity restriction maximum")
+			}
+			if size >= sizeMin && size <= sizeMax {
+				maximum = capacityMax
+				break
+			}
+		}
+	}
+
+	if maximum == 0 {
+		return 0, bosherr.Errorf("Unable to find iops for storage space size: %d", size)
+	}
+	return maximum, nil
+}
+
 func FindSaaSSnapshotSpacePrice(productPackage datatypes.Product_Package, size int, iops int) (datatypes.Product_Item_Price, error) {
 	for _, item := range productPackage.Items {
 		if float64(*item.Capacity) != float64(size) {
@@ -1494,6 +2306,181 @@ func FindSaaSSnapshotSpacePrice(productPackage datatypes.Product_Package, size i
 	return datatypes.Product_Item_Price{}, bosherr.Errorf("Unable to find price snapshot space size: %d, iops: %d", size, iops)
 }
 
+// Endurance tiers in IOPS per GB and the capacity of their storage_tier_level items
+var enduranceTierLevels = map[float64]int{
+	0.25: 100,
+	2:    200,
+	4:    300,
+	10:   1000,
+}
+
+// EnduranceTiersByStorageTierLevel are the endurance tiers in IOPS per GB by the storage tier level of a volume
+var EnduranceTiersByStorageTierLevel = map[string]float64{
+	"LOW_INTENSITY_TIER": 0.25,
+	"READHEAVY_TIER":     2,
+	"WRITEHEAVY_TIER":    4,
+	"10_IOPS_PER_GB":     10,
+}
+
+func EnduranceTierLevel(tier float64) (int, error) {
+	tierLevel, ok := enduranceTierLevels[tier]
+	if !ok {
+		return 0, bosherr.Errorf("Invalid endurance tier '%v', it must be one of 0.25, 2, 4 or 10", tier)
+	}
+	return tierLevel, nil
+}
+
+func FindSaaSEnduranceTierPrice(productPackage datatypes.Product_Package, tier float64) (datatypes.Product_Item_Price, error) {
+	tierLevel, err := EnduranceTierLevel(tier)
+	if err != nil {
+		return datatypes.Product_Item_Price{}, err
+	}
+
+	for _, item := range productPackage.Items {
+		if item.Capacity == nil || int(*item.Capacity) != tierLevel {
+			continue
+		}
+
+		for _, price := range item.Prices {
+			// Only collect prices from valid location groups.
+			if price.LocationGroupId != nil {
+				continue
+			}
+			if !hasCategory(price.Categories, "storage_tier_level") {
+				continue
+			}
+			return price, nil
+		}
+	}
+	return datatypes.Product_Item_Price{}, bosherr.Errorf("Unable to find price for endurance tier %v", tier)
+}
+
+func FindSaaSEnduranceSpacePrice(productPackage datatypes.Product_Package, size int, tier float64) (datatypes.Product_Item_Price, error) {
+	keyName := strings.Replace(fmt.Sprintf("STORAGE_SPACE_FOR_%v_IOPS_PER_GB", tier), ".", "_", -1)
+
+	for _, item := range productPackage.Items {
+		if item.KeyName == nil || !strings.Contains(*item.KeyName, keyName) {
+			continue
+		}
+
+		if item.CapacityMinimum == nil || item.CapacityMaximum == nil {
+			continue
+		}
+
+		capacityMin, err := strconv.Atoi(*item.CapacityMinimum)
+		if err != nil {
+			return datatypes.Product_Item_Price{}, bosherr.WrapError(err, "Convert price capacity minimum")
+		}
+		capacityMax, err := strconv.Atoi(*item.CapacityMaximum)
+		if err != nil {
+			return datatypes.Product_Item_Price{}, bosherr.WrapError(err, "Convert price capacity maximum")
+		}
+		if size < capacityMin || size > capacityMax {
+			continue
+		}
+
+		for _, price := range item.Prices {
+			// Only collect prices from valid location groups.
+			if price.LocationGroupId != nil {
+				continue
+			}
+			if !hasCategory(price.Categories, "performance_storage_space") {
+				continue
+			}
+			return price, nil
+		}
+	}
+	return datatypes.Product_Item_Price{}, bosherr.Errorf("Unable to find price for storage space size: %d, endurance tier: %v", size, tier)
+}
+
+func FindSaaSEnduranceSnapshotSpacePrice(productPackage datatypes.Product_Package, size int, tier float64) (datatypes.Product_Item_Price, error) {
+	tierLevel, err := EnduranceTierLevel(tier)
+	if err != nil {
+		return datatypes.Product_Item_Price{}, err
+	}
+
+	for _, item := range productPackage.Items {
+		if item.Capacity == nil || float64(*item.Capacity) != float64(size) {
+			continue
+		}
+
+		for _, price := range item.Prices {
+			// Only collect prices from valid location groups.
+			if price.LocationGroupId != nil {
+				continue
+			}
+			if !hasCategory(price.Categories, "storage_snapshot_space") {
+				continue
+			}
+			if price.CapacityRestrictionType == nil || *price.CapacityRestrictionType != "STORAGE_TIER_LEVEL" ||
+				price.CapacityRestrictionMinimum == nil || price.CapacityRestrictionMaximum == nil {
+				continue
+			}
+
+			capacityMin, err := strconv.Atoi(*price.CapacityRestrictionMinimum)
+			if err != nil {
+				return datatypes.Product_Item_Price{}, bosherr.WrapError(err, "Convert price capacity restriction minimum")
+			}
+			capacityMax, err := strconv.Atoi(*price.CapacityRestrictionMaximum)
+			if err != nil {
+				return datatypes.Product_Item_Price{}, bosherr.WrapError(err, "Convert price capacity restriction maximum")
+			}
+			if tierLevel < capacityMin || tierLevel > capacityMax {
+				continue
+			}
+
+			return price, nil
+		}
+	}
+	return datatypes.Product_Item_Price{}, bosherr.Errorf("Unable to find price snapshot space size: %d, endurance tier: %v", size, tier)
+}
+
+func FindSaaSReplicationPrice(productPackage datatypes.Product_Package, iops int, tier float64) (datatypes.Product_Item_Price, error) {
+	keyName, restrictionType, restrictionValue := "REPLICATION_FOR_IOPSBASED_PERFORMANCE", "IOPS", iops
+	if tier > 0 {
+		tierLevel, err := EnduranceTierLevel(tier)
+		if err != nil {
+			return datatypes.Product_Item_Price{}, err
+		}
+		keyName, restrictionType, restrictionValue = "REPLICATION_FOR_TIERBASED_PERFORMANCE", "STORAGE_TIER_LEVEL", tierLevel
+	}
+
+	for _, item := range productPackage.Items {
+		if item.KeyName == nil || *item.KeyName != keyName {
+			continue
+		}
+
+		for _, price := range item.Prices {
+			// Only collect prices from valid location groups.
+			if price.LocationGroupId != nil {
+				continue
+			}
+			if !hasCategory(price.Categories, "performance_storage_replication") {
+				continue
+			}
+			if price.CapacityRestrictionType == nil || *price.CapacityRestrictionType != restrictionType ||
+				price.CapacityRestrictionMinimum == nil || price.CapacityRestrictionMaximum == nil {
+				continue
+			}
+
+			capacityMin, err := strconv.Atoi(*price.CapacityRestrictionMinimum)
+			if err != nil {
+				return datatypes.Product_Item_Price{}, bosherr.WrapError(err, "Convert price capacity restriction minimum")
+			}
+			capacityMax, err := strconv.Atoi(*price.CapacityRestrictionMaximum)
+			if err != nil {
+				return datatypes.Product_Item_Price{}, bosherr.WrapError(err, "Convert price capacity restriction maximum")
+			}
+			if restrictionValue < capacityMin || restrictionValue > capacityMax {
+				continue
+			}
+
+			return price, nil
+		}
+	}
+	return datatypes.Product_Item_Price{}, bosherr.Errorf("Unable to find price for replication of iops: %d, endurance tier: %v", iops, tier)
+}
+
 // Find the price in the given package that has the specified category
 func FindPerformancePrice(productPackage datatypes.Product_Package, priceCategory string) (datatypes.Product_Item_Price, error) {
 	for _, item := range productPackage.Items {
@@ -1733,6 +2720,32 @@ func (c *ClientManager) DeleteSshKey(id int) (bool, error) {
 	return c.SecuritySshKeyService.Id(id).DeleteObject()
 }
 
+// stampOrder identifies the order with the director request ID, so support cases can be
+// correlated with the director task that placed it.
+func (c *ClientManager) stampOrder(order *datatypes.Container_Product_Order) {
+	if requestID := c.logger.GetRequestID(); requestID != "" {
+		order.ContainerIdentifier = sl.String(orderIdentifier(requestID))
+	}
+}
+
+// stampInstance identifies an instance ordered with createObject, which takes no container
+// identifier, with the director request ID in its notes. Failing to is only logged.
+func (c *ClientManager) stampInstance(id int) {
+	requestID := c.logger.GetRequestID()
+	if requestID == "" {
+		return
+	}
+
+	_, err := c.VirtualGuestService.Id(id).EditObject(&datatypes.Virtual_Guest{Notes: sl.String(orderIdentifier(requestID))})
+	if err != nil {
+		c.logger.Warn(softlayerClientLogTag, "Setting notes of instance '%d' to request '%s': %s", id, requestID, err)
+	}
+}
+
+func orderIdentifier(requestID string) string {
+	return fmt.Sprintf("bosh-request-%s", requestID)
+}
+
 func (c *ClientManager) CreateTicket(ticketSubject *string, ticketTitle *string, contents *string, attachmentId *int, attachmentType *string) error {
 	ticketSubjects, err := c.TicketSubjectSerivce.GetAllObjects()
 	if err != nil {
@@ -1782,11 +2795,10 @@ func (c *ClientManager) SetUserDataWithID(id int, userData *registry.SoftlayerUs
 	userData.Server = registry.SoftlayerUserDataServerName{
 		Name: strconv.Itoa(id),
 	}
-	contentsBytes, err := json.Marshal(userData)
+	encodedUserData, err := encodeUserData(userData)
 	if err != nil {
-		return bosherr.WrapError(err, "Preparing user data contents")
+		return err
 	}
-	encodedUserData := sl.String(base64.RawURLEncoding.EncodeToString(contentsBytes))
 
 	for {
 		result, err := c.SetInstanceMetadata(id, encodedUserData)
@@ -1809,6 +2821,15 @@ func (c *ClientManager) SetUserDataWithID(id int, userData *registry.SoftlayerUs
 	}
 }
 
+// encodeUserData returns the user data as the base64 encoded JSON that SoftLayer serves to the instance.
+func encodeUserData(userData *registry.SoftlayerUserData) (*string, error) {
+	contentsBytes, err := json.Marshal(userData)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Preparing user data contents")
+	}
+	return sl.String(base64.RawURLEncoding.EncodeToString(contentsBytes)), nil
+}
+
 func (c *ClientManager) SetInstanceMetadata(id int, encodedUserData *string) (bool, error) {
 	_, err := c.VirtualGuestService.Id(id).SetUserMetadata([]string{*encodedUserData})
 	if err != nil {
@@ -1987,6 +3008,21 @@ func (c *ClientManager) selectMaximunIopsItemPriceIdOnSize(size int) (datatypes.
 	return datatypes.Product_Item_Price{}, bosherr.Errorf("No proper performance storage (iSCSI volume) for size %d", size)
 }
 
+// Select the IOPS price of a storage as a service volume and the IOPS it is for: the maximum IOPS of the package
+// for the size, unless IOPS is given
+func selectSaaSIopsPrice(productPackage datatypes.Product_Package, size int, iops int) (datatypes.Product_Item_Price, int, error) {
+	if iops == 0 {
+		var err error
+		iops, err = FindSaaSMaximumPerformIops(productPackage, size)
+		if err != nil {
+			return datatypes.Product_Item_Price{}, 0, err
+		}
+	}
+
+	price, err := FindSaaSPerformIopsPrice(productPackage, size, iops)
+	return price, iops, err
+}
+
 func (c *ClientManager) reloadOperatingSystemWithConfig(id int, config *datatypes.Container_Hardware_Server_Configuration, until time.Time) error {
 	var slError sl.Error
 
@@ -684,6 +684,54 @@ var _ = Describe("DiskHandler", func() {
 		})
 	})
 
+	Describe("VerifyVolumeOrder", func() {
+		It("Verify successfully when verify 250GB volume with 1500 iops", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetPerformanceIscsiPackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_Performance.json",
+					"statusCode": http.StatusOK,
+				},
+				// VerifyOrder
+				{
+					"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.VerifyVolumeOrder("dal02", 250, 1500)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Return error when the iops can not be ordered with the size", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetPerformanceIscsiPackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_Performance.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.VerifyVolumeOrder("dal02", 250, 100000)
+			Expect(err).To(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+
 	Describe("OrderBlockVolume", func() {
 		It("Order successfully when order 250GB volume", func() {
 			respParas = []map[string]interface{}{
@@ -740,6 +788,70 @@ var _ = Describe("DiskHandler", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("Order successfully when order 250GB endurance volume", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService_Tier4Replication.json",
+					"statusCode": http.StatusOK,
+				},
+				// PlaceOrder
+				{
+					"filename":   "SoftLayer_Product_Order_placeOrder.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err := cli.OrderBlockVolume2("storage_service_enterprise", "dal02", 250, 0, 4, 0)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Return error when endurance tier price is not found", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err := cli.OrderBlockVolume2("storage_service_enterprise", "dal02", 250, 0, 10, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unable to find price for endurance tier 10"))
+		})
+
+		It("Return error when endurance volume has iops", func() {
+			_, err := cli.OrderBlockVolume2("storage_service_enterprise", "dal02", 250, 1500, 4, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Endurance block volumes require a tier and no iops"))
+		})
+
+		It("Return error when performance volume has tier", func() {
+			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 4, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Performance block volumes have no endurance tier"))
+		})
+
+		It("Return error when storage type is unknown", func() {
+			_, err := cli.OrderBlockVolume2("fake-storage-type", "dal02", 250, 0, 0, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Block volume storage_type must be either Performance or Endurance"))
+		})
+
 		It("Order successfully when order 500GB volume", func() {
 			respParas = []map[string]interface{}{
 				// GetLocationId
@@ -1052,11 +1164,6 @@ var _ = Describe("DiskHandler", func() {
 					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
 					"statusCode": http.StatusOK,
 				},
-				// selectMaximunIopsItemPriceIdOnSize
-				{
-					"filename":   "SoftLayer_Product_Package_getItemPrices.json",
-					"statusCode": http.StatusOK,
-				},
 				// PlaceOrder
 				{
 					"filename":   "SoftLayer_Product_Order_placeOrder.json",
@@ -1066,7 +1173,7 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 0)
+			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 0, 0)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -1091,7 +1198,7 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 1500, 0)
+			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 1500, 0, 0)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -1107,11 +1214,6 @@ var _ = Describe("DiskHandler", func() {
 					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService500.json",
 					"statusCode": http.StatusOK,
 				},
-				// selectMaximunIopsItemPriceIdOnSize
-				{
-					"filename":   "SoftLayer_Product_Package_getItemPrices.json",
-					"statusCode": http.StatusOK,
-				},
 				// PlaceOrder
 				{
 					"filename":   "SoftLayer_Product_Order_placeOrder.json",
@@ -1121,7 +1223,7 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 500, 0, 0)
+			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 500, 0, 0, 0)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -1137,11 +1239,6 @@ var _ = Describe("DiskHandler", func() {
 					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService1000.json",
 					"statusCode": http.StatusOK,
 				},
-				// selectMaximunIopsItemPriceIdOnSize
-				{
-					"filename":   "SoftLayer_Product_Package_getItemPrices.json",
-					"statusCode": http.StatusOK,
-				},
 				// PlaceOrder
 				{
 					"filename":   "SoftLayer_Product_Order_placeOrder.json",
@@ -1151,7 +1248,7 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 1000, 0, 0)
+			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 1000, 0, 0, 0)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -1166,7 +1263,7 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 0)
+			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 0, 0)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("Invalid datacenter name specified. Please provide the lower case short name"))
 		})
@@ -1187,7 +1284,7 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 0)
+			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 0, 0)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("Unable to find price storage category"))
 		})
@@ -1208,64 +1305,12 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 1000, 0, 0)
+			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 1000, 0, 0, 0)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("Unable to find price storage size"))
 		})
 
-		It("Return error when SoftLayerProductPackage getItemPrices return error", func() {
-			respParas = []map[string]interface{}{
-				// GetLocationId
-				{
-					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
-					"statusCode": http.StatusOK,
-				},
-				// GetStorageAsServicePackage
-				{
-					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
-					"statusCode": http.StatusOK,
-				},
-				// selectMaximunIopsItemPriceIdOnSize
-				{
-					"filename":   "SoftLayer_Product_Package_getItemPrices_InternalError.json",
-					"statusCode": http.StatusInternalServerError,
-				},
-			}
-			err = test_helpers.SpecifyServerResps(respParas, server)
-			Expect(err).NotTo(HaveOccurred())
-
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 0)
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
-		})
-
-		It("Return error when itemPrices is empty before filter", func() {
-			respParas = []map[string]interface{}{
-				// GetLocationId
-				{
-					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
-					"statusCode": http.StatusOK,
-				},
-				// GetStorageAsServicePackage
-				{
-					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
-					"statusCode": http.StatusOK,
-				},
-				// selectMaximunIopsItemPriceIdOnSize
-				{
-					"filename":   "SoftLayer_Product_Package_getItemPrices_Empty.json",
-					"statusCode": http.StatusOK,
-				},
-			}
-			err = test_helpers.SpecifyServerResps(respParas, server)
-			Expect(err).NotTo(HaveOccurred())
-
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 0)
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("No proper performance storage (iSCSI volume) for size"))
-		})
-
-		It("Return error when itemPrices is empty after filter", func() {
+		It("Return error when the package has no iops price for the size", func() {
 			respParas = []map[string]interface{}{
 				// GetLocationId
 				{
@@ -1274,21 +1319,16 @@ var _ = Describe("DiskHandler", func() {
 				},
 				// GetStorageAsServicePackage
 				{
-					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
-					"statusCode": http.StatusOK,
-				},
-				// selectMaximunIopsItemPriceIdOnSize
-				{
-					"filename":   "SoftLayer_Product_Package_getItemPrices_HasLocation.json",
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService500.json",
 					"statusCode": http.StatusOK,
 				},
 			}
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 0)
+			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 1000, 0, 0, 0)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("No proper performance storage (iSCSI volume) for size"))
+			Expect(err.Error()).To(ContainSubstring("Unable to find iops for storage space size: 1000"))
 		})
 
 		It("Order successfully when order 250GB volume with 3000 iops", func() {
@@ -1307,7 +1347,7 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 3000, 0)
+			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 3000, 0, 0)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unable to find price for storage space size: %d, iops: %d", 250, 3000)))
 		})
@@ -1328,7 +1368,7 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 500, 0)
+			_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 500, 0, 0)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unable to find price for storage space size: %d, iops: %d", 250, 500)))
 		})
@@ -1346,11 +1386,6 @@ var _ = Describe("DiskHandler", func() {
 						"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
 						"statusCode": http.StatusOK,
 					},
-					// selectMaximunIopsItemPriceIdOnSize
-					{
-						"filename":   "SoftLayer_Product_Package_getItemPrices.json",
-						"statusCode": http.StatusOK,
-					},
 					// PlaceOrder
 					{
 						"filename":   "SoftLayer_Product_Order_placeOrder.json",
@@ -1360,7 +1395,7 @@ var _ = Describe("DiskHandler", func() {
 				err = test_helpers.SpecifyServerResps(respParas, server)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 10)
+				_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 0, 10)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -1376,11 +1411,6 @@ var _ = Describe("DiskHandler", func() {
 						"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
 						"statusCode": http.StatusOK,
 					},
-					// selectMaximunIopsItemPriceIdOnSize
-					{
-						"filename":   "SoftLayer_Product_Package_getItemPrices.json",
-						"statusCode": http.StatusOK,
-					},
 					// PlaceOrder
 					{
 						"filename":   "SoftLayer_Product_Order_placeOrder.json",
@@ -1390,7 +1420,7 @@ var _ = Describe("DiskHandler", func() {
 				err = test_helpers.SpecifyServerResps(respParas, server)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 100)
+				_, err := cli.OrderBlockVolume2("performance_storage_iscsi", "dal02", 250, 0, 0, 100)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("Unable to find price snapshot space size"))
 			})
@@ -1405,14 +1435,39 @@ var _ = Describe("DiskHandler", func() {
 					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
 					"statusCode": http.StatusOK,
 				},
-				// GetPerformanceIscsiPackage
+				// GetStorageAsServicePackage
 				{
-					"filename":   "SoftLayer_Product_Package_getObject_Performance.json",
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
 					"statusCode": http.StatusOK,
 				},
-				// selectMaximunIopsItemPriceIdOnSize
+				// PlaceOrder
 				{
-					"filename":   "SoftLayer_Product_Package_getItemPrices.json",
+					"filename":   "SoftLayer_Product_Order_placeOrder.json",
+					"statusCode": http.StatusOK,
+				},
+				// WaitVolumeProvisioningWithOrderId
+				{
+					"filename":   "SoftLayer_Account_getIscsiNetworkStorage.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err := cli.CreateVolume("dal02", 250, 0, 0, 0)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Create successfully when seet snapshotSpace", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
 					"statusCode": http.StatusOK,
 				},
 				// PlaceOrder
@@ -1429,11 +1484,11 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.CreateVolume("dal02", 250, 0, 0)
+			_, err := cli.CreateVolume("dal02", 250, 0, 0, 10)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("Create successfully when seet snapshotSpace", func() {
+		It("Create performance volume with iops", func() {
 			respParas = []map[string]interface{}{
 				// GetLocationId
 				{
@@ -1445,9 +1500,35 @@ var _ = Describe("DiskHandler", func() {
 					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
 					"statusCode": http.StatusOK,
 				},
-				// selectMaximunIopsItemPriceIdOnSize
+				// PlaceOrder
 				{
-					"filename":   "SoftLayer_Product_Package_getItemPrices.json",
+					"filename":   "SoftLayer_Product_Order_placeOrder.json",
+					"statusCode": http.StatusOK,
+				},
+				// WaitVolumeProvisioningWithOrderId
+				{
+					"filename":   "SoftLayer_Account_getIscsiNetworkStorage.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			volume, err := cli.CreateVolume("dal02", 250, 1500, 0, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volume.Id).NotTo(BeNil())
+		})
+
+		It("Create endurance volume", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService_Tier4Replication.json",
 					"statusCode": http.StatusOK,
 				},
 				// PlaceOrder
@@ -1464,8 +1545,9 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.CreateVolume("dal02", 250, 0, 10)
+			volume, err := cli.CreateVolume("dal02", 250, 0, 4, 0)
 			Expect(err).NotTo(HaveOccurred())
+			Expect(volume.Id).NotTo(BeNil())
 		})
 
 		It("Return error when call OrderBlockVolume return error", func() {
@@ -1479,7 +1561,7 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.CreateVolume("dal02", 250, 0, 0)
+			_, err := cli.CreateVolume("dal02", 250, 0, 0, 0)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("Invalid datacenter name specified. Please provide the lower case short name"))
 		})
@@ -1491,14 +1573,9 @@ var _ = Describe("DiskHandler", func() {
 					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
 					"statusCode": http.StatusOK,
 				},
-				// GetPerformanceIscsiPackage
-				{
-					"filename":   "SoftLayer_Product_Package_getObject_Performance.json",
-					"statusCode": http.StatusOK,
-				},
-				// selectMaximunIopsItemPriceIdOnSize
+				// GetStorageAsServicePackage
 				{
-					"filename":   "SoftLayer_Product_Package_getItemPrices.json",
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
 					"statusCode": http.StatusOK,
 				},
 				// PlaceOrder
@@ -1510,12 +1587,368 @@ var _ = Describe("DiskHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err := cli.CreateVolume("dal02", 250, 0, 0)
+			_, err := cli.CreateVolume("dal02", 250, 0, 0, 0)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("No order id returned after placing order with size of"))
 		})
 	})
 
+	Describe("DuplicateVolume", func() {
+		It("Duplicate successfully", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
+					"statusCode": http.StatusOK,
+				},
+				// PlaceOrder
+				{
+					"filename":   "SoftLayer_Product_Order_placeOrder.json",
+					"statusCode": http.StatusOK,
+				},
+				// WaitVolumeProvisioningWithOrderId
+				{
+					"filename":   "SoftLayer_Account_getIscsiNetworkStorage.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			volume, err := cli.DuplicateVolume(diskID, 32345678, "dal02", 250, 1500, 0, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volume.Id).NotTo(BeNil())
+		})
+
+		It("Return error when call GetStorageAsServicePackage return error", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_InternalError.json",
+					"statusCode": http.StatusInternalServerError,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err := cli.DuplicateVolume(diskID, 32345678, "dal02", 250, 1500, 0, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+
+		It("Return error when call placeOrder return receipt without order id", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
+					"statusCode": http.StatusOK,
+				},
+				// PlaceOrder
+				{
+					"filename":   "SoftLayer_Product_Order_placeOrder_Without_Orderid.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err := cli.DuplicateVolume(diskID, 32345678, "dal02", 250, 1500, 0, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("No order id returned after placing duplicate order of volume"))
+		})
+	})
+
+	Describe("OrderReplicantVolume", func() {
+		It("Order successfully", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService_Tier4Replication.json",
+					"statusCode": http.StatusOK,
+				},
+				// PlaceOrder
+				{
+					"filename":   "SoftLayer_Product_Order_placeOrder.json",
+					"statusCode": http.StatusOK,
+				},
+				// WaitVolumeProvisioningWithOrderId
+				{
+					"filename":   "SoftLayer_Account_getIscsiNetworkStorage.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			volume, err := cli.OrderReplicantVolume(diskID, 42345678, "dal02", 250, 1500, 0, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volume.Id).NotTo(BeNil())
+		})
+
+		It("Return error when call GetStorageAsServicePackage return error", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_InternalError.json",
+					"statusCode": http.StatusInternalServerError,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err := cli.OrderReplicantVolume(diskID, 42345678, "dal02", 250, 1500, 0, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+
+		It("Return error when a price of the replicant is not found", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err := cli.OrderReplicantVolume(diskID, 42345678, "dal02", 250, 1500, 0, 10)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unable to find price"))
+		})
+
+		It("Return error when call placeOrder return receipt without order id", func() {
+			respParas = []map[string]interface{}{
+				// GetLocationId
+				{
+					"filename":   "SoftLayer_Location_Datacenter_getDatacenters.json",
+					"statusCode": http.StatusOK,
+				},
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService_Tier4Replication.json",
+					"statusCode": http.StatusOK,
+				},
+				// PlaceOrder
+				{
+					"filename":   "SoftLayer_Product_Order_placeOrder_Without_Orderid.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err := cli.OrderReplicantVolume(diskID, 42345678, "dal02", 250, 1500, 0, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("No order id returned after placing replicant order of volume"))
+		})
+	})
+
+	Describe("ModifyVolume", func() {
+		It("Modify successfully", func() {
+			respParas = []map[string]interface{}{
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
+					"statusCode": http.StatusOK,
+				},
+				// PlaceOrder
+				{
+					"filename":   "SoftLayer_Product_Order_placeOrder.json",
+					"statusCode": http.StatusOK,
+				},
+				// WaitVolumeModificationCompleted
+				{
+					"filename":   "SoftLayer_Network_Storage_getObject_Resized.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.ModifyVolume(diskID, 250, 1500, 0)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Modify the tier of an endurance volume successfully", func() {
+			respParas = []map[string]interface{}{
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService_Tier4Replication.json",
+					"statusCode": http.StatusOK,
+				},
+				// PlaceOrder
+				{
+					"filename":   "SoftLayer_Product_Order_placeOrder.json",
+					"statusCode": http.StatusOK,
+				},
+				// WaitVolumeModificationCompleted
+				{
+					"filename":   "SoftLayer_Network_Storage_getObject_Resized_Endurance.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.ModifyVolume(diskID, 250, 0, 4)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Return error when call GetStorageAsServicePackage return error", func() {
+			respParas = []map[string]interface{}{
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_InternalError.json",
+					"statusCode": http.StatusInternalServerError,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.ModifyVolume(diskID, 250, 1500, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+
+		It("Return error when call placeOrder return receipt without order id", func() {
+			respParas = []map[string]interface{}{
+				// GetStorageAsServicePackage
+				{
+					"filename":   "SoftLayer_Product_Package_getObject_StorageAsService.json",
+					"statusCode": http.StatusOK,
+				},
+				// PlaceOrder
+				{
+					"filename":   "SoftLayer_Product_Order_placeOrder_Without_Orderid.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.ModifyVolume(diskID, 250, 1500, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("No order id returned after placing upgrade order with size of"))
+		})
+	})
+
+	Describe("WaitVolumeModificationCompleted", func() {
+		It("Return timeout error when volume capacity is not modified", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Storage_getObject.json",
+					"statusCode": http.StatusOK,
+				},
+				{
+					"filename":   "SoftLayer_Network_Storage_getObject.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.WaitVolumeModificationCompleted(diskID, 250, 0, 0, time.Now().Add(3000000))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Waiting volume modification with id of '%d' has time out", diskID)))
+		})
+
+		It("Return error when StorageService getObject call return error", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Storage_getObject_InternalError.json",
+					"statusCode": http.StatusInternalServerError,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.WaitVolumeModificationCompleted(diskID, 250, 0, 0, time.Now().Add(1*time.Hour))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Getting volume with id of"))
+		})
+	})
+
+	Describe("WaitSnapshotSpaceCompleted", func() {
+		It("Wait successfully when volume has the snapshot space", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Storage_getObject.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.WaitSnapshotSpaceCompleted(diskID, 20, time.Now().Add(1*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Return timeout error when snapshot space is not upgraded", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Storage_getObject.json",
+					"statusCode": http.StatusOK,
+				},
+				{
+					"filename":   "SoftLayer_Network_Storage_getObject.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.WaitSnapshotSpaceCompleted(diskID, 40, time.Now().Add(3000000))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Waiting snapshot space of volume with id of '%d' has time out", diskID)))
+		})
+
+		It("Return error when StorageService getObject call return error", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Storage_getObject_InternalError.json",
+					"statusCode": http.StatusInternalServerError,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.WaitSnapshotSpaceCompleted(diskID, 40, time.Now().Add(1*time.Hour))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Getting volume with id of"))
+		})
+	})
+
 	Describe("SetNotes", func() {
 		Context("when StorageService editObject call successfully", func() {
 			It("set tags successfully", func() {
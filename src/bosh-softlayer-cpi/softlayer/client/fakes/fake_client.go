@@ -22,10 +22,11 @@ type FakeClient struct {
 	cancelInstanceReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CreateInstanceStub        func(template *datatypes.Virtual_Guest, userData *registry.SoftlayerUserData) (*datatypes.Virtual_Guest, error)
+	CreateInstanceStub        func(template *datatypes.Virtual_Guest, options client.InstanceOrderOptions, userData *registry.SoftlayerUserData) (*datatypes.Virtual_Guest, error)
 	createInstanceMutex       sync.RWMutex
 	createInstanceArgsForCall []struct {
 		template *datatypes.Virtual_Guest
+		options  client.InstanceOrderOptions
 		userData *registry.SoftlayerUserData
 	}
 	createInstanceReturns struct {
@@ -307,22 +308,6 @@ type FakeClient struct {
 		result1 bool
 		result2 error
 	}
-	CreateVolumeStub        func(location string, size int, iops int, snapshotSpace int) (*datatypes.Network_Storage, error)
-	createVolumeMutex       sync.RWMutex
-	createVolumeArgsForCall []struct {
-		location      string
-		size          int
-		iops          int
-		snapshotSpace int
-	}
-	createVolumeReturns struct {
-		result1 *datatypes.Network_Storage
-		result2 error
-	}
-	createVolumeReturnsOnCall map[int]struct {
-		result1 *datatypes.Network_Storage
-		result2 error
-	}
 	OrderBlockVolumeStub        func(storageType string, location string, size int, iops int) (*datatypes.Container_Product_Order_Receipt, error)
 	orderBlockVolumeMutex       sync.RWMutex
 	orderBlockVolumeArgsForCall []struct {
@@ -339,23 +324,6 @@ type FakeClient struct {
 		result1 *datatypes.Container_Product_Order_Receipt
 		result2 error
 	}
-	OrderBlockVolume2Stub        func(storageType string, location string, size int, iops int, snapshotSpace int) (*datatypes.Container_Product_Order_Receipt, error)
-	orderBlockVolume2Mutex       sync.RWMutex
-	orderBlockVolume2ArgsForCall []struct {
-		storageType   string
-		location      string
-		size          int
-		iops          int
-		snapshotSpace int
-	}
-	orderBlockVolume2Returns struct {
-		result1 *datatypes.Container_Product_Order_Receipt
-		result2 error
-	}
-	orderBlockVolume2ReturnsOnCall map[int]struct {
-		result1 *datatypes.Container_Product_Order_Receipt
-		result2 error
-	}
 	CancelBlockVolumeStub        func(volumeId int, reason string, immediate bool) (bool, error)
 	cancelBlockVolumeMutex       sync.RWMutex
 	cancelBlockVolumeArgsForCall []struct {
@@ -667,2419 +635,4651 @@ type FakeClient struct {
 		result1 int
 		result2 error
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
-}
-
-func (fake *FakeClient) CancelInstance(id int) error {
-	fake.cancelInstanceMutex.Lock()
-	ret, specificReturn := fake.cancelInstanceReturnsOnCall[len(fake.cancelInstanceArgsForCall)]
-	fake.cancelInstanceArgsForCall = append(fake.cancelInstanceArgsForCall, struct {
-		id int
-	}{id})
-	fake.recordInvocation("CancelInstance", []interface{}{id})
-	fake.cancelInstanceMutex.Unlock()
-	if fake.CancelInstanceStub != nil {
-		return fake.CancelInstanceStub(id)
+	GetVirtualServerPackageStub        func(mask string) (datatypes.Product_Package, error)
+	getVirtualServerPackageMutex       sync.RWMutex
+	getVirtualServerPackageArgsForCall []struct {
+		mask string
 	}
-	if specificReturn {
-		return ret.result1
+	getVirtualServerPackageReturns struct {
+		result1 datatypes.Product_Package
+		result2 error
 	}
-	return fake.cancelInstanceReturns.result1
-}
-
-func (fake *FakeClient) CancelInstanceCallCount() int {
-	fake.cancelInstanceMutex.RLock()
-	defer fake.cancelInstanceMutex.RUnlock()
-	return len(fake.cancelInstanceArgsForCall)
-}
-
-func (fake *FakeClient) CancelInstanceArgsForCall(i int) int {
-	fake.cancelInstanceMutex.RLock()
-	defer fake.cancelInstanceMutex.RUnlock()
-	return fake.cancelInstanceArgsForCall[i].id
-}
-
-func (fake *FakeClient) CancelInstanceReturns(result1 error) {
-	fake.CancelInstanceStub = nil
-	fake.cancelInstanceReturns = struct {
+	getVirtualServerPackageReturnsOnCall map[int]struct {
+		result1 datatypes.Product_Package
+		result2 error
+	}
+	ModifyVolumeStub        func(volumeId int, size int, iops int, tier float64) error
+	modifyVolumeMutex       sync.RWMutex
+	modifyVolumeArgsForCall []struct {
+		volumeId int
+		size     int
+		iops     int
+		tier     float64
+	}
+	modifyVolumeReturns struct {
 		result1 error
-	}{result1}
-}
-
-func (fake *FakeClient) CancelInstanceReturnsOnCall(i int, result1 error) {
-	fake.CancelInstanceStub = nil
-	if fake.cancelInstanceReturnsOnCall == nil {
-		fake.cancelInstanceReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
 	}
-	fake.cancelInstanceReturnsOnCall[i] = struct {
+	modifyVolumeReturnsOnCall map[int]struct {
 		result1 error
-	}{result1}
-}
-
-func (fake *FakeClient) CreateInstance(template *datatypes.Virtual_Guest, userData *registry.SoftlayerUserData) (*datatypes.Virtual_Guest, error) {
-	fake.createInstanceMutex.Lock()
-	ret, specificReturn := fake.createInstanceReturnsOnCall[len(fake.createInstanceArgsForCall)]
-	fake.createInstanceArgsForCall = append(fake.createInstanceArgsForCall, struct {
-		template *datatypes.Virtual_Guest
-		userData *registry.SoftlayerUserData
-	}{template, userData})
-	fake.recordInvocation("CreateInstance", []interface{}{template, userData})
-	fake.createInstanceMutex.Unlock()
-	if fake.CreateInstanceStub != nil {
-		return fake.CreateInstanceStub(template, userData)
 	}
-	if specificReturn {
-		return ret.result1, ret.result2
+	UpgradeBlockVolumeStub        func(volumeId int, size int, iops int, tier float64) (*datatypes.Container_Product_Order_Receipt, error)
+	upgradeBlockVolumeMutex       sync.RWMutex
+	upgradeBlockVolumeArgsForCall []struct {
+		volumeId int
+		size     int
+		iops     int
+		tier     float64
 	}
-	return fake.createInstanceReturns.result1, fake.createInstanceReturns.result2
-}
-
-func (fake *FakeClient) CreateInstanceCallCount() int {
-	fake.createInstanceMutex.RLock()
-	defer fake.createInstanceMutex.RUnlock()
-	return len(fake.createInstanceArgsForCall)
-}
-
-func (fake *FakeClient) CreateInstanceArgsForCall(i int) (*datatypes.Virtual_Guest, *registry.SoftlayerUserData) {
-	fake.createInstanceMutex.RLock()
-	defer fake.createInstanceMutex.RUnlock()
-	return fake.createInstanceArgsForCall[i].template, fake.createInstanceArgsForCall[i].userData
-}
-
-func (fake *FakeClient) CreateInstanceReturns(result1 *datatypes.Virtual_Guest, result2 error) {
-	fake.CreateInstanceStub = nil
-	fake.createInstanceReturns = struct {
-		result1 *datatypes.Virtual_Guest
+	upgradeBlockVolumeReturns struct {
+		result1 *datatypes.Container_Product_Order_Receipt
 		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeClient) CreateInstanceReturnsOnCall(i int, result1 *datatypes.Virtual_Guest, result2 error) {
-	fake.CreateInstanceStub = nil
-	if fake.createInstanceReturnsOnCall == nil {
-		fake.createInstanceReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Virtual_Guest
-			result2 error
-		})
 	}
-	fake.createInstanceReturnsOnCall[i] = struct {
-		result1 *datatypes.Virtual_Guest
+	upgradeBlockVolumeReturnsOnCall map[int]struct {
+		result1 *datatypes.Container_Product_Order_Receipt
 		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeClient) EditInstance(id int, template *datatypes.Virtual_Guest) (bool, error) {
-	fake.editInstanceMutex.Lock()
-	ret, specificReturn := fake.editInstanceReturnsOnCall[len(fake.editInstanceArgsForCall)]
-	fake.editInstanceArgsForCall = append(fake.editInstanceArgsForCall, struct {
-		id       int
-		template *datatypes.Virtual_Guest
-	}{id, template})
-	fake.recordInvocation("EditInstance", []interface{}{id, template})
-	fake.editInstanceMutex.Unlock()
-	if fake.EditInstanceStub != nil {
-		return fake.EditInstanceStub(id, template)
 	}
-	if specificReturn {
-		return ret.result1, ret.result2
+	OrderSnapshotSpaceStub        func(volumeId int, location string, size int, iops int, tier float64, upgrade bool) (*datatypes.Container_Product_Order_Receipt, error)
+	orderSnapshotSpaceMutex       sync.RWMutex
+	orderSnapshotSpaceArgsForCall []struct {
+		volumeId int
+		location string
+		size     int
+		iops     int
+		tier     float64
+		upgrade  bool
 	}
-	return fake.editInstanceReturns.result1, fake.editInstanceReturns.result2
-}
-
-func (fake *FakeClient) EditInstanceCallCount() int {
-	fake.editInstanceMutex.RLock()
-	defer fake.editInstanceMutex.RUnlock()
-	return len(fake.editInstanceArgsForCall)
-}
-
-func (fake *FakeClient) EditInstanceArgsForCall(i int) (int, *datatypes.Virtual_Guest) {
-	fake.editInstanceMutex.RLock()
-	defer fake.editInstanceMutex.RUnlock()
-	return fake.editInstanceArgsForCall[i].id, fake.editInstanceArgsForCall[i].template
-}
-
-func (fake *FakeClient) EditInstanceReturns(result1 bool, result2 error) {
-	fake.EditInstanceStub = nil
-	fake.editInstanceReturns = struct {
-		result1 bool
+	orderSnapshotSpaceReturns struct {
+		result1 *datatypes.Container_Product_Order_Receipt
 		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeClient) EditInstanceReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.EditInstanceStub = nil
-	if fake.editInstanceReturnsOnCall == nil {
-		fake.editInstanceReturnsOnCall = make(map[int]struct {
-			result1 bool
-			result2 error
-		})
 	}
-	fake.editInstanceReturnsOnCall[i] = struct {
-		result1 bool
+	orderSnapshotSpaceReturnsOnCall map[int]struct {
+		result1 *datatypes.Container_Product_Order_Receipt
 		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeClient) GetInstance(id int, mask string) (*datatypes.Virtual_Guest, bool, error) {
-	fake.getInstanceMutex.Lock()
-	ret, specificReturn := fake.getInstanceReturnsOnCall[len(fake.getInstanceArgsForCall)]
-	fake.getInstanceArgsForCall = append(fake.getInstanceArgsForCall, struct {
-		id   int
-		mask string
-	}{id, mask})
-	fake.recordInvocation("GetInstance", []interface{}{id, mask})
-	fake.getInstanceMutex.Unlock()
-	if fake.GetInstanceStub != nil {
-		return fake.GetInstanceStub(id, mask)
 	}
-	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+	WaitVolumeModificationCompletedStub        func(volumeId int, size int, iops int, tier float64, until time.Time) error
+	waitVolumeModificationCompletedMutex       sync.RWMutex
+	waitVolumeModificationCompletedArgsForCall []struct {
+		volumeId int
+		size     int
+		iops     int
+		tier     float64
+		until    time.Time
 	}
-	return fake.getInstanceReturns.result1, fake.getInstanceReturns.result2, fake.getInstanceReturns.result3
-}
-
-func (fake *FakeClient) GetInstanceCallCount() int {
-	fake.getInstanceMutex.RLock()
-	defer fake.getInstanceMutex.RUnlock()
-	return len(fake.getInstanceArgsForCall)
-}
-
-func (fake *FakeClient) GetInstanceArgsForCall(i int) (int, string) {
-	fake.getInstanceMutex.RLock()
-	defer fake.getInstanceMutex.RUnlock()
-	return fake.getInstanceArgsForCall[i].id, fake.getInstanceArgsForCall[i].mask
-}
-
-func (fake *FakeClient) GetInstanceReturns(result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
-	fake.GetInstanceStub = nil
-	fake.getInstanceReturns = struct {
-		result1 *datatypes.Virtual_Guest
+	waitVolumeModificationCompletedReturns struct {
+		result1 error
+	}
+	waitVolumeModificationCompletedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WaitSnapshotSpaceCompletedStub        func(volumeId int, size int, until time.Time) error
+	waitSnapshotSpaceCompletedMutex       sync.RWMutex
+	waitSnapshotSpaceCompletedArgsForCall []struct {
+		volumeId int
+		size     int
+		until    time.Time
+	}
+	waitSnapshotSpaceCompletedReturns struct {
+		result1 error
+	}
+	waitSnapshotSpaceCompletedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetSubnetByIpAddressStub        func(ip string, mask string) (*datatypes.Network_Subnet, bool, error)
+	getSubnetByIpAddressMutex       sync.RWMutex
+	getSubnetByIpAddressArgsForCall []struct {
+		ip   string
+		mask string
+	}
+	getSubnetByIpAddressReturns struct {
+		result1 *datatypes.Network_Subnet
 		result2 bool
 		result3 error
-	}{result1, result2, result3}
-}
-
-func (fake *FakeClient) GetInstanceReturnsOnCall(i int, result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
-	fake.GetInstanceStub = nil
-	if fake.getInstanceReturnsOnCall == nil {
-		fake.getInstanceReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Virtual_Guest
-			result2 bool
-			result3 error
-		})
 	}
-	fake.getInstanceReturnsOnCall[i] = struct {
-		result1 *datatypes.Virtual_Guest
+	getSubnetByIpAddressReturnsOnCall map[int]struct {
+		result1 *datatypes.Network_Subnet
 		result2 bool
 		result3 error
-	}{result1, result2, result3}
-}
-
-func (fake *FakeClient) GetInstanceByPrimaryBackendIpAddress(ip string) (*datatypes.Virtual_Guest, bool, error) {
-	fake.getInstanceByPrimaryBackendIpAddressMutex.Lock()
-	ret, specificReturn := fake.getInstanceByPrimaryBackendIpAddressReturnsOnCall[len(fake.getInstanceByPrimaryBackendIpAddressArgsForCall)]
-	fake.getInstanceByPrimaryBackendIpAddressArgsForCall = append(fake.getInstanceByPrimaryBackendIpAddressArgsForCall, struct {
-		ip string
-	}{ip})
-	fake.recordInvocation("GetInstanceByPrimaryBackendIpAddress", []interface{}{ip})
-	fake.getInstanceByPrimaryBackendIpAddressMutex.Unlock()
-	if fake.GetInstanceByPrimaryBackendIpAddressStub != nil {
-		return fake.GetInstanceByPrimaryBackendIpAddressStub(ip)
 	}
-	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+	GetGlobalIpStub        func(ip string) (*datatypes.Network_Subnet_IpAddress_Global, bool, error)
+	getGlobalIpMutex       sync.RWMutex
+	getGlobalIpArgsForCall []struct {
+		ip string
+	}
+	getGlobalIpReturns struct {
+		result1 *datatypes.Network_Subnet_IpAddress_Global
+		result2 bool
+		result3 error
+	}
+	getGlobalIpReturnsOnCall map[int]struct {
+		result1 *datatypes.Network_Subnet_IpAddress_Global
+		result2 bool
+		result3 error
+	}
+	GetGlobalIpsByDestinationStub        func(ip string) ([]datatypes.Network_Subnet_IpAddress_Global, error)
+	getGlobalIpsByDestinationMutex       sync.RWMutex
+	getGlobalIpsByDestinationArgsForCall []struct {
+		ip string
+	}
+	getGlobalIpsByDestinationReturns struct {
+		result1 []datatypes.Network_Subnet_IpAddress_Global
+		result2 error
+	}
+	getGlobalIpsByDestinationReturnsOnCall map[int]struct {
+		result1 []datatypes.Network_Subnet_IpAddress_Global
+		result2 error
+	}
+	RouteGlobalIpStub        func(id int, destinationIp string) error
+	routeGlobalIpMutex       sync.RWMutex
+	routeGlobalIpArgsForCall []struct {
+		id            int
+		destinationIp string
+	}
+	routeGlobalIpReturns struct {
+		result1 error
+	}
+	routeGlobalIpReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnrouteGlobalIpStub        func(id int) error
+	unrouteGlobalIpMutex       sync.RWMutex
+	unrouteGlobalIpArgsForCall []struct {
+		id int
+	}
+	unrouteGlobalIpReturns struct {
+		result1 error
+	}
+	unrouteGlobalIpReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetLoadBalancerStub        func(uuid string) (*datatypes.Network_LBaaS_LoadBalancer, bool, error)
+	getLoadBalancerMutex       sync.RWMutex
+	getLoadBalancerArgsForCall []struct {
+		uuid string
+	}
+	getLoadBalancerReturns struct {
+		result1 *datatypes.Network_LBaaS_LoadBalancer
+		result2 bool
+		result3 error
+	}
+	getLoadBalancerReturnsOnCall map[int]struct {
+		result1 *datatypes.Network_LBaaS_LoadBalancer
+		result2 bool
+		result3 error
+	}
+	AddLoadBalancerMemberStub        func(uuid string, ip string, weight int) error
+	addLoadBalancerMemberMutex       sync.RWMutex
+	addLoadBalancerMemberArgsForCall []struct {
+		uuid   string
+		ip     string
+		weight int
+	}
+	addLoadBalancerMemberReturns struct {
+		result1 error
+	}
+	addLoadBalancerMemberReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateLoadBalancerMemberWeightStub        func(uuid string, memberUuid string, weight int) error
+	updateLoadBalancerMemberWeightMutex       sync.RWMutex
+	updateLoadBalancerMemberWeightArgsForCall []struct {
+		uuid       string
+		memberUuid string
+		weight     int
+	}
+	updateLoadBalancerMemberWeightReturns struct {
+		result1 error
+	}
+	updateLoadBalancerMemberWeightReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteLoadBalancerMemberStub        func(uuid string, memberUuid string) error
+	deleteLoadBalancerMemberMutex       sync.RWMutex
+	deleteLoadBalancerMemberArgsForCall []struct {
+		uuid       string
+		memberUuid string
+	}
+	deleteLoadBalancerMemberReturns struct {
+		result1 error
+	}
+	deleteLoadBalancerMemberReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WaitLoadBalancerActiveStub        func(uuid string, until time.Time) error
+	waitLoadBalancerActiveMutex       sync.RWMutex
+	waitLoadBalancerActiveArgsForCall []struct {
+		uuid  string
+		until time.Time
+	}
+	waitLoadBalancerActiveReturns struct {
+		result1 error
+	}
+	waitLoadBalancerActiveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetSecurityGroupsStub        func() ([]datatypes.Network_SecurityGroup, error)
+	getSecurityGroupsMutex       sync.RWMutex
+	getSecurityGroupsArgsForCall []struct{}
+	getSecurityGroupsReturns     struct {
+		result1 []datatypes.Network_SecurityGroup
+		result2 error
+	}
+	getSecurityGroupsReturnsOnCall map[int]struct {
+		result1 []datatypes.Network_SecurityGroup
+		result2 error
+	}
+	AttachSecurityGroupComponentsStub        func(id int, componentIds []int) error
+	attachSecurityGroupComponentsMutex       sync.RWMutex
+	attachSecurityGroupComponentsArgsForCall []struct {
+		id           int
+		componentIds []int
+	}
+	attachSecurityGroupComponentsReturns struct {
+		result1 error
+	}
+	attachSecurityGroupComponentsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DetachSecurityGroupComponentsStub        func(id int, componentIds []int) error
+	detachSecurityGroupComponentsMutex       sync.RWMutex
+	detachSecurityGroupComponentsArgsForCall []struct {
+		id           int
+		componentIds []int
+	}
+	detachSecurityGroupComponentsReturns struct {
+		result1 error
+	}
+	detachSecurityGroupComponentsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetDedicatedHostsStub        func() ([]client.DedicatedHost, error)
+	getDedicatedHostsMutex       sync.RWMutex
+	getDedicatedHostsArgsForCall []struct{}
+	getDedicatedHostsReturns     struct {
+		result1 []client.DedicatedHost
+		result2 error
+	}
+	getDedicatedHostsReturnsOnCall map[int]struct {
+		result1 []client.DedicatedHost
+		result2 error
+	}
+	GetPlacementGroupsStub        func() ([]client.PlacementGroup, error)
+	getPlacementGroupsMutex       sync.RWMutex
+	getPlacementGroupsArgsForCall []struct{}
+	getPlacementGroupsReturns     struct {
+		result1 []client.PlacementGroup
+		result2 error
+	}
+	getPlacementGroupsReturnsOnCall map[int]struct {
+		result1 []client.PlacementGroup
+		result2 error
+	}
+	GetPlacementGroupStub        func(id int) (*client.PlacementGroup, bool, error)
+	getPlacementGroupMutex       sync.RWMutex
+	getPlacementGroupArgsForCall []struct {
+		id int
+	}
+	getPlacementGroupReturns struct {
+		result1 *client.PlacementGroup
+		result2 bool
+		result3 error
+	}
+	getPlacementGroupReturnsOnCall map[int]struct {
+		result1 *client.PlacementGroup
+		result2 bool
+		result3 error
+	}
+	CreatePlacementGroupStub        func(name string, backendRouterId int) (*client.PlacementGroup, error)
+	createPlacementGroupMutex       sync.RWMutex
+	createPlacementGroupArgsForCall []struct {
+		name            string
+		backendRouterId int
+	}
+	createPlacementGroupReturns struct {
+		result1 *client.PlacementGroup
+		result2 error
+	}
+	createPlacementGroupReturnsOnCall map[int]struct {
+		result1 *client.PlacementGroup
+		result2 error
+	}
+	DeletePlacementGroupStub        func(id int) error
+	deletePlacementGroupMutex       sync.RWMutex
+	deletePlacementGroupArgsForCall []struct {
+		id int
+	}
+	deletePlacementGroupReturns struct {
+		result1 error
+	}
+	deletePlacementGroupReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetInstancePlacementGroupIdStub        func(id int) (int, error)
+	getInstancePlacementGroupIdMutex       sync.RWMutex
+	getInstancePlacementGroupIdArgsForCall []struct {
+		id int
+	}
+	getInstancePlacementGroupIdReturns struct {
+		result1 int
+		result2 error
+	}
+	getInstancePlacementGroupIdReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	IsInstanceReclaimedStub        func(id int) (bool, error)
+	isInstanceReclaimedMutex       sync.RWMutex
+	isInstanceReclaimedArgsForCall []struct {
+		id int
+	}
+	isInstanceReclaimedReturns struct {
+		result1 bool
+		result2 error
+	}
+	isInstanceReclaimedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	VerifyVolumeOrderStub        func(location string, size int, iops int) error
+	verifyVolumeOrderMutex       sync.RWMutex
+	verifyVolumeOrderArgsForCall []struct {
+		location string
+		size     int
+		iops     int
+	}
+	verifyVolumeOrderReturns struct {
+		result1 error
+	}
+	verifyVolumeOrderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DuplicateVolumeStub        func(originVolumeId int, snapshotId int, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error)
+	duplicateVolumeMutex       sync.RWMutex
+	duplicateVolumeArgsForCall []struct {
+		originVolumeId int
+		snapshotId     int
+		location       string
+		size           int
+		iops           int
+		tier           float64
+		snapshotSpace  int
+	}
+	duplicateVolumeReturns struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}
+	duplicateVolumeReturnsOnCall map[int]struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}
+	GetSnapshotSchedulesStub        func(volumeId int) ([]datatypes.Network_Storage_Schedule, error)
+	getSnapshotSchedulesMutex       sync.RWMutex
+	getSnapshotSchedulesArgsForCall []struct {
+		volumeId int
+	}
+	getSnapshotSchedulesReturns struct {
+		result1 []datatypes.Network_Storage_Schedule
+		result2 error
+	}
+	getSnapshotSchedulesReturnsOnCall map[int]struct {
+		result1 []datatypes.Network_Storage_Schedule
+		result2 error
+	}
+	EnableSnapshotStub        func(volumeId int, scheduleType string, retentionCount int, minute int, hour int, dayOfWeek string) error
+	enableSnapshotMutex       sync.RWMutex
+	enableSnapshotArgsForCall []struct {
+		volumeId       int
+		scheduleType   string
+		retentionCount int
+		minute         int
+		hour           int
+		dayOfWeek      string
+	}
+	enableSnapshotReturns struct {
+		result1 error
+	}
+	enableSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DisableSnapshotsStub        func(volumeId int, scheduleType string) error
+	disableSnapshotsMutex       sync.RWMutex
+	disableSnapshotsArgsForCall []struct {
+		volumeId     int
+		scheduleType string
+	}
+	disableSnapshotsReturns struct {
+		result1 error
+	}
+	disableSnapshotsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetSnapshotsStub        func(volumeId int) ([]datatypes.Network_Storage, error)
+	getSnapshotsMutex       sync.RWMutex
+	getSnapshotsArgsForCall []struct {
+		volumeId int
+	}
+	getSnapshotsReturns struct {
+		result1 []datatypes.Network_Storage
+		result2 error
+	}
+	getSnapshotsReturnsOnCall map[int]struct {
+		result1 []datatypes.Network_Storage
+		result2 error
+	}
+	OrderReplicantVolumeStub        func(originVolumeId int, originScheduleId int, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error)
+	orderReplicantVolumeMutex       sync.RWMutex
+	orderReplicantVolumeArgsForCall []struct {
+		originVolumeId   int
+		originScheduleId int
+		location         string
+		size             int
+		iops             int
+		tier             float64
+		snapshotSpace    int
+	}
+	orderReplicantVolumeReturns struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}
+	orderReplicantVolumeReturnsOnCall map[int]struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}
+	CreateVolumeStub        func(location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error)
+	createVolumeMutex       sync.RWMutex
+	createVolumeArgsForCall []struct {
+		location      string
+		size          int
+		iops          int
+		tier          float64
+		snapshotSpace int
+	}
+	createVolumeReturns struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}
+	createVolumeReturnsOnCall map[int]struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}
+	OrderBlockVolume2Stub        func(storageType string, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Container_Product_Order_Receipt, error)
+	orderBlockVolume2Mutex       sync.RWMutex
+	orderBlockVolume2ArgsForCall []struct {
+		storageType   string
+		location      string
+		size          int
+		iops          int
+		tier          float64
+		snapshotSpace int
+	}
+	orderBlockVolume2Returns struct {
+		result1 *datatypes.Container_Product_Order_Receipt
+		result2 error
+	}
+	orderBlockVolume2ReturnsOnCall map[int]struct {
+		result1 *datatypes.Container_Product_Order_Receipt
+		result2 error
+	}
+	SetIpAddressNoteStub        func(id int, note string) error
+	setIpAddressNoteMutex       sync.RWMutex
+	setIpAddressNoteArgsForCall []struct {
+		id   int
+		note string
+	}
+	setIpAddressNoteReturns struct {
+		result1 error
+	}
+	setIpAddressNoteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) CancelInstance(id int) error {
+	fake.cancelInstanceMutex.Lock()
+	ret, specificReturn := fake.cancelInstanceReturnsOnCall[len(fake.cancelInstanceArgsForCall)]
+	fake.cancelInstanceArgsForCall = append(fake.cancelInstanceArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("CancelInstance", []interface{}{id})
+	fake.cancelInstanceMutex.Unlock()
+	if fake.CancelInstanceStub != nil {
+		return fake.CancelInstanceStub(id)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.cancelInstanceReturns.result1
+}
+
+func (fake *FakeClient) CancelInstanceCallCount() int {
+	fake.cancelInstanceMutex.RLock()
+	defer fake.cancelInstanceMutex.RUnlock()
+	return len(fake.cancelInstanceArgsForCall)
+}
+
+func (fake *FakeClient) CancelInstanceArgsForCall(i int) int {
+	fake.cancelInstanceMutex.RLock()
+	defer fake.cancelInstanceMutex.RUnlock()
+	return fake.cancelInstanceArgsForCall[i].id
+}
+
+func (fake *FakeClient) CancelInstanceReturns(result1 error) {
+	fake.CancelInstanceStub = nil
+	fake.cancelInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CancelInstanceReturnsOnCall(i int, result1 error) {
+	fake.CancelInstanceStub = nil
+	if fake.cancelInstanceReturnsOnCall == nil {
+		fake.cancelInstanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cancelInstanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CreateInstance(template *datatypes.Virtual_Guest, options client.InstanceOrderOptions, userData *registry.SoftlayerUserData) (*datatypes.Virtual_Guest, error) {
+	fake.createInstanceMutex.Lock()
+	ret, specificReturn := fake.createInstanceReturnsOnCall[len(fake.createInstanceArgsForCall)]
+	fake.createInstanceArgsForCall = append(fake.createInstanceArgsForCall, struct {
+		template *datatypes.Virtual_Guest
+		options  client.InstanceOrderOptions
+		userData *registry.SoftlayerUserData
+	}{template, options, userData})
+	fake.recordInvocation("CreateInstance", []interface{}{template, options, userData})
+	fake.createInstanceMutex.Unlock()
+	if fake.CreateInstanceStub != nil {
+		return fake.CreateInstanceStub(template, options, userData)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createInstanceReturns.result1, fake.createInstanceReturns.result2
+}
+
+func (fake *FakeClient) CreateInstanceCallCount() int {
+	fake.createInstanceMutex.RLock()
+	defer fake.createInstanceMutex.RUnlock()
+	return len(fake.createInstanceArgsForCall)
+}
+
+func (fake *FakeClient) CreateInstanceArgsForCall(i int) (*datatypes.Virtual_Guest, client.InstanceOrderOptions, *registry.SoftlayerUserData) {
+	fake.createInstanceMutex.RLock()
+	defer fake.createInstanceMutex.RUnlock()
+	return fake.createInstanceArgsForCall[i].template, fake.createInstanceArgsForCall[i].options, fake.createInstanceArgsForCall[i].userData
+}
+
+func (fake *FakeClient) CreateInstanceReturns(result1 *datatypes.Virtual_Guest, result2 error) {
+	fake.CreateInstanceStub = nil
+	fake.createInstanceReturns = struct {
+		result1 *datatypes.Virtual_Guest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CreateInstanceReturnsOnCall(i int, result1 *datatypes.Virtual_Guest, result2 error) {
+	fake.CreateInstanceStub = nil
+	if fake.createInstanceReturnsOnCall == nil {
+		fake.createInstanceReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Virtual_Guest
+			result2 error
+		})
+	}
+	fake.createInstanceReturnsOnCall[i] = struct {
+		result1 *datatypes.Virtual_Guest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) EditInstance(id int, template *datatypes.Virtual_Guest) (bool, error) {
+	fake.editInstanceMutex.Lock()
+	ret, specificReturn := fake.editInstanceReturnsOnCall[len(fake.editInstanceArgsForCall)]
+	fake.editInstanceArgsForCall = append(fake.editInstanceArgsForCall, struct {
+		id       int
+		template *datatypes.Virtual_Guest
+	}{id, template})
+	fake.recordInvocation("EditInstance", []interface{}{id, template})
+	fake.editInstanceMutex.Unlock()
+	if fake.EditInstanceStub != nil {
+		return fake.EditInstanceStub(id, template)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.editInstanceReturns.result1, fake.editInstanceReturns.result2
+}
+
+func (fake *FakeClient) EditInstanceCallCount() int {
+	fake.editInstanceMutex.RLock()
+	defer fake.editInstanceMutex.RUnlock()
+	return len(fake.editInstanceArgsForCall)
+}
+
+func (fake *FakeClient) EditInstanceArgsForCall(i int) (int, *datatypes.Virtual_Guest) {
+	fake.editInstanceMutex.RLock()
+	defer fake.editInstanceMutex.RUnlock()
+	return fake.editInstanceArgsForCall[i].id, fake.editInstanceArgsForCall[i].template
+}
+
+func (fake *FakeClient) EditInstanceReturns(result1 bool, result2 error) {
+	fake.EditInstanceStub = nil
+	fake.editInstanceReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) EditInstanceReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.EditInstanceStub = nil
+	if fake.editInstanceReturnsOnCall == nil {
+		fake.editInstanceReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.editInstanceReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetInstance(id int, mask string) (*datatypes.Virtual_Guest, bool, error) {
+	fake.getInstanceMutex.Lock()
+	ret, specificReturn := fake.getInstanceReturnsOnCall[len(fake.getInstanceArgsForCall)]
+	fake.getInstanceArgsForCall = append(fake.getInstanceArgsForCall, struct {
+		id   int
+		mask string
+	}{id, mask})
+	fake.recordInvocation("GetInstance", []interface{}{id, mask})
+	fake.getInstanceMutex.Unlock()
+	if fake.GetInstanceStub != nil {
+		return fake.GetInstanceStub(id, mask)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getInstanceReturns.result1, fake.getInstanceReturns.result2, fake.getInstanceReturns.result3
+}
+
+func (fake *FakeClient) GetInstanceCallCount() int {
+	fake.getInstanceMutex.RLock()
+	defer fake.getInstanceMutex.RUnlock()
+	return len(fake.getInstanceArgsForCall)
+}
+
+func (fake *FakeClient) GetInstanceArgsForCall(i int) (int, string) {
+	fake.getInstanceMutex.RLock()
+	defer fake.getInstanceMutex.RUnlock()
+	return fake.getInstanceArgsForCall[i].id, fake.getInstanceArgsForCall[i].mask
+}
+
+func (fake *FakeClient) GetInstanceReturns(result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
+	fake.GetInstanceStub = nil
+	fake.getInstanceReturns = struct {
+		result1 *datatypes.Virtual_Guest
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetInstanceReturnsOnCall(i int, result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
+	fake.GetInstanceStub = nil
+	if fake.getInstanceReturnsOnCall == nil {
+		fake.getInstanceReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Virtual_Guest
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getInstanceReturnsOnCall[i] = struct {
+		result1 *datatypes.Virtual_Guest
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetInstanceByPrimaryBackendIpAddress(ip string) (*datatypes.Virtual_Guest, bool, error) {
+	fake.getInstanceByPrimaryBackendIpAddressMutex.Lock()
+	ret, specificReturn := fake.getInstanceByPrimaryBackendIpAddressReturnsOnCall[len(fake.getInstanceByPrimaryBackendIpAddressArgsForCall)]
+	fake.getInstanceByPrimaryBackendIpAddressArgsForCall = append(fake.getInstanceByPrimaryBackendIpAddressArgsForCall, struct {
+		ip string
+	}{ip})
+	fake.recordInvocation("GetInstanceByPrimaryBackendIpAddress", []interface{}{ip})
+	fake.getInstanceByPrimaryBackendIpAddressMutex.Unlock()
+	if fake.GetInstanceByPrimaryBackendIpAddressStub != nil {
+		return fake.GetInstanceByPrimaryBackendIpAddressStub(ip)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
 	}
 	return fake.getInstanceByPrimaryBackendIpAddressReturns.result1, fake.getInstanceByPrimaryBackendIpAddressReturns.result2, fake.getInstanceByPrimaryBackendIpAddressReturns.result3
 }
 
-func (fake *FakeClient) GetInstanceByPrimaryBackendIpAddressCallCount() int {
-	fake.getInstanceByPrimaryBackendIpAddressMutex.RLock()
-	defer fake.getInstanceByPrimaryBackendIpAddressMutex.RUnlock()
-	return len(fake.getInstanceByPrimaryBackendIpAddressArgsForCall)
+func (fake *FakeClient) GetInstanceByPrimaryBackendIpAddressCallCount() int {
+	fake.getInstanceByPrimaryBackendIpAddressMutex.RLock()
+	defer fake.getInstanceByPrimaryBackendIpAddressMutex.RUnlock()
+	return len(fake.getInstanceByPrimaryBackendIpAddressArgsForCall)
+}
+
+func (fake *FakeClient) GetInstanceByPrimaryBackendIpAddressArgsForCall(i int) string {
+	fake.getInstanceByPrimaryBackendIpAddressMutex.RLock()
+	defer fake.getInstanceByPrimaryBackendIpAddressMutex.RUnlock()
+	return fake.getInstanceByPrimaryBackendIpAddressArgsForCall[i].ip
+}
+
+func (fake *FakeClient) GetInstanceByPrimaryBackendIpAddressReturns(result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
+	fake.GetInstanceByPrimaryBackendIpAddressStub = nil
+	fake.getInstanceByPrimaryBackendIpAddressReturns = struct {
+		result1 *datatypes.Virtual_Guest
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetInstanceByPrimaryBackendIpAddressReturnsOnCall(i int, result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
+	fake.GetInstanceByPrimaryBackendIpAddressStub = nil
+	if fake.getInstanceByPrimaryBackendIpAddressReturnsOnCall == nil {
+		fake.getInstanceByPrimaryBackendIpAddressReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Virtual_Guest
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getInstanceByPrimaryBackendIpAddressReturnsOnCall[i] = struct {
+		result1 *datatypes.Virtual_Guest
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetInstanceByPrimaryIpAddress(ip string) (*datatypes.Virtual_Guest, bool, error) {
+	fake.getInstanceByPrimaryIpAddressMutex.Lock()
+	ret, specificReturn := fake.getInstanceByPrimaryIpAddressReturnsOnCall[len(fake.getInstanceByPrimaryIpAddressArgsForCall)]
+	fake.getInstanceByPrimaryIpAddressArgsForCall = append(fake.getInstanceByPrimaryIpAddressArgsForCall, struct {
+		ip string
+	}{ip})
+	fake.recordInvocation("GetInstanceByPrimaryIpAddress", []interface{}{ip})
+	fake.getInstanceByPrimaryIpAddressMutex.Unlock()
+	if fake.GetInstanceByPrimaryIpAddressStub != nil {
+		return fake.GetInstanceByPrimaryIpAddressStub(ip)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getInstanceByPrimaryIpAddressReturns.result1, fake.getInstanceByPrimaryIpAddressReturns.result2, fake.getInstanceByPrimaryIpAddressReturns.result3
+}
+
+func (fake *FakeClient) GetInstanceByPrimaryIpAddressCallCount() int {
+	fake.getInstanceByPrimaryIpAddressMutex.RLock()
+	defer fake.getInstanceByPrimaryIpAddressMutex.RUnlock()
+	return len(fake.getInstanceByPrimaryIpAddressArgsForCall)
+}
+
+func (fake *FakeClient) GetInstanceByPrimaryIpAddressArgsForCall(i int) string {
+	fake.getInstanceByPrimaryIpAddressMutex.RLock()
+	defer fake.getInstanceByPrimaryIpAddressMutex.RUnlock()
+	return fake.getInstanceByPrimaryIpAddressArgsForCall[i].ip
+}
+
+func (fake *FakeClient) GetInstanceByPrimaryIpAddressReturns(result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
+	fake.GetInstanceByPrimaryIpAddressStub = nil
+	fake.getInstanceByPrimaryIpAddressReturns = struct {
+		result1 *datatypes.Virtual_Guest
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetInstanceByPrimaryIpAddressReturnsOnCall(i int, result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
+	fake.GetInstanceByPrimaryIpAddressStub = nil
+	if fake.getInstanceByPrimaryIpAddressReturnsOnCall == nil {
+		fake.getInstanceByPrimaryIpAddressReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Virtual_Guest
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getInstanceByPrimaryIpAddressReturnsOnCall[i] = struct {
+		result1 *datatypes.Virtual_Guest
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) RebootInstance(id int, soft bool, hard bool) error {
+	fake.rebootInstanceMutex.Lock()
+	ret, specificReturn := fake.rebootInstanceReturnsOnCall[len(fake.rebootInstanceArgsForCall)]
+	fake.rebootInstanceArgsForCall = append(fake.rebootInstanceArgsForCall, struct {
+		id   int
+		soft bool
+		hard bool
+	}{id, soft, hard})
+	fake.recordInvocation("RebootInstance", []interface{}{id, soft, hard})
+	fake.rebootInstanceMutex.Unlock()
+	if fake.RebootInstanceStub != nil {
+		return fake.RebootInstanceStub(id, soft, hard)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.rebootInstanceReturns.result1
+}
+
+func (fake *FakeClient) RebootInstanceCallCount() int {
+	fake.rebootInstanceMutex.RLock()
+	defer fake.rebootInstanceMutex.RUnlock()
+	return len(fake.rebootInstanceArgsForCall)
+}
+
+func (fake *FakeClient) RebootInstanceArgsForCall(i int) (int, bool, bool) {
+	fake.rebootInstanceMutex.RLock()
+	defer fake.rebootInstanceMutex.RUnlock()
+	return fake.rebootInstanceArgsForCall[i].id, fake.rebootInstanceArgsForCall[i].soft, fake.rebootInstanceArgsForCall[i].hard
+}
+
+func (fake *FakeClient) RebootInstanceReturns(result1 error) {
+	fake.RebootInstanceStub = nil
+	fake.rebootInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) RebootInstanceReturnsOnCall(i int, result1 error) {
+	fake.RebootInstanceStub = nil
+	if fake.rebootInstanceReturnsOnCall == nil {
+		fake.rebootInstanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rebootInstanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ReloadInstance(id int, stemcellId int, sshKeyIds []int, hostname string, domain string, userData *registry.SoftlayerUserData) error {
+	var sshKeyIdsCopy []int
+	if sshKeyIds != nil {
+		sshKeyIdsCopy = make([]int, len(sshKeyIds))
+		copy(sshKeyIdsCopy, sshKeyIds)
+	}
+	fake.reloadInstanceMutex.Lock()
+	ret, specificReturn := fake.reloadInstanceReturnsOnCall[len(fake.reloadInstanceArgsForCall)]
+	fake.reloadInstanceArgsForCall = append(fake.reloadInstanceArgsForCall, struct {
+		id         int
+		stemcellId int
+		sshKeyIds  []int
+		hostname   string
+		domain     string
+		userData   *registry.SoftlayerUserData
+	}{id, stemcellId, sshKeyIdsCopy, hostname, domain, userData})
+	fake.recordInvocation("ReloadInstance", []interface{}{id, stemcellId, sshKeyIdsCopy, hostname, domain, userData})
+	fake.reloadInstanceMutex.Unlock()
+	if fake.ReloadInstanceStub != nil {
+		return fake.ReloadInstanceStub(id, stemcellId, sshKeyIds, hostname, domain, userData)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.reloadInstanceReturns.result1
+}
+
+func (fake *FakeClient) ReloadInstanceCallCount() int {
+	fake.reloadInstanceMutex.RLock()
+	defer fake.reloadInstanceMutex.RUnlock()
+	return len(fake.reloadInstanceArgsForCall)
+}
+
+func (fake *FakeClient) ReloadInstanceArgsForCall(i int) (int, int, []int, string, string, *registry.SoftlayerUserData) {
+	fake.reloadInstanceMutex.RLock()
+	defer fake.reloadInstanceMutex.RUnlock()
+	return fake.reloadInstanceArgsForCall[i].id, fake.reloadInstanceArgsForCall[i].stemcellId, fake.reloadInstanceArgsForCall[i].sshKeyIds, fake.reloadInstanceArgsForCall[i].hostname, fake.reloadInstanceArgsForCall[i].domain, fake.reloadInstanceArgsForCall[i].userData
+}
+
+func (fake *FakeClient) ReloadInstanceReturns(result1 error) {
+	fake.ReloadInstanceStub = nil
+	fake.reloadInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ReloadInstanceReturnsOnCall(i int, result1 error) {
+	fake.ReloadInstanceStub = nil
+	if fake.reloadInstanceReturnsOnCall == nil {
+		fake.reloadInstanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reloadInstanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpgradeInstanceConfig(id int, cpu int, memory int, network int, privateCPU bool, dedicatedHost bool) error {
+	fake.upgradeInstanceConfigMutex.Lock()
+	ret, specificReturn := fake.upgradeInstanceConfigReturnsOnCall[len(fake.upgradeInstanceConfigArgsForCall)]
+	fake.upgradeInstanceConfigArgsForCall = append(fake.upgradeInstanceConfigArgsForCall, struct {
+		id            int
+		cpu           int
+		memory        int
+		network       int
+		privateCPU    bool
+		dedicatedHost bool
+	}{id, cpu, memory, network, privateCPU, dedicatedHost})
+	fake.recordInvocation("UpgradeInstanceConfig", []interface{}{id, cpu, memory, network, privateCPU, dedicatedHost})
+	fake.upgradeInstanceConfigMutex.Unlock()
+	if fake.UpgradeInstanceConfigStub != nil {
+		return fake.UpgradeInstanceConfigStub(id, cpu, memory, network, privateCPU, dedicatedHost)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.upgradeInstanceConfigReturns.result1
+}
+
+func (fake *FakeClient) UpgradeInstanceConfigCallCount() int {
+	fake.upgradeInstanceConfigMutex.RLock()
+	defer fake.upgradeInstanceConfigMutex.RUnlock()
+	return len(fake.upgradeInstanceConfigArgsForCall)
+}
+
+func (fake *FakeClient) UpgradeInstanceConfigArgsForCall(i int) (int, int, int, int, bool, bool) {
+	fake.upgradeInstanceConfigMutex.RLock()
+	defer fake.upgradeInstanceConfigMutex.RUnlock()
+	return fake.upgradeInstanceConfigArgsForCall[i].id, fake.upgradeInstanceConfigArgsForCall[i].cpu, fake.upgradeInstanceConfigArgsForCall[i].memory, fake.upgradeInstanceConfigArgsForCall[i].network, fake.upgradeInstanceConfigArgsForCall[i].privateCPU, fake.upgradeInstanceConfigArgsForCall[i].dedicatedHost
+}
+
+func (fake *FakeClient) UpgradeInstanceConfigReturns(result1 error) {
+	fake.UpgradeInstanceConfigStub = nil
+	fake.upgradeInstanceConfigReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpgradeInstanceConfigReturnsOnCall(i int, result1 error) {
+	fake.UpgradeInstanceConfigStub = nil
+	if fake.upgradeInstanceConfigReturnsOnCall == nil {
+		fake.upgradeInstanceConfigReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.upgradeInstanceConfigReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpgradeInstance(id int, cpu int, memory int, network int, privateCPU bool, dedicatedHost bool, secondDiskSize int) (int, error) {
+	fake.upgradeInstanceMutex.Lock()
+	ret, specificReturn := fake.upgradeInstanceReturnsOnCall[len(fake.upgradeInstanceArgsForCall)]
+	fake.upgradeInstanceArgsForCall = append(fake.upgradeInstanceArgsForCall, struct {
+		id             int
+		cpu            int
+		memory         int
+		network        int
+		privateCPU     bool
+		dedicatedHost  bool
+		secondDiskSize int
+	}{id, cpu, memory, network, privateCPU, dedicatedHost, secondDiskSize})
+	fake.recordInvocation("UpgradeInstance", []interface{}{id, cpu, memory, network, privateCPU, dedicatedHost, secondDiskSize})
+	fake.upgradeInstanceMutex.Unlock()
+	if fake.UpgradeInstanceStub != nil {
+		return fake.UpgradeInstanceStub(id, cpu, memory, network, privateCPU, dedicatedHost, secondDiskSize)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.upgradeInstanceReturns.result1, fake.upgradeInstanceReturns.result2
+}
+
+func (fake *FakeClient) UpgradeInstanceCallCount() int {
+	fake.upgradeInstanceMutex.RLock()
+	defer fake.upgradeInstanceMutex.RUnlock()
+	return len(fake.upgradeInstanceArgsForCall)
+}
+
+func (fake *FakeClient) UpgradeInstanceArgsForCall(i int) (int, int, int, int, bool, bool, int) {
+	fake.upgradeInstanceMutex.RLock()
+	defer fake.upgradeInstanceMutex.RUnlock()
+	return fake.upgradeInstanceArgsForCall[i].id, fake.upgradeInstanceArgsForCall[i].cpu, fake.upgradeInstanceArgsForCall[i].memory, fake.upgradeInstanceArgsForCall[i].network, fake.upgradeInstanceArgsForCall[i].privateCPU, fake.upgradeInstanceArgsForCall[i].dedicatedHost, fake.upgradeInstanceArgsForCall[i].secondDiskSize
+}
+
+func (fake *FakeClient) UpgradeInstanceReturns(result1 int, result2 error) {
+	fake.UpgradeInstanceStub = nil
+	fake.upgradeInstanceReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) UpgradeInstanceReturnsOnCall(i int, result1 int, result2 error) {
+	fake.UpgradeInstanceStub = nil
+	if fake.upgradeInstanceReturnsOnCall == nil {
+		fake.upgradeInstanceReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.upgradeInstanceReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) WaitInstanceUntilReady(id int, until time.Time) error {
+	fake.waitInstanceUntilReadyMutex.Lock()
+	ret, specificReturn := fake.waitInstanceUntilReadyReturnsOnCall[len(fake.waitInstanceUntilReadyArgsForCall)]
+	fake.waitInstanceUntilReadyArgsForCall = append(fake.waitInstanceUntilReadyArgsForCall, struct {
+		id    int
+		until time.Time
+	}{id, until})
+	fake.recordInvocation("WaitInstanceUntilReady", []interface{}{id, until})
+	fake.waitInstanceUntilReadyMutex.Unlock()
+	if fake.WaitInstanceUntilReadyStub != nil {
+		return fake.WaitInstanceUntilReadyStub(id, until)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.waitInstanceUntilReadyReturns.result1
+}
+
+func (fake *FakeClient) WaitInstanceUntilReadyCallCount() int {
+	fake.waitInstanceUntilReadyMutex.RLock()
+	defer fake.waitInstanceUntilReadyMutex.RUnlock()
+	return len(fake.waitInstanceUntilReadyArgsForCall)
+}
+
+func (fake *FakeClient) WaitInstanceUntilReadyArgsForCall(i int) (int, time.Time) {
+	fake.waitInstanceUntilReadyMutex.RLock()
+	defer fake.waitInstanceUntilReadyMutex.RUnlock()
+	return fake.waitInstanceUntilReadyArgsForCall[i].id, fake.waitInstanceUntilReadyArgsForCall[i].until
+}
+
+func (fake *FakeClient) WaitInstanceUntilReadyReturns(result1 error) {
+	fake.WaitInstanceUntilReadyStub = nil
+	fake.waitInstanceUntilReadyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) WaitInstanceUntilReadyReturnsOnCall(i int, result1 error) {
+	fake.WaitInstanceUntilReadyStub = nil
+	if fake.waitInstanceUntilReadyReturnsOnCall == nil {
+		fake.waitInstanceUntilReadyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitInstanceUntilReadyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) WaitInstanceUntilReadyWithTicket(id int, until time.Time) error {
+	fake.waitInstanceUntilReadyWithTicketMutex.Lock()
+	ret, specificReturn := fake.waitInstanceUntilReadyWithTicketReturnsOnCall[len(fake.waitInstanceUntilReadyWithTicketArgsForCall)]
+	fake.waitInstanceUntilReadyWithTicketArgsForCall = append(fake.waitInstanceUntilReadyWithTicketArgsForCall, struct {
+		id    int
+		until time.Time
+	}{id, until})
+	fake.recordInvocation("WaitInstanceUntilReadyWithTicket", []interface{}{id, until})
+	fake.waitInstanceUntilReadyWithTicketMutex.Unlock()
+	if fake.WaitInstanceUntilReadyWithTicketStub != nil {
+		return fake.WaitInstanceUntilReadyWithTicketStub(id, until)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.waitInstanceUntilReadyWithTicketReturns.result1
+}
+
+func (fake *FakeClient) WaitInstanceUntilReadyWithTicketCallCount() int {
+	fake.waitInstanceUntilReadyWithTicketMutex.RLock()
+	defer fake.waitInstanceUntilReadyWithTicketMutex.RUnlock()
+	return len(fake.waitInstanceUntilReadyWithTicketArgsForCall)
+}
+
+func (fake *FakeClient) WaitInstanceUntilReadyWithTicketArgsForCall(i int) (int, time.Time) {
+	fake.waitInstanceUntilReadyWithTicketMutex.RLock()
+	defer fake.waitInstanceUntilReadyWithTicketMutex.RUnlock()
+	return fake.waitInstanceUntilReadyWithTicketArgsForCall[i].id, fake.waitInstanceUntilReadyWithTicketArgsForCall[i].until
+}
+
+func (fake *FakeClient) WaitInstanceUntilReadyWithTicketReturns(result1 error) {
+	fake.WaitInstanceUntilReadyWithTicketStub = nil
+	fake.waitInstanceUntilReadyWithTicketReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) WaitInstanceUntilReadyWithTicketReturnsOnCall(i int, result1 error) {
+	fake.WaitInstanceUntilReadyWithTicketStub = nil
+	if fake.waitInstanceUntilReadyWithTicketReturnsOnCall == nil {
+		fake.waitInstanceUntilReadyWithTicketReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitInstanceUntilReadyWithTicketReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) WaitInstanceHasActiveTransaction(id int, until time.Time) error {
+	fake.waitInstanceHasActiveTransactionMutex.Lock()
+	ret, specificReturn := fake.waitInstanceHasActiveTransactionReturnsOnCall[len(fake.waitInstanceHasActiveTransactionArgsForCall)]
+	fake.waitInstanceHasActiveTransactionArgsForCall = append(fake.waitInstanceHasActiveTransactionArgsForCall, struct {
+		id    int
+		until time.Time
+	}{id, until})
+	fake.recordInvocation("WaitInstanceHasActiveTransaction", []interface{}{id, until})
+	fake.waitInstanceHasActiveTransactionMutex.Unlock()
+	if fake.WaitInstanceHasActiveTransactionStub != nil {
+		return fake.WaitInstanceHasActiveTransactionStub(id, until)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.waitInstanceHasActiveTransactionReturns.result1
+}
+
+func (fake *FakeClient) WaitInstanceHasActiveTransactionCallCount() int {
+	fake.waitInstanceHasActiveTransactionMutex.RLock()
+	defer fake.waitInstanceHasActiveTransactionMutex.RUnlock()
+	return len(fake.waitInstanceHasActiveTransactionArgsForCall)
+}
+
+func (fake *FakeClient) WaitInstanceHasActiveTransactionArgsForCall(i int) (int, time.Time) {
+	fake.waitInstanceHasActiveTransactionMutex.RLock()
+	defer fake.waitInstanceHasActiveTransactionMutex.RUnlock()
+	return fake.waitInstanceHasActiveTransactionArgsForCall[i].id, fake.waitInstanceHasActiveTransactionArgsForCall[i].until
+}
+
+func (fake *FakeClient) WaitInstanceHasActiveTransactionReturns(result1 error) {
+	fake.WaitInstanceHasActiveTransactionStub = nil
+	fake.waitInstanceHasActiveTransactionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) WaitInstanceHasActiveTransactionReturnsOnCall(i int, result1 error) {
+	fake.WaitInstanceHasActiveTransactionStub = nil
+	if fake.waitInstanceHasActiveTransactionReturnsOnCall == nil {
+		fake.waitInstanceHasActiveTransactionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitInstanceHasActiveTransactionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) WaitInstanceHasNoneActiveTransaction(id int, until time.Time) error {
+	fake.waitInstanceHasNoneActiveTransactionMutex.Lock()
+	ret, specificReturn := fake.waitInstanceHasNoneActiveTransactionReturnsOnCall[len(fake.waitInstanceHasNoneActiveTransactionArgsForCall)]
+	fake.waitInstanceHasNoneActiveTransactionArgsForCall = append(fake.waitInstanceHasNoneActiveTransactionArgsForCall, struct {
+		id    int
+		until time.Time
+	}{id, until})
+	fake.recordInvocation("WaitInstanceHasNoneActiveTransaction", []interface{}{id, until})
+	fake.waitInstanceHasNoneActiveTransactionMutex.Unlock()
+	if fake.WaitInstanceHasNoneActiveTransactionStub != nil {
+		return fake.WaitInstanceHasNoneActiveTransactionStub(id, until)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.waitInstanceHasNoneActiveTransactionReturns.result1
+}
+
+func (fake *FakeClient) WaitInstanceHasNoneActiveTransactionCallCount() int {
+	fake.waitInstanceHasNoneActiveTransactionMutex.RLock()
+	defer fake.waitInstanceHasNoneActiveTransactionMutex.RUnlock()
+	return len(fake.waitInstanceHasNoneActiveTransactionArgsForCall)
+}
+
+func (fake *FakeClient) WaitInstanceHasNoneActiveTransactionArgsForCall(i int) (int, time.Time) {
+	fake.waitInstanceHasNoneActiveTransactionMutex.RLock()
+	defer fake.waitInstanceHasNoneActiveTransactionMutex.RUnlock()
+	return fake.waitInstanceHasNoneActiveTransactionArgsForCall[i].id, fake.waitInstanceHasNoneActiveTransactionArgsForCall[i].until
+}
+
+func (fake *FakeClient) WaitInstanceHasNoneActiveTransactionReturns(result1 error) {
+	fake.WaitInstanceHasNoneActiveTransactionStub = nil
+	fake.waitInstanceHasNoneActiveTransactionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) WaitInstanceHasNoneActiveTransactionReturnsOnCall(i int, result1 error) {
+	fake.WaitInstanceHasNoneActiveTransactionStub = nil
+	if fake.waitInstanceHasNoneActiveTransactionReturnsOnCall == nil {
+		fake.waitInstanceHasNoneActiveTransactionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitInstanceHasNoneActiveTransactionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) WaitVolumeProvisioningWithOrderId(orderId int, until time.Time) (*datatypes.Network_Storage, error) {
+	fake.waitVolumeProvisioningWithOrderIdMutex.Lock()
+	ret, specificReturn := fake.waitVolumeProvisioningWithOrderIdReturnsOnCall[len(fake.waitVolumeProvisioningWithOrderIdArgsForCall)]
+	fake.waitVolumeProvisioningWithOrderIdArgsForCall = append(fake.waitVolumeProvisioningWithOrderIdArgsForCall, struct {
+		orderId int
+		until   time.Time
+	}{orderId, until})
+	fake.recordInvocation("WaitVolumeProvisioningWithOrderId", []interface{}{orderId, until})
+	fake.waitVolumeProvisioningWithOrderIdMutex.Unlock()
+	if fake.WaitVolumeProvisioningWithOrderIdStub != nil {
+		return fake.WaitVolumeProvisioningWithOrderIdStub(orderId, until)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.waitVolumeProvisioningWithOrderIdReturns.result1, fake.waitVolumeProvisioningWithOrderIdReturns.result2
+}
+
+func (fake *FakeClient) WaitVolumeProvisioningWithOrderIdCallCount() int {
+	fake.waitVolumeProvisioningWithOrderIdMutex.RLock()
+	defer fake.waitVolumeProvisioningWithOrderIdMutex.RUnlock()
+	return len(fake.waitVolumeProvisioningWithOrderIdArgsForCall)
+}
+
+func (fake *FakeClient) WaitVolumeProvisioningWithOrderIdArgsForCall(i int) (int, time.Time) {
+	fake.waitVolumeProvisioningWithOrderIdMutex.RLock()
+	defer fake.waitVolumeProvisioningWithOrderIdMutex.RUnlock()
+	return fake.waitVolumeProvisioningWithOrderIdArgsForCall[i].orderId, fake.waitVolumeProvisioningWithOrderIdArgsForCall[i].until
+}
+
+func (fake *FakeClient) WaitVolumeProvisioningWithOrderIdReturns(result1 *datatypes.Network_Storage, result2 error) {
+	fake.WaitVolumeProvisioningWithOrderIdStub = nil
+	fake.waitVolumeProvisioningWithOrderIdReturns = struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) WaitVolumeProvisioningWithOrderIdReturnsOnCall(i int, result1 *datatypes.Network_Storage, result2 error) {
+	fake.WaitVolumeProvisioningWithOrderIdStub = nil
+	if fake.waitVolumeProvisioningWithOrderIdReturnsOnCall == nil {
+		fake.waitVolumeProvisioningWithOrderIdReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_Storage
+			result2 error
+		})
+	}
+	fake.waitVolumeProvisioningWithOrderIdReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SetTags(id int, tags string) (bool, error) {
+	fake.setTagsMutex.Lock()
+	ret, specificReturn := fake.setTagsReturnsOnCall[len(fake.setTagsArgsForCall)]
+	fake.setTagsArgsForCall = append(fake.setTagsArgsForCall, struct {
+		id   int
+		tags string
+	}{id, tags})
+	fake.recordInvocation("SetTags", []interface{}{id, tags})
+	fake.setTagsMutex.Unlock()
+	if fake.SetTagsStub != nil {
+		return fake.SetTagsStub(id, tags)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.setTagsReturns.result1, fake.setTagsReturns.result2
+}
+
+func (fake *FakeClient) SetTagsCallCount() int {
+	fake.setTagsMutex.RLock()
+	defer fake.setTagsMutex.RUnlock()
+	return len(fake.setTagsArgsForCall)
+}
+
+func (fake *FakeClient) SetTagsArgsForCall(i int) (int, string) {
+	fake.setTagsMutex.RLock()
+	defer fake.setTagsMutex.RUnlock()
+	return fake.setTagsArgsForCall[i].id, fake.setTagsArgsForCall[i].tags
+}
+
+func (fake *FakeClient) SetTagsReturns(result1 bool, result2 error) {
+	fake.SetTagsStub = nil
+	fake.setTagsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SetTagsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.SetTagsStub = nil
+	if fake.setTagsReturnsOnCall == nil {
+		fake.setTagsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.setTagsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SetInstanceMetadata(id int, encodedUserData *string) (bool, error) {
+	fake.setInstanceMetadataMutex.Lock()
+	ret, specificReturn := fake.setInstanceMetadataReturnsOnCall[len(fake.setInstanceMetadataArgsForCall)]
+	fake.setInstanceMetadataArgsForCall = append(fake.setInstanceMetadataArgsForCall, struct {
+		id              int
+		encodedUserData *string
+	}{id, encodedUserData})
+	fake.recordInvocation("SetInstanceMetadata", []interface{}{id, encodedUserData})
+	fake.setInstanceMetadataMutex.Unlock()
+	if fake.SetInstanceMetadataStub != nil {
+		return fake.SetInstanceMetadataStub(id, encodedUserData)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.setInstanceMetadataReturns.result1, fake.setInstanceMetadataReturns.result2
+}
+
+func (fake *FakeClient) SetInstanceMetadataCallCount() int {
+	fake.setInstanceMetadataMutex.RLock()
+	defer fake.setInstanceMetadataMutex.RUnlock()
+	return len(fake.setInstanceMetadataArgsForCall)
+}
+
+func (fake *FakeClient) SetInstanceMetadataArgsForCall(i int) (int, *string) {
+	fake.setInstanceMetadataMutex.RLock()
+	defer fake.setInstanceMetadataMutex.RUnlock()
+	return fake.setInstanceMetadataArgsForCall[i].id, fake.setInstanceMetadataArgsForCall[i].encodedUserData
+}
+
+func (fake *FakeClient) SetInstanceMetadataReturns(result1 bool, result2 error) {
+	fake.SetInstanceMetadataStub = nil
+	fake.setInstanceMetadataReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SetInstanceMetadataReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.SetInstanceMetadataStub = nil
+	if fake.setInstanceMetadataReturnsOnCall == nil {
+		fake.setInstanceMetadataReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.setInstanceMetadataReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) AttachSecondDiskToInstance(id int, diskSize int) error {
+	fake.attachSecondDiskToInstanceMutex.Lock()
+	ret, specificReturn := fake.attachSecondDiskToInstanceReturnsOnCall[len(fake.attachSecondDiskToInstanceArgsForCall)]
+	fake.attachSecondDiskToInstanceArgsForCall = append(fake.attachSecondDiskToInstanceArgsForCall, struct {
+		id       int
+		diskSize int
+	}{id, diskSize})
+	fake.recordInvocation("AttachSecondDiskToInstance", []interface{}{id, diskSize})
+	fake.attachSecondDiskToInstanceMutex.Unlock()
+	if fake.AttachSecondDiskToInstanceStub != nil {
+		return fake.AttachSecondDiskToInstanceStub(id, diskSize)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.attachSecondDiskToInstanceReturns.result1
+}
+
+func (fake *FakeClient) AttachSecondDiskToInstanceCallCount() int {
+	fake.attachSecondDiskToInstanceMutex.RLock()
+	defer fake.attachSecondDiskToInstanceMutex.RUnlock()
+	return len(fake.attachSecondDiskToInstanceArgsForCall)
+}
+
+func (fake *FakeClient) AttachSecondDiskToInstanceArgsForCall(i int) (int, int) {
+	fake.attachSecondDiskToInstanceMutex.RLock()
+	defer fake.attachSecondDiskToInstanceMutex.RUnlock()
+	return fake.attachSecondDiskToInstanceArgsForCall[i].id, fake.attachSecondDiskToInstanceArgsForCall[i].diskSize
+}
+
+func (fake *FakeClient) AttachSecondDiskToInstanceReturns(result1 error) {
+	fake.AttachSecondDiskToInstanceStub = nil
+	fake.attachSecondDiskToInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) AttachSecondDiskToInstanceReturnsOnCall(i int, result1 error) {
+	fake.AttachSecondDiskToInstanceStub = nil
+	if fake.attachSecondDiskToInstanceReturnsOnCall == nil {
+		fake.attachSecondDiskToInstanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.attachSecondDiskToInstanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) GetInstanceAllowedHost(id int) (*datatypes.Network_Storage_Allowed_Host, bool, error) {
+	fake.getInstanceAllowedHostMutex.Lock()
+	ret, specificReturn := fake.getInstanceAllowedHostReturnsOnCall[len(fake.getInstanceAllowedHostArgsForCall)]
+	fake.getInstanceAllowedHostArgsForCall = append(fake.getInstanceAllowedHostArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("GetInstanceAllowedHost", []interface{}{id})
+	fake.getInstanceAllowedHostMutex.Unlock()
+	if fake.GetInstanceAllowedHostStub != nil {
+		return fake.GetInstanceAllowedHostStub(id)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getInstanceAllowedHostReturns.result1, fake.getInstanceAllowedHostReturns.result2, fake.getInstanceAllowedHostReturns.result3
+}
+
+func (fake *FakeClient) GetInstanceAllowedHostCallCount() int {
+	fake.getInstanceAllowedHostMutex.RLock()
+	defer fake.getInstanceAllowedHostMutex.RUnlock()
+	return len(fake.getInstanceAllowedHostArgsForCall)
+}
+
+func (fake *FakeClient) GetInstanceAllowedHostArgsForCall(i int) int {
+	fake.getInstanceAllowedHostMutex.RLock()
+	defer fake.getInstanceAllowedHostMutex.RUnlock()
+	return fake.getInstanceAllowedHostArgsForCall[i].id
+}
+
+func (fake *FakeClient) GetInstanceAllowedHostReturns(result1 *datatypes.Network_Storage_Allowed_Host, result2 bool, result3 error) {
+	fake.GetInstanceAllowedHostStub = nil
+	fake.getInstanceAllowedHostReturns = struct {
+		result1 *datatypes.Network_Storage_Allowed_Host
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetInstanceAllowedHostReturnsOnCall(i int, result1 *datatypes.Network_Storage_Allowed_Host, result2 bool, result3 error) {
+	fake.GetInstanceAllowedHostStub = nil
+	if fake.getInstanceAllowedHostReturnsOnCall == nil {
+		fake.getInstanceAllowedHostReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_Storage_Allowed_Host
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getInstanceAllowedHostReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_Storage_Allowed_Host
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) AuthorizeHostToVolume(instance *datatypes.Virtual_Guest, volumeId int, until time.Time) (bool, error) {
+	fake.authorizeHostToVolumeMutex.Lock()
+	ret, specificReturn := fake.authorizeHostToVolumeReturnsOnCall[len(fake.authorizeHostToVolumeArgsForCall)]
+	fake.authorizeHostToVolumeArgsForCall = append(fake.authorizeHostToVolumeArgsForCall, struct {
+		instance *datatypes.Virtual_Guest
+		volumeId int
+		until    time.Time
+	}{instance, volumeId, until})
+	fake.recordInvocation("AuthorizeHostToVolume", []interface{}{instance, volumeId, until})
+	fake.authorizeHostToVolumeMutex.Unlock()
+	if fake.AuthorizeHostToVolumeStub != nil {
+		return fake.AuthorizeHostToVolumeStub(instance, volumeId, until)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.authorizeHostToVolumeReturns.result1, fake.authorizeHostToVolumeReturns.result2
+}
+
+func (fake *FakeClient) AuthorizeHostToVolumeCallCount() int {
+	fake.authorizeHostToVolumeMutex.RLock()
+	defer fake.authorizeHostToVolumeMutex.RUnlock()
+	return len(fake.authorizeHostToVolumeArgsForCall)
+}
+
+func (fake *FakeClient) AuthorizeHostToVolumeArgsForCall(i int) (*datatypes.Virtual_Guest, int, time.Time) {
+	fake.authorizeHostToVolumeMutex.RLock()
+	defer fake.authorizeHostToVolumeMutex.RUnlock()
+	return fake.authorizeHostToVolumeArgsForCall[i].instance, fake.authorizeHostToVolumeArgsForCall[i].volumeId, fake.authorizeHostToVolumeArgsForCall[i].until
+}
+
+func (fake *FakeClient) AuthorizeHostToVolumeReturns(result1 bool, result2 error) {
+	fake.AuthorizeHostToVolumeStub = nil
+	fake.authorizeHostToVolumeReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) AuthorizeHostToVolumeReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.AuthorizeHostToVolumeStub = nil
+	if fake.authorizeHostToVolumeReturnsOnCall == nil {
+		fake.authorizeHostToVolumeReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.authorizeHostToVolumeReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DeauthorizeHostToVolume(instance *datatypes.Virtual_Guest, volumeId int, until time.Time) (bool, error) {
+	fake.deauthorizeHostToVolumeMutex.Lock()
+	ret, specificReturn := fake.deauthorizeHostToVolumeReturnsOnCall[len(fake.deauthorizeHostToVolumeArgsForCall)]
+	fake.deauthorizeHostToVolumeArgsForCall = append(fake.deauthorizeHostToVolumeArgsForCall, struct {
+		instance *datatypes.Virtual_Guest
+		volumeId int
+		until    time.Time
+	}{instance, volumeId, until})
+	fake.recordInvocation("DeauthorizeHostToVolume", []interface{}{instance, volumeId, until})
+	fake.deauthorizeHostToVolumeMutex.Unlock()
+	if fake.DeauthorizeHostToVolumeStub != nil {
+		return fake.DeauthorizeHostToVolumeStub(instance, volumeId, until)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.deauthorizeHostToVolumeReturns.result1, fake.deauthorizeHostToVolumeReturns.result2
+}
+
+func (fake *FakeClient) DeauthorizeHostToVolumeCallCount() int {
+	fake.deauthorizeHostToVolumeMutex.RLock()
+	defer fake.deauthorizeHostToVolumeMutex.RUnlock()
+	return len(fake.deauthorizeHostToVolumeArgsForCall)
+}
+
+func (fake *FakeClient) DeauthorizeHostToVolumeArgsForCall(i int) (*datatypes.Virtual_Guest, int, time.Time) {
+	fake.deauthorizeHostToVolumeMutex.RLock()
+	defer fake.deauthorizeHostToVolumeMutex.RUnlock()
+	return fake.deauthorizeHostToVolumeArgsForCall[i].instance, fake.deauthorizeHostToVolumeArgsForCall[i].volumeId, fake.deauthorizeHostToVolumeArgsForCall[i].until
+}
+
+func (fake *FakeClient) DeauthorizeHostToVolumeReturns(result1 bool, result2 error) {
+	fake.DeauthorizeHostToVolumeStub = nil
+	fake.deauthorizeHostToVolumeReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DeauthorizeHostToVolumeReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.DeauthorizeHostToVolumeStub = nil
+	if fake.deauthorizeHostToVolumeReturnsOnCall == nil {
+		fake.deauthorizeHostToVolumeReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deauthorizeHostToVolumeReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) OrderBlockVolume(storageType string, location string, size int, iops int) (*datatypes.Container_Product_Order_Receipt, error) {
+	fake.orderBlockVolumeMutex.Lock()
+	ret, specificReturn := fake.orderBlockVolumeReturnsOnCall[len(fake.orderBlockVolumeArgsForCall)]
+	fake.orderBlockVolumeArgsForCall = append(fake.orderBlockVolumeArgsForCall, struct {
+		storageType string
+		location    string
+		size        int
+		iops        int
+	}{storageType, location, size, iops})
+	fake.recordInvocation("OrderBlockVolume", []interface{}{storageType, location, size, iops})
+	fake.orderBlockVolumeMutex.Unlock()
+	if fake.OrderBlockVolumeStub != nil {
+		return fake.OrderBlockVolumeStub(storageType, location, size, iops)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.orderBlockVolumeReturns.result1, fake.orderBlockVolumeReturns.result2
+}
+
+func (fake *FakeClient) OrderBlockVolumeCallCount() int {
+	fake.orderBlockVolumeMutex.RLock()
+	defer fake.orderBlockVolumeMutex.RUnlock()
+	return len(fake.orderBlockVolumeArgsForCall)
+}
+
+func (fake *FakeClient) OrderBlockVolumeArgsForCall(i int) (string, string, int, int) {
+	fake.orderBlockVolumeMutex.RLock()
+	defer fake.orderBlockVolumeMutex.RUnlock()
+	return fake.orderBlockVolumeArgsForCall[i].storageType, fake.orderBlockVolumeArgsForCall[i].location, fake.orderBlockVolumeArgsForCall[i].size, fake.orderBlockVolumeArgsForCall[i].iops
+}
+
+func (fake *FakeClient) OrderBlockVolumeReturns(result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
+	fake.OrderBlockVolumeStub = nil
+	fake.orderBlockVolumeReturns = struct {
+		result1 *datatypes.Container_Product_Order_Receipt
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) OrderBlockVolumeReturnsOnCall(i int, result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
+	fake.OrderBlockVolumeStub = nil
+	if fake.orderBlockVolumeReturnsOnCall == nil {
+		fake.orderBlockVolumeReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Container_Product_Order_Receipt
+			result2 error
+		})
+	}
+	fake.orderBlockVolumeReturnsOnCall[i] = struct {
+		result1 *datatypes.Container_Product_Order_Receipt
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CancelBlockVolume(volumeId int, reason string, immediate bool) (bool, error) {
+	fake.cancelBlockVolumeMutex.Lock()
+	ret, specificReturn := fake.cancelBlockVolumeReturnsOnCall[len(fake.cancelBlockVolumeArgsForCall)]
+	fake.cancelBlockVolumeArgsForCall = append(fake.cancelBlockVolumeArgsForCall, struct {
+		volumeId  int
+		reason    string
+		immediate bool
+	}{volumeId, reason, immediate})
+	fake.recordInvocation("CancelBlockVolume", []interface{}{volumeId, reason, immediate})
+	fake.cancelBlockVolumeMutex.Unlock()
+	if fake.CancelBlockVolumeStub != nil {
+		return fake.CancelBlockVolumeStub(volumeId, reason, immediate)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.cancelBlockVolumeReturns.result1, fake.cancelBlockVolumeReturns.result2
+}
+
+func (fake *FakeClient) CancelBlockVolumeCallCount() int {
+	fake.cancelBlockVolumeMutex.RLock()
+	defer fake.cancelBlockVolumeMutex.RUnlock()
+	return len(fake.cancelBlockVolumeArgsForCall)
+}
+
+func (fake *FakeClient) CancelBlockVolumeArgsForCall(i int) (int, string, bool) {
+	fake.cancelBlockVolumeMutex.RLock()
+	defer fake.cancelBlockVolumeMutex.RUnlock()
+	return fake.cancelBlockVolumeArgsForCall[i].volumeId, fake.cancelBlockVolumeArgsForCall[i].reason, fake.cancelBlockVolumeArgsForCall[i].immediate
+}
+
+func (fake *FakeClient) CancelBlockVolumeReturns(result1 bool, result2 error) {
+	fake.CancelBlockVolumeStub = nil
+	fake.cancelBlockVolumeReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CancelBlockVolumeReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.CancelBlockVolumeStub = nil
+	if fake.cancelBlockVolumeReturnsOnCall == nil {
+		fake.cancelBlockVolumeReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.cancelBlockVolumeReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetBlockVolumeDetails(volumeId int, mask string) (*datatypes.Network_Storage, bool, error) {
+	fake.getBlockVolumeDetailsMutex.Lock()
+	ret, specificReturn := fake.getBlockVolumeDetailsReturnsOnCall[len(fake.getBlockVolumeDetailsArgsForCall)]
+	fake.getBlockVolumeDetailsArgsForCall = append(fake.getBlockVolumeDetailsArgsForCall, struct {
+		volumeId int
+		mask     string
+	}{volumeId, mask})
+	fake.recordInvocation("GetBlockVolumeDetails", []interface{}{volumeId, mask})
+	fake.getBlockVolumeDetailsMutex.Unlock()
+	if fake.GetBlockVolumeDetailsStub != nil {
+		return fake.GetBlockVolumeDetailsStub(volumeId, mask)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getBlockVolumeDetailsReturns.result1, fake.getBlockVolumeDetailsReturns.result2, fake.getBlockVolumeDetailsReturns.result3
+}
+
+func (fake *FakeClient) GetBlockVolumeDetailsCallCount() int {
+	fake.getBlockVolumeDetailsMutex.RLock()
+	defer fake.getBlockVolumeDetailsMutex.RUnlock()
+	return len(fake.getBlockVolumeDetailsArgsForCall)
+}
+
+func (fake *FakeClient) GetBlockVolumeDetailsArgsForCall(i int) (int, string) {
+	fake.getBlockVolumeDetailsMutex.RLock()
+	defer fake.getBlockVolumeDetailsMutex.RUnlock()
+	return fake.getBlockVolumeDetailsArgsForCall[i].volumeId, fake.getBlockVolumeDetailsArgsForCall[i].mask
+}
+
+func (fake *FakeClient) GetBlockVolumeDetailsReturns(result1 *datatypes.Network_Storage, result2 bool, result3 error) {
+	fake.GetBlockVolumeDetailsStub = nil
+	fake.getBlockVolumeDetailsReturns = struct {
+		result1 *datatypes.Network_Storage
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetBlockVolumeDetailsReturnsOnCall(i int, result1 *datatypes.Network_Storage, result2 bool, result3 error) {
+	fake.GetBlockVolumeDetailsStub = nil
+	if fake.getBlockVolumeDetailsReturnsOnCall == nil {
+		fake.getBlockVolumeDetailsReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_Storage
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getBlockVolumeDetailsReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_Storage
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetBlockVolumeDetailsBySoftLayerAccount(volumeId int, mask string) (datatypes.Network_Storage, error) {
+	fake.getBlockVolumeDetailsBySoftLayerAccountMutex.Lock()
+	ret, specificReturn := fake.getBlockVolumeDetailsBySoftLayerAccountReturnsOnCall[len(fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall)]
+	fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall = append(fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall, struct {
+		volumeId int
+		mask     string
+	}{volumeId, mask})
+	fake.recordInvocation("GetBlockVolumeDetailsBySoftLayerAccount", []interface{}{volumeId, mask})
+	fake.getBlockVolumeDetailsBySoftLayerAccountMutex.Unlock()
+	if fake.GetBlockVolumeDetailsBySoftLayerAccountStub != nil {
+		return fake.GetBlockVolumeDetailsBySoftLayerAccountStub(volumeId, mask)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getBlockVolumeDetailsBySoftLayerAccountReturns.result1, fake.getBlockVolumeDetailsBySoftLayerAccountReturns.result2
+}
+
+func (fake *FakeClient) GetBlockVolumeDetailsBySoftLayerAccountCallCount() int {
+	fake.getBlockVolumeDetailsBySoftLayerAccountMutex.RLock()
+	defer fake.getBlockVolumeDetailsBySoftLayerAccountMutex.RUnlock()
+	return len(fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall)
 }
 
-func (fake *FakeClient) GetInstanceByPrimaryBackendIpAddressArgsForCall(i int) string {
-	fake.getInstanceByPrimaryBackendIpAddressMutex.RLock()
-	defer fake.getInstanceByPrimaryBackendIpAddressMutex.RUnlock()
-	return fake.getInstanceByPrimaryBackendIpAddressArgsForCall[i].ip
+func (fake *FakeClient) GetBlockVolumeDetailsBySoftLayerAccountArgsForCall(i int) (int, string) {
+	fake.getBlockVolumeDetailsBySoftLayerAccountMutex.RLock()
+	defer fake.getBlockVolumeDetailsBySoftLayerAccountMutex.RUnlock()
+	return fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall[i].volumeId, fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall[i].mask
 }
 
-func (fake *FakeClient) GetInstanceByPrimaryBackendIpAddressReturns(result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
-	fake.GetInstanceByPrimaryBackendIpAddressStub = nil
-	fake.getInstanceByPrimaryBackendIpAddressReturns = struct {
-		result1 *datatypes.Virtual_Guest
+func (fake *FakeClient) GetBlockVolumeDetailsBySoftLayerAccountReturns(result1 datatypes.Network_Storage, result2 error) {
+	fake.GetBlockVolumeDetailsBySoftLayerAccountStub = nil
+	fake.getBlockVolumeDetailsBySoftLayerAccountReturns = struct {
+		result1 datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetBlockVolumeDetailsBySoftLayerAccountReturnsOnCall(i int, result1 datatypes.Network_Storage, result2 error) {
+	fake.GetBlockVolumeDetailsBySoftLayerAccountStub = nil
+	if fake.getBlockVolumeDetailsBySoftLayerAccountReturnsOnCall == nil {
+		fake.getBlockVolumeDetailsBySoftLayerAccountReturnsOnCall = make(map[int]struct {
+			result1 datatypes.Network_Storage
+			result2 error
+		})
+	}
+	fake.getBlockVolumeDetailsBySoftLayerAccountReturnsOnCall[i] = struct {
+		result1 datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetNetworkStorageTarget(volumeId int, mask string) (string, bool, error) {
+	fake.getNetworkStorageTargetMutex.Lock()
+	ret, specificReturn := fake.getNetworkStorageTargetReturnsOnCall[len(fake.getNetworkStorageTargetArgsForCall)]
+	fake.getNetworkStorageTargetArgsForCall = append(fake.getNetworkStorageTargetArgsForCall, struct {
+		volumeId int
+		mask     string
+	}{volumeId, mask})
+	fake.recordInvocation("GetNetworkStorageTarget", []interface{}{volumeId, mask})
+	fake.getNetworkStorageTargetMutex.Unlock()
+	if fake.GetNetworkStorageTargetStub != nil {
+		return fake.GetNetworkStorageTargetStub(volumeId, mask)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getNetworkStorageTargetReturns.result1, fake.getNetworkStorageTargetReturns.result2, fake.getNetworkStorageTargetReturns.result3
+}
+
+func (fake *FakeClient) GetNetworkStorageTargetCallCount() int {
+	fake.getNetworkStorageTargetMutex.RLock()
+	defer fake.getNetworkStorageTargetMutex.RUnlock()
+	return len(fake.getNetworkStorageTargetArgsForCall)
+}
+
+func (fake *FakeClient) GetNetworkStorageTargetArgsForCall(i int) (int, string) {
+	fake.getNetworkStorageTargetMutex.RLock()
+	defer fake.getNetworkStorageTargetMutex.RUnlock()
+	return fake.getNetworkStorageTargetArgsForCall[i].volumeId, fake.getNetworkStorageTargetArgsForCall[i].mask
+}
+
+func (fake *FakeClient) GetNetworkStorageTargetReturns(result1 string, result2 bool, result3 error) {
+	fake.GetNetworkStorageTargetStub = nil
+	fake.getNetworkStorageTargetReturns = struct {
+		result1 string
 		result2 bool
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) GetInstanceByPrimaryBackendIpAddressReturnsOnCall(i int, result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
-	fake.GetInstanceByPrimaryBackendIpAddressStub = nil
-	if fake.getInstanceByPrimaryBackendIpAddressReturnsOnCall == nil {
-		fake.getInstanceByPrimaryBackendIpAddressReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Virtual_Guest
+func (fake *FakeClient) GetNetworkStorageTargetReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.GetNetworkStorageTargetStub = nil
+	if fake.getNetworkStorageTargetReturnsOnCall == nil {
+		fake.getNetworkStorageTargetReturnsOnCall = make(map[int]struct {
+			result1 string
 			result2 bool
 			result3 error
 		})
 	}
-	fake.getInstanceByPrimaryBackendIpAddressReturnsOnCall[i] = struct {
-		result1 *datatypes.Virtual_Guest
+	fake.getNetworkStorageTargetReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) SetNotes(id int, notes string) (bool, error) {
+	fake.setNotesMutex.Lock()
+	ret, specificReturn := fake.setNotesReturnsOnCall[len(fake.setNotesArgsForCall)]
+	fake.setNotesArgsForCall = append(fake.setNotesArgsForCall, struct {
+		id    int
+		notes string
+	}{id, notes})
+	fake.recordInvocation("SetNotes", []interface{}{id, notes})
+	fake.setNotesMutex.Unlock()
+	if fake.SetNotesStub != nil {
+		return fake.SetNotesStub(id, notes)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.setNotesReturns.result1, fake.setNotesReturns.result2
+}
+
+func (fake *FakeClient) SetNotesCallCount() int {
+	fake.setNotesMutex.RLock()
+	defer fake.setNotesMutex.RUnlock()
+	return len(fake.setNotesArgsForCall)
+}
+
+func (fake *FakeClient) SetNotesArgsForCall(i int) (int, string) {
+	fake.setNotesMutex.RLock()
+	defer fake.setNotesMutex.RUnlock()
+	return fake.setNotesArgsForCall[i].id, fake.setNotesArgsForCall[i].notes
+}
+
+func (fake *FakeClient) SetNotesReturns(result1 bool, result2 error) {
+	fake.SetNotesStub = nil
+	fake.setNotesReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SetNotesReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.SetNotesStub = nil
+	if fake.setNotesReturnsOnCall == nil {
+		fake.setNotesReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.setNotesReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetImage(imageId int, mask string) (*datatypes.Virtual_Guest_Block_Device_Template_Group, bool, error) {
+	fake.getImageMutex.Lock()
+	ret, specificReturn := fake.getImageReturnsOnCall[len(fake.getImageArgsForCall)]
+	fake.getImageArgsForCall = append(fake.getImageArgsForCall, struct {
+		imageId int
+		mask    string
+	}{imageId, mask})
+	fake.recordInvocation("GetImage", []interface{}{imageId, mask})
+	fake.getImageMutex.Unlock()
+	if fake.GetImageStub != nil {
+		return fake.GetImageStub(imageId, mask)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getImageReturns.result1, fake.getImageReturns.result2, fake.getImageReturns.result3
+}
+
+func (fake *FakeClient) GetImageCallCount() int {
+	fake.getImageMutex.RLock()
+	defer fake.getImageMutex.RUnlock()
+	return len(fake.getImageArgsForCall)
+}
+
+func (fake *FakeClient) GetImageArgsForCall(i int) (int, string) {
+	fake.getImageMutex.RLock()
+	defer fake.getImageMutex.RUnlock()
+	return fake.getImageArgsForCall[i].imageId, fake.getImageArgsForCall[i].mask
+}
+
+func (fake *FakeClient) GetImageReturns(result1 *datatypes.Virtual_Guest_Block_Device_Template_Group, result2 bool, result3 error) {
+	fake.GetImageStub = nil
+	fake.getImageReturns = struct {
+		result1 *datatypes.Virtual_Guest_Block_Device_Template_Group
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetImageReturnsOnCall(i int, result1 *datatypes.Virtual_Guest_Block_Device_Template_Group, result2 bool, result3 error) {
+	fake.GetImageStub = nil
+	if fake.getImageReturnsOnCall == nil {
+		fake.getImageReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Virtual_Guest_Block_Device_Template_Group
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getImageReturnsOnCall[i] = struct {
+		result1 *datatypes.Virtual_Guest_Block_Device_Template_Group
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetVlan(id int, mask string) (*datatypes.Network_Vlan, bool, error) {
+	fake.getVlanMutex.Lock()
+	ret, specificReturn := fake.getVlanReturnsOnCall[len(fake.getVlanArgsForCall)]
+	fake.getVlanArgsForCall = append(fake.getVlanArgsForCall, struct {
+		id   int
+		mask string
+	}{id, mask})
+	fake.recordInvocation("GetVlan", []interface{}{id, mask})
+	fake.getVlanMutex.Unlock()
+	if fake.GetVlanStub != nil {
+		return fake.GetVlanStub(id, mask)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getVlanReturns.result1, fake.getVlanReturns.result2, fake.getVlanReturns.result3
+}
+
+func (fake *FakeClient) GetVlanCallCount() int {
+	fake.getVlanMutex.RLock()
+	defer fake.getVlanMutex.RUnlock()
+	return len(fake.getVlanArgsForCall)
+}
+
+func (fake *FakeClient) GetVlanArgsForCall(i int) (int, string) {
+	fake.getVlanMutex.RLock()
+	defer fake.getVlanMutex.RUnlock()
+	return fake.getVlanArgsForCall[i].id, fake.getVlanArgsForCall[i].mask
+}
+
+func (fake *FakeClient) GetVlanReturns(result1 *datatypes.Network_Vlan, result2 bool, result3 error) {
+	fake.GetVlanStub = nil
+	fake.getVlanReturns = struct {
+		result1 *datatypes.Network_Vlan
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetVlanReturnsOnCall(i int, result1 *datatypes.Network_Vlan, result2 bool, result3 error) {
+	fake.GetVlanStub = nil
+	if fake.getVlanReturnsOnCall == nil {
+		fake.getVlanReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_Vlan
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getVlanReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_Vlan
 		result2 bool
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) GetInstanceByPrimaryIpAddress(ip string) (*datatypes.Virtual_Guest, bool, error) {
-	fake.getInstanceByPrimaryIpAddressMutex.Lock()
-	ret, specificReturn := fake.getInstanceByPrimaryIpAddressReturnsOnCall[len(fake.getInstanceByPrimaryIpAddressArgsForCall)]
-	fake.getInstanceByPrimaryIpAddressArgsForCall = append(fake.getInstanceByPrimaryIpAddressArgsForCall, struct {
-		ip string
-	}{ip})
-	fake.recordInvocation("GetInstanceByPrimaryIpAddress", []interface{}{ip})
-	fake.getInstanceByPrimaryIpAddressMutex.Unlock()
-	if fake.GetInstanceByPrimaryIpAddressStub != nil {
-		return fake.GetInstanceByPrimaryIpAddressStub(ip)
+func (fake *FakeClient) GetSubnet(id int, mask string) (*datatypes.Network_Subnet, bool, error) {
+	fake.getSubnetMutex.Lock()
+	ret, specificReturn := fake.getSubnetReturnsOnCall[len(fake.getSubnetArgsForCall)]
+	fake.getSubnetArgsForCall = append(fake.getSubnetArgsForCall, struct {
+		id   int
+		mask string
+	}{id, mask})
+	fake.recordInvocation("GetSubnet", []interface{}{id, mask})
+	fake.getSubnetMutex.Unlock()
+	if fake.GetSubnetStub != nil {
+		return fake.GetSubnetStub(id, mask)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
 	}
-	return fake.getInstanceByPrimaryIpAddressReturns.result1, fake.getInstanceByPrimaryIpAddressReturns.result2, fake.getInstanceByPrimaryIpAddressReturns.result3
+	return fake.getSubnetReturns.result1, fake.getSubnetReturns.result2, fake.getSubnetReturns.result3
 }
 
-func (fake *FakeClient) GetInstanceByPrimaryIpAddressCallCount() int {
-	fake.getInstanceByPrimaryIpAddressMutex.RLock()
-	defer fake.getInstanceByPrimaryIpAddressMutex.RUnlock()
-	return len(fake.getInstanceByPrimaryIpAddressArgsForCall)
+func (fake *FakeClient) GetSubnetCallCount() int {
+	fake.getSubnetMutex.RLock()
+	defer fake.getSubnetMutex.RUnlock()
+	return len(fake.getSubnetArgsForCall)
 }
 
-func (fake *FakeClient) GetInstanceByPrimaryIpAddressArgsForCall(i int) string {
-	fake.getInstanceByPrimaryIpAddressMutex.RLock()
-	defer fake.getInstanceByPrimaryIpAddressMutex.RUnlock()
-	return fake.getInstanceByPrimaryIpAddressArgsForCall[i].ip
+func (fake *FakeClient) GetSubnetArgsForCall(i int) (int, string) {
+	fake.getSubnetMutex.RLock()
+	defer fake.getSubnetMutex.RUnlock()
+	return fake.getSubnetArgsForCall[i].id, fake.getSubnetArgsForCall[i].mask
 }
 
-func (fake *FakeClient) GetInstanceByPrimaryIpAddressReturns(result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
-	fake.GetInstanceByPrimaryIpAddressStub = nil
-	fake.getInstanceByPrimaryIpAddressReturns = struct {
-		result1 *datatypes.Virtual_Guest
+func (fake *FakeClient) GetSubnetReturns(result1 *datatypes.Network_Subnet, result2 bool, result3 error) {
+	fake.GetSubnetStub = nil
+	fake.getSubnetReturns = struct {
+		result1 *datatypes.Network_Subnet
 		result2 bool
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) GetInstanceByPrimaryIpAddressReturnsOnCall(i int, result1 *datatypes.Virtual_Guest, result2 bool, result3 error) {
-	fake.GetInstanceByPrimaryIpAddressStub = nil
-	if fake.getInstanceByPrimaryIpAddressReturnsOnCall == nil {
-		fake.getInstanceByPrimaryIpAddressReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Virtual_Guest
+func (fake *FakeClient) GetSubnetReturnsOnCall(i int, result1 *datatypes.Network_Subnet, result2 bool, result3 error) {
+	fake.GetSubnetStub = nil
+	if fake.getSubnetReturnsOnCall == nil {
+		fake.getSubnetReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_Subnet
 			result2 bool
 			result3 error
 		})
 	}
-	fake.getInstanceByPrimaryIpAddressReturnsOnCall[i] = struct {
-		result1 *datatypes.Virtual_Guest
+	fake.getSubnetReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_Subnet
 		result2 bool
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) RebootInstance(id int, soft bool, hard bool) error {
-	fake.rebootInstanceMutex.Lock()
-	ret, specificReturn := fake.rebootInstanceReturnsOnCall[len(fake.rebootInstanceArgsForCall)]
-	fake.rebootInstanceArgsForCall = append(fake.rebootInstanceArgsForCall, struct {
-		id   int
-		soft bool
-		hard bool
-	}{id, soft, hard})
-	fake.recordInvocation("RebootInstance", []interface{}{id, soft, hard})
-	fake.rebootInstanceMutex.Unlock()
-	if fake.RebootInstanceStub != nil {
-		return fake.RebootInstanceStub(id, soft, hard)
+func (fake *FakeClient) GetAllowedHostCredential(id int) (*datatypes.Network_Storage_Allowed_Host, bool, error) {
+	fake.getAllowedHostCredentialMutex.Lock()
+	ret, specificReturn := fake.getAllowedHostCredentialReturnsOnCall[len(fake.getAllowedHostCredentialArgsForCall)]
+	fake.getAllowedHostCredentialArgsForCall = append(fake.getAllowedHostCredentialArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("GetAllowedHostCredential", []interface{}{id})
+	fake.getAllowedHostCredentialMutex.Unlock()
+	if fake.GetAllowedHostCredentialStub != nil {
+		return fake.GetAllowedHostCredentialStub(id)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2, ret.result3
 	}
-	return fake.rebootInstanceReturns.result1
+	return fake.getAllowedHostCredentialReturns.result1, fake.getAllowedHostCredentialReturns.result2, fake.getAllowedHostCredentialReturns.result3
 }
 
-func (fake *FakeClient) RebootInstanceCallCount() int {
-	fake.rebootInstanceMutex.RLock()
-	defer fake.rebootInstanceMutex.RUnlock()
-	return len(fake.rebootInstanceArgsForCall)
+func (fake *FakeClient) GetAllowedHostCredentialCallCount() int {
+	fake.getAllowedHostCredentialMutex.RLock()
+	defer fake.getAllowedHostCredentialMutex.RUnlock()
+	return len(fake.getAllowedHostCredentialArgsForCall)
 }
 
-func (fake *FakeClient) RebootInstanceArgsForCall(i int) (int, bool, bool) {
-	fake.rebootInstanceMutex.RLock()
-	defer fake.rebootInstanceMutex.RUnlock()
-	return fake.rebootInstanceArgsForCall[i].id, fake.rebootInstanceArgsForCall[i].soft, fake.rebootInstanceArgsForCall[i].hard
+func (fake *FakeClient) GetAllowedHostCredentialArgsForCall(i int) int {
+	fake.getAllowedHostCredentialMutex.RLock()
+	defer fake.getAllowedHostCredentialMutex.RUnlock()
+	return fake.getAllowedHostCredentialArgsForCall[i].id
 }
 
-func (fake *FakeClient) RebootInstanceReturns(result1 error) {
-	fake.RebootInstanceStub = nil
-	fake.rebootInstanceReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeClient) GetAllowedHostCredentialReturns(result1 *datatypes.Network_Storage_Allowed_Host, result2 bool, result3 error) {
+	fake.GetAllowedHostCredentialStub = nil
+	fake.getAllowedHostCredentialReturns = struct {
+		result1 *datatypes.Network_Storage_Allowed_Host
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) RebootInstanceReturnsOnCall(i int, result1 error) {
-	fake.RebootInstanceStub = nil
-	if fake.rebootInstanceReturnsOnCall == nil {
-		fake.rebootInstanceReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeClient) GetAllowedHostCredentialReturnsOnCall(i int, result1 *datatypes.Network_Storage_Allowed_Host, result2 bool, result3 error) {
+	fake.GetAllowedHostCredentialStub = nil
+	if fake.getAllowedHostCredentialReturnsOnCall == nil {
+		fake.getAllowedHostCredentialReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_Storage_Allowed_Host
+			result2 bool
+			result3 error
 		})
 	}
-	fake.rebootInstanceReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.getAllowedHostCredentialReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_Storage_Allowed_Host
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) ReloadInstance(id int, stemcellId int, sshKeyIds []int, hostname string, domain string, userData *registry.SoftlayerUserData) error {
-	var sshKeyIdsCopy []int
-	if sshKeyIds != nil {
-		sshKeyIdsCopy = make([]int, len(sshKeyIds))
-		copy(sshKeyIdsCopy, sshKeyIds)
-	}
-	fake.reloadInstanceMutex.Lock()
-	ret, specificReturn := fake.reloadInstanceReturnsOnCall[len(fake.reloadInstanceArgsForCall)]
-	fake.reloadInstanceArgsForCall = append(fake.reloadInstanceArgsForCall, struct {
-		id         int
-		stemcellId int
-		sshKeyIds  []int
-		hostname   string
-		domain     string
-		userData   *registry.SoftlayerUserData
-	}{id, stemcellId, sshKeyIdsCopy, hostname, domain, userData})
-	fake.recordInvocation("ReloadInstance", []interface{}{id, stemcellId, sshKeyIdsCopy, hostname, domain, userData})
-	fake.reloadInstanceMutex.Unlock()
-	if fake.ReloadInstanceStub != nil {
-		return fake.ReloadInstanceStub(id, stemcellId, sshKeyIds, hostname, domain, userData)
+func (fake *FakeClient) GetAllowedNetworkStorage(id int) ([]string, bool, error) {
+	fake.getAllowedNetworkStorageMutex.Lock()
+	ret, specificReturn := fake.getAllowedNetworkStorageReturnsOnCall[len(fake.getAllowedNetworkStorageArgsForCall)]
+	fake.getAllowedNetworkStorageArgsForCall = append(fake.getAllowedNetworkStorageArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("GetAllowedNetworkStorage", []interface{}{id})
+	fake.getAllowedNetworkStorageMutex.Unlock()
+	if fake.GetAllowedNetworkStorageStub != nil {
+		return fake.GetAllowedNetworkStorageStub(id)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2, ret.result3
 	}
-	return fake.reloadInstanceReturns.result1
+	return fake.getAllowedNetworkStorageReturns.result1, fake.getAllowedNetworkStorageReturns.result2, fake.getAllowedNetworkStorageReturns.result3
 }
 
-func (fake *FakeClient) ReloadInstanceCallCount() int {
-	fake.reloadInstanceMutex.RLock()
-	defer fake.reloadInstanceMutex.RUnlock()
-	return len(fake.reloadInstanceArgsForCall)
+func (fake *FakeClient) GetAllowedNetworkStorageCallCount() int {
+	fake.getAllowedNetworkStorageMutex.RLock()
+	defer fake.getAllowedNetworkStorageMutex.RUnlock()
+	return len(fake.getAllowedNetworkStorageArgsForCall)
 }
 
-func (fake *FakeClient) ReloadInstanceArgsForCall(i int) (int, int, []int, string, string, *registry.SoftlayerUserData) {
-	fake.reloadInstanceMutex.RLock()
-	defer fake.reloadInstanceMutex.RUnlock()
-	return fake.reloadInstanceArgsForCall[i].id, fake.reloadInstanceArgsForCall[i].stemcellId, fake.reloadInstanceArgsForCall[i].sshKeyIds, fake.reloadInstanceArgsForCall[i].hostname, fake.reloadInstanceArgsForCall[i].domain, fake.reloadInstanceArgsForCall[i].userData
+func (fake *FakeClient) GetAllowedNetworkStorageArgsForCall(i int) int {
+	fake.getAllowedNetworkStorageMutex.RLock()
+	defer fake.getAllowedNetworkStorageMutex.RUnlock()
+	return fake.getAllowedNetworkStorageArgsForCall[i].id
 }
 
-func (fake *FakeClient) ReloadInstanceReturns(result1 error) {
-	fake.ReloadInstanceStub = nil
-	fake.reloadInstanceReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeClient) GetAllowedNetworkStorageReturns(result1 []string, result2 bool, result3 error) {
+	fake.GetAllowedNetworkStorageStub = nil
+	fake.getAllowedNetworkStorageReturns = struct {
+		result1 []string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) ReloadInstanceReturnsOnCall(i int, result1 error) {
-	fake.ReloadInstanceStub = nil
-	if fake.reloadInstanceReturnsOnCall == nil {
-		fake.reloadInstanceReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeClient) GetAllowedNetworkStorageReturnsOnCall(i int, result1 []string, result2 bool, result3 error) {
+	fake.GetAllowedNetworkStorageStub = nil
+	if fake.getAllowedNetworkStorageReturnsOnCall == nil {
+		fake.getAllowedNetworkStorageReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 bool
+			result3 error
 		})
 	}
-	fake.reloadInstanceReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.getAllowedNetworkStorageReturnsOnCall[i] = struct {
+		result1 []string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) UpgradeInstanceConfig(id int, cpu int, memory int, network int, privateCPU bool, dedicatedHost bool) error {
-	fake.upgradeInstanceConfigMutex.Lock()
-	ret, specificReturn := fake.upgradeInstanceConfigReturnsOnCall[len(fake.upgradeInstanceConfigArgsForCall)]
-	fake.upgradeInstanceConfigArgsForCall = append(fake.upgradeInstanceConfigArgsForCall, struct {
-		id            int
-		cpu           int
-		memory        int
-		network       int
-		privateCPU    bool
-		dedicatedHost bool
-	}{id, cpu, memory, network, privateCPU, dedicatedHost})
-	fake.recordInvocation("UpgradeInstanceConfig", []interface{}{id, cpu, memory, network, privateCPU, dedicatedHost})
-	fake.upgradeInstanceConfigMutex.Unlock()
-	if fake.UpgradeInstanceConfigStub != nil {
-		return fake.UpgradeInstanceConfigStub(id, cpu, memory, network, privateCPU, dedicatedHost)
+func (fake *FakeClient) CreateSshKey(label *string, key *string, fingerPrint *string) (*datatypes.Security_Ssh_Key, error) {
+	fake.createSshKeyMutex.Lock()
+	ret, specificReturn := fake.createSshKeyReturnsOnCall[len(fake.createSshKeyArgsForCall)]
+	fake.createSshKeyArgsForCall = append(fake.createSshKeyArgsForCall, struct {
+		label       *string
+		key         *string
+		fingerPrint *string
+	}{label, key, fingerPrint})
+	fake.recordInvocation("CreateSshKey", []interface{}{label, key, fingerPrint})
+	fake.createSshKeyMutex.Unlock()
+	if fake.CreateSshKeyStub != nil {
+		return fake.CreateSshKeyStub(label, key, fingerPrint)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.upgradeInstanceConfigReturns.result1
+	return fake.createSshKeyReturns.result1, fake.createSshKeyReturns.result2
 }
 
-func (fake *FakeClient) UpgradeInstanceConfigCallCount() int {
-	fake.upgradeInstanceConfigMutex.RLock()
-	defer fake.upgradeInstanceConfigMutex.RUnlock()
-	return len(fake.upgradeInstanceConfigArgsForCall)
+func (fake *FakeClient) CreateSshKeyCallCount() int {
+	fake.createSshKeyMutex.RLock()
+	defer fake.createSshKeyMutex.RUnlock()
+	return len(fake.createSshKeyArgsForCall)
 }
 
-func (fake *FakeClient) UpgradeInstanceConfigArgsForCall(i int) (int, int, int, int, bool, bool) {
-	fake.upgradeInstanceConfigMutex.RLock()
-	defer fake.upgradeInstanceConfigMutex.RUnlock()
-	return fake.upgradeInstanceConfigArgsForCall[i].id, fake.upgradeInstanceConfigArgsForCall[i].cpu, fake.upgradeInstanceConfigArgsForCall[i].memory, fake.upgradeInstanceConfigArgsForCall[i].network, fake.upgradeInstanceConfigArgsForCall[i].privateCPU, fake.upgradeInstanceConfigArgsForCall[i].dedicatedHost
+func (fake *FakeClient) CreateSshKeyArgsForCall(i int) (*string, *string, *string) {
+	fake.createSshKeyMutex.RLock()
+	defer fake.createSshKeyMutex.RUnlock()
+	return fake.createSshKeyArgsForCall[i].label, fake.createSshKeyArgsForCall[i].key, fake.createSshKeyArgsForCall[i].fingerPrint
 }
 
-func (fake *FakeClient) UpgradeInstanceConfigReturns(result1 error) {
-	fake.UpgradeInstanceConfigStub = nil
-	fake.upgradeInstanceConfigReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeClient) CreateSshKeyReturns(result1 *datatypes.Security_Ssh_Key, result2 error) {
+	fake.CreateSshKeyStub = nil
+	fake.createSshKeyReturns = struct {
+		result1 *datatypes.Security_Ssh_Key
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) UpgradeInstanceConfigReturnsOnCall(i int, result1 error) {
-	fake.UpgradeInstanceConfigStub = nil
-	if fake.upgradeInstanceConfigReturnsOnCall == nil {
-		fake.upgradeInstanceConfigReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeClient) CreateSshKeyReturnsOnCall(i int, result1 *datatypes.Security_Ssh_Key, result2 error) {
+	fake.CreateSshKeyStub = nil
+	if fake.createSshKeyReturnsOnCall == nil {
+		fake.createSshKeyReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Security_Ssh_Key
+			result2 error
 		})
 	}
-	fake.upgradeInstanceConfigReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.createSshKeyReturnsOnCall[i] = struct {
+		result1 *datatypes.Security_Ssh_Key
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) UpgradeInstance(id int, cpu int, memory int, network int, privateCPU bool, dedicatedHost bool, secondDiskSize int) (int, error) {
-	fake.upgradeInstanceMutex.Lock()
-	ret, specificReturn := fake.upgradeInstanceReturnsOnCall[len(fake.upgradeInstanceArgsForCall)]
-	fake.upgradeInstanceArgsForCall = append(fake.upgradeInstanceArgsForCall, struct {
-		id             int
-		cpu            int
-		memory         int
-		network        int
-		privateCPU     bool
-		dedicatedHost  bool
-		secondDiskSize int
-	}{id, cpu, memory, network, privateCPU, dedicatedHost, secondDiskSize})
-	fake.recordInvocation("UpgradeInstance", []interface{}{id, cpu, memory, network, privateCPU, dedicatedHost, secondDiskSize})
-	fake.upgradeInstanceMutex.Unlock()
-	if fake.UpgradeInstanceStub != nil {
-		return fake.UpgradeInstanceStub(id, cpu, memory, network, privateCPU, dedicatedHost, secondDiskSize)
+func (fake *FakeClient) DeleteSshKey(id int) (bool, error) {
+	fake.deleteSshKeyMutex.Lock()
+	ret, specificReturn := fake.deleteSshKeyReturnsOnCall[len(fake.deleteSshKeyArgsForCall)]
+	fake.deleteSshKeyArgsForCall = append(fake.deleteSshKeyArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("DeleteSshKey", []interface{}{id})
+	fake.deleteSshKeyMutex.Unlock()
+	if fake.DeleteSshKeyStub != nil {
+		return fake.DeleteSshKeyStub(id)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.upgradeInstanceReturns.result1, fake.upgradeInstanceReturns.result2
+	return fake.deleteSshKeyReturns.result1, fake.deleteSshKeyReturns.result2
 }
 
-func (fake *FakeClient) UpgradeInstanceCallCount() int {
-	fake.upgradeInstanceMutex.RLock()
-	defer fake.upgradeInstanceMutex.RUnlock()
-	return len(fake.upgradeInstanceArgsForCall)
+func (fake *FakeClient) DeleteSshKeyCallCount() int {
+	fake.deleteSshKeyMutex.RLock()
+	defer fake.deleteSshKeyMutex.RUnlock()
+	return len(fake.deleteSshKeyArgsForCall)
 }
 
-func (fake *FakeClient) UpgradeInstanceArgsForCall(i int) (int, int, int, int, bool, bool, int) {
-	fake.upgradeInstanceMutex.RLock()
-	defer fake.upgradeInstanceMutex.RUnlock()
-	return fake.upgradeInstanceArgsForCall[i].id, fake.upgradeInstanceArgsForCall[i].cpu, fake.upgradeInstanceArgsForCall[i].memory, fake.upgradeInstanceArgsForCall[i].network, fake.upgradeInstanceArgsForCall[i].privateCPU, fake.upgradeInstanceArgsForCall[i].dedicatedHost, fake.upgradeInstanceArgsForCall[i].secondDiskSize
+func (fake *FakeClient) DeleteSshKeyArgsForCall(i int) int {
+	fake.deleteSshKeyMutex.RLock()
+	defer fake.deleteSshKeyMutex.RUnlock()
+	return fake.deleteSshKeyArgsForCall[i].id
 }
 
-func (fake *FakeClient) UpgradeInstanceReturns(result1 int, result2 error) {
-	fake.UpgradeInstanceStub = nil
-	fake.upgradeInstanceReturns = struct {
-		result1 int
+func (fake *FakeClient) DeleteSshKeyReturns(result1 bool, result2 error) {
+	fake.DeleteSshKeyStub = nil
+	fake.deleteSshKeyReturns = struct {
+		result1 bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) UpgradeInstanceReturnsOnCall(i int, result1 int, result2 error) {
-	fake.UpgradeInstanceStub = nil
-	if fake.upgradeInstanceReturnsOnCall == nil {
-		fake.upgradeInstanceReturnsOnCall = make(map[int]struct {
-			result1 int
+func (fake *FakeClient) DeleteSshKeyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.DeleteSshKeyStub = nil
+	if fake.deleteSshKeyReturnsOnCall == nil {
+		fake.deleteSshKeyReturnsOnCall = make(map[int]struct {
+			result1 bool
 			result2 error
 		})
 	}
-	fake.upgradeInstanceReturnsOnCall[i] = struct {
-		result1 int
+	fake.deleteSshKeyReturnsOnCall[i] = struct {
+		result1 bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) WaitInstanceUntilReady(id int, until time.Time) error {
-	fake.waitInstanceUntilReadyMutex.Lock()
-	ret, specificReturn := fake.waitInstanceUntilReadyReturnsOnCall[len(fake.waitInstanceUntilReadyArgsForCall)]
-	fake.waitInstanceUntilReadyArgsForCall = append(fake.waitInstanceUntilReadyArgsForCall, struct {
-		id    int
-		until time.Time
-	}{id, until})
-	fake.recordInvocation("WaitInstanceUntilReady", []interface{}{id, until})
-	fake.waitInstanceUntilReadyMutex.Unlock()
-	if fake.WaitInstanceUntilReadyStub != nil {
-		return fake.WaitInstanceUntilReadyStub(id, until)
+func (fake *FakeClient) CreateInstanceFromVPS(template *datatypes.Virtual_Guest, stemcellID int, sshKeys []int, userData *registry.SoftlayerUserData) (*datatypes.Virtual_Guest, error) {
+	var sshKeysCopy []int
+	if sshKeys != nil {
+		sshKeysCopy = make([]int, len(sshKeys))
+		copy(sshKeysCopy, sshKeys)
+	}
+	fake.createInstanceFromVPSMutex.Lock()
+	ret, specificReturn := fake.createInstanceFromVPSReturnsOnCall[len(fake.createInstanceFromVPSArgsForCall)]
+	fake.createInstanceFromVPSArgsForCall = append(fake.createInstanceFromVPSArgsForCall, struct {
+		template   *datatypes.Virtual_Guest
+		stemcellID int
+		sshKeys    []int
+		userData   *registry.SoftlayerUserData
+	}{template, stemcellID, sshKeysCopy, userData})
+	fake.recordInvocation("CreateInstanceFromVPS", []interface{}{template, stemcellID, sshKeysCopy, userData})
+	fake.createInstanceFromVPSMutex.Unlock()
+	if fake.CreateInstanceFromVPSStub != nil {
+		return fake.CreateInstanceFromVPSStub(template, stemcellID, sshKeys, userData)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.waitInstanceUntilReadyReturns.result1
+	return fake.createInstanceFromVPSReturns.result1, fake.createInstanceFromVPSReturns.result2
 }
 
-func (fake *FakeClient) WaitInstanceUntilReadyCallCount() int {
-	fake.waitInstanceUntilReadyMutex.RLock()
-	defer fake.waitInstanceUntilReadyMutex.RUnlock()
-	return len(fake.waitInstanceUntilReadyArgsForCall)
+func (fake *FakeClient) CreateInstanceFromVPSCallCount() int {
+	fake.createInstanceFromVPSMutex.RLock()
+	defer fake.createInstanceFromVPSMutex.RUnlock()
+	return len(fake.createInstanceFromVPSArgsForCall)
 }
 
-func (fake *FakeClient) WaitInstanceUntilReadyArgsForCall(i int) (int, time.Time) {
-	fake.waitInstanceUntilReadyMutex.RLock()
-	defer fake.waitInstanceUntilReadyMutex.RUnlock()
-	return fake.waitInstanceUntilReadyArgsForCall[i].id, fake.waitInstanceUntilReadyArgsForCall[i].until
+func (fake *FakeClient) CreateInstanceFromVPSArgsForCall(i int) (*datatypes.Virtual_Guest, int, []int, *registry.SoftlayerUserData) {
+	fake.createInstanceFromVPSMutex.RLock()
+	defer fake.createInstanceFromVPSMutex.RUnlock()
+	return fake.createInstanceFromVPSArgsForCall[i].template, fake.createInstanceFromVPSArgsForCall[i].stemcellID, fake.createInstanceFromVPSArgsForCall[i].sshKeys, fake.createInstanceFromVPSArgsForCall[i].userData
 }
 
-func (fake *FakeClient) WaitInstanceUntilReadyReturns(result1 error) {
-	fake.WaitInstanceUntilReadyStub = nil
-	fake.waitInstanceUntilReadyReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeClient) CreateInstanceFromVPSReturns(result1 *datatypes.Virtual_Guest, result2 error) {
+	fake.CreateInstanceFromVPSStub = nil
+	fake.createInstanceFromVPSReturns = struct {
+		result1 *datatypes.Virtual_Guest
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) WaitInstanceUntilReadyReturnsOnCall(i int, result1 error) {
-	fake.WaitInstanceUntilReadyStub = nil
-	if fake.waitInstanceUntilReadyReturnsOnCall == nil {
-		fake.waitInstanceUntilReadyReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeClient) CreateInstanceFromVPSReturnsOnCall(i int, result1 *datatypes.Virtual_Guest, result2 error) {
+	fake.CreateInstanceFromVPSStub = nil
+	if fake.createInstanceFromVPSReturnsOnCall == nil {
+		fake.createInstanceFromVPSReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Virtual_Guest
+			result2 error
 		})
 	}
-	fake.waitInstanceUntilReadyReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.createInstanceFromVPSReturnsOnCall[i] = struct {
+		result1 *datatypes.Virtual_Guest
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) WaitInstanceUntilReadyWithTicket(id int, until time.Time) error {
-	fake.waitInstanceUntilReadyWithTicketMutex.Lock()
-	ret, specificReturn := fake.waitInstanceUntilReadyWithTicketReturnsOnCall[len(fake.waitInstanceUntilReadyWithTicketArgsForCall)]
-	fake.waitInstanceUntilReadyWithTicketArgsForCall = append(fake.waitInstanceUntilReadyWithTicketArgsForCall, struct {
-		id    int
-		until time.Time
-	}{id, until})
-	fake.recordInvocation("WaitInstanceUntilReadyWithTicket", []interface{}{id, until})
-	fake.waitInstanceUntilReadyWithTicketMutex.Unlock()
-	if fake.WaitInstanceUntilReadyWithTicketStub != nil {
-		return fake.WaitInstanceUntilReadyWithTicketStub(id, until)
+func (fake *FakeClient) DeleteInstanceFromVPS(id int) error {
+	fake.deleteInstanceFromVPSMutex.Lock()
+	ret, specificReturn := fake.deleteInstanceFromVPSReturnsOnCall[len(fake.deleteInstanceFromVPSArgsForCall)]
+	fake.deleteInstanceFromVPSArgsForCall = append(fake.deleteInstanceFromVPSArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("DeleteInstanceFromVPS", []interface{}{id})
+	fake.deleteInstanceFromVPSMutex.Unlock()
+	if fake.DeleteInstanceFromVPSStub != nil {
+		return fake.DeleteInstanceFromVPSStub(id)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.waitInstanceUntilReadyWithTicketReturns.result1
-}
-
-func (fake *FakeClient) WaitInstanceUntilReadyWithTicketCallCount() int {
-	fake.waitInstanceUntilReadyWithTicketMutex.RLock()
-	defer fake.waitInstanceUntilReadyWithTicketMutex.RUnlock()
-	return len(fake.waitInstanceUntilReadyWithTicketArgsForCall)
+	return fake.deleteInstanceFromVPSReturns.result1
 }
 
-func (fake *FakeClient) WaitInstanceUntilReadyWithTicketArgsForCall(i int) (int, time.Time) {
-	fake.waitInstanceUntilReadyWithTicketMutex.RLock()
-	defer fake.waitInstanceUntilReadyWithTicketMutex.RUnlock()
-	return fake.waitInstanceUntilReadyWithTicketArgsForCall[i].id, fake.waitInstanceUntilReadyWithTicketArgsForCall[i].until
+func (fake *FakeClient) DeleteInstanceFromVPSCallCount() int {
+	fake.deleteInstanceFromVPSMutex.RLock()
+	defer fake.deleteInstanceFromVPSMutex.RUnlock()
+	return len(fake.deleteInstanceFromVPSArgsForCall)
 }
 
-func (fake *FakeClient) WaitInstanceUntilReadyWithTicketReturns(result1 error) {
-	fake.WaitInstanceUntilReadyWithTicketStub = nil
-	fake.waitInstanceUntilReadyWithTicketReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeClient) DeleteInstanceFromVPSArgsForCall(i int) int {
+	fake.deleteInstanceFromVPSMutex.RLock()
+	defer fake.deleteInstanceFromVPSMutex.RUnlock()
+	return fake.deleteInstanceFromVPSArgsForCall[i].id
 }
 
-func (fake *FakeClient) WaitInstanceUntilReadyWithTicketReturnsOnCall(i int, result1 error) {
-	fake.WaitInstanceUntilReadyWithTicketStub = nil
-	if fake.waitInstanceUntilReadyWithTicketReturnsOnCall == nil {
-		fake.waitInstanceUntilReadyWithTicketReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
-	}
-	fake.waitInstanceUntilReadyWithTicketReturnsOnCall[i] = struct {
+func (fake *FakeClient) DeleteInstanceFromVPSReturns(result1 error) {
+	fake.DeleteInstanceFromVPSStub = nil
+	fake.deleteInstanceFromVPSReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) WaitInstanceHasActiveTransaction(id int, until time.Time) error {
-	fake.waitInstanceHasActiveTransactionMutex.Lock()
-	ret, specificReturn := fake.waitInstanceHasActiveTransactionReturnsOnCall[len(fake.waitInstanceHasActiveTransactionArgsForCall)]
-	fake.waitInstanceHasActiveTransactionArgsForCall = append(fake.waitInstanceHasActiveTransactionArgsForCall, struct {
-		id    int
-		until time.Time
-	}{id, until})
-	fake.recordInvocation("WaitInstanceHasActiveTransaction", []interface{}{id, until})
-	fake.waitInstanceHasActiveTransactionMutex.Unlock()
-	if fake.WaitInstanceHasActiveTransactionStub != nil {
-		return fake.WaitInstanceHasActiveTransactionStub(id, until)
+func (fake *FakeClient) DeleteInstanceFromVPSReturnsOnCall(i int, result1 error) {
+	fake.DeleteInstanceFromVPSStub = nil
+	if fake.deleteInstanceFromVPSReturnsOnCall == nil {
+		fake.deleteInstanceFromVPSReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteInstanceFromVPSReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CreateSnapshot(volumeId int, notes string) (datatypes.Network_Storage, error) {
+	fake.createSnapshotMutex.Lock()
+	ret, specificReturn := fake.createSnapshotReturnsOnCall[len(fake.createSnapshotArgsForCall)]
+	fake.createSnapshotArgsForCall = append(fake.createSnapshotArgsForCall, struct {
+		volumeId int
+		notes    string
+	}{volumeId, notes})
+	fake.recordInvocation("CreateSnapshot", []interface{}{volumeId, notes})
+	fake.createSnapshotMutex.Unlock()
+	if fake.CreateSnapshotStub != nil {
+		return fake.CreateSnapshotStub(volumeId, notes)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.waitInstanceHasActiveTransactionReturns.result1
+	return fake.createSnapshotReturns.result1, fake.createSnapshotReturns.result2
 }
 
-func (fake *FakeClient) WaitInstanceHasActiveTransactionCallCount() int {
-	fake.waitInstanceHasActiveTransactionMutex.RLock()
-	defer fake.waitInstanceHasActiveTransactionMutex.RUnlock()
-	return len(fake.waitInstanceHasActiveTransactionArgsForCall)
+func (fake *FakeClient) CreateSnapshotCallCount() int {
+	fake.createSnapshotMutex.RLock()
+	defer fake.createSnapshotMutex.RUnlock()
+	return len(fake.createSnapshotArgsForCall)
 }
 
-func (fake *FakeClient) WaitInstanceHasActiveTransactionArgsForCall(i int) (int, time.Time) {
-	fake.waitInstanceHasActiveTransactionMutex.RLock()
-	defer fake.waitInstanceHasActiveTransactionMutex.RUnlock()
-	return fake.waitInstanceHasActiveTransactionArgsForCall[i].id, fake.waitInstanceHasActiveTransactionArgsForCall[i].until
+func (fake *FakeClient) CreateSnapshotArgsForCall(i int) (int, string) {
+	fake.createSnapshotMutex.RLock()
+	defer fake.createSnapshotMutex.RUnlock()
+	return fake.createSnapshotArgsForCall[i].volumeId, fake.createSnapshotArgsForCall[i].notes
 }
 
-func (fake *FakeClient) WaitInstanceHasActiveTransactionReturns(result1 error) {
-	fake.WaitInstanceHasActiveTransactionStub = nil
-	fake.waitInstanceHasActiveTransactionReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeClient) CreateSnapshotReturns(result1 datatypes.Network_Storage, result2 error) {
+	fake.CreateSnapshotStub = nil
+	fake.createSnapshotReturns = struct {
+		result1 datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) WaitInstanceHasActiveTransactionReturnsOnCall(i int, result1 error) {
-	fake.WaitInstanceHasActiveTransactionStub = nil
-	if fake.waitInstanceHasActiveTransactionReturnsOnCall == nil {
-		fake.waitInstanceHasActiveTransactionReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeClient) CreateSnapshotReturnsOnCall(i int, result1 datatypes.Network_Storage, result2 error) {
+	fake.CreateSnapshotStub = nil
+	if fake.createSnapshotReturnsOnCall == nil {
+		fake.createSnapshotReturnsOnCall = make(map[int]struct {
+			result1 datatypes.Network_Storage
+			result2 error
 		})
 	}
-	fake.waitInstanceHasActiveTransactionReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.createSnapshotReturnsOnCall[i] = struct {
+		result1 datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) WaitInstanceHasNoneActiveTransaction(id int, until time.Time) error {
-	fake.waitInstanceHasNoneActiveTransactionMutex.Lock()
-	ret, specificReturn := fake.waitInstanceHasNoneActiveTransactionReturnsOnCall[len(fake.waitInstanceHasNoneActiveTransactionArgsForCall)]
-	fake.waitInstanceHasNoneActiveTransactionArgsForCall = append(fake.waitInstanceHasNoneActiveTransactionArgsForCall, struct {
-		id    int
-		until time.Time
-	}{id, until})
-	fake.recordInvocation("WaitInstanceHasNoneActiveTransaction", []interface{}{id, until})
-	fake.waitInstanceHasNoneActiveTransactionMutex.Unlock()
-	if fake.WaitInstanceHasNoneActiveTransactionStub != nil {
-		return fake.WaitInstanceHasNoneActiveTransactionStub(id, until)
+func (fake *FakeClient) DeleteSnapshot(snapshotId int) error {
+	fake.deleteSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
+	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
+		snapshotId int
+	}{snapshotId})
+	fake.recordInvocation("DeleteSnapshot", []interface{}{snapshotId})
+	fake.deleteSnapshotMutex.Unlock()
+	if fake.DeleteSnapshotStub != nil {
+		return fake.DeleteSnapshotStub(snapshotId)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.waitInstanceHasNoneActiveTransactionReturns.result1
+	return fake.deleteSnapshotReturns.result1
 }
 
-func (fake *FakeClient) WaitInstanceHasNoneActiveTransactionCallCount() int {
-	fake.waitInstanceHasNoneActiveTransactionMutex.RLock()
-	defer fake.waitInstanceHasNoneActiveTransactionMutex.RUnlock()
-	return len(fake.waitInstanceHasNoneActiveTransactionArgsForCall)
+func (fake *FakeClient) DeleteSnapshotCallCount() int {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	return len(fake.deleteSnapshotArgsForCall)
 }
 
-func (fake *FakeClient) WaitInstanceHasNoneActiveTransactionArgsForCall(i int) (int, time.Time) {
-	fake.waitInstanceHasNoneActiveTransactionMutex.RLock()
-	defer fake.waitInstanceHasNoneActiveTransactionMutex.RUnlock()
-	return fake.waitInstanceHasNoneActiveTransactionArgsForCall[i].id, fake.waitInstanceHasNoneActiveTransactionArgsForCall[i].until
+func (fake *FakeClient) DeleteSnapshotArgsForCall(i int) int {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	return fake.deleteSnapshotArgsForCall[i].snapshotId
 }
 
-func (fake *FakeClient) WaitInstanceHasNoneActiveTransactionReturns(result1 error) {
-	fake.WaitInstanceHasNoneActiveTransactionStub = nil
-	fake.waitInstanceHasNoneActiveTransactionReturns = struct {
+func (fake *FakeClient) DeleteSnapshotReturns(result1 error) {
+	fake.DeleteSnapshotStub = nil
+	fake.deleteSnapshotReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) WaitInstanceHasNoneActiveTransactionReturnsOnCall(i int, result1 error) {
-	fake.WaitInstanceHasNoneActiveTransactionStub = nil
-	if fake.waitInstanceHasNoneActiveTransactionReturnsOnCall == nil {
-		fake.waitInstanceHasNoneActiveTransactionReturnsOnCall = make(map[int]struct {
+func (fake *FakeClient) DeleteSnapshotReturnsOnCall(i int, result1 error) {
+	fake.DeleteSnapshotStub = nil
+	if fake.deleteSnapshotReturnsOnCall == nil {
+		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.waitInstanceHasNoneActiveTransactionReturnsOnCall[i] = struct {
+	fake.deleteSnapshotReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) WaitVolumeProvisioningWithOrderId(orderId int, until time.Time) (*datatypes.Network_Storage, error) {
-	fake.waitVolumeProvisioningWithOrderIdMutex.Lock()
-	ret, specificReturn := fake.waitVolumeProvisioningWithOrderIdReturnsOnCall[len(fake.waitVolumeProvisioningWithOrderIdArgsForCall)]
-	fake.waitVolumeProvisioningWithOrderIdArgsForCall = append(fake.waitVolumeProvisioningWithOrderIdArgsForCall, struct {
-		orderId int
-		until   time.Time
-	}{orderId, until})
-	fake.recordInvocation("WaitVolumeProvisioningWithOrderId", []interface{}{orderId, until})
-	fake.waitVolumeProvisioningWithOrderIdMutex.Unlock()
-	if fake.WaitVolumeProvisioningWithOrderIdStub != nil {
-		return fake.WaitVolumeProvisioningWithOrderIdStub(orderId, until)
+func (fake *FakeClient) CreateTicket(ticketSubject *string, ticketTitle *string, contents *string, attachmentId *int, attachmentType *string) error {
+	fake.createTicketMutex.Lock()
+	ret, specificReturn := fake.createTicketReturnsOnCall[len(fake.createTicketArgsForCall)]
+	fake.createTicketArgsForCall = append(fake.createTicketArgsForCall, struct {
+		ticketSubject  *string
+		ticketTitle    *string
+		contents       *string
+		attachmentId   *int
+		attachmentType *string
+	}{ticketSubject, ticketTitle, contents, attachmentId, attachmentType})
+	fake.recordInvocation("CreateTicket", []interface{}{ticketSubject, ticketTitle, contents, attachmentId, attachmentType})
+	fake.createTicketMutex.Unlock()
+	if fake.CreateTicketStub != nil {
+		return fake.CreateTicketStub(ticketSubject, ticketTitle, contents, attachmentId, attachmentType)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.waitVolumeProvisioningWithOrderIdReturns.result1, fake.waitVolumeProvisioningWithOrderIdReturns.result2
+	return fake.createTicketReturns.result1
 }
 
-func (fake *FakeClient) WaitVolumeProvisioningWithOrderIdCallCount() int {
-	fake.waitVolumeProvisioningWithOrderIdMutex.RLock()
-	defer fake.waitVolumeProvisioningWithOrderIdMutex.RUnlock()
-	return len(fake.waitVolumeProvisioningWithOrderIdArgsForCall)
+func (fake *FakeClient) CreateTicketCallCount() int {
+	fake.createTicketMutex.RLock()
+	defer fake.createTicketMutex.RUnlock()
+	return len(fake.createTicketArgsForCall)
 }
 
-func (fake *FakeClient) WaitVolumeProvisioningWithOrderIdArgsForCall(i int) (int, time.Time) {
-	fake.waitVolumeProvisioningWithOrderIdMutex.RLock()
-	defer fake.waitVolumeProvisioningWithOrderIdMutex.RUnlock()
-	return fake.waitVolumeProvisioningWithOrderIdArgsForCall[i].orderId, fake.waitVolumeProvisioningWithOrderIdArgsForCall[i].until
+func (fake *FakeClient) CreateTicketArgsForCall(i int) (*string, *string, *string, *int, *string) {
+	fake.createTicketMutex.RLock()
+	defer fake.createTicketMutex.RUnlock()
+	return fake.createTicketArgsForCall[i].ticketSubject, fake.createTicketArgsForCall[i].ticketTitle, fake.createTicketArgsForCall[i].contents, fake.createTicketArgsForCall[i].attachmentId, fake.createTicketArgsForCall[i].attachmentType
 }
 
-func (fake *FakeClient) WaitVolumeProvisioningWithOrderIdReturns(result1 *datatypes.Network_Storage, result2 error) {
-	fake.WaitVolumeProvisioningWithOrderIdStub = nil
-	fake.waitVolumeProvisioningWithOrderIdReturns = struct {
-		result1 *datatypes.Network_Storage
-		result2 error
-	}{result1, result2}
+func (fake *FakeClient) CreateTicketReturns(result1 error) {
+	fake.CreateTicketStub = nil
+	fake.createTicketReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) WaitVolumeProvisioningWithOrderIdReturnsOnCall(i int, result1 *datatypes.Network_Storage, result2 error) {
-	fake.WaitVolumeProvisioningWithOrderIdStub = nil
-	if fake.waitVolumeProvisioningWithOrderIdReturnsOnCall == nil {
-		fake.waitVolumeProvisioningWithOrderIdReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Network_Storage
-			result2 error
+func (fake *FakeClient) CreateTicketReturnsOnCall(i int, result1 error) {
+	fake.CreateTicketStub = nil
+	if fake.createTicketReturnsOnCall == nil {
+		fake.createTicketReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.waitVolumeProvisioningWithOrderIdReturnsOnCall[i] = struct {
-		result1 *datatypes.Network_Storage
-		result2 error
-	}{result1, result2}
+	fake.createTicketReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) SetTags(id int, tags string) (bool, error) {
-	fake.setTagsMutex.Lock()
-	ret, specificReturn := fake.setTagsReturnsOnCall[len(fake.setTagsArgsForCall)]
-	fake.setTagsArgsForCall = append(fake.setTagsArgsForCall, struct {
-		id   int
-		tags string
-	}{id, tags})
-	fake.recordInvocation("SetTags", []interface{}{id, tags})
-	fake.setTagsMutex.Unlock()
-	if fake.SetTagsStub != nil {
-		return fake.SetTagsStub(id, tags)
+func (fake *FakeClient) CreateSwiftContainer(containerName string) error {
+	fake.createSwiftContainerMutex.Lock()
+	ret, specificReturn := fake.createSwiftContainerReturnsOnCall[len(fake.createSwiftContainerArgsForCall)]
+	fake.createSwiftContainerArgsForCall = append(fake.createSwiftContainerArgsForCall, struct {
+		containerName string
+	}{containerName})
+	fake.recordInvocation("CreateSwiftContainer", []interface{}{containerName})
+	fake.createSwiftContainerMutex.Unlock()
+	if fake.CreateSwiftContainerStub != nil {
+		return fake.CreateSwiftContainerStub(containerName)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.setTagsReturns.result1, fake.setTagsReturns.result2
-}
-
-func (fake *FakeClient) SetTagsCallCount() int {
-	fake.setTagsMutex.RLock()
-	defer fake.setTagsMutex.RUnlock()
-	return len(fake.setTagsArgsForCall)
+	return fake.createSwiftContainerReturns.result1
 }
 
-func (fake *FakeClient) SetTagsArgsForCall(i int) (int, string) {
-	fake.setTagsMutex.RLock()
-	defer fake.setTagsMutex.RUnlock()
-	return fake.setTagsArgsForCall[i].id, fake.setTagsArgsForCall[i].tags
+func (fake *FakeClient) CreateSwiftContainerCallCount() int {
+	fake.createSwiftContainerMutex.RLock()
+	defer fake.createSwiftContainerMutex.RUnlock()
+	return len(fake.createSwiftContainerArgsForCall)
 }
 
-func (fake *FakeClient) SetTagsReturns(result1 bool, result2 error) {
-	fake.SetTagsStub = nil
-	fake.setTagsReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+func (fake *FakeClient) CreateSwiftContainerArgsForCall(i int) string {
+	fake.createSwiftContainerMutex.RLock()
+	defer fake.createSwiftContainerMutex.RUnlock()
+	return fake.createSwiftContainerArgsForCall[i].containerName
 }
 
-func (fake *FakeClient) SetTagsReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.SetTagsStub = nil
-	if fake.setTagsReturnsOnCall == nil {
-		fake.setTagsReturnsOnCall = make(map[int]struct {
-			result1 bool
-			result2 error
+func (fake *FakeClient) CreateSwiftContainerReturns(result1 error) {
+	fake.CreateSwiftContainerStub = nil
+	fake.createSwiftContainerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CreateSwiftContainerReturnsOnCall(i int, result1 error) {
+	fake.CreateSwiftContainerStub = nil
+	if fake.createSwiftContainerReturnsOnCall == nil {
+		fake.createSwiftContainerReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.setTagsReturnsOnCall[i] = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+	fake.createSwiftContainerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) SetInstanceMetadata(id int, encodedUserData *string) (bool, error) {
-	fake.setInstanceMetadataMutex.Lock()
-	ret, specificReturn := fake.setInstanceMetadataReturnsOnCall[len(fake.setInstanceMetadataArgsForCall)]
-	fake.setInstanceMetadataArgsForCall = append(fake.setInstanceMetadataArgsForCall, struct {
-		id              int
-		encodedUserData *string
-	}{id, encodedUserData})
-	fake.recordInvocation("SetInstanceMetadata", []interface{}{id, encodedUserData})
-	fake.setInstanceMetadataMutex.Unlock()
-	if fake.SetInstanceMetadataStub != nil {
-		return fake.SetInstanceMetadataStub(id, encodedUserData)
+func (fake *FakeClient) DeleteSwiftContainer(containerName string) error {
+	fake.deleteSwiftContainerMutex.Lock()
+	ret, specificReturn := fake.deleteSwiftContainerReturnsOnCall[len(fake.deleteSwiftContainerArgsForCall)]
+	fake.deleteSwiftContainerArgsForCall = append(fake.deleteSwiftContainerArgsForCall, struct {
+		containerName string
+	}{containerName})
+	fake.recordInvocation("DeleteSwiftContainer", []interface{}{containerName})
+	fake.deleteSwiftContainerMutex.Unlock()
+	if fake.DeleteSwiftContainerStub != nil {
+		return fake.DeleteSwiftContainerStub(containerName)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.setInstanceMetadataReturns.result1, fake.setInstanceMetadataReturns.result2
+	return fake.deleteSwiftContainerReturns.result1
 }
 
-func (fake *FakeClient) SetInstanceMetadataCallCount() int {
-	fake.setInstanceMetadataMutex.RLock()
-	defer fake.setInstanceMetadataMutex.RUnlock()
-	return len(fake.setInstanceMetadataArgsForCall)
+func (fake *FakeClient) DeleteSwiftContainerCallCount() int {
+	fake.deleteSwiftContainerMutex.RLock()
+	defer fake.deleteSwiftContainerMutex.RUnlock()
+	return len(fake.deleteSwiftContainerArgsForCall)
 }
 
-func (fake *FakeClient) SetInstanceMetadataArgsForCall(i int) (int, *string) {
-	fake.setInstanceMetadataMutex.RLock()
-	defer fake.setInstanceMetadataMutex.RUnlock()
-	return fake.setInstanceMetadataArgsForCall[i].id, fake.setInstanceMetadataArgsForCall[i].encodedUserData
+func (fake *FakeClient) DeleteSwiftContainerArgsForCall(i int) string {
+	fake.deleteSwiftContainerMutex.RLock()
+	defer fake.deleteSwiftContainerMutex.RUnlock()
+	return fake.deleteSwiftContainerArgsForCall[i].containerName
 }
 
-func (fake *FakeClient) SetInstanceMetadataReturns(result1 bool, result2 error) {
-	fake.SetInstanceMetadataStub = nil
-	fake.setInstanceMetadataReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+func (fake *FakeClient) DeleteSwiftContainerReturns(result1 error) {
+	fake.DeleteSwiftContainerStub = nil
+	fake.deleteSwiftContainerReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) SetInstanceMetadataReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.SetInstanceMetadataStub = nil
-	if fake.setInstanceMetadataReturnsOnCall == nil {
-		fake.setInstanceMetadataReturnsOnCall = make(map[int]struct {
-			result1 bool
-			result2 error
+func (fake *FakeClient) DeleteSwiftContainerReturnsOnCall(i int, result1 error) {
+	fake.DeleteSwiftContainerStub = nil
+	if fake.deleteSwiftContainerReturnsOnCall == nil {
+		fake.deleteSwiftContainerReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.setInstanceMetadataReturnsOnCall[i] = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+	fake.deleteSwiftContainerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) AttachSecondDiskToInstance(id int, diskSize int) error {
-	fake.attachSecondDiskToInstanceMutex.Lock()
-	ret, specificReturn := fake.attachSecondDiskToInstanceReturnsOnCall[len(fake.attachSecondDiskToInstanceArgsForCall)]
-	fake.attachSecondDiskToInstanceArgsForCall = append(fake.attachSecondDiskToInstanceArgsForCall, struct {
-		id       int
-		diskSize int
-	}{id, diskSize})
-	fake.recordInvocation("AttachSecondDiskToInstance", []interface{}{id, diskSize})
-	fake.attachSecondDiskToInstanceMutex.Unlock()
-	if fake.AttachSecondDiskToInstanceStub != nil {
-		return fake.AttachSecondDiskToInstanceStub(id, diskSize)
+func (fake *FakeClient) UploadSwiftLargeObject(containerName string, objectName string, objectFile string) error {
+	fake.uploadSwiftLargeObjectMutex.Lock()
+	ret, specificReturn := fake.uploadSwiftLargeObjectReturnsOnCall[len(fake.uploadSwiftLargeObjectArgsForCall)]
+	fake.uploadSwiftLargeObjectArgsForCall = append(fake.uploadSwiftLargeObjectArgsForCall, struct {
+		containerName string
+		objectName    string
+		objectFile    string
+	}{containerName, objectName, objectFile})
+	fake.recordInvocation("UploadSwiftLargeObject", []interface{}{containerName, objectName, objectFile})
+	fake.uploadSwiftLargeObjectMutex.Unlock()
+	if fake.UploadSwiftLargeObjectStub != nil {
+		return fake.UploadSwiftLargeObjectStub(containerName, objectName, objectFile)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.attachSecondDiskToInstanceReturns.result1
+	return fake.uploadSwiftLargeObjectReturns.result1
 }
 
-func (fake *FakeClient) AttachSecondDiskToInstanceCallCount() int {
-	fake.attachSecondDiskToInstanceMutex.RLock()
-	defer fake.attachSecondDiskToInstanceMutex.RUnlock()
-	return len(fake.attachSecondDiskToInstanceArgsForCall)
+func (fake *FakeClient) UploadSwiftLargeObjectCallCount() int {
+	fake.uploadSwiftLargeObjectMutex.RLock()
+	defer fake.uploadSwiftLargeObjectMutex.RUnlock()
+	return len(fake.uploadSwiftLargeObjectArgsForCall)
 }
 
-func (fake *FakeClient) AttachSecondDiskToInstanceArgsForCall(i int) (int, int) {
-	fake.attachSecondDiskToInstanceMutex.RLock()
-	defer fake.attachSecondDiskToInstanceMutex.RUnlock()
-	return fake.attachSecondDiskToInstanceArgsForCall[i].id, fake.attachSecondDiskToInstanceArgsForCall[i].diskSize
+func (fake *FakeClient) UploadSwiftLargeObjectArgsForCall(i int) (string, string, string) {
+	fake.uploadSwiftLargeObjectMutex.RLock()
+	defer fake.uploadSwiftLargeObjectMutex.RUnlock()
+	return fake.uploadSwiftLargeObjectArgsForCall[i].containerName, fake.uploadSwiftLargeObjectArgsForCall[i].objectName, fake.uploadSwiftLargeObjectArgsForCall[i].objectFile
 }
 
-func (fake *FakeClient) AttachSecondDiskToInstanceReturns(result1 error) {
-	fake.AttachSecondDiskToInstanceStub = nil
-	fake.attachSecondDiskToInstanceReturns = struct {
+func (fake *FakeClient) UploadSwiftLargeObjectReturns(result1 error) {
+	fake.UploadSwiftLargeObjectStub = nil
+	fake.uploadSwiftLargeObjectReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) AttachSecondDiskToInstanceReturnsOnCall(i int, result1 error) {
-	fake.AttachSecondDiskToInstanceStub = nil
-	if fake.attachSecondDiskToInstanceReturnsOnCall == nil {
-		fake.attachSecondDiskToInstanceReturnsOnCall = make(map[int]struct {
+func (fake *FakeClient) UploadSwiftLargeObjectReturnsOnCall(i int, result1 error) {
+	fake.UploadSwiftLargeObjectStub = nil
+	if fake.uploadSwiftLargeObjectReturnsOnCall == nil {
+		fake.uploadSwiftLargeObjectReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.attachSecondDiskToInstanceReturnsOnCall[i] = struct {
+	fake.uploadSwiftLargeObjectReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) GetInstanceAllowedHost(id int) (*datatypes.Network_Storage_Allowed_Host, bool, error) {
-	fake.getInstanceAllowedHostMutex.Lock()
-	ret, specificReturn := fake.getInstanceAllowedHostReturnsOnCall[len(fake.getInstanceAllowedHostArgsForCall)]
-	fake.getInstanceAllowedHostArgsForCall = append(fake.getInstanceAllowedHostArgsForCall, struct {
-		id int
-	}{id})
-	fake.recordInvocation("GetInstanceAllowedHost", []interface{}{id})
-	fake.getInstanceAllowedHostMutex.Unlock()
-	if fake.GetInstanceAllowedHostStub != nil {
-		return fake.GetInstanceAllowedHostStub(id)
+func (fake *FakeClient) DeleteSwiftLargeObject(containerName string, objectFileName string) error {
+	fake.deleteSwiftLargeObjectMutex.Lock()
+	ret, specificReturn := fake.deleteSwiftLargeObjectReturnsOnCall[len(fake.deleteSwiftLargeObjectArgsForCall)]
+	fake.deleteSwiftLargeObjectArgsForCall = append(fake.deleteSwiftLargeObjectArgsForCall, struct {
+		containerName  string
+		objectFileName string
+	}{containerName, objectFileName})
+	fake.recordInvocation("DeleteSwiftLargeObject", []interface{}{containerName, objectFileName})
+	fake.deleteSwiftLargeObjectMutex.Unlock()
+	if fake.DeleteSwiftLargeObjectStub != nil {
+		return fake.DeleteSwiftLargeObjectStub(containerName, objectFileName)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1
 	}
-	return fake.getInstanceAllowedHostReturns.result1, fake.getInstanceAllowedHostReturns.result2, fake.getInstanceAllowedHostReturns.result3
+	return fake.deleteSwiftLargeObjectReturns.result1
 }
 
-func (fake *FakeClient) GetInstanceAllowedHostCallCount() int {
-	fake.getInstanceAllowedHostMutex.RLock()
-	defer fake.getInstanceAllowedHostMutex.RUnlock()
-	return len(fake.getInstanceAllowedHostArgsForCall)
+func (fake *FakeClient) DeleteSwiftLargeObjectCallCount() int {
+	fake.deleteSwiftLargeObjectMutex.RLock()
+	defer fake.deleteSwiftLargeObjectMutex.RUnlock()
+	return len(fake.deleteSwiftLargeObjectArgsForCall)
 }
 
-func (fake *FakeClient) GetInstanceAllowedHostArgsForCall(i int) int {
-	fake.getInstanceAllowedHostMutex.RLock()
-	defer fake.getInstanceAllowedHostMutex.RUnlock()
-	return fake.getInstanceAllowedHostArgsForCall[i].id
+func (fake *FakeClient) DeleteSwiftLargeObjectArgsForCall(i int) (string, string) {
+	fake.deleteSwiftLargeObjectMutex.RLock()
+	defer fake.deleteSwiftLargeObjectMutex.RUnlock()
+	return fake.deleteSwiftLargeObjectArgsForCall[i].containerName, fake.deleteSwiftLargeObjectArgsForCall[i].objectFileName
 }
 
-func (fake *FakeClient) GetInstanceAllowedHostReturns(result1 *datatypes.Network_Storage_Allowed_Host, result2 bool, result3 error) {
-	fake.GetInstanceAllowedHostStub = nil
-	fake.getInstanceAllowedHostReturns = struct {
-		result1 *datatypes.Network_Storage_Allowed_Host
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+func (fake *FakeClient) DeleteSwiftLargeObjectReturns(result1 error) {
+	fake.DeleteSwiftLargeObjectStub = nil
+	fake.deleteSwiftLargeObjectReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) GetInstanceAllowedHostReturnsOnCall(i int, result1 *datatypes.Network_Storage_Allowed_Host, result2 bool, result3 error) {
-	fake.GetInstanceAllowedHostStub = nil
-	if fake.getInstanceAllowedHostReturnsOnCall == nil {
-		fake.getInstanceAllowedHostReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Network_Storage_Allowed_Host
-			result2 bool
-			result3 error
+func (fake *FakeClient) DeleteSwiftLargeObjectReturnsOnCall(i int, result1 error) {
+	fake.DeleteSwiftLargeObjectStub = nil
+	if fake.deleteSwiftLargeObjectReturnsOnCall == nil {
+		fake.deleteSwiftLargeObjectReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getInstanceAllowedHostReturnsOnCall[i] = struct {
-		result1 *datatypes.Network_Storage_Allowed_Host
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+	fake.deleteSwiftLargeObjectReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) AuthorizeHostToVolume(instance *datatypes.Virtual_Guest, volumeId int, until time.Time) (bool, error) {
-	fake.authorizeHostToVolumeMutex.Lock()
-	ret, specificReturn := fake.authorizeHostToVolumeReturnsOnCall[len(fake.authorizeHostToVolumeArgsForCall)]
-	fake.authorizeHostToVolumeArgsForCall = append(fake.authorizeHostToVolumeArgsForCall, struct {
-		instance *datatypes.Virtual_Guest
-		volumeId int
-		until    time.Time
-	}{instance, volumeId, until})
-	fake.recordInvocation("AuthorizeHostToVolume", []interface{}{instance, volumeId, until})
-	fake.authorizeHostToVolumeMutex.Unlock()
-	if fake.AuthorizeHostToVolumeStub != nil {
-		return fake.AuthorizeHostToVolumeStub(instance, volumeId, until)
+func (fake *FakeClient) CreateImageFromExternalSource(imageName string, note string, cluster string, osCode string) (int, error) {
+	fake.createImageFromExternalSourceMutex.Lock()
+	ret, specificReturn := fake.createImageFromExternalSourceReturnsOnCall[len(fake.createImageFromExternalSourceArgsForCall)]
+	fake.createImageFromExternalSourceArgsForCall = append(fake.createImageFromExternalSourceArgsForCall, struct {
+		imageName string
+		note      string
+		cluster   string
+		osCode    string
+	}{imageName, note, cluster, osCode})
+	fake.recordInvocation("CreateImageFromExternalSource", []interface{}{imageName, note, cluster, osCode})
+	fake.createImageFromExternalSourceMutex.Unlock()
+	if fake.CreateImageFromExternalSourceStub != nil {
+		return fake.CreateImageFromExternalSourceStub(imageName, note, cluster, osCode)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.authorizeHostToVolumeReturns.result1, fake.authorizeHostToVolumeReturns.result2
+	return fake.createImageFromExternalSourceReturns.result1, fake.createImageFromExternalSourceReturns.result2
 }
 
-func (fake *FakeClient) AuthorizeHostToVolumeCallCount() int {
-	fake.authorizeHostToVolumeMutex.RLock()
-	defer fake.authorizeHostToVolumeMutex.RUnlock()
-	return len(fake.authorizeHostToVolumeArgsForCall)
+func (fake *FakeClient) CreateImageFromExternalSourceCallCount() int {
+	fake.createImageFromExternalSourceMutex.RLock()
+	defer fake.createImageFromExternalSourceMutex.RUnlock()
+	return len(fake.createImageFromExternalSourceArgsForCall)
 }
 
-func (fake *FakeClient) AuthorizeHostToVolumeArgsForCall(i int) (*datatypes.Virtual_Guest, int, time.Time) {
-	fake.authorizeHostToVolumeMutex.RLock()
-	defer fake.authorizeHostToVolumeMutex.RUnlock()
-	return fake.authorizeHostToVolumeArgsForCall[i].instance, fake.authorizeHostToVolumeArgsForCall[i].volumeId, fake.authorizeHostToVolumeArgsForCall[i].until
+func (fake *FakeClient) CreateImageFromExternalSourceArgsForCall(i int) (string, string, string, string) {
+	fake.createImageFromExternalSourceMutex.RLock()
+	defer fake.createImageFromExternalSourceMutex.RUnlock()
+	return fake.createImageFromExternalSourceArgsForCall[i].imageName, fake.createImageFromExternalSourceArgsForCall[i].note, fake.createImageFromExternalSourceArgsForCall[i].cluster, fake.createImageFromExternalSourceArgsForCall[i].osCode
 }
 
-func (fake *FakeClient) AuthorizeHostToVolumeReturns(result1 bool, result2 error) {
-	fake.AuthorizeHostToVolumeStub = nil
-	fake.authorizeHostToVolumeReturns = struct {
-		result1 bool
+func (fake *FakeClient) CreateImageFromExternalSourceReturns(result1 int, result2 error) {
+	fake.CreateImageFromExternalSourceStub = nil
+	fake.createImageFromExternalSourceReturns = struct {
+		result1 int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) AuthorizeHostToVolumeReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.AuthorizeHostToVolumeStub = nil
-	if fake.authorizeHostToVolumeReturnsOnCall == nil {
-		fake.authorizeHostToVolumeReturnsOnCall = make(map[int]struct {
-			result1 bool
+func (fake *FakeClient) CreateImageFromExternalSourceReturnsOnCall(i int, result1 int, result2 error) {
+	fake.CreateImageFromExternalSourceStub = nil
+	if fake.createImageFromExternalSourceReturnsOnCall == nil {
+		fake.createImageFromExternalSourceReturnsOnCall = make(map[int]struct {
+			result1 int
 			result2 error
 		})
 	}
-	fake.authorizeHostToVolumeReturnsOnCall[i] = struct {
-		result1 bool
+	fake.createImageFromExternalSourceReturnsOnCall[i] = struct {
+		result1 int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) DeauthorizeHostToVolume(instance *datatypes.Virtual_Guest, volumeId int, until time.Time) (bool, error) {
-	fake.deauthorizeHostToVolumeMutex.Lock()
-	ret, specificReturn := fake.deauthorizeHostToVolumeReturnsOnCall[len(fake.deauthorizeHostToVolumeArgsForCall)]
-	fake.deauthorizeHostToVolumeArgsForCall = append(fake.deauthorizeHostToVolumeArgsForCall, struct {
-		instance *datatypes.Virtual_Guest
-		volumeId int
-		until    time.Time
-	}{instance, volumeId, until})
-	fake.recordInvocation("DeauthorizeHostToVolume", []interface{}{instance, volumeId, until})
-	fake.deauthorizeHostToVolumeMutex.Unlock()
-	if fake.DeauthorizeHostToVolumeStub != nil {
-		return fake.DeauthorizeHostToVolumeStub(instance, volumeId, until)
+func (fake *FakeClient) GetVirtualServerPackage(mask string) (datatypes.Product_Package, error) {
+	fake.getVirtualServerPackageMutex.Lock()
+	ret, specificReturn := fake.getVirtualServerPackageReturnsOnCall[len(fake.getVirtualServerPackageArgsForCall)]
+	fake.getVirtualServerPackageArgsForCall = append(fake.getVirtualServerPackageArgsForCall, struct {
+		mask string
+	}{mask})
+	fake.recordInvocation("GetVirtualServerPackage", []interface{}{mask})
+	fake.getVirtualServerPackageMutex.Unlock()
+	if fake.GetVirtualServerPackageStub != nil {
+		return fake.GetVirtualServerPackageStub(mask)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.deauthorizeHostToVolumeReturns.result1, fake.deauthorizeHostToVolumeReturns.result2
+	return fake.getVirtualServerPackageReturns.result1, fake.getVirtualServerPackageReturns.result2
 }
 
-func (fake *FakeClient) DeauthorizeHostToVolumeCallCount() int {
-	fake.deauthorizeHostToVolumeMutex.RLock()
-	defer fake.deauthorizeHostToVolumeMutex.RUnlock()
-	return len(fake.deauthorizeHostToVolumeArgsForCall)
+func (fake *FakeClient) GetVirtualServerPackageCallCount() int {
+	fake.getVirtualServerPackageMutex.RLock()
+	defer fake.getVirtualServerPackageMutex.RUnlock()
+	return len(fake.getVirtualServerPackageArgsForCall)
 }
 
-func (fake *FakeClient) DeauthorizeHostToVolumeArgsForCall(i int) (*datatypes.Virtual_Guest, int, time.Time) {
-	fake.deauthorizeHostToVolumeMutex.RLock()
-	defer fake.deauthorizeHostToVolumeMutex.RUnlock()
-	return fake.deauthorizeHostToVolumeArgsForCall[i].instance, fake.deauthorizeHostToVolumeArgsForCall[i].volumeId, fake.deauthorizeHostToVolumeArgsForCall[i].until
+func (fake *FakeClient) GetVirtualServerPackageArgsForCall(i int) string {
+	fake.getVirtualServerPackageMutex.RLock()
+	defer fake.getVirtualServerPackageMutex.RUnlock()
+	return fake.getVirtualServerPackageArgsForCall[i].mask
 }
 
-func (fake *FakeClient) DeauthorizeHostToVolumeReturns(result1 bool, result2 error) {
-	fake.DeauthorizeHostToVolumeStub = nil
-	fake.deauthorizeHostToVolumeReturns = struct {
-		result1 bool
+func (fake *FakeClient) GetVirtualServerPackageReturns(result1 datatypes.Product_Package, result2 error) {
+	fake.GetVirtualServerPackageStub = nil
+	fake.getVirtualServerPackageReturns = struct {
+		result1 datatypes.Product_Package
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) DeauthorizeHostToVolumeReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.DeauthorizeHostToVolumeStub = nil
-	if fake.deauthorizeHostToVolumeReturnsOnCall == nil {
-		fake.deauthorizeHostToVolumeReturnsOnCall = make(map[int]struct {
-			result1 bool
+func (fake *FakeClient) GetVirtualServerPackageReturnsOnCall(i int, result1 datatypes.Product_Package, result2 error) {
+	fake.GetVirtualServerPackageStub = nil
+	if fake.getVirtualServerPackageReturnsOnCall == nil {
+		fake.getVirtualServerPackageReturnsOnCall = make(map[int]struct {
+			result1 datatypes.Product_Package
 			result2 error
 		})
 	}
-	fake.deauthorizeHostToVolumeReturnsOnCall[i] = struct {
-		result1 bool
+	fake.getVirtualServerPackageReturnsOnCall[i] = struct {
+		result1 datatypes.Product_Package
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) CreateVolume(location string, size int, iops int, snapshotSpace int) (*datatypes.Network_Storage, error) {
-	fake.createVolumeMutex.Lock()
-	ret, specificReturn := fake.createVolumeReturnsOnCall[len(fake.createVolumeArgsForCall)]
-	fake.createVolumeArgsForCall = append(fake.createVolumeArgsForCall, struct {
-		location      string
-		size          int
-		iops          int
-		snapshotSpace int
-	}{location, size, iops, snapshotSpace})
-	fake.recordInvocation("CreateVolume", []interface{}{location, size, iops, snapshotSpace})
-	fake.createVolumeMutex.Unlock()
-	if fake.CreateVolumeStub != nil {
-		return fake.CreateVolumeStub(location, size, iops, snapshotSpace)
+func (fake *FakeClient) ModifyVolume(volumeId int, size int, iops int, tier float64) error {
+	fake.modifyVolumeMutex.Lock()
+	ret, specificReturn := fake.modifyVolumeReturnsOnCall[len(fake.modifyVolumeArgsForCall)]
+	fake.modifyVolumeArgsForCall = append(fake.modifyVolumeArgsForCall, struct {
+		volumeId int
+		size     int
+		iops     int
+		tier     float64
+	}{volumeId, size, iops, tier})
+	fake.recordInvocation("ModifyVolume", []interface{}{volumeId, size, iops, tier})
+	fake.modifyVolumeMutex.Unlock()
+	if fake.ModifyVolumeStub != nil {
+		return fake.ModifyVolumeStub(volumeId, size, iops, tier)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.createVolumeReturns.result1, fake.createVolumeReturns.result2
+	return fake.modifyVolumeReturns.result1
 }
 
-func (fake *FakeClient) CreateVolumeCallCount() int {
-	fake.createVolumeMutex.RLock()
-	defer fake.createVolumeMutex.RUnlock()
-	return len(fake.createVolumeArgsForCall)
+func (fake *FakeClient) ModifyVolumeCallCount() int {
+	fake.modifyVolumeMutex.RLock()
+	defer fake.modifyVolumeMutex.RUnlock()
+	return len(fake.modifyVolumeArgsForCall)
 }
 
-func (fake *FakeClient) CreateVolumeArgsForCall(i int) (string, int, int, int) {
-	fake.createVolumeMutex.RLock()
-	defer fake.createVolumeMutex.RUnlock()
-	return fake.createVolumeArgsForCall[i].location, fake.createVolumeArgsForCall[i].size, fake.createVolumeArgsForCall[i].iops, fake.createVolumeArgsForCall[i].snapshotSpace
+func (fake *FakeClient) ModifyVolumeArgsForCall(i int) (int, int, int, float64) {
+	fake.modifyVolumeMutex.RLock()
+	defer fake.modifyVolumeMutex.RUnlock()
+	return fake.modifyVolumeArgsForCall[i].volumeId, fake.modifyVolumeArgsForCall[i].size, fake.modifyVolumeArgsForCall[i].iops, fake.modifyVolumeArgsForCall[i].tier
 }
 
-func (fake *FakeClient) CreateVolumeReturns(result1 *datatypes.Network_Storage, result2 error) {
-	fake.CreateVolumeStub = nil
-	fake.createVolumeReturns = struct {
-		result1 *datatypes.Network_Storage
-		result2 error
-	}{result1, result2}
+func (fake *FakeClient) ModifyVolumeReturns(result1 error) {
+	fake.ModifyVolumeStub = nil
+	fake.modifyVolumeReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) CreateVolumeReturnsOnCall(i int, result1 *datatypes.Network_Storage, result2 error) {
-	fake.CreateVolumeStub = nil
-	if fake.createVolumeReturnsOnCall == nil {
-		fake.createVolumeReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Network_Storage
-			result2 error
+func (fake *FakeClient) ModifyVolumeReturnsOnCall(i int, result1 error) {
+	fake.ModifyVolumeStub = nil
+	if fake.modifyVolumeReturnsOnCall == nil {
+		fake.modifyVolumeReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.createVolumeReturnsOnCall[i] = struct {
-		result1 *datatypes.Network_Storage
-		result2 error
-	}{result1, result2}
+	fake.modifyVolumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) OrderBlockVolume(storageType string, location string, size int, iops int) (*datatypes.Container_Product_Order_Receipt, error) {
-	fake.orderBlockVolumeMutex.Lock()
-	ret, specificReturn := fake.orderBlockVolumeReturnsOnCall[len(fake.orderBlockVolumeArgsForCall)]
-	fake.orderBlockVolumeArgsForCall = append(fake.orderBlockVolumeArgsForCall, struct {
-		storageType string
-		location    string
-		size        int
-		iops        int
-	}{storageType, location, size, iops})
-	fake.recordInvocation("OrderBlockVolume", []interface{}{storageType, location, size, iops})
-	fake.orderBlockVolumeMutex.Unlock()
-	if fake.OrderBlockVolumeStub != nil {
-		return fake.OrderBlockVolumeStub(storageType, location, size, iops)
+func (fake *FakeClient) UpgradeBlockVolume(volumeId int, size int, iops int, tier float64) (*datatypes.Container_Product_Order_Receipt, error) {
+	fake.upgradeBlockVolumeMutex.Lock()
+	ret, specificReturn := fake.upgradeBlockVolumeReturnsOnCall[len(fake.upgradeBlockVolumeArgsForCall)]
+	fake.upgradeBlockVolumeArgsForCall = append(fake.upgradeBlockVolumeArgsForCall, struct {
+		volumeId int
+		size     int
+		iops     int
+		tier     float64
+	}{volumeId, size, iops, tier})
+	fake.recordInvocation("UpgradeBlockVolume", []interface{}{volumeId, size, iops, tier})
+	fake.upgradeBlockVolumeMutex.Unlock()
+	if fake.UpgradeBlockVolumeStub != nil {
+		return fake.UpgradeBlockVolumeStub(volumeId, size, iops, tier)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.orderBlockVolumeReturns.result1, fake.orderBlockVolumeReturns.result2
+	return fake.upgradeBlockVolumeReturns.result1, fake.upgradeBlockVolumeReturns.result2
 }
 
-func (fake *FakeClient) OrderBlockVolumeCallCount() int {
-	fake.orderBlockVolumeMutex.RLock()
-	defer fake.orderBlockVolumeMutex.RUnlock()
-	return len(fake.orderBlockVolumeArgsForCall)
+func (fake *FakeClient) UpgradeBlockVolumeCallCount() int {
+	fake.upgradeBlockVolumeMutex.RLock()
+	defer fake.upgradeBlockVolumeMutex.RUnlock()
+	return len(fake.upgradeBlockVolumeArgsForCall)
 }
 
-func (fake *FakeClient) OrderBlockVolumeArgsForCall(i int) (string, string, int, int) {
-	fake.orderBlockVolumeMutex.RLock()
-	defer fake.orderBlockVolumeMutex.RUnlock()
-	return fake.orderBlockVolumeArgsForCall[i].storageType, fake.orderBlockVolumeArgsForCall[i].location, fake.orderBlockVolumeArgsForCall[i].size, fake.orderBlockVolumeArgsForCall[i].iops
+func (fake *FakeClient) UpgradeBlockVolumeArgsForCall(i int) (int, int, int, float64) {
+	fake.upgradeBlockVolumeMutex.RLock()
+	defer fake.upgradeBlockVolumeMutex.RUnlock()
+	return fake.upgradeBlockVolumeArgsForCall[i].volumeId, fake.upgradeBlockVolumeArgsForCall[i].size, fake.upgradeBlockVolumeArgsForCall[i].iops, fake.upgradeBlockVolumeArgsForCall[i].tier
 }
 
-func (fake *FakeClient) OrderBlockVolumeReturns(result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
-	fake.OrderBlockVolumeStub = nil
-	fake.orderBlockVolumeReturns = struct {
+func (fake *FakeClient) UpgradeBlockVolumeReturns(result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
+	fake.UpgradeBlockVolumeStub = nil
+	fake.upgradeBlockVolumeReturns = struct {
 		result1 *datatypes.Container_Product_Order_Receipt
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) OrderBlockVolumeReturnsOnCall(i int, result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
-	fake.OrderBlockVolumeStub = nil
-	if fake.orderBlockVolumeReturnsOnCall == nil {
-		fake.orderBlockVolumeReturnsOnCall = make(map[int]struct {
+func (fake *FakeClient) UpgradeBlockVolumeReturnsOnCall(i int, result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
+	fake.UpgradeBlockVolumeStub = nil
+	if fake.upgradeBlockVolumeReturnsOnCall == nil {
+		fake.upgradeBlockVolumeReturnsOnCall = make(map[int]struct {
 			result1 *datatypes.Container_Product_Order_Receipt
 			result2 error
 		})
 	}
-	fake.orderBlockVolumeReturnsOnCall[i] = struct {
+	fake.upgradeBlockVolumeReturnsOnCall[i] = struct {
 		result1 *datatypes.Container_Product_Order_Receipt
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) OrderBlockVolume2(storageType string, location string, size int, iops int, snapshotSpace int) (*datatypes.Container_Product_Order_Receipt, error) {
-	fake.orderBlockVolume2Mutex.Lock()
-	ret, specificReturn := fake.orderBlockVolume2ReturnsOnCall[len(fake.orderBlockVolume2ArgsForCall)]
-	fake.orderBlockVolume2ArgsForCall = append(fake.orderBlockVolume2ArgsForCall, struct {
-		storageType   string
-		location      string
-		size          int
-		iops          int
-		snapshotSpace int
-	}{storageType, location, size, iops, snapshotSpace})
-	fake.recordInvocation("OrderBlockVolume2", []interface{}{storageType, location, size, iops, snapshotSpace})
-	fake.orderBlockVolume2Mutex.Unlock()
-	if fake.OrderBlockVolume2Stub != nil {
-		return fake.OrderBlockVolume2Stub(storageType, location, size, iops, snapshotSpace)
+func (fake *FakeClient) OrderSnapshotSpace(volumeId int, location string, size int, iops int, tier float64, upgrade bool) (*datatypes.Container_Product_Order_Receipt, error) {
+	fake.orderSnapshotSpaceMutex.Lock()
+	ret, specificReturn := fake.orderSnapshotSpaceReturnsOnCall[len(fake.orderSnapshotSpaceArgsForCall)]
+	fake.orderSnapshotSpaceArgsForCall = append(fake.orderSnapshotSpaceArgsForCall, struct {
+		volumeId int
+		location string
+		size     int
+		iops     int
+		tier     float64
+		upgrade  bool
+	}{volumeId, location, size, iops, tier, upgrade})
+	fake.recordInvocation("OrderSnapshotSpace", []interface{}{volumeId, location, size, iops, tier, upgrade})
+	fake.orderSnapshotSpaceMutex.Unlock()
+	if fake.OrderSnapshotSpaceStub != nil {
+		return fake.OrderSnapshotSpaceStub(volumeId, location, size, iops, tier, upgrade)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.orderBlockVolume2Returns.result1, fake.orderBlockVolume2Returns.result2
+	return fake.orderSnapshotSpaceReturns.result1, fake.orderSnapshotSpaceReturns.result2
 }
 
-func (fake *FakeClient) OrderBlockVolume2CallCount() int {
-	fake.orderBlockVolume2Mutex.RLock()
-	defer fake.orderBlockVolume2Mutex.RUnlock()
-	return len(fake.orderBlockVolume2ArgsForCall)
+func (fake *FakeClient) OrderSnapshotSpaceCallCount() int {
+	fake.orderSnapshotSpaceMutex.RLock()
+	defer fake.orderSnapshotSpaceMutex.RUnlock()
+	return len(fake.orderSnapshotSpaceArgsForCall)
 }
 
-func (fake *FakeClient) OrderBlockVolume2ArgsForCall(i int) (string, string, int, int, int) {
-	fake.orderBlockVolume2Mutex.RLock()
-	defer fake.orderBlockVolume2Mutex.RUnlock()
-	return fake.orderBlockVolume2ArgsForCall[i].storageType, fake.orderBlockVolume2ArgsForCall[i].location, fake.orderBlockVolume2ArgsForCall[i].size, fake.orderBlockVolume2ArgsForCall[i].iops, fake.orderBlockVolume2ArgsForCall[i].snapshotSpace
+func (fake *FakeClient) OrderSnapshotSpaceArgsForCall(i int) (int, string, int, int, float64, bool) {
+	fake.orderSnapshotSpaceMutex.RLock()
+	defer fake.orderSnapshotSpaceMutex.RUnlock()
+	return fake.orderSnapshotSpaceArgsForCall[i].volumeId, fake.orderSnapshotSpaceArgsForCall[i].location, fake.orderSnapshotSpaceArgsForCall[i].size, fake.orderSnapshotSpaceArgsForCall[i].iops, fake.orderSnapshotSpaceArgsForCall[i].tier, fake.orderSnapshotSpaceArgsForCall[i].upgrade
 }
 
-func (fake *FakeClient) OrderBlockVolume2Returns(result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
-	fake.OrderBlockVolume2Stub = nil
-	fake.orderBlockVolume2Returns = struct {
+func (fake *FakeClient) OrderSnapshotSpaceReturns(result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
+	fake.OrderSnapshotSpaceStub = nil
+	fake.orderSnapshotSpaceReturns = struct {
 		result1 *datatypes.Container_Product_Order_Receipt
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) OrderBlockVolume2ReturnsOnCall(i int, result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
-	fake.OrderBlockVolume2Stub = nil
-	if fake.orderBlockVolume2ReturnsOnCall == nil {
-		fake.orderBlockVolume2ReturnsOnCall = make(map[int]struct {
+func (fake *FakeClient) OrderSnapshotSpaceReturnsOnCall(i int, result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
+	fake.OrderSnapshotSpaceStub = nil
+	if fake.orderSnapshotSpaceReturnsOnCall == nil {
+		fake.orderSnapshotSpaceReturnsOnCall = make(map[int]struct {
 			result1 *datatypes.Container_Product_Order_Receipt
 			result2 error
 		})
 	}
-	fake.orderBlockVolume2ReturnsOnCall[i] = struct {
+	fake.orderSnapshotSpaceReturnsOnCall[i] = struct {
 		result1 *datatypes.Container_Product_Order_Receipt
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) CancelBlockVolume(volumeId int, reason string, immediate bool) (bool, error) {
-	fake.cancelBlockVolumeMutex.Lock()
-	ret, specificReturn := fake.cancelBlockVolumeReturnsOnCall[len(fake.cancelBlockVolumeArgsForCall)]
-	fake.cancelBlockVolumeArgsForCall = append(fake.cancelBlockVolumeArgsForCall, struct {
-		volumeId  int
-		reason    string
-		immediate bool
-	}{volumeId, reason, immediate})
-	fake.recordInvocation("CancelBlockVolume", []interface{}{volumeId, reason, immediate})
-	fake.cancelBlockVolumeMutex.Unlock()
-	if fake.CancelBlockVolumeStub != nil {
-		return fake.CancelBlockVolumeStub(volumeId, reason, immediate)
+func (fake *FakeClient) WaitVolumeModificationCompleted(volumeId int, size int, iops int, tier float64, until time.Time) error {
+	fake.waitVolumeModificationCompletedMutex.Lock()
+	ret, specificReturn := fake.waitVolumeModificationCompletedReturnsOnCall[len(fake.waitVolumeModificationCompletedArgsForCall)]
+	fake.waitVolumeModificationCompletedArgsForCall = append(fake.waitVolumeModificationCompletedArgsForCall, struct {
+		volumeId int
+		size     int
+		iops     int
+		tier     float64
+		until    time.Time
+	}{volumeId, size, iops, tier, until})
+	fake.recordInvocation("WaitVolumeModificationCompleted", []interface{}{volumeId, size, iops, tier, until})
+	fake.waitVolumeModificationCompletedMutex.Unlock()
+	if fake.WaitVolumeModificationCompletedStub != nil {
+		return fake.WaitVolumeModificationCompletedStub(volumeId, size, iops, tier, until)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.cancelBlockVolumeReturns.result1, fake.cancelBlockVolumeReturns.result2
+	return fake.waitVolumeModificationCompletedReturns.result1
 }
 
-func (fake *FakeClient) CancelBlockVolumeCallCount() int {
-	fake.cancelBlockVolumeMutex.RLock()
-	defer fake.cancelBlockVolumeMutex.RUnlock()
-	return len(fake.cancelBlockVolumeArgsForCall)
+func (fake *FakeClient) WaitVolumeModificationCompletedCallCount() int {
+	fake.waitVolumeModificationCompletedMutex.RLock()
+	defer fake.waitVolumeModificationCompletedMutex.RUnlock()
+	return len(fake.waitVolumeModificationCompletedArgsForCall)
 }
 
-func (fake *FakeClient) CancelBlockVolumeArgsForCall(i int) (int, string, bool) {
-	fake.cancelBlockVolumeMutex.RLock()
-	defer fake.cancelBlockVolumeMutex.RUnlock()
-	return fake.cancelBlockVolumeArgsForCall[i].volumeId, fake.cancelBlockVolumeArgsForCall[i].reason, fake.cancelBlockVolumeArgsForCall[i].immediate
+func (fake *FakeClient) WaitVolumeModificationCompletedArgsForCall(i int) (int, int, int, float64, time.Time) {
+	fake.waitVolumeModificationCompletedMutex.RLock()
+	defer fake.waitVolumeModificationCompletedMutex.RUnlock()
+	return fake.waitVolumeModificationCompletedArgsForCall[i].volumeId, fake.waitVolumeModificationCompletedArgsForCall[i].size, fake.waitVolumeModificationCompletedArgsForCall[i].iops, fake.waitVolumeModificationCompletedArgsForCall[i].tier, fake.waitVolumeModificationCompletedArgsForCall[i].until
 }
 
-func (fake *FakeClient) CancelBlockVolumeReturns(result1 bool, result2 error) {
-	fake.CancelBlockVolumeStub = nil
-	fake.cancelBlockVolumeReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+func (fake *FakeClient) WaitVolumeModificationCompletedReturns(result1 error) {
+	fake.WaitVolumeModificationCompletedStub = nil
+	fake.waitVolumeModificationCompletedReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) CancelBlockVolumeReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.CancelBlockVolumeStub = nil
-	if fake.cancelBlockVolumeReturnsOnCall == nil {
-		fake.cancelBlockVolumeReturnsOnCall = make(map[int]struct {
-			result1 bool
-			result2 error
+func (fake *FakeClient) WaitVolumeModificationCompletedReturnsOnCall(i int, result1 error) {
+	fake.WaitVolumeModificationCompletedStub = nil
+	if fake.waitVolumeModificationCompletedReturnsOnCall == nil {
+		fake.waitVolumeModificationCompletedReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.cancelBlockVolumeReturnsOnCall[i] = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+	fake.waitVolumeModificationCompletedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) GetBlockVolumeDetails(volumeId int, mask string) (*datatypes.Network_Storage, bool, error) {
-	fake.getBlockVolumeDetailsMutex.Lock()
-	ret, specificReturn := fake.getBlockVolumeDetailsReturnsOnCall[len(fake.getBlockVolumeDetailsArgsForCall)]
-	fake.getBlockVolumeDetailsArgsForCall = append(fake.getBlockVolumeDetailsArgsForCall, struct {
+func (fake *FakeClient) WaitSnapshotSpaceCompleted(volumeId int, size int, until time.Time) error {
+	fake.waitSnapshotSpaceCompletedMutex.Lock()
+	ret, specificReturn := fake.waitSnapshotSpaceCompletedReturnsOnCall[len(fake.waitSnapshotSpaceCompletedArgsForCall)]
+	fake.waitSnapshotSpaceCompletedArgsForCall = append(fake.waitSnapshotSpaceCompletedArgsForCall, struct {
 		volumeId int
-		mask     string
-	}{volumeId, mask})
-	fake.recordInvocation("GetBlockVolumeDetails", []interface{}{volumeId, mask})
-	fake.getBlockVolumeDetailsMutex.Unlock()
-	if fake.GetBlockVolumeDetailsStub != nil {
-		return fake.GetBlockVolumeDetailsStub(volumeId, mask)
+		size     int
+		until    time.Time
+	}{volumeId, size, until})
+	fake.recordInvocation("WaitSnapshotSpaceCompleted", []interface{}{volumeId, size, until})
+	fake.waitSnapshotSpaceCompletedMutex.Unlock()
+	if fake.WaitSnapshotSpaceCompletedStub != nil {
+		return fake.WaitSnapshotSpaceCompletedStub(volumeId, size, until)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.waitSnapshotSpaceCompletedReturns.result1
+}
+
+func (fake *FakeClient) WaitSnapshotSpaceCompletedCallCount() int {
+	fake.waitSnapshotSpaceCompletedMutex.RLock()
+	defer fake.waitSnapshotSpaceCompletedMutex.RUnlock()
+	return len(fake.waitSnapshotSpaceCompletedArgsForCall)
+}
+
+func (fake *FakeClient) WaitSnapshotSpaceCompletedArgsForCall(i int) (int, int, time.Time) {
+	fake.waitSnapshotSpaceCompletedMutex.RLock()
+	defer fake.waitSnapshotSpaceCompletedMutex.RUnlock()
+	return fake.waitSnapshotSpaceCompletedArgsForCall[i].volumeId, fake.waitSnapshotSpaceCompletedArgsForCall[i].size, fake.waitSnapshotSpaceCompletedArgsForCall[i].until
+}
+
+func (fake *FakeClient) WaitSnapshotSpaceCompletedReturns(result1 error) {
+	fake.WaitSnapshotSpaceCompletedStub = nil
+	fake.waitSnapshotSpaceCompletedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) WaitSnapshotSpaceCompletedReturnsOnCall(i int, result1 error) {
+	fake.WaitSnapshotSpaceCompletedStub = nil
+	if fake.waitSnapshotSpaceCompletedReturnsOnCall == nil {
+		fake.waitSnapshotSpaceCompletedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitSnapshotSpaceCompletedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) GetSubnetByIpAddress(ip string, mask string) (*datatypes.Network_Subnet, bool, error) {
+	fake.getSubnetByIpAddressMutex.Lock()
+	ret, specificReturn := fake.getSubnetByIpAddressReturnsOnCall[len(fake.getSubnetByIpAddressArgsForCall)]
+	fake.getSubnetByIpAddressArgsForCall = append(fake.getSubnetByIpAddressArgsForCall, struct {
+		ip   string
+		mask string
+	}{ip, mask})
+	fake.recordInvocation("GetSubnetByIpAddress", []interface{}{ip, mask})
+	fake.getSubnetByIpAddressMutex.Unlock()
+	if fake.GetSubnetByIpAddressStub != nil {
+		return fake.GetSubnetByIpAddressStub(ip, mask)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getSubnetByIpAddressReturns.result1, fake.getSubnetByIpAddressReturns.result2, fake.getSubnetByIpAddressReturns.result3
+}
+
+func (fake *FakeClient) GetSubnetByIpAddressCallCount() int {
+	fake.getSubnetByIpAddressMutex.RLock()
+	defer fake.getSubnetByIpAddressMutex.RUnlock()
+	return len(fake.getSubnetByIpAddressArgsForCall)
+}
+
+func (fake *FakeClient) GetSubnetByIpAddressArgsForCall(i int) (string, string) {
+	fake.getSubnetByIpAddressMutex.RLock()
+	defer fake.getSubnetByIpAddressMutex.RUnlock()
+	return fake.getSubnetByIpAddressArgsForCall[i].ip, fake.getSubnetByIpAddressArgsForCall[i].mask
+}
+
+func (fake *FakeClient) GetSubnetByIpAddressReturns(result1 *datatypes.Network_Subnet, result2 bool, result3 error) {
+	fake.GetSubnetByIpAddressStub = nil
+	fake.getSubnetByIpAddressReturns = struct {
+		result1 *datatypes.Network_Subnet
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetSubnetByIpAddressReturnsOnCall(i int, result1 *datatypes.Network_Subnet, result2 bool, result3 error) {
+	fake.GetSubnetByIpAddressStub = nil
+	if fake.getSubnetByIpAddressReturnsOnCall == nil {
+		fake.getSubnetByIpAddressReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_Subnet
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getSubnetByIpAddressReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_Subnet
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetGlobalIp(ip string) (*datatypes.Network_Subnet_IpAddress_Global, bool, error) {
+	fake.getGlobalIpMutex.Lock()
+	ret, specificReturn := fake.getGlobalIpReturnsOnCall[len(fake.getGlobalIpArgsForCall)]
+	fake.getGlobalIpArgsForCall = append(fake.getGlobalIpArgsForCall, struct {
+		ip string
+	}{ip})
+	fake.recordInvocation("GetGlobalIp", []interface{}{ip})
+	fake.getGlobalIpMutex.Unlock()
+	if fake.GetGlobalIpStub != nil {
+		return fake.GetGlobalIpStub(ip)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
 	}
-	return fake.getBlockVolumeDetailsReturns.result1, fake.getBlockVolumeDetailsReturns.result2, fake.getBlockVolumeDetailsReturns.result3
+	return fake.getGlobalIpReturns.result1, fake.getGlobalIpReturns.result2, fake.getGlobalIpReturns.result3
 }
 
-func (fake *FakeClient) GetBlockVolumeDetailsCallCount() int {
-	fake.getBlockVolumeDetailsMutex.RLock()
-	defer fake.getBlockVolumeDetailsMutex.RUnlock()
-	return len(fake.getBlockVolumeDetailsArgsForCall)
+func (fake *FakeClient) GetGlobalIpCallCount() int {
+	fake.getGlobalIpMutex.RLock()
+	defer fake.getGlobalIpMutex.RUnlock()
+	return len(fake.getGlobalIpArgsForCall)
 }
 
-func (fake *FakeClient) GetBlockVolumeDetailsArgsForCall(i int) (int, string) {
-	fake.getBlockVolumeDetailsMutex.RLock()
-	defer fake.getBlockVolumeDetailsMutex.RUnlock()
-	return fake.getBlockVolumeDetailsArgsForCall[i].volumeId, fake.getBlockVolumeDetailsArgsForCall[i].mask
+func (fake *FakeClient) GetGlobalIpArgsForCall(i int) string {
+	fake.getGlobalIpMutex.RLock()
+	defer fake.getGlobalIpMutex.RUnlock()
+	return fake.getGlobalIpArgsForCall[i].ip
 }
 
-func (fake *FakeClient) GetBlockVolumeDetailsReturns(result1 *datatypes.Network_Storage, result2 bool, result3 error) {
-	fake.GetBlockVolumeDetailsStub = nil
-	fake.getBlockVolumeDetailsReturns = struct {
-		result1 *datatypes.Network_Storage
+func (fake *FakeClient) GetGlobalIpReturns(result1 *datatypes.Network_Subnet_IpAddress_Global, result2 bool, result3 error) {
+	fake.GetGlobalIpStub = nil
+	fake.getGlobalIpReturns = struct {
+		result1 *datatypes.Network_Subnet_IpAddress_Global
 		result2 bool
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) GetBlockVolumeDetailsReturnsOnCall(i int, result1 *datatypes.Network_Storage, result2 bool, result3 error) {
-	fake.GetBlockVolumeDetailsStub = nil
-	if fake.getBlockVolumeDetailsReturnsOnCall == nil {
-		fake.getBlockVolumeDetailsReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Network_Storage
+func (fake *FakeClient) GetGlobalIpReturnsOnCall(i int, result1 *datatypes.Network_Subnet_IpAddress_Global, result2 bool, result3 error) {
+	fake.GetGlobalIpStub = nil
+	if fake.getGlobalIpReturnsOnCall == nil {
+		fake.getGlobalIpReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_Subnet_IpAddress_Global
 			result2 bool
 			result3 error
 		})
 	}
-	fake.getBlockVolumeDetailsReturnsOnCall[i] = struct {
-		result1 *datatypes.Network_Storage
+	fake.getGlobalIpReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_Subnet_IpAddress_Global
 		result2 bool
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) GetBlockVolumeDetailsBySoftLayerAccount(volumeId int, mask string) (datatypes.Network_Storage, error) {
-	fake.getBlockVolumeDetailsBySoftLayerAccountMutex.Lock()
-	ret, specificReturn := fake.getBlockVolumeDetailsBySoftLayerAccountReturnsOnCall[len(fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall)]
-	fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall = append(fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall, struct {
-		volumeId int
-		mask     string
-	}{volumeId, mask})
-	fake.recordInvocation("GetBlockVolumeDetailsBySoftLayerAccount", []interface{}{volumeId, mask})
-	fake.getBlockVolumeDetailsBySoftLayerAccountMutex.Unlock()
-	if fake.GetBlockVolumeDetailsBySoftLayerAccountStub != nil {
-		return fake.GetBlockVolumeDetailsBySoftLayerAccountStub(volumeId, mask)
+func (fake *FakeClient) GetGlobalIpsByDestination(ip string) ([]datatypes.Network_Subnet_IpAddress_Global, error) {
+	fake.getGlobalIpsByDestinationMutex.Lock()
+	ret, specificReturn := fake.getGlobalIpsByDestinationReturnsOnCall[len(fake.getGlobalIpsByDestinationArgsForCall)]
+	fake.getGlobalIpsByDestinationArgsForCall = append(fake.getGlobalIpsByDestinationArgsForCall, struct {
+		ip string
+	}{ip})
+	fake.recordInvocation("GetGlobalIpsByDestination", []interface{}{ip})
+	fake.getGlobalIpsByDestinationMutex.Unlock()
+	if fake.GetGlobalIpsByDestinationStub != nil {
+		return fake.GetGlobalIpsByDestinationStub(ip)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getBlockVolumeDetailsBySoftLayerAccountReturns.result1, fake.getBlockVolumeDetailsBySoftLayerAccountReturns.result2
+	return fake.getGlobalIpsByDestinationReturns.result1, fake.getGlobalIpsByDestinationReturns.result2
 }
 
-func (fake *FakeClient) GetBlockVolumeDetailsBySoftLayerAccountCallCount() int {
-	fake.getBlockVolumeDetailsBySoftLayerAccountMutex.RLock()
-	defer fake.getBlockVolumeDetailsBySoftLayerAccountMutex.RUnlock()
-	return len(fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall)
+func (fake *FakeClient) GetGlobalIpsByDestinationCallCount() int {
+	fake.getGlobalIpsByDestinationMutex.RLock()
+	defer fake.getGlobalIpsByDestinationMutex.RUnlock()
+	return len(fake.getGlobalIpsByDestinationArgsForCall)
 }
 
-func (fake *FakeClient) GetBlockVolumeDetailsBySoftLayerAccountArgsForCall(i int) (int, string) {
-	fake.getBlockVolumeDetailsBySoftLayerAccountMutex.RLock()
-	defer fake.getBlockVolumeDetailsBySoftLayerAccountMutex.RUnlock()
-	return fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall[i].volumeId, fake.getBlockVolumeDetailsBySoftLayerAccountArgsForCall[i].mask
+func (fake *FakeClient) GetGlobalIpsByDestinationArgsForCall(i int) string {
+	fake.getGlobalIpsByDestinationMutex.RLock()
+	defer fake.getGlobalIpsByDestinationMutex.RUnlock()
+	return fake.getGlobalIpsByDestinationArgsForCall[i].ip
 }
 
-func (fake *FakeClient) GetBlockVolumeDetailsBySoftLayerAccountReturns(result1 datatypes.Network_Storage, result2 error) {
-	fake.GetBlockVolumeDetailsBySoftLayerAccountStub = nil
-	fake.getBlockVolumeDetailsBySoftLayerAccountReturns = struct {
-		result1 datatypes.Network_Storage
+func (fake *FakeClient) GetGlobalIpsByDestinationReturns(result1 []datatypes.Network_Subnet_IpAddress_Global, result2 error) {
+	fake.GetGlobalIpsByDestinationStub = nil
+	fake.getGlobalIpsByDestinationReturns = struct {
+		result1 []datatypes.Network_Subnet_IpAddress_Global
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) GetBlockVolumeDetailsBySoftLayerAccountReturnsOnCall(i int, result1 datatypes.Network_Storage, result2 error) {
-	fake.GetBlockVolumeDetailsBySoftLayerAccountStub = nil
-	if fake.getBlockVolumeDetailsBySoftLayerAccountReturnsOnCall == nil {
-		fake.getBlockVolumeDetailsBySoftLayerAccountReturnsOnCall = make(map[int]struct {
-			result1 datatypes.Network_Storage
+func (fake *FakeClient) GetGlobalIpsByDestinationReturnsOnCall(i int, result1 []datatypes.Network_Subnet_IpAddress_Global, result2 error) {
+	fake.GetGlobalIpsByDestinationStub = nil
+	if fake.getGlobalIpsByDestinationReturnsOnCall == nil {
+		fake.getGlobalIpsByDestinationReturnsOnCall = make(map[int]struct {
+			result1 []datatypes.Network_Subnet_IpAddress_Global
 			result2 error
 		})
 	}
-	fake.getBlockVolumeDetailsBySoftLayerAccountReturnsOnCall[i] = struct {
-		result1 datatypes.Network_Storage
+	fake.getGlobalIpsByDestinationReturnsOnCall[i] = struct {
+		result1 []datatypes.Network_Subnet_IpAddress_Global
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) GetNetworkStorageTarget(volumeId int, mask string) (string, bool, error) {
-	fake.getNetworkStorageTargetMutex.Lock()
-	ret, specificReturn := fake.getNetworkStorageTargetReturnsOnCall[len(fake.getNetworkStorageTargetArgsForCall)]
-	fake.getNetworkStorageTargetArgsForCall = append(fake.getNetworkStorageTargetArgsForCall, struct {
-		volumeId int
-		mask     string
-	}{volumeId, mask})
-	fake.recordInvocation("GetNetworkStorageTarget", []interface{}{volumeId, mask})
-	fake.getNetworkStorageTargetMutex.Unlock()
-	if fake.GetNetworkStorageTargetStub != nil {
-		return fake.GetNetworkStorageTargetStub(volumeId, mask)
+func (fake *FakeClient) RouteGlobalIp(id int, destinationIp string) error {
+	fake.routeGlobalIpMutex.Lock()
+	ret, specificReturn := fake.routeGlobalIpReturnsOnCall[len(fake.routeGlobalIpArgsForCall)]
+	fake.routeGlobalIpArgsForCall = append(fake.routeGlobalIpArgsForCall, struct {
+		id            int
+		destinationIp string
+	}{id, destinationIp})
+	fake.recordInvocation("RouteGlobalIp", []interface{}{id, destinationIp})
+	fake.routeGlobalIpMutex.Unlock()
+	if fake.RouteGlobalIpStub != nil {
+		return fake.RouteGlobalIpStub(id, destinationIp)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1
 	}
-	return fake.getNetworkStorageTargetReturns.result1, fake.getNetworkStorageTargetReturns.result2, fake.getNetworkStorageTargetReturns.result3
+	return fake.routeGlobalIpReturns.result1
 }
 
-func (fake *FakeClient) GetNetworkStorageTargetCallCount() int {
-	fake.getNetworkStorageTargetMutex.RLock()
-	defer fake.getNetworkStorageTargetMutex.RUnlock()
-	return len(fake.getNetworkStorageTargetArgsForCall)
+func (fake *FakeClient) RouteGlobalIpCallCount() int {
+	fake.routeGlobalIpMutex.RLock()
+	defer fake.routeGlobalIpMutex.RUnlock()
+	return len(fake.routeGlobalIpArgsForCall)
 }
 
-func (fake *FakeClient) GetNetworkStorageTargetArgsForCall(i int) (int, string) {
-	fake.getNetworkStorageTargetMutex.RLock()
-	defer fake.getNetworkStorageTargetMutex.RUnlock()
-	return fake.getNetworkStorageTargetArgsForCall[i].volumeId, fake.getNetworkStorageTargetArgsForCall[i].mask
+func (fake *FakeClient) RouteGlobalIpArgsForCall(i int) (int, string) {
+	fake.routeGlobalIpMutex.RLock()
+	defer fake.routeGlobalIpMutex.RUnlock()
+	return fake.routeGlobalIpArgsForCall[i].id, fake.routeGlobalIpArgsForCall[i].destinationIp
 }
 
-func (fake *FakeClient) GetNetworkStorageTargetReturns(result1 string, result2 bool, result3 error) {
-	fake.GetNetworkStorageTargetStub = nil
-	fake.getNetworkStorageTargetReturns = struct {
-		result1 string
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+func (fake *FakeClient) RouteGlobalIpReturns(result1 error) {
+	fake.RouteGlobalIpStub = nil
+	fake.routeGlobalIpReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) GetNetworkStorageTargetReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
-	fake.GetNetworkStorageTargetStub = nil
-	if fake.getNetworkStorageTargetReturnsOnCall == nil {
-		fake.getNetworkStorageTargetReturnsOnCall = make(map[int]struct {
-			result1 string
-			result2 bool
-			result3 error
+func (fake *FakeClient) RouteGlobalIpReturnsOnCall(i int, result1 error) {
+	fake.RouteGlobalIpStub = nil
+	if fake.routeGlobalIpReturnsOnCall == nil {
+		fake.routeGlobalIpReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getNetworkStorageTargetReturnsOnCall[i] = struct {
-		result1 string
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+	fake.routeGlobalIpReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) SetNotes(id int, notes string) (bool, error) {
-	fake.setNotesMutex.Lock()
-	ret, specificReturn := fake.setNotesReturnsOnCall[len(fake.setNotesArgsForCall)]
-	fake.setNotesArgsForCall = append(fake.setNotesArgsForCall, struct {
-		id    int
-		notes string
-	}{id, notes})
-	fake.recordInvocation("SetNotes", []interface{}{id, notes})
-	fake.setNotesMutex.Unlock()
-	if fake.SetNotesStub != nil {
-		return fake.SetNotesStub(id, notes)
+func (fake *FakeClient) UnrouteGlobalIp(id int) error {
+	fake.unrouteGlobalIpMutex.Lock()
+	ret, specificReturn := fake.unrouteGlobalIpReturnsOnCall[len(fake.unrouteGlobalIpArgsForCall)]
+	fake.unrouteGlobalIpArgsForCall = append(fake.unrouteGlobalIpArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("UnrouteGlobalIp", []interface{}{id})
+	fake.unrouteGlobalIpMutex.Unlock()
+	if fake.UnrouteGlobalIpStub != nil {
+		return fake.UnrouteGlobalIpStub(id)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.setNotesReturns.result1, fake.setNotesReturns.result2
+	return fake.unrouteGlobalIpReturns.result1
 }
 
-func (fake *FakeClient) SetNotesCallCount() int {
-	fake.setNotesMutex.RLock()
-	defer fake.setNotesMutex.RUnlock()
-	return len(fake.setNotesArgsForCall)
+func (fake *FakeClient) UnrouteGlobalIpCallCount() int {
+	fake.unrouteGlobalIpMutex.RLock()
+	defer fake.unrouteGlobalIpMutex.RUnlock()
+	return len(fake.unrouteGlobalIpArgsForCall)
 }
 
-func (fake *FakeClient) SetNotesArgsForCall(i int) (int, string) {
-	fake.setNotesMutex.RLock()
-	defer fake.setNotesMutex.RUnlock()
-	return fake.setNotesArgsForCall[i].id, fake.setNotesArgsForCall[i].notes
+func (fake *FakeClient) UnrouteGlobalIpArgsForCall(i int) int {
+	fake.unrouteGlobalIpMutex.RLock()
+	defer fake.unrouteGlobalIpMutex.RUnlock()
+	return fake.unrouteGlobalIpArgsForCall[i].id
 }
 
-func (fake *FakeClient) SetNotesReturns(result1 bool, result2 error) {
-	fake.SetNotesStub = nil
-	fake.setNotesReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+func (fake *FakeClient) UnrouteGlobalIpReturns(result1 error) {
+	fake.UnrouteGlobalIpStub = nil
+	fake.unrouteGlobalIpReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) SetNotesReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.SetNotesStub = nil
-	if fake.setNotesReturnsOnCall == nil {
-		fake.setNotesReturnsOnCall = make(map[int]struct {
-			result1 bool
-			result2 error
+func (fake *FakeClient) UnrouteGlobalIpReturnsOnCall(i int, result1 error) {
+	fake.UnrouteGlobalIpStub = nil
+	if fake.unrouteGlobalIpReturnsOnCall == nil {
+		fake.unrouteGlobalIpReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.setNotesReturnsOnCall[i] = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+	fake.unrouteGlobalIpReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) GetImage(imageId int, mask string) (*datatypes.Virtual_Guest_Block_Device_Template_Group, bool, error) {
-	fake.getImageMutex.Lock()
-	ret, specificReturn := fake.getImageReturnsOnCall[len(fake.getImageArgsForCall)]
-	fake.getImageArgsForCall = append(fake.getImageArgsForCall, struct {
-		imageId int
-		mask    string
-	}{imageId, mask})
-	fake.recordInvocation("GetImage", []interface{}{imageId, mask})
-	fake.getImageMutex.Unlock()
-	if fake.GetImageStub != nil {
-		return fake.GetImageStub(imageId, mask)
+func (fake *FakeClient) GetLoadBalancer(uuid string) (*datatypes.Network_LBaaS_LoadBalancer, bool, error) {
+	fake.getLoadBalancerMutex.Lock()
+	ret, specificReturn := fake.getLoadBalancerReturnsOnCall[len(fake.getLoadBalancerArgsForCall)]
+	fake.getLoadBalancerArgsForCall = append(fake.getLoadBalancerArgsForCall, struct {
+		uuid string
+	}{uuid})
+	fake.recordInvocation("GetLoadBalancer", []interface{}{uuid})
+	fake.getLoadBalancerMutex.Unlock()
+	if fake.GetLoadBalancerStub != nil {
+		return fake.GetLoadBalancerStub(uuid)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
 	}
-	return fake.getImageReturns.result1, fake.getImageReturns.result2, fake.getImageReturns.result3
+	return fake.getLoadBalancerReturns.result1, fake.getLoadBalancerReturns.result2, fake.getLoadBalancerReturns.result3
 }
 
-func (fake *FakeClient) GetImageCallCount() int {
-	fake.getImageMutex.RLock()
-	defer fake.getImageMutex.RUnlock()
-	return len(fake.getImageArgsForCall)
+func (fake *FakeClient) GetLoadBalancerCallCount() int {
+	fake.getLoadBalancerMutex.RLock()
+	defer fake.getLoadBalancerMutex.RUnlock()
+	return len(fake.getLoadBalancerArgsForCall)
 }
 
-func (fake *FakeClient) GetImageArgsForCall(i int) (int, string) {
-	fake.getImageMutex.RLock()
-	defer fake.getImageMutex.RUnlock()
-	return fake.getImageArgsForCall[i].imageId, fake.getImageArgsForCall[i].mask
+func (fake *FakeClient) GetLoadBalancerArgsForCall(i int) string {
+	fake.getLoadBalancerMutex.RLock()
+	defer fake.getLoadBalancerMutex.RUnlock()
+	return fake.getLoadBalancerArgsForCall[i].uuid
 }
 
-func (fake *FakeClient) GetImageReturns(result1 *datatypes.Virtual_Guest_Block_Device_Template_Group, result2 bool, result3 error) {
-	fake.GetImageStub = nil
-	fake.getImageReturns = struct {
-		result1 *datatypes.Virtual_Guest_Block_Device_Template_Group
+func (fake *FakeClient) GetLoadBalancerReturns(result1 *datatypes.Network_LBaaS_LoadBalancer, result2 bool, result3 error) {
+	fake.GetLoadBalancerStub = nil
+	fake.getLoadBalancerReturns = struct {
+		result1 *datatypes.Network_LBaaS_LoadBalancer
 		result2 bool
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) GetImageReturnsOnCall(i int, result1 *datatypes.Virtual_Guest_Block_Device_Template_Group, result2 bool, result3 error) {
-	fake.GetImageStub = nil
-	if fake.getImageReturnsOnCall == nil {
-		fake.getImageReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Virtual_Guest_Block_Device_Template_Group
+func (fake *FakeClient) GetLoadBalancerReturnsOnCall(i int, result1 *datatypes.Network_LBaaS_LoadBalancer, result2 bool, result3 error) {
+	fake.GetLoadBalancerStub = nil
+	if fake.getLoadBalancerReturnsOnCall == nil {
+		fake.getLoadBalancerReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_LBaaS_LoadBalancer
 			result2 bool
 			result3 error
 		})
 	}
-	fake.getImageReturnsOnCall[i] = struct {
-		result1 *datatypes.Virtual_Guest_Block_Device_Template_Group
+	fake.getLoadBalancerReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_LBaaS_LoadBalancer
 		result2 bool
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) GetVlan(id int, mask string) (*datatypes.Network_Vlan, bool, error) {
-	fake.getVlanMutex.Lock()
-	ret, specificReturn := fake.getVlanReturnsOnCall[len(fake.getVlanArgsForCall)]
-	fake.getVlanArgsForCall = append(fake.getVlanArgsForCall, struct {
-		id   int
-		mask string
-	}{id, mask})
-	fake.recordInvocation("GetVlan", []interface{}{id, mask})
-	fake.getVlanMutex.Unlock()
-	if fake.GetVlanStub != nil {
-		return fake.GetVlanStub(id, mask)
+func (fake *FakeClient) AddLoadBalancerMember(uuid string, ip string, weight int) error {
+	fake.addLoadBalancerMemberMutex.Lock()
+	ret, specificReturn := fake.addLoadBalancerMemberReturnsOnCall[len(fake.addLoadBalancerMemberArgsForCall)]
+	fake.addLoadBalancerMemberArgsForCall = append(fake.addLoadBalancerMemberArgsForCall, struct {
+		uuid   string
+		ip     string
+		weight int
+	}{uuid, ip, weight})
+	fake.recordInvocation("AddLoadBalancerMember", []interface{}{uuid, ip, weight})
+	fake.addLoadBalancerMemberMutex.Unlock()
+	if fake.AddLoadBalancerMemberStub != nil {
+		return fake.AddLoadBalancerMemberStub(uuid, ip, weight)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.addLoadBalancerMemberReturns.result1
+}
+
+func (fake *FakeClient) AddLoadBalancerMemberCallCount() int {
+	fake.addLoadBalancerMemberMutex.RLock()
+	defer fake.addLoadBalancerMemberMutex.RUnlock()
+	return len(fake.addLoadBalancerMemberArgsForCall)
+}
+
+func (fake *FakeClient) AddLoadBalancerMemberArgsForCall(i int) (string, string, int) {
+	fake.addLoadBalancerMemberMutex.RLock()
+	defer fake.addLoadBalancerMemberMutex.RUnlock()
+	return fake.addLoadBalancerMemberArgsForCall[i].uuid, fake.addLoadBalancerMemberArgsForCall[i].ip, fake.addLoadBalancerMemberArgsForCall[i].weight
+}
+
+func (fake *FakeClient) AddLoadBalancerMemberReturns(result1 error) {
+	fake.AddLoadBalancerMemberStub = nil
+	fake.addLoadBalancerMemberReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) AddLoadBalancerMemberReturnsOnCall(i int, result1 error) {
+	fake.AddLoadBalancerMemberStub = nil
+	if fake.addLoadBalancerMemberReturnsOnCall == nil {
+		fake.addLoadBalancerMemberReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addLoadBalancerMemberReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateLoadBalancerMemberWeight(uuid string, memberUuid string, weight int) error {
+	fake.updateLoadBalancerMemberWeightMutex.Lock()
+	ret, specificReturn := fake.updateLoadBalancerMemberWeightReturnsOnCall[len(fake.updateLoadBalancerMemberWeightArgsForCall)]
+	fake.updateLoadBalancerMemberWeightArgsForCall = append(fake.updateLoadBalancerMemberWeightArgsForCall, struct {
+		uuid       string
+		memberUuid string
+		weight     int
+	}{uuid, memberUuid, weight})
+	fake.recordInvocation("UpdateLoadBalancerMemberWeight", []interface{}{uuid, memberUuid, weight})
+	fake.updateLoadBalancerMemberWeightMutex.Unlock()
+	if fake.UpdateLoadBalancerMemberWeightStub != nil {
+		return fake.UpdateLoadBalancerMemberWeightStub(uuid, memberUuid, weight)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateLoadBalancerMemberWeightReturns.result1
+}
+
+func (fake *FakeClient) UpdateLoadBalancerMemberWeightCallCount() int {
+	fake.updateLoadBalancerMemberWeightMutex.RLock()
+	defer fake.updateLoadBalancerMemberWeightMutex.RUnlock()
+	return len(fake.updateLoadBalancerMemberWeightArgsForCall)
+}
+
+func (fake *FakeClient) UpdateLoadBalancerMemberWeightArgsForCall(i int) (string, string, int) {
+	fake.updateLoadBalancerMemberWeightMutex.RLock()
+	defer fake.updateLoadBalancerMemberWeightMutex.RUnlock()
+	return fake.updateLoadBalancerMemberWeightArgsForCall[i].uuid, fake.updateLoadBalancerMemberWeightArgsForCall[i].memberUuid, fake.updateLoadBalancerMemberWeightArgsForCall[i].weight
+}
+
+func (fake *FakeClient) UpdateLoadBalancerMemberWeightReturns(result1 error) {
+	fake.UpdateLoadBalancerMemberWeightStub = nil
+	fake.updateLoadBalancerMemberWeightReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateLoadBalancerMemberWeightReturnsOnCall(i int, result1 error) {
+	fake.UpdateLoadBalancerMemberWeightStub = nil
+	if fake.updateLoadBalancerMemberWeightReturnsOnCall == nil {
+		fake.updateLoadBalancerMemberWeightReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateLoadBalancerMemberWeightReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DeleteLoadBalancerMember(uuid string, memberUuid string) error {
+	fake.deleteLoadBalancerMemberMutex.Lock()
+	ret, specificReturn := fake.deleteLoadBalancerMemberReturnsOnCall[len(fake.deleteLoadBalancerMemberArgsForCall)]
+	fake.deleteLoadBalancerMemberArgsForCall = append(fake.deleteLoadBalancerMemberArgsForCall, struct {
+		uuid       string
+		memberUuid string
+	}{uuid, memberUuid})
+	fake.recordInvocation("DeleteLoadBalancerMember", []interface{}{uuid, memberUuid})
+	fake.deleteLoadBalancerMemberMutex.Unlock()
+	if fake.DeleteLoadBalancerMemberStub != nil {
+		return fake.DeleteLoadBalancerMemberStub(uuid, memberUuid)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.deleteLoadBalancerMemberReturns.result1
+}
+
+func (fake *FakeClient) DeleteLoadBalancerMemberCallCount() int {
+	fake.deleteLoadBalancerMemberMutex.RLock()
+	defer fake.deleteLoadBalancerMemberMutex.RUnlock()
+	return len(fake.deleteLoadBalancerMemberArgsForCall)
+}
+
+func (fake *FakeClient) DeleteLoadBalancerMemberArgsForCall(i int) (string, string) {
+	fake.deleteLoadBalancerMemberMutex.RLock()
+	defer fake.deleteLoadBalancerMemberMutex.RUnlock()
+	return fake.deleteLoadBalancerMemberArgsForCall[i].uuid, fake.deleteLoadBalancerMemberArgsForCall[i].memberUuid
+}
+
+func (fake *FakeClient) DeleteLoadBalancerMemberReturns(result1 error) {
+	fake.DeleteLoadBalancerMemberStub = nil
+	fake.deleteLoadBalancerMemberReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DeleteLoadBalancerMemberReturnsOnCall(i int, result1 error) {
+	fake.DeleteLoadBalancerMemberStub = nil
+	if fake.deleteLoadBalancerMemberReturnsOnCall == nil {
+		fake.deleteLoadBalancerMemberReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteLoadBalancerMemberReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) WaitLoadBalancerActive(uuid string, until time.Time) error {
+	fake.waitLoadBalancerActiveMutex.Lock()
+	ret, specificReturn := fake.waitLoadBalancerActiveReturnsOnCall[len(fake.waitLoadBalancerActiveArgsForCall)]
+	fake.waitLoadBalancerActiveArgsForCall = append(fake.waitLoadBalancerActiveArgsForCall, struct {
+		uuid  string
+		until time.Time
+	}{uuid, until})
+	fake.recordInvocation("WaitLoadBalancerActive", []interface{}{uuid, until})
+	fake.waitLoadBalancerActiveMutex.Unlock()
+	if fake.WaitLoadBalancerActiveStub != nil {
+		return fake.WaitLoadBalancerActiveStub(uuid, until)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1
 	}
-	return fake.getVlanReturns.result1, fake.getVlanReturns.result2, fake.getVlanReturns.result3
+	return fake.waitLoadBalancerActiveReturns.result1
 }
 
-func (fake *FakeClient) GetVlanCallCount() int {
-	fake.getVlanMutex.RLock()
-	defer fake.getVlanMutex.RUnlock()
-	return len(fake.getVlanArgsForCall)
+func (fake *FakeClient) WaitLoadBalancerActiveCallCount() int {
+	fake.waitLoadBalancerActiveMutex.RLock()
+	defer fake.waitLoadBalancerActiveMutex.RUnlock()
+	return len(fake.waitLoadBalancerActiveArgsForCall)
 }
 
-func (fake *FakeClient) GetVlanArgsForCall(i int) (int, string) {
-	fake.getVlanMutex.RLock()
-	defer fake.getVlanMutex.RUnlock()
-	return fake.getVlanArgsForCall[i].id, fake.getVlanArgsForCall[i].mask
+func (fake *FakeClient) WaitLoadBalancerActiveArgsForCall(i int) (string, time.Time) {
+	fake.waitLoadBalancerActiveMutex.RLock()
+	defer fake.waitLoadBalancerActiveMutex.RUnlock()
+	return fake.waitLoadBalancerActiveArgsForCall[i].uuid, fake.waitLoadBalancerActiveArgsForCall[i].until
 }
 
-func (fake *FakeClient) GetVlanReturns(result1 *datatypes.Network_Vlan, result2 bool, result3 error) {
-	fake.GetVlanStub = nil
-	fake.getVlanReturns = struct {
-		result1 *datatypes.Network_Vlan
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+func (fake *FakeClient) WaitLoadBalancerActiveReturns(result1 error) {
+	fake.WaitLoadBalancerActiveStub = nil
+	fake.waitLoadBalancerActiveReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) GetVlanReturnsOnCall(i int, result1 *datatypes.Network_Vlan, result2 bool, result3 error) {
-	fake.GetVlanStub = nil
-	if fake.getVlanReturnsOnCall == nil {
-		fake.getVlanReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Network_Vlan
-			result2 bool
-			result3 error
+func (fake *FakeClient) WaitLoadBalancerActiveReturnsOnCall(i int, result1 error) {
+	fake.WaitLoadBalancerActiveStub = nil
+	if fake.waitLoadBalancerActiveReturnsOnCall == nil {
+		fake.waitLoadBalancerActiveReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getVlanReturnsOnCall[i] = struct {
-		result1 *datatypes.Network_Vlan
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+	fake.waitLoadBalancerActiveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) GetSubnet(id int, mask string) (*datatypes.Network_Subnet, bool, error) {
-	fake.getSubnetMutex.Lock()
-	ret, specificReturn := fake.getSubnetReturnsOnCall[len(fake.getSubnetArgsForCall)]
-	fake.getSubnetArgsForCall = append(fake.getSubnetArgsForCall, struct {
-		id   int
-		mask string
-	}{id, mask})
-	fake.recordInvocation("GetSubnet", []interface{}{id, mask})
-	fake.getSubnetMutex.Unlock()
-	if fake.GetSubnetStub != nil {
-		return fake.GetSubnetStub(id, mask)
+func (fake *FakeClient) GetSecurityGroups() ([]datatypes.Network_SecurityGroup, error) {
+	fake.getSecurityGroupsMutex.Lock()
+	ret, specificReturn := fake.getSecurityGroupsReturnsOnCall[len(fake.getSecurityGroupsArgsForCall)]
+	fake.getSecurityGroupsArgsForCall = append(fake.getSecurityGroupsArgsForCall, struct{}{})
+	fake.recordInvocation("GetSecurityGroups", []interface{}{})
+	fake.getSecurityGroupsMutex.Unlock()
+	if fake.GetSecurityGroupsStub != nil {
+		return fake.GetSecurityGroupsStub()
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1, ret.result2
 	}
-	return fake.getSubnetReturns.result1, fake.getSubnetReturns.result2, fake.getSubnetReturns.result3
-}
-
-func (fake *FakeClient) GetSubnetCallCount() int {
-	fake.getSubnetMutex.RLock()
-	defer fake.getSubnetMutex.RUnlock()
-	return len(fake.getSubnetArgsForCall)
+	return fake.getSecurityGroupsReturns.result1, fake.getSecurityGroupsReturns.result2
 }
 
-func (fake *FakeClient) GetSubnetArgsForCall(i int) (int, string) {
-	fake.getSubnetMutex.RLock()
-	defer fake.getSubnetMutex.RUnlock()
-	return fake.getSubnetArgsForCall[i].id, fake.getSubnetArgsForCall[i].mask
+func (fake *FakeClient) GetSecurityGroupsCallCount() int {
+	fake.getSecurityGroupsMutex.RLock()
+	defer fake.getSecurityGroupsMutex.RUnlock()
+	return len(fake.getSecurityGroupsArgsForCall)
 }
 
-func (fake *FakeClient) GetSubnetReturns(result1 *datatypes.Network_Subnet, result2 bool, result3 error) {
-	fake.GetSubnetStub = nil
-	fake.getSubnetReturns = struct {
-		result1 *datatypes.Network_Subnet
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+func (fake *FakeClient) GetSecurityGroupsReturns(result1 []datatypes.Network_SecurityGroup, result2 error) {
+	fake.GetSecurityGroupsStub = nil
+	fake.getSecurityGroupsReturns = struct {
+		result1 []datatypes.Network_SecurityGroup
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) GetSubnetReturnsOnCall(i int, result1 *datatypes.Network_Subnet, result2 bool, result3 error) {
-	fake.GetSubnetStub = nil
-	if fake.getSubnetReturnsOnCall == nil {
-		fake.getSubnetReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Network_Subnet
-			result2 bool
-			result3 error
+func (fake *FakeClient) GetSecurityGroupsReturnsOnCall(i int, result1 []datatypes.Network_SecurityGroup, result2 error) {
+	fake.GetSecurityGroupsStub = nil
+	if fake.getSecurityGroupsReturnsOnCall == nil {
+		fake.getSecurityGroupsReturnsOnCall = make(map[int]struct {
+			result1 []datatypes.Network_SecurityGroup
+			result2 error
 		})
 	}
-	fake.getSubnetReturnsOnCall[i] = struct {
-		result1 *datatypes.Network_Subnet
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+	fake.getSecurityGroupsReturnsOnCall[i] = struct {
+		result1 []datatypes.Network_SecurityGroup
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) GetAllowedHostCredential(id int) (*datatypes.Network_Storage_Allowed_Host, bool, error) {
-	fake.getAllowedHostCredentialMutex.Lock()
-	ret, specificReturn := fake.getAllowedHostCredentialReturnsOnCall[len(fake.getAllowedHostCredentialArgsForCall)]
-	fake.getAllowedHostCredentialArgsForCall = append(fake.getAllowedHostCredentialArgsForCall, struct {
-		id int
-	}{id})
-	fake.recordInvocation("GetAllowedHostCredential", []interface{}{id})
-	fake.getAllowedHostCredentialMutex.Unlock()
-	if fake.GetAllowedHostCredentialStub != nil {
-		return fake.GetAllowedHostCredentialStub(id)
+func (fake *FakeClient) AttachSecurityGroupComponents(id int, componentIds []int) error {
+	var componentIdsCopy []int
+	if componentIds != nil {
+		componentIdsCopy = make([]int, len(componentIds))
+		copy(componentIdsCopy, componentIds)
+	}
+	fake.attachSecurityGroupComponentsMutex.Lock()
+	ret, specificReturn := fake.attachSecurityGroupComponentsReturnsOnCall[len(fake.attachSecurityGroupComponentsArgsForCall)]
+	fake.attachSecurityGroupComponentsArgsForCall = append(fake.attachSecurityGroupComponentsArgsForCall, struct {
+		id           int
+		componentIds []int
+	}{id, componentIdsCopy})
+	fake.recordInvocation("AttachSecurityGroupComponents", []interface{}{id, componentIdsCopy})
+	fake.attachSecurityGroupComponentsMutex.Unlock()
+	if fake.AttachSecurityGroupComponentsStub != nil {
+		return fake.AttachSecurityGroupComponentsStub(id, componentIds)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1
 	}
-	return fake.getAllowedHostCredentialReturns.result1, fake.getAllowedHostCredentialReturns.result2, fake.getAllowedHostCredentialReturns.result3
+	return fake.attachSecurityGroupComponentsReturns.result1
 }
 
-func (fake *FakeClient) GetAllowedHostCredentialCallCount() int {
-	fake.getAllowedHostCredentialMutex.RLock()
-	defer fake.getAllowedHostCredentialMutex.RUnlock()
-	return len(fake.getAllowedHostCredentialArgsForCall)
+func (fake *FakeClient) AttachSecurityGroupComponentsCallCount() int {
+	fake.attachSecurityGroupComponentsMutex.RLock()
+	defer fake.attachSecurityGroupComponentsMutex.RUnlock()
+	return len(fake.attachSecurityGroupComponentsArgsForCall)
 }
 
-func (fake *FakeClient) GetAllowedHostCredentialArgsForCall(i int) int {
-	fake.getAllowedHostCredentialMutex.RLock()
-	defer fake.getAllowedHostCredentialMutex.RUnlock()
-	return fake.getAllowedHostCredentialArgsForCall[i].id
+func (fake *FakeClient) AttachSecurityGroupComponentsArgsForCall(i int) (int, []int) {
+	fake.attachSecurityGroupComponentsMutex.RLock()
+	defer fake.attachSecurityGroupComponentsMutex.RUnlock()
+	return fake.attachSecurityGroupComponentsArgsForCall[i].id, fake.attachSecurityGroupComponentsArgsForCall[i].componentIds
 }
 
-func (fake *FakeClient) GetAllowedHostCredentialReturns(result1 *datatypes.Network_Storage_Allowed_Host, result2 bool, result3 error) {
-	fake.GetAllowedHostCredentialStub = nil
-	fake.getAllowedHostCredentialReturns = struct {
-		result1 *datatypes.Network_Storage_Allowed_Host
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+func (fake *FakeClient) AttachSecurityGroupComponentsReturns(result1 error) {
+	fake.AttachSecurityGroupComponentsStub = nil
+	fake.attachSecurityGroupComponentsReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) GetAllowedHostCredentialReturnsOnCall(i int, result1 *datatypes.Network_Storage_Allowed_Host, result2 bool, result3 error) {
-	fake.GetAllowedHostCredentialStub = nil
-	if fake.getAllowedHostCredentialReturnsOnCall == nil {
-		fake.getAllowedHostCredentialReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Network_Storage_Allowed_Host
-			result2 bool
-			result3 error
+func (fake *FakeClient) AttachSecurityGroupComponentsReturnsOnCall(i int, result1 error) {
+	fake.AttachSecurityGroupComponentsStub = nil
+	if fake.attachSecurityGroupComponentsReturnsOnCall == nil {
+		fake.attachSecurityGroupComponentsReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getAllowedHostCredentialReturnsOnCall[i] = struct {
-		result1 *datatypes.Network_Storage_Allowed_Host
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+	fake.attachSecurityGroupComponentsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) GetAllowedNetworkStorage(id int) ([]string, bool, error) {
-	fake.getAllowedNetworkStorageMutex.Lock()
-	ret, specificReturn := fake.getAllowedNetworkStorageReturnsOnCall[len(fake.getAllowedNetworkStorageArgsForCall)]
-	fake.getAllowedNetworkStorageArgsForCall = append(fake.getAllowedNetworkStorageArgsForCall, struct {
-		id int
-	}{id})
-	fake.recordInvocation("GetAllowedNetworkStorage", []interface{}{id})
-	fake.getAllowedNetworkStorageMutex.Unlock()
-	if fake.GetAllowedNetworkStorageStub != nil {
-		return fake.GetAllowedNetworkStorageStub(id)
+func (fake *FakeClient) DetachSecurityGroupComponents(id int, componentIds []int) error {
+	var componentIdsCopy []int
+	if componentIds != nil {
+		componentIdsCopy = make([]int, len(componentIds))
+		copy(componentIdsCopy, componentIds)
+	}
+	fake.detachSecurityGroupComponentsMutex.Lock()
+	ret, specificReturn := fake.detachSecurityGroupComponentsReturnsOnCall[len(fake.detachSecurityGroupComponentsArgsForCall)]
+	fake.detachSecurityGroupComponentsArgsForCall = append(fake.detachSecurityGroupComponentsArgsForCall, struct {
+		id           int
+		componentIds []int
+	}{id, componentIdsCopy})
+	fake.recordInvocation("DetachSecurityGroupComponents", []interface{}{id, componentIdsCopy})
+	fake.detachSecurityGroupComponentsMutex.Unlock()
+	if fake.DetachSecurityGroupComponentsStub != nil {
+		return fake.DetachSecurityGroupComponentsStub(id, componentIds)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1
 	}
-	return fake.getAllowedNetworkStorageReturns.result1, fake.getAllowedNetworkStorageReturns.result2, fake.getAllowedNetworkStorageReturns.result3
+	return fake.detachSecurityGroupComponentsReturns.result1
 }
 
-func (fake *FakeClient) GetAllowedNetworkStorageCallCount() int {
-	fake.getAllowedNetworkStorageMutex.RLock()
-	defer fake.getAllowedNetworkStorageMutex.RUnlock()
-	return len(fake.getAllowedNetworkStorageArgsForCall)
+func (fake *FakeClient) DetachSecurityGroupComponentsCallCount() int {
+	fake.detachSecurityGroupComponentsMutex.RLock()
+	defer fake.detachSecurityGroupComponentsMutex.RUnlock()
+	return len(fake.detachSecurityGroupComponentsArgsForCall)
 }
 
-func (fake *FakeClient) GetAllowedNetworkStorageArgsForCall(i int) int {
-	fake.getAllowedNetworkStorageMutex.RLock()
-	defer fake.getAllowedNetworkStorageMutex.RUnlock()
-	return fake.getAllowedNetworkStorageArgsForCall[i].id
+func (fake *FakeClient) DetachSecurityGroupComponentsArgsForCall(i int) (int, []int) {
+	fake.detachSecurityGroupComponentsMutex.RLock()
+	defer fake.detachSecurityGroupComponentsMutex.RUnlock()
+	return fake.detachSecurityGroupComponentsArgsForCall[i].id, fake.detachSecurityGroupComponentsArgsForCall[i].componentIds
 }
 
-func (fake *FakeClient) GetAllowedNetworkStorageReturns(result1 []string, result2 bool, result3 error) {
-	fake.GetAllowedNetworkStorageStub = nil
-	fake.getAllowedNetworkStorageReturns = struct {
-		result1 []string
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+func (fake *FakeClient) DetachSecurityGroupComponentsReturns(result1 error) {
+	fake.DetachSecurityGroupComponentsStub = nil
+	fake.detachSecurityGroupComponentsReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) GetAllowedNetworkStorageReturnsOnCall(i int, result1 []string, result2 bool, result3 error) {
-	fake.GetAllowedNetworkStorageStub = nil
-	if fake.getAllowedNetworkStorageReturnsOnCall == nil {
-		fake.getAllowedNetworkStorageReturnsOnCall = make(map[int]struct {
-			result1 []string
-			result2 bool
-			result3 error
+func (fake *FakeClient) DetachSecurityGroupComponentsReturnsOnCall(i int, result1 error) {
+	fake.DetachSecurityGroupComponentsStub = nil
+	if fake.detachSecurityGroupComponentsReturnsOnCall == nil {
+		fake.detachSecurityGroupComponentsReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getAllowedNetworkStorageReturnsOnCall[i] = struct {
-		result1 []string
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+	fake.detachSecurityGroupComponentsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeClient) CreateSshKey(label *string, key *string, fingerPrint *string) (*datatypes.Security_Ssh_Key, error) {
-	fake.createSshKeyMutex.Lock()
-	ret, specificReturn := fake.createSshKeyReturnsOnCall[len(fake.createSshKeyArgsForCall)]
-	fake.createSshKeyArgsForCall = append(fake.createSshKeyArgsForCall, struct {
-		label       *string
-		key         *string
-		fingerPrint *string
-	}{label, key, fingerPrint})
-	fake.recordInvocation("CreateSshKey", []interface{}{label, key, fingerPrint})
-	fake.createSshKeyMutex.Unlock()
-	if fake.CreateSshKeyStub != nil {
-		return fake.CreateSshKeyStub(label, key, fingerPrint)
+func (fake *FakeClient) GetDedicatedHosts() ([]client.DedicatedHost, error) {
+	fake.getDedicatedHostsMutex.Lock()
+	ret, specificReturn := fake.getDedicatedHostsReturnsOnCall[len(fake.getDedicatedHostsArgsForCall)]
+	fake.getDedicatedHostsArgsForCall = append(fake.getDedicatedHostsArgsForCall, struct{}{})
+	fake.recordInvocation("GetDedicatedHosts", []interface{}{})
+	fake.getDedicatedHostsMutex.Unlock()
+	if fake.GetDedicatedHostsStub != nil {
+		return fake.GetDedicatedHostsStub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createSshKeyReturns.result1, fake.createSshKeyReturns.result2
-}
-
-func (fake *FakeClient) CreateSshKeyCallCount() int {
-	fake.createSshKeyMutex.RLock()
-	defer fake.createSshKeyMutex.RUnlock()
-	return len(fake.createSshKeyArgsForCall)
+	return fake.getDedicatedHostsReturns.result1, fake.getDedicatedHostsReturns.result2
 }
 
-func (fake *FakeClient) CreateSshKeyArgsForCall(i int) (*string, *string, *string) {
-	fake.createSshKeyMutex.RLock()
-	defer fake.createSshKeyMutex.RUnlock()
-	return fake.createSshKeyArgsForCall[i].label, fake.createSshKeyArgsForCall[i].key, fake.createSshKeyArgsForCall[i].fingerPrint
+func (fake *FakeClient) GetDedicatedHostsCallCount() int {
+	fake.getDedicatedHostsMutex.RLock()
+	defer fake.getDedicatedHostsMutex.RUnlock()
+	return len(fake.getDedicatedHostsArgsForCall)
 }
 
-func (fake *FakeClient) CreateSshKeyReturns(result1 *datatypes.Security_Ssh_Key, result2 error) {
-	fake.CreateSshKeyStub = nil
-	fake.createSshKeyReturns = struct {
-		result1 *datatypes.Security_Ssh_Key
+func (fake *FakeClient) GetDedicatedHostsReturns(result1 []client.DedicatedHost, result2 error) {
+	fake.GetDedicatedHostsStub = nil
+	fake.getDedicatedHostsReturns = struct {
+		result1 []client.DedicatedHost
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) CreateSshKeyReturnsOnCall(i int, result1 *datatypes.Security_Ssh_Key, result2 error) {
-	fake.CreateSshKeyStub = nil
-	if fake.createSshKeyReturnsOnCall == nil {
-		fake.createSshKeyReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Security_Ssh_Key
+func (fake *FakeClient) GetDedicatedHostsReturnsOnCall(i int, result1 []client.DedicatedHost, result2 error) {
+	fake.GetDedicatedHostsStub = nil
+	if fake.getDedicatedHostsReturnsOnCall == nil {
+		fake.getDedicatedHostsReturnsOnCall = make(map[int]struct {
+			result1 []client.DedicatedHost
 			result2 error
 		})
 	}
-	fake.createSshKeyReturnsOnCall[i] = struct {
-		result1 *datatypes.Security_Ssh_Key
+	fake.getDedicatedHostsReturnsOnCall[i] = struct {
+		result1 []client.DedicatedHost
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) DeleteSshKey(id int) (bool, error) {
-	fake.deleteSshKeyMutex.Lock()
-	ret, specificReturn := fake.deleteSshKeyReturnsOnCall[len(fake.deleteSshKeyArgsForCall)]
-	fake.deleteSshKeyArgsForCall = append(fake.deleteSshKeyArgsForCall, struct {
-		id int
-	}{id})
-	fake.recordInvocation("DeleteSshKey", []interface{}{id})
-	fake.deleteSshKeyMutex.Unlock()
-	if fake.DeleteSshKeyStub != nil {
-		return fake.DeleteSshKeyStub(id)
+func (fake *FakeClient) GetPlacementGroups() ([]client.PlacementGroup, error) {
+	fake.getPlacementGroupsMutex.Lock()
+	ret, specificReturn := fake.getPlacementGroupsReturnsOnCall[len(fake.getPlacementGroupsArgsForCall)]
+	fake.getPlacementGroupsArgsForCall = append(fake.getPlacementGroupsArgsForCall, struct{}{})
+	fake.recordInvocation("GetPlacementGroups", []interface{}{})
+	fake.getPlacementGroupsMutex.Unlock()
+	if fake.GetPlacementGroupsStub != nil {
+		return fake.GetPlacementGroupsStub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.deleteSshKeyReturns.result1, fake.deleteSshKeyReturns.result2
+	return fake.getPlacementGroupsReturns.result1, fake.getPlacementGroupsReturns.result2
 }
 
-func (fake *FakeClient) DeleteSshKeyCallCount() int {
-	fake.deleteSshKeyMutex.RLock()
-	defer fake.deleteSshKeyMutex.RUnlock()
-	return len(fake.deleteSshKeyArgsForCall)
+func (fake *FakeClient) GetPlacementGroupsCallCount() int {
+	fake.getPlacementGroupsMutex.RLock()
+	defer fake.getPlacementGroupsMutex.RUnlock()
+	return len(fake.getPlacementGroupsArgsForCall)
 }
 
-func (fake *FakeClient) DeleteSshKeyArgsForCall(i int) int {
-	fake.deleteSshKeyMutex.RLock()
-	defer fake.deleteSshKeyMutex.RUnlock()
-	return fake.deleteSshKeyArgsForCall[i].id
+func (fake *FakeClient) GetPlacementGroupsReturns(result1 []client.PlacementGroup, result2 error) {
+	fake.GetPlacementGroupsStub = nil
+	fake.getPlacementGroupsReturns = struct {
+		result1 []client.PlacementGroup
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) DeleteSshKeyReturns(result1 bool, result2 error) {
-	fake.DeleteSshKeyStub = nil
-	fake.deleteSshKeyReturns = struct {
-		result1 bool
+func (fake *FakeClient) GetPlacementGroupsReturnsOnCall(i int, result1 []client.PlacementGroup, result2 error) {
+	fake.GetPlacementGroupsStub = nil
+	if fake.getPlacementGroupsReturnsOnCall == nil {
+		fake.getPlacementGroupsReturnsOnCall = make(map[int]struct {
+			result1 []client.PlacementGroup
+			result2 error
+		})
+	}
+	fake.getPlacementGroupsReturnsOnCall[i] = struct {
+		result1 []client.PlacementGroup
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) DeleteSshKeyReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.DeleteSshKeyStub = nil
-	if fake.deleteSshKeyReturnsOnCall == nil {
-		fake.deleteSshKeyReturnsOnCall = make(map[int]struct {
-			result1 bool
-			result2 error
+func (fake *FakeClient) GetPlacementGroup(id int) (*client.PlacementGroup, bool, error) {
+	fake.getPlacementGroupMutex.Lock()
+	ret, specificReturn := fake.getPlacementGroupReturnsOnCall[len(fake.getPlacementGroupArgsForCall)]
+	fake.getPlacementGroupArgsForCall = append(fake.getPlacementGroupArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("GetPlacementGroup", []interface{}{id})
+	fake.getPlacementGroupMutex.Unlock()
+	if fake.GetPlacementGroupStub != nil {
+		return fake.GetPlacementGroupStub(id)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getPlacementGroupReturns.result1, fake.getPlacementGroupReturns.result2, fake.getPlacementGroupReturns.result3
+}
+
+func (fake *FakeClient) GetPlacementGroupCallCount() int {
+	fake.getPlacementGroupMutex.RLock()
+	defer fake.getPlacementGroupMutex.RUnlock()
+	return len(fake.getPlacementGroupArgsForCall)
+}
+
+func (fake *FakeClient) GetPlacementGroupArgsForCall(i int) int {
+	fake.getPlacementGroupMutex.RLock()
+	defer fake.getPlacementGroupMutex.RUnlock()
+	return fake.getPlacementGroupArgsForCall[i].id
+}
+
+func (fake *FakeClient) GetPlacementGroupReturns(result1 *client.PlacementGroup, result2 bool, result3 error) {
+	fake.GetPlacementGroupStub = nil
+	fake.getPlacementGroupReturns = struct {
+		result1 *client.PlacementGroup
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetPlacementGroupReturnsOnCall(i int, result1 *client.PlacementGroup, result2 bool, result3 error) {
+	fake.GetPlacementGroupStub = nil
+	if fake.getPlacementGroupReturnsOnCall == nil {
+		fake.getPlacementGroupReturnsOnCall = make(map[int]struct {
+			result1 *client.PlacementGroup
+			result2 bool
+			result3 error
 		})
 	}
-	fake.deleteSshKeyReturnsOnCall[i] = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+	fake.getPlacementGroupReturnsOnCall[i] = struct {
+		result1 *client.PlacementGroup
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeClient) CreateInstanceFromVPS(template *datatypes.Virtual_Guest, stemcellID int, sshKeys []int, userData *registry.SoftlayerUserData) (*datatypes.Virtual_Guest, error) {
-	var sshKeysCopy []int
-	if sshKeys != nil {
-		sshKeysCopy = make([]int, len(sshKeys))
-		copy(sshKeysCopy, sshKeys)
-	}
-	fake.createInstanceFromVPSMutex.Lock()
-	ret, specificReturn := fake.createInstanceFromVPSReturnsOnCall[len(fake.createInstanceFromVPSArgsForCall)]
-	fake.createInstanceFromVPSArgsForCall = append(fake.createInstanceFromVPSArgsForCall, struct {
-		template   *datatypes.Virtual_Guest
-		stemcellID int
-		sshKeys    []int
-		userData   *registry.SoftlayerUserData
-	}{template, stemcellID, sshKeysCopy, userData})
-	fake.recordInvocation("CreateInstanceFromVPS", []interface{}{template, stemcellID, sshKeysCopy, userData})
-	fake.createInstanceFromVPSMutex.Unlock()
-	if fake.CreateInstanceFromVPSStub != nil {
-		return fake.CreateInstanceFromVPSStub(template, stemcellID, sshKeys, userData)
+func (fake *FakeClient) CreatePlacementGroup(name string, backendRouterId int) (*client.PlacementGroup, error) {
+	fake.createPlacementGroupMutex.Lock()
+	ret, specificReturn := fake.createPlacementGroupReturnsOnCall[len(fake.createPlacementGroupArgsForCall)]
+	fake.createPlacementGroupArgsForCall = append(fake.createPlacementGroupArgsForCall, struct {
+		name            string
+		backendRouterId int
+	}{name, backendRouterId})
+	fake.recordInvocation("CreatePlacementGroup", []interface{}{name, backendRouterId})
+	fake.createPlacementGroupMutex.Unlock()
+	if fake.CreatePlacementGroupStub != nil {
+		return fake.CreatePlacementGroupStub(name, backendRouterId)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createInstanceFromVPSReturns.result1, fake.createInstanceFromVPSReturns.result2
+	return fake.createPlacementGroupReturns.result1, fake.createPlacementGroupReturns.result2
 }
 
-func (fake *FakeClient) CreateInstanceFromVPSCallCount() int {
-	fake.createInstanceFromVPSMutex.RLock()
-	defer fake.createInstanceFromVPSMutex.RUnlock()
-	return len(fake.createInstanceFromVPSArgsForCall)
+func (fake *FakeClient) CreatePlacementGroupCallCount() int {
+	fake.createPlacementGroupMutex.RLock()
+	defer fake.createPlacementGroupMutex.RUnlock()
+	return len(fake.createPlacementGroupArgsForCall)
 }
 
-func (fake *FakeClient) CreateInstanceFromVPSArgsForCall(i int) (*datatypes.Virtual_Guest, int, []int, *registry.SoftlayerUserData) {
-	fake.createInstanceFromVPSMutex.RLock()
-	defer fake.createInstanceFromVPSMutex.RUnlock()
-	return fake.createInstanceFromVPSArgsForCall[i].template, fake.createInstanceFromVPSArgsForCall[i].stemcellID, fake.createInstanceFromVPSArgsForCall[i].sshKeys, fake.createInstanceFromVPSArgsForCall[i].userData
+func (fake *FakeClient) CreatePlacementGroupArgsForCall(i int) (string, int) {
+	fake.createPlacementGroupMutex.RLock()
+	defer fake.createPlacementGroupMutex.RUnlock()
+	return fake.createPlacementGroupArgsForCall[i].name, fake.createPlacementGroupArgsForCall[i].backendRouterId
 }
 
-func (fake *FakeClient) CreateInstanceFromVPSReturns(result1 *datatypes.Virtual_Guest, result2 error) {
-	fake.CreateInstanceFromVPSStub = nil
-	fake.createInstanceFromVPSReturns = struct {
-		result1 *datatypes.Virtual_Guest
+func (fake *FakeClient) CreatePlacementGroupReturns(result1 *client.PlacementGroup, result2 error) {
+	fake.CreatePlacementGroupStub = nil
+	fake.createPlacementGroupReturns = struct {
+		result1 *client.PlacementGroup
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) CreateInstanceFromVPSReturnsOnCall(i int, result1 *datatypes.Virtual_Guest, result2 error) {
-	fake.CreateInstanceFromVPSStub = nil
-	if fake.createInstanceFromVPSReturnsOnCall == nil {
-		fake.createInstanceFromVPSReturnsOnCall = make(map[int]struct {
-			result1 *datatypes.Virtual_Guest
+func (fake *FakeClient) CreatePlacementGroupReturnsOnCall(i int, result1 *client.PlacementGroup, result2 error) {
+	fake.CreatePlacementGroupStub = nil
+	if fake.createPlacementGroupReturnsOnCall == nil {
+		fake.createPlacementGroupReturnsOnCall = make(map[int]struct {
+			result1 *client.PlacementGroup
 			result2 error
 		})
 	}
-	fake.createInstanceFromVPSReturnsOnCall[i] = struct {
-		result1 *datatypes.Virtual_Guest
+	fake.createPlacementGroupReturnsOnCall[i] = struct {
+		result1 *client.PlacementGroup
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) DeleteInstanceFromVPS(id int) error {
-	fake.deleteInstanceFromVPSMutex.Lock()
-	ret, specificReturn := fake.deleteInstanceFromVPSReturnsOnCall[len(fake.deleteInstanceFromVPSArgsForCall)]
-	fake.deleteInstanceFromVPSArgsForCall = append(fake.deleteInstanceFromVPSArgsForCall, struct {
+func (fake *FakeClient) DeletePlacementGroup(id int) error {
+	fake.deletePlacementGroupMutex.Lock()
+	ret, specificReturn := fake.deletePlacementGroupReturnsOnCall[len(fake.deletePlacementGroupArgsForCall)]
+	fake.deletePlacementGroupArgsForCall = append(fake.deletePlacementGroupArgsForCall, struct {
 		id int
 	}{id})
-	fake.recordInvocation("DeleteInstanceFromVPS", []interface{}{id})
-	fake.deleteInstanceFromVPSMutex.Unlock()
-	if fake.DeleteInstanceFromVPSStub != nil {
-		return fake.DeleteInstanceFromVPSStub(id)
+	fake.recordInvocation("DeletePlacementGroup", []interface{}{id})
+	fake.deletePlacementGroupMutex.Unlock()
+	if fake.DeletePlacementGroupStub != nil {
+		return fake.DeletePlacementGroupStub(id)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteInstanceFromVPSReturns.result1
+	return fake.deletePlacementGroupReturns.result1
 }
 
-func (fake *FakeClient) DeleteInstanceFromVPSCallCount() int {
-	fake.deleteInstanceFromVPSMutex.RLock()
-	defer fake.deleteInstanceFromVPSMutex.RUnlock()
-	return len(fake.deleteInstanceFromVPSArgsForCall)
+func (fake *FakeClient) DeletePlacementGroupCallCount() int {
+	fake.deletePlacementGroupMutex.RLock()
+	defer fake.deletePlacementGroupMutex.RUnlock()
+	return len(fake.deletePlacementGroupArgsForCall)
 }
 
-func (fake *FakeClient) DeleteInstanceFromVPSArgsForCall(i int) int {
-	fake.deleteInstanceFromVPSMutex.RLock()
-	defer fake.deleteInstanceFromVPSMutex.RUnlock()
-	return fake.deleteInstanceFromVPSArgsForCall[i].id
+func (fake *FakeClient) DeletePlacementGroupArgsForCall(i int) int {
+	fake.deletePlacementGroupMutex.RLock()
+	defer fake.deletePlacementGroupMutex.RUnlock()
+	return fake.deletePlacementGroupArgsForCall[i].id
 }
 
-func (fake *FakeClient) DeleteInstanceFromVPSReturns(result1 error) {
-	fake.DeleteInstanceFromVPSStub = nil
-	fake.deleteInstanceFromVPSReturns = struct {
+func (fake *FakeClient) DeletePlacementGroupReturns(result1 error) {
+	fake.DeletePlacementGroupStub = nil
+	fake.deletePlacementGroupReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) DeleteInstanceFromVPSReturnsOnCall(i int, result1 error) {
-	fake.DeleteInstanceFromVPSStub = nil
-	if fake.deleteInstanceFromVPSReturnsOnCall == nil {
-		fake.deleteInstanceFromVPSReturnsOnCall = make(map[int]struct {
+func (fake *FakeClient) DeletePlacementGroupReturnsOnCall(i int, result1 error) {
+	fake.DeletePlacementGroupStub = nil
+	if fake.deletePlacementGroupReturnsOnCall == nil {
+		fake.deletePlacementGroupReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.deleteInstanceFromVPSReturnsOnCall[i] = struct {
+	fake.deletePlacementGroupReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) CreateSnapshot(volumeId int, notes string) (datatypes.Network_Storage, error) {
-	fake.createSnapshotMutex.Lock()
-	ret, specificReturn := fake.createSnapshotReturnsOnCall[len(fake.createSnapshotArgsForCall)]
-	fake.createSnapshotArgsForCall = append(fake.createSnapshotArgsForCall, struct {
-		volumeId int
-		notes    string
-	}{volumeId, notes})
-	fake.recordInvocation("CreateSnapshot", []interface{}{volumeId, notes})
-	fake.createSnapshotMutex.Unlock()
-	if fake.CreateSnapshotStub != nil {
-		return fake.CreateSnapshotStub(volumeId, notes)
+func (fake *FakeClient) GetInstancePlacementGroupId(id int) (int, error) {
+	fake.getInstancePlacementGroupIdMutex.Lock()
+	ret, specificReturn := fake.getInstancePlacementGroupIdReturnsOnCall[len(fake.getInstancePlacementGroupIdArgsForCall)]
+	fake.getInstancePlacementGroupIdArgsForCall = append(fake.getInstancePlacementGroupIdArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("GetInstancePlacementGroupId", []interface{}{id})
+	fake.getInstancePlacementGroupIdMutex.Unlock()
+	if fake.GetInstancePlacementGroupIdStub != nil {
+		return fake.GetInstancePlacementGroupIdStub(id)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createSnapshotReturns.result1, fake.createSnapshotReturns.result2
+	return fake.getInstancePlacementGroupIdReturns.result1, fake.getInstancePlacementGroupIdReturns.result2
 }
 
-func (fake *FakeClient) CreateSnapshotCallCount() int {
-	fake.createSnapshotMutex.RLock()
-	defer fake.createSnapshotMutex.RUnlock()
-	return len(fake.createSnapshotArgsForCall)
+func (fake *FakeClient) GetInstancePlacementGroupIdCallCount() int {
+	fake.getInstancePlacementGroupIdMutex.RLock()
+	defer fake.getInstancePlacementGroupIdMutex.RUnlock()
+	return len(fake.getInstancePlacementGroupIdArgsForCall)
 }
 
-func (fake *FakeClient) CreateSnapshotArgsForCall(i int) (int, string) {
-	fake.createSnapshotMutex.RLock()
-	defer fake.createSnapshotMutex.RUnlock()
-	return fake.createSnapshotArgsForCall[i].volumeId, fake.createSnapshotArgsForCall[i].notes
+func (fake *FakeClient) GetInstancePlacementGroupIdArgsForCall(i int) int {
+	fake.getInstancePlacementGroupIdMutex.RLock()
+	defer fake.getInstancePlacementGroupIdMutex.RUnlock()
+	return fake.getInstancePlacementGroupIdArgsForCall[i].id
 }
 
-func (fake *FakeClient) CreateSnapshotReturns(result1 datatypes.Network_Storage, result2 error) {
-	fake.CreateSnapshotStub = nil
-	fake.createSnapshotReturns = struct {
-		result1 datatypes.Network_Storage
+func (fake *FakeClient) GetInstancePlacementGroupIdReturns(result1 int, result2 error) {
+	fake.GetInstancePlacementGroupIdStub = nil
+	fake.getInstancePlacementGroupIdReturns = struct {
+		result1 int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) CreateSnapshotReturnsOnCall(i int, result1 datatypes.Network_Storage, result2 error) {
-	fake.CreateSnapshotStub = nil
-	if fake.createSnapshotReturnsOnCall == nil {
-		fake.createSnapshotReturnsOnCall = make(map[int]struct {
-			result1 datatypes.Network_Storage
+func (fake *FakeClient) GetInstancePlacementGroupIdReturnsOnCall(i int, result1 int, result2 error) {
+	fake.GetInstancePlacementGroupIdStub = nil
+	if fake.getInstancePlacementGroupIdReturnsOnCall == nil {
+		fake.getInstancePlacementGroupIdReturnsOnCall = make(map[int]struct {
+			result1 int
 			result2 error
 		})
 	}
-	fake.createSnapshotReturnsOnCall[i] = struct {
-		result1 datatypes.Network_Storage
+	fake.getInstancePlacementGroupIdReturnsOnCall[i] = struct {
+		result1 int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) DeleteSnapshot(snapshotId int) error {
-	fake.deleteSnapshotMutex.Lock()
-	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
-	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
-		snapshotId int
-	}{snapshotId})
-	fake.recordInvocation("DeleteSnapshot", []interface{}{snapshotId})
-	fake.deleteSnapshotMutex.Unlock()
-	if fake.DeleteSnapshotStub != nil {
-		return fake.DeleteSnapshotStub(snapshotId)
+func (fake *FakeClient) IsInstanceReclaimed(id int) (bool, error) {
+	fake.isInstanceReclaimedMutex.Lock()
+	ret, specificReturn := fake.isInstanceReclaimedReturnsOnCall[len(fake.isInstanceReclaimedArgsForCall)]
+	fake.isInstanceReclaimedArgsForCall = append(fake.isInstanceReclaimedArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("IsInstanceReclaimed", []interface{}{id})
+	fake.isInstanceReclaimedMutex.Unlock()
+	if fake.IsInstanceReclaimedStub != nil {
+		return fake.IsInstanceReclaimedStub(id)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.deleteSnapshotReturns.result1
+	return fake.isInstanceReclaimedReturns.result1, fake.isInstanceReclaimedReturns.result2
 }
 
-func (fake *FakeClient) DeleteSnapshotCallCount() int {
-	fake.deleteSnapshotMutex.RLock()
-	defer fake.deleteSnapshotMutex.RUnlock()
-	return len(fake.deleteSnapshotArgsForCall)
+func (fake *FakeClient) IsInstanceReclaimedCallCount() int {
+	fake.isInstanceReclaimedMutex.RLock()
+	defer fake.isInstanceReclaimedMutex.RUnlock()
+	return len(fake.isInstanceReclaimedArgsForCall)
 }
 
-func (fake *FakeClient) DeleteSnapshotArgsForCall(i int) int {
-	fake.deleteSnapshotMutex.RLock()
-	defer fake.deleteSnapshotMutex.RUnlock()
-	return fake.deleteSnapshotArgsForCall[i].snapshotId
+func (fake *FakeClient) IsInstanceReclaimedArgsForCall(i int) int {
+	fake.isInstanceReclaimedMutex.RLock()
+	defer fake.isInstanceReclaimedMutex.RUnlock()
+	return fake.isInstanceReclaimedArgsForCall[i].id
 }
 
-func (fake *FakeClient) DeleteSnapshotReturns(result1 error) {
-	fake.DeleteSnapshotStub = nil
-	fake.deleteSnapshotReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeClient) IsInstanceReclaimedReturns(result1 bool, result2 error) {
+	fake.IsInstanceReclaimedStub = nil
+	fake.isInstanceReclaimedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) DeleteSnapshotReturnsOnCall(i int, result1 error) {
-	fake.DeleteSnapshotStub = nil
-	if fake.deleteSnapshotReturnsOnCall == nil {
-		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeClient) IsInstanceReclaimedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.IsInstanceReclaimedStub = nil
+	if fake.isInstanceReclaimedReturnsOnCall == nil {
+		fake.isInstanceReclaimedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
 		})
 	}
-	fake.deleteSnapshotReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.isInstanceReclaimedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) CreateTicket(ticketSubject *string, ticketTitle *string, contents *string, attachmentId *int, attachmentType *string) error {
-	fake.createTicketMutex.Lock()
-	ret, specificReturn := fake.createTicketReturnsOnCall[len(fake.createTicketArgsForCall)]
-	fake.createTicketArgsForCall = append(fake.createTicketArgsForCall, struct {
-		ticketSubject  *string
-		ticketTitle    *string
-		contents       *string
-		attachmentId   *int
-		attachmentType *string
-	}{ticketSubject, ticketTitle, contents, attachmentId, attachmentType})
-	fake.recordInvocation("CreateTicket", []interface{}{ticketSubject, ticketTitle, contents, attachmentId, attachmentType})
-	fake.createTicketMutex.Unlock()
-	if fake.CreateTicketStub != nil {
-		return fake.CreateTicketStub(ticketSubject, ticketTitle, contents, attachmentId, attachmentType)
+func (fake *FakeClient) VerifyVolumeOrder(location string, size int, iops int) error {
+	fake.verifyVolumeOrderMutex.Lock()
+	ret, specificReturn := fake.verifyVolumeOrderReturnsOnCall[len(fake.verifyVolumeOrderArgsForCall)]
+	fake.verifyVolumeOrderArgsForCall = append(fake.verifyVolumeOrderArgsForCall, struct {
+		location string
+		size     int
+		iops     int
+	}{location, size, iops})
+	fake.recordInvocation("VerifyVolumeOrder", []interface{}{location, size, iops})
+	fake.verifyVolumeOrderMutex.Unlock()
+	if fake.VerifyVolumeOrderStub != nil {
+		return fake.VerifyVolumeOrderStub(location, size, iops)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.createTicketReturns.result1
+	return fake.verifyVolumeOrderReturns.result1
 }
 
-func (fake *FakeClient) CreateTicketCallCount() int {
-	fake.createTicketMutex.RLock()
-	defer fake.createTicketMutex.RUnlock()
-	return len(fake.createTicketArgsForCall)
+func (fake *FakeClient) VerifyVolumeOrderCallCount() int {
+	fake.verifyVolumeOrderMutex.RLock()
+	defer fake.verifyVolumeOrderMutex.RUnlock()
+	return len(fake.verifyVolumeOrderArgsForCall)
 }
 
-func (fake *FakeClient) CreateTicketArgsForCall(i int) (*string, *string, *string, *int, *string) {
-	fake.createTicketMutex.RLock()
-	defer fake.createTicketMutex.RUnlock()
-	return fake.createTicketArgsForCall[i].ticketSubject, fake.createTicketArgsForCall[i].ticketTitle, fake.createTicketArgsForCall[i].contents, fake.createTicketArgsForCall[i].attachmentId, fake.createTicketArgsForCall[i].attachmentType
+func (fake *FakeClient) VerifyVolumeOrderArgsForCall(i int) (string, int, int) {
+	fake.verifyVolumeOrderMutex.RLock()
+	defer fake.verifyVolumeOrderMutex.RUnlock()
+	return fake.verifyVolumeOrderArgsForCall[i].location, fake.verifyVolumeOrderArgsForCall[i].size, fake.verifyVolumeOrderArgsForCall[i].iops
 }
 
-func (fake *FakeClient) CreateTicketReturns(result1 error) {
-	fake.CreateTicketStub = nil
-	fake.createTicketReturns = struct {
+func (fake *FakeClient) VerifyVolumeOrderReturns(result1 error) {
+	fake.VerifyVolumeOrderStub = nil
+	fake.verifyVolumeOrderReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) CreateTicketReturnsOnCall(i int, result1 error) {
-	fake.CreateTicketStub = nil
-	if fake.createTicketReturnsOnCall == nil {
-		fake.createTicketReturnsOnCall = make(map[int]struct {
+func (fake *FakeClient) VerifyVolumeOrderReturnsOnCall(i int, result1 error) {
+	fake.VerifyVolumeOrderStub = nil
+	if fake.verifyVolumeOrderReturnsOnCall == nil {
+		fake.verifyVolumeOrderReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.createTicketReturnsOnCall[i] = struct {
+	fake.verifyVolumeOrderReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) CreateSwiftContainer(containerName string) error {
-	fake.createSwiftContainerMutex.Lock()
-	ret, specificReturn := fake.createSwiftContainerReturnsOnCall[len(fake.createSwiftContainerArgsForCall)]
-	fake.createSwiftContainerArgsForCall = append(fake.createSwiftContainerArgsForCall, struct {
-		containerName string
-	}{containerName})
-	fake.recordInvocation("CreateSwiftContainer", []interface{}{containerName})
-	fake.createSwiftContainerMutex.Unlock()
-	if fake.CreateSwiftContainerStub != nil {
-		return fake.CreateSwiftContainerStub(containerName)
+func (fake *FakeClient) DuplicateVolume(originVolumeId int, snapshotId int, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error) {
+	fake.duplicateVolumeMutex.Lock()
+	ret, specificReturn := fake.duplicateVolumeReturnsOnCall[len(fake.duplicateVolumeArgsForCall)]
+	fake.duplicateVolumeArgsForCall = append(fake.duplicateVolumeArgsForCall, struct {
+		originVolumeId int
+		snapshotId     int
+		location       string
+		size           int
+		iops           int
+		tier           float64
+		snapshotSpace  int
+	}{originVolumeId, snapshotId, location, size, iops, tier, snapshotSpace})
+	fake.recordInvocation("DuplicateVolume", []interface{}{originVolumeId, snapshotId, location, size, iops, tier, snapshotSpace})
+	fake.duplicateVolumeMutex.Unlock()
+	if fake.DuplicateVolumeStub != nil {
+		return fake.DuplicateVolumeStub(originVolumeId, snapshotId, location, size, iops, tier, snapshotSpace)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.duplicateVolumeReturns.result1, fake.duplicateVolumeReturns.result2
+}
+
+func (fake *FakeClient) DuplicateVolumeCallCount() int {
+	fake.duplicateVolumeMutex.RLock()
+	defer fake.duplicateVolumeMutex.RUnlock()
+	return len(fake.duplicateVolumeArgsForCall)
+}
+
+func (fake *FakeClient) DuplicateVolumeArgsForCall(i int) (int, int, string, int, int, float64, int) {
+	fake.duplicateVolumeMutex.RLock()
+	defer fake.duplicateVolumeMutex.RUnlock()
+	return fake.duplicateVolumeArgsForCall[i].originVolumeId, fake.duplicateVolumeArgsForCall[i].snapshotId, fake.duplicateVolumeArgsForCall[i].location, fake.duplicateVolumeArgsForCall[i].size, fake.duplicateVolumeArgsForCall[i].iops, fake.duplicateVolumeArgsForCall[i].tier, fake.duplicateVolumeArgsForCall[i].snapshotSpace
+}
+
+func (fake *FakeClient) DuplicateVolumeReturns(result1 *datatypes.Network_Storage, result2 error) {
+	fake.DuplicateVolumeStub = nil
+	fake.duplicateVolumeReturns = struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DuplicateVolumeReturnsOnCall(i int, result1 *datatypes.Network_Storage, result2 error) {
+	fake.DuplicateVolumeStub = nil
+	if fake.duplicateVolumeReturnsOnCall == nil {
+		fake.duplicateVolumeReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_Storage
+			result2 error
+		})
+	}
+	fake.duplicateVolumeReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetSnapshotSchedules(volumeId int) ([]datatypes.Network_Storage_Schedule, error) {
+	fake.getSnapshotSchedulesMutex.Lock()
+	ret, specificReturn := fake.getSnapshotSchedulesReturnsOnCall[len(fake.getSnapshotSchedulesArgsForCall)]
+	fake.getSnapshotSchedulesArgsForCall = append(fake.getSnapshotSchedulesArgsForCall, struct {
+		volumeId int
+	}{volumeId})
+	fake.recordInvocation("GetSnapshotSchedules", []interface{}{volumeId})
+	fake.getSnapshotSchedulesMutex.Unlock()
+	if fake.GetSnapshotSchedulesStub != nil {
+		return fake.GetSnapshotSchedulesStub(volumeId)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getSnapshotSchedulesReturns.result1, fake.getSnapshotSchedulesReturns.result2
+}
+
+func (fake *FakeClient) GetSnapshotSchedulesCallCount() int {
+	fake.getSnapshotSchedulesMutex.RLock()
+	defer fake.getSnapshotSchedulesMutex.RUnlock()
+	return len(fake.getSnapshotSchedulesArgsForCall)
+}
+
+func (fake *FakeClient) GetSnapshotSchedulesArgsForCall(i int) int {
+	fake.getSnapshotSchedulesMutex.RLock()
+	defer fake.getSnapshotSchedulesMutex.RUnlock()
+	return fake.getSnapshotSchedulesArgsForCall[i].volumeId
+}
+
+func (fake *FakeClient) GetSnapshotSchedulesReturns(result1 []datatypes.Network_Storage_Schedule, result2 error) {
+	fake.GetSnapshotSchedulesStub = nil
+	fake.getSnapshotSchedulesReturns = struct {
+		result1 []datatypes.Network_Storage_Schedule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetSnapshotSchedulesReturnsOnCall(i int, result1 []datatypes.Network_Storage_Schedule, result2 error) {
+	fake.GetSnapshotSchedulesStub = nil
+	if fake.getSnapshotSchedulesReturnsOnCall == nil {
+		fake.getSnapshotSchedulesReturnsOnCall = make(map[int]struct {
+			result1 []datatypes.Network_Storage_Schedule
+			result2 error
+		})
+	}
+	fake.getSnapshotSchedulesReturnsOnCall[i] = struct {
+		result1 []datatypes.Network_Storage_Schedule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) EnableSnapshot(volumeId int, scheduleType string, retentionCount int, minute int, hour int, dayOfWeek string) error {
+	fake.enableSnapshotMutex.Lock()
+	ret, specificReturn := fake.enableSnapshotReturnsOnCall[len(fake.enableSnapshotArgsForCall)]
+	fake.enableSnapshotArgsForCall = append(fake.enableSnapshotArgsForCall, struct {
+		volumeId       int
+		scheduleType   string
+		retentionCount int
+		minute         int
+		hour           int
+		dayOfWeek      string
+	}{volumeId, scheduleType, retentionCount, minute, hour, dayOfWeek})
+	fake.recordInvocation("EnableSnapshot", []interface{}{volumeId, scheduleType, retentionCount, minute, hour, dayOfWeek})
+	fake.enableSnapshotMutex.Unlock()
+	if fake.EnableSnapshotStub != nil {
+		return fake.EnableSnapshotStub(volumeId, scheduleType, retentionCount, minute, hour, dayOfWeek)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.createSwiftContainerReturns.result1
+	return fake.enableSnapshotReturns.result1
 }
 
-func (fake *FakeClient) CreateSwiftContainerCallCount() int {
-	fake.createSwiftContainerMutex.RLock()
-	defer fake.createSwiftContainerMutex.RUnlock()
-	return len(fake.createSwiftContainerArgsForCall)
+func (fake *FakeClient) EnableSnapshotCallCount() int {
+	fake.enableSnapshotMutex.RLock()
+	defer fake.enableSnapshotMutex.RUnlock()
+	return len(fake.enableSnapshotArgsForCall)
 }
 
-func (fake *FakeClient) CreateSwiftContainerArgsForCall(i int) string {
-	fake.createSwiftContainerMutex.RLock()
-	defer fake.createSwiftContainerMutex.RUnlock()
-	return fake.createSwiftContainerArgsForCall[i].containerName
+func (fake *FakeClient) EnableSnapshotArgsForCall(i int) (int, string, int, int, int, string) {
+	fake.enableSnapshotMutex.RLock()
+	defer fake.enableSnapshotMutex.RUnlock()
+	return fake.enableSnapshotArgsForCall[i].volumeId, fake.enableSnapshotArgsForCall[i].scheduleType, fake.enableSnapshotArgsForCall[i].retentionCount, fake.enableSnapshotArgsForCall[i].minute, fake.enableSnapshotArgsForCall[i].hour, fake.enableSnapshotArgsForCall[i].dayOfWeek
 }
 
-func (fake *FakeClient) CreateSwiftContainerReturns(result1 error) {
-	fake.CreateSwiftContainerStub = nil
-	fake.createSwiftContainerReturns = struct {
+func (fake *FakeClient) EnableSnapshotReturns(result1 error) {
+	fake.EnableSnapshotStub = nil
+	fake.enableSnapshotReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) CreateSwiftContainerReturnsOnCall(i int, result1 error) {
-	fake.CreateSwiftContainerStub = nil
-	if fake.createSwiftContainerReturnsOnCall == nil {
-		fake.createSwiftContainerReturnsOnCall = make(map[int]struct {
+func (fake *FakeClient) EnableSnapshotReturnsOnCall(i int, result1 error) {
+	fake.EnableSnapshotStub = nil
+	if fake.enableSnapshotReturnsOnCall == nil {
+		fake.enableSnapshotReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.createSwiftContainerReturnsOnCall[i] = struct {
+	fake.enableSnapshotReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) DeleteSwiftContainer(containerName string) error {
-	fake.deleteSwiftContainerMutex.Lock()
-	ret, specificReturn := fake.deleteSwiftContainerReturnsOnCall[len(fake.deleteSwiftContainerArgsForCall)]
-	fake.deleteSwiftContainerArgsForCall = append(fake.deleteSwiftContainerArgsForCall, struct {
-		containerName string
-	}{containerName})
-	fake.recordInvocation("DeleteSwiftContainer", []interface{}{containerName})
-	fake.deleteSwiftContainerMutex.Unlock()
-	if fake.DeleteSwiftContainerStub != nil {
-		return fake.DeleteSwiftContainerStub(containerName)
+func (fake *FakeClient) DisableSnapshots(volumeId int, scheduleType string) error {
+	fake.disableSnapshotsMutex.Lock()
+	ret, specificReturn := fake.disableSnapshotsReturnsOnCall[len(fake.disableSnapshotsArgsForCall)]
+	fake.disableSnapshotsArgsForCall = append(fake.disableSnapshotsArgsForCall, struct {
+		volumeId     int
+		scheduleType string
+	}{volumeId, scheduleType})
+	fake.recordInvocation("DisableSnapshots", []interface{}{volumeId, scheduleType})
+	fake.disableSnapshotsMutex.Unlock()
+	if fake.DisableSnapshotsStub != nil {
+		return fake.DisableSnapshotsStub(volumeId, scheduleType)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteSwiftContainerReturns.result1
+	return fake.disableSnapshotsReturns.result1
 }
 
-func (fake *FakeClient) DeleteSwiftContainerCallCount() int {
-	fake.deleteSwiftContainerMutex.RLock()
-	defer fake.deleteSwiftContainerMutex.RUnlock()
-	return len(fake.deleteSwiftContainerArgsForCall)
+func (fake *FakeClient) DisableSnapshotsCallCount() int {
+	fake.disableSnapshotsMutex.RLock()
+	defer fake.disableSnapshotsMutex.RUnlock()
+	return len(fake.disableSnapshotsArgsForCall)
 }
 
-func (fake *FakeClient) DeleteSwiftContainerArgsForCall(i int) string {
-	fake.deleteSwiftContainerMutex.RLock()
-	defer fake.deleteSwiftContainerMutex.RUnlock()
-	return fake.deleteSwiftContainerArgsForCall[i].containerName
+func (fake *FakeClient) DisableSnapshotsArgsForCall(i int) (int, string) {
+	fake.disableSnapshotsMutex.RLock()
+	defer fake.disableSnapshotsMutex.RUnlock()
+	return fake.disableSnapshotsArgsForCall[i].volumeId, fake.disableSnapshotsArgsForCall[i].scheduleType
 }
 
-func (fake *FakeClient) DeleteSwiftContainerReturns(result1 error) {
-	fake.DeleteSwiftContainerStub = nil
-	fake.deleteSwiftContainerReturns = struct {
+func (fake *FakeClient) DisableSnapshotsReturns(result1 error) {
+	fake.DisableSnapshotsStub = nil
+	fake.disableSnapshotsReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) DeleteSwiftContainerReturnsOnCall(i int, result1 error) {
-	fake.DeleteSwiftContainerStub = nil
-	if fake.deleteSwiftContainerReturnsOnCall == nil {
-		fake.deleteSwiftContainerReturnsOnCall = make(map[int]struct {
+func (fake *FakeClient) DisableSnapshotsReturnsOnCall(i int, result1 error) {
+	fake.DisableSnapshotsStub = nil
+	if fake.disableSnapshotsReturnsOnCall == nil {
+		fake.disableSnapshotsReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.deleteSwiftContainerReturnsOnCall[i] = struct {
+	fake.disableSnapshotsReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeClient) UploadSwiftLargeObject(containerName string, objectName string, objectFile string) error {
-	fake.uploadSwiftLargeObjectMutex.Lock()
-	ret, specificReturn := fake.uploadSwiftLargeObjectReturnsOnCall[len(fake.uploadSwiftLargeObjectArgsForCall)]
-	fake.uploadSwiftLargeObjectArgsForCall = append(fake.uploadSwiftLargeObjectArgsForCall, struct {
-		containerName string
-		objectName    string
-		objectFile    string
-	}{containerName, objectName, objectFile})
-	fake.recordInvocation("UploadSwiftLargeObject", []interface{}{containerName, objectName, objectFile})
-	fake.uploadSwiftLargeObjectMutex.Unlock()
-	if fake.UploadSwiftLargeObjectStub != nil {
-		return fake.UploadSwiftLargeObjectStub(containerName, objectName, objectFile)
+func (fake *FakeClient) GetSnapshots(volumeId int) ([]datatypes.Network_Storage, error) {
+	fake.getSnapshotsMutex.Lock()
+	ret, specificReturn := fake.getSnapshotsReturnsOnCall[len(fake.getSnapshotsArgsForCall)]
+	fake.getSnapshotsArgsForCall = append(fake.getSnapshotsArgsForCall, struct {
+		volumeId int
+	}{volumeId})
+	fake.recordInvocation("GetSnapshots", []interface{}{volumeId})
+	fake.getSnapshotsMutex.Unlock()
+	if fake.GetSnapshotsStub != nil {
+		return fake.GetSnapshotsStub(volumeId)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.uploadSwiftLargeObjectReturns.result1
+	return fake.getSnapshotsReturns.result1, fake.getSnapshotsReturns.result2
 }
 
-func (fake *FakeClient) UploadSwiftLargeObjectCallCount() int {
-	fake.uploadSwiftLargeObjectMutex.RLock()
-	defer fake.uploadSwiftLargeObjectMutex.RUnlock()
-	return len(fake.uploadSwiftLargeObjectArgsForCall)
+func (fake *FakeClient) GetSnapshotsCallCount() int {
+	fake.getSnapshotsMutex.RLock()
+	defer fake.getSnapshotsMutex.RUnlock()
+	return len(fake.getSnapshotsArgsForCall)
 }
 
-func (fake *FakeClient) UploadSwiftLargeObjectArgsForCall(i int) (string, string, string) {
-	fake.uploadSwiftLargeObjectMutex.RLock()
-	defer fake.uploadSwiftLargeObjectMutex.RUnlock()
-	return fake.uploadSwiftLargeObjectArgsForCall[i].containerName, fake.uploadSwiftLargeObjectArgsForCall[i].objectName, fake.uploadSwiftLargeObjectArgsForCall[i].objectFile
+func (fake *FakeClient) GetSnapshotsArgsForCall(i int) int {
+	fake.getSnapshotsMutex.RLock()
+	defer fake.getSnapshotsMutex.RUnlock()
+	return fake.getSnapshotsArgsForCall[i].volumeId
 }
 
-func (fake *FakeClient) UploadSwiftLargeObjectReturns(result1 error) {
-	fake.UploadSwiftLargeObjectStub = nil
-	fake.uploadSwiftLargeObjectReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeClient) GetSnapshotsReturns(result1 []datatypes.Network_Storage, result2 error) {
+	fake.GetSnapshotsStub = nil
+	fake.getSnapshotsReturns = struct {
+		result1 []datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) UploadSwiftLargeObjectReturnsOnCall(i int, result1 error) {
-	fake.UploadSwiftLargeObjectStub = nil
-	if fake.uploadSwiftLargeObjectReturnsOnCall == nil {
-		fake.uploadSwiftLargeObjectReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeClient) GetSnapshotsReturnsOnCall(i int, result1 []datatypes.Network_Storage, result2 error) {
+	fake.GetSnapshotsStub = nil
+	if fake.getSnapshotsReturnsOnCall == nil {
+		fake.getSnapshotsReturnsOnCall = make(map[int]struct {
+			result1 []datatypes.Network_Storage
+			result2 error
 		})
 	}
-	fake.uploadSwiftLargeObjectReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.getSnapshotsReturnsOnCall[i] = struct {
+		result1 []datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) DeleteSwiftLargeObject(containerName string, objectFileName string) error {
-	fake.deleteSwiftLargeObjectMutex.Lock()
-	ret, specificReturn := fake.deleteSwiftLargeObjectReturnsOnCall[len(fake.deleteSwiftLargeObjectArgsForCall)]
-	fake.deleteSwiftLargeObjectArgsForCall = append(fake.deleteSwiftLargeObjectArgsForCall, struct {
-		containerName  string
-		objectFileName string
-	}{containerName, objectFileName})
-	fake.recordInvocation("DeleteSwiftLargeObject", []interface{}{containerName, objectFileName})
-	fake.deleteSwiftLargeObjectMutex.Unlock()
-	if fake.DeleteSwiftLargeObjectStub != nil {
-		return fake.DeleteSwiftLargeObjectStub(containerName, objectFileName)
+func (fake *FakeClient) OrderReplicantVolume(originVolumeId int, originScheduleId int, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error) {
+	fake.orderReplicantVolumeMutex.Lock()
+	ret, specificReturn := fake.orderReplicantVolumeReturnsOnCall[len(fake.orderReplicantVolumeArgsForCall)]
+	fake.orderReplicantVolumeArgsForCall = append(fake.orderReplicantVolumeArgsForCall, struct {
+		originVolumeId   int
+		originScheduleId int
+		location         string
+		size             int
+		iops             int
+		tier             float64
+		snapshotSpace    int
+	}{originVolumeId, originScheduleId, location, size, iops, tier, snapshotSpace})
+	fake.recordInvocation("OrderReplicantVolume", []interface{}{originVolumeId, originScheduleId, location, size, iops, tier, snapshotSpace})
+	fake.orderReplicantVolumeMutex.Unlock()
+	if fake.OrderReplicantVolumeStub != nil {
+		return fake.OrderReplicantVolumeStub(originVolumeId, originScheduleId, location, size, iops, tier, snapshotSpace)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.deleteSwiftLargeObjectReturns.result1
+	return fake.orderReplicantVolumeReturns.result1, fake.orderReplicantVolumeReturns.result2
 }
 
-func (fake *FakeClient) DeleteSwiftLargeObjectCallCount() int {
-	fake.deleteSwiftLargeObjectMutex.RLock()
-	defer fake.deleteSwiftLargeObjectMutex.RUnlock()
-	return len(fake.deleteSwiftLargeObjectArgsForCall)
+func (fake *FakeClient) OrderReplicantVolumeCallCount() int {
+	fake.orderReplicantVolumeMutex.RLock()
+	defer fake.orderReplicantVolumeMutex.RUnlock()
+	return len(fake.orderReplicantVolumeArgsForCall)
 }
 
-func (fake *FakeClient) DeleteSwiftLargeObjectArgsForCall(i int) (string, string) {
-	fake.deleteSwiftLargeObjectMutex.RLock()
-	defer fake.deleteSwiftLargeObjectMutex.RUnlock()
-	return fake.deleteSwiftLargeObjectArgsForCall[i].containerName, fake.deleteSwiftLargeObjectArgsForCall[i].objectFileName
+func (fake *FakeClient) OrderReplicantVolumeArgsForCall(i int) (int, int, string, int, int, float64, int) {
+	fake.orderReplicantVolumeMutex.RLock()
+	defer fake.orderReplicantVolumeMutex.RUnlock()
+	return fake.orderReplicantVolumeArgsForCall[i].originVolumeId, fake.orderReplicantVolumeArgsForCall[i].originScheduleId, fake.orderReplicantVolumeArgsForCall[i].location, fake.orderReplicantVolumeArgsForCall[i].size, fake.orderReplicantVolumeArgsForCall[i].iops, fake.orderReplicantVolumeArgsForCall[i].tier, fake.orderReplicantVolumeArgsForCall[i].snapshotSpace
 }
 
-func (fake *FakeClient) DeleteSwiftLargeObjectReturns(result1 error) {
-	fake.DeleteSwiftLargeObjectStub = nil
-	fake.deleteSwiftLargeObjectReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeClient) OrderReplicantVolumeReturns(result1 *datatypes.Network_Storage, result2 error) {
+	fake.OrderReplicantVolumeStub = nil
+	fake.orderReplicantVolumeReturns = struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) DeleteSwiftLargeObjectReturnsOnCall(i int, result1 error) {
-	fake.DeleteSwiftLargeObjectStub = nil
-	if fake.deleteSwiftLargeObjectReturnsOnCall == nil {
-		fake.deleteSwiftLargeObjectReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeClient) OrderReplicantVolumeReturnsOnCall(i int, result1 *datatypes.Network_Storage, result2 error) {
+	fake.OrderReplicantVolumeStub = nil
+	if fake.orderReplicantVolumeReturnsOnCall == nil {
+		fake.orderReplicantVolumeReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_Storage
+			result2 error
 		})
 	}
-	fake.deleteSwiftLargeObjectReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.orderReplicantVolumeReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeClient) CreateImageFromExternalSource(imageName string, note string, cluster string, osCode string) (int, error) {
-	fake.createImageFromExternalSourceMutex.Lock()
-	ret, specificReturn := fake.createImageFromExternalSourceReturnsOnCall[len(fake.createImageFromExternalSourceArgsForCall)]
-	fake.createImageFromExternalSourceArgsForCall = append(fake.createImageFromExternalSourceArgsForCall, struct {
-		imageName string
-		note      string
-		cluster   string
-		osCode    string
-	}{imageName, note, cluster, osCode})
-	fake.recordInvocation("CreateImageFromExternalSource", []interface{}{imageName, note, cluster, osCode})
-	fake.createImageFromExternalSourceMutex.Unlock()
-	if fake.CreateImageFromExternalSourceStub != nil {
-		return fake.CreateImageFromExternalSourceStub(imageName, note, cluster, osCode)
+func (fake *FakeClient) CreateVolume(location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Network_Storage, error) {
+	fake.createVolumeMutex.Lock()
+	ret, specificReturn := fake.createVolumeReturnsOnCall[len(fake.createVolumeArgsForCall)]
+	fake.createVolumeArgsForCall = append(fake.createVolumeArgsForCall, struct {
+		location      string
+		size          int
+		iops          int
+		tier          float64
+		snapshotSpace int
+	}{location, size, iops, tier, snapshotSpace})
+	fake.recordInvocation("CreateVolume", []interface{}{location, size, iops, tier, snapshotSpace})
+	fake.createVolumeMutex.Unlock()
+	if fake.CreateVolumeStub != nil {
+		return fake.CreateVolumeStub(location, size, iops, tier, snapshotSpace)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createImageFromExternalSourceReturns.result1, fake.createImageFromExternalSourceReturns.result2
+	return fake.createVolumeReturns.result1, fake.createVolumeReturns.result2
 }
 
-func (fake *FakeClient) CreateImageFromExternalSourceCallCount() int {
-	fake.createImageFromExternalSourceMutex.RLock()
-	defer fake.createImageFromExternalSourceMutex.RUnlock()
-	return len(fake.createImageFromExternalSourceArgsForCall)
+func (fake *FakeClient) CreateVolumeCallCount() int {
+	fake.createVolumeMutex.RLock()
+	defer fake.createVolumeMutex.RUnlock()
+	return len(fake.createVolumeArgsForCall)
 }
 
-func (fake *FakeClient) CreateImageFromExternalSourceArgsForCall(i int) (string, string, string, string) {
-	fake.createImageFromExternalSourceMutex.RLock()
-	defer fake.createImageFromExternalSourceMutex.RUnlock()
-	return fake.createImageFromExternalSourceArgsForCall[i].imageName, fake.createImageFromExternalSourceArgsForCall[i].note, fake.createImageFromExternalSourceArgsForCall[i].cluster, fake.createImageFromExternalSourceArgsForCall[i].osCode
+func (fake *FakeClient) CreateVolumeArgsForCall(i int) (string, int, int, float64, int) {
+	fake.createVolumeMutex.RLock()
+	defer fake.createVolumeMutex.RUnlock()
+	return fake.createVolumeArgsForCall[i].location, fake.createVolumeArgsForCall[i].size, fake.createVolumeArgsForCall[i].iops, fake.createVolumeArgsForCall[i].tier, fake.createVolumeArgsForCall[i].snapshotSpace
 }
 
-func (fake *FakeClient) CreateImageFromExternalSourceReturns(result1 int, result2 error) {
-	fake.CreateImageFromExternalSourceStub = nil
-	fake.createImageFromExternalSourceReturns = struct {
-		result1 int
+func (fake *FakeClient) CreateVolumeReturns(result1 *datatypes.Network_Storage, result2 error) {
+	fake.CreateVolumeStub = nil
+	fake.createVolumeReturns = struct {
+		result1 *datatypes.Network_Storage
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) CreateImageFromExternalSourceReturnsOnCall(i int, result1 int, result2 error) {
-	fake.CreateImageFromExternalSourceStub = nil
-	if fake.createImageFromExternalSourceReturnsOnCall == nil {
-		fake.createImageFromExternalSourceReturnsOnCall = make(map[int]struct {
-			result1 int
+func (fake *FakeClient) CreateVolumeReturnsOnCall(i int, result1 *datatypes.Network_Storage, result2 error) {
+	fake.CreateVolumeStub = nil
+	if fake.createVolumeReturnsOnCall == nil {
+		fake.createVolumeReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Network_Storage
 			result2 error
 		})
 	}
-	fake.createImageFromExternalSourceReturnsOnCall[i] = struct {
-		result1 int
+	fake.createVolumeReturnsOnCall[i] = struct {
+		result1 *datatypes.Network_Storage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) OrderBlockVolume2(storageType string, location string, size int, iops int, tier float64, snapshotSpace int) (*datatypes.Container_Product_Order_Receipt, error) {
+	fake.orderBlockVolume2Mutex.Lock()
+	ret, specificReturn := fake.orderBlockVolume2ReturnsOnCall[len(fake.orderBlockVolume2ArgsForCall)]
+	fake.orderBlockVolume2ArgsForCall = append(fake.orderBlockVolume2ArgsForCall, struct {
+		storageType   string
+		location      string
+		size          int
+		iops          int
+		tier          float64
+		snapshotSpace int
+	}{storageType, location, size, iops, tier, snapshotSpace})
+	fake.recordInvocation("OrderBlockVolume2", []interface{}{storageType, location, size, iops, tier, snapshotSpace})
+	fake.orderBlockVolume2Mutex.Unlock()
+	if fake.OrderBlockVolume2Stub != nil {
+		return fake.OrderBlockVolume2Stub(storageType, location, size, iops, tier, snapshotSpace)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.orderBlockVolume2Returns.result1, fake.orderBlockVolume2Returns.result2
+}
+
+func (fake *FakeClient) OrderBlockVolume2CallCount() int {
+	fake.orderBlockVolume2Mutex.RLock()
+	defer fake.orderBlockVolume2Mutex.RUnlock()
+	return len(fake.orderBlockVolume2ArgsForCall)
+}
+
+func (fake *FakeClient) OrderBlockVolume2ArgsForCall(i int) (string, string, int, int, float64, int) {
+	fake.orderBlockVolume2Mutex.RLock()
+	defer fake.orderBlockVolume2Mutex.RUnlock()
+	return fake.orderBlockVolume2ArgsForCall[i].storageType, fake.orderBlockVolume2ArgsForCall[i].location, fake.orderBlockVolume2ArgsForCall[i].size, fake.orderBlockVolume2ArgsForCall[i].iops, fake.orderBlockVolume2ArgsForCall[i].tier, fake.orderBlockVolume2ArgsForCall[i].snapshotSpace
+}
+
+func (fake *FakeClient) OrderBlockVolume2Returns(result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
+	fake.OrderBlockVolume2Stub = nil
+	fake.orderBlockVolume2Returns = struct {
+		result1 *datatypes.Container_Product_Order_Receipt
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) OrderBlockVolume2ReturnsOnCall(i int, result1 *datatypes.Container_Product_Order_Receipt, result2 error) {
+	fake.OrderBlockVolume2Stub = nil
+	if fake.orderBlockVolume2ReturnsOnCall == nil {
+		fake.orderBlockVolume2ReturnsOnCall = make(map[int]struct {
+			result1 *datatypes.Container_Product_Order_Receipt
+			result2 error
+		})
+	}
+	fake.orderBlockVolume2ReturnsOnCall[i] = struct {
+		result1 *datatypes.Container_Product_Order_Receipt
 		result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeClient) SetIpAddressNote(id int, note string) error {
+	fake.setIpAddressNoteMutex.Lock()
+	ret, specificReturn := fake.setIpAddressNoteReturnsOnCall[len(fake.setIpAddressNoteArgsForCall)]
+	fake.setIpAddressNoteArgsForCall = append(fake.setIpAddressNoteArgsForCall, struct {
+		id   int
+		note string
+	}{id, note})
+	fake.recordInvocation("SetIpAddressNote", []interface{}{id, note})
+	fake.setIpAddressNoteMutex.Unlock()
+	if fake.SetIpAddressNoteStub != nil {
+		return fake.SetIpAddressNoteStub(id, note)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setIpAddressNoteReturns.result1
+}
+
+func (fake *FakeClient) SetIpAddressNoteCallCount() int {
+	fake.setIpAddressNoteMutex.RLock()
+	defer fake.setIpAddressNoteMutex.RUnlock()
+	return len(fake.setIpAddressNoteArgsForCall)
+}
+
+func (fake *FakeClient) SetIpAddressNoteArgsForCall(i int) (int, string) {
+	fake.setIpAddressNoteMutex.RLock()
+	defer fake.setIpAddressNoteMutex.RUnlock()
+	return fake.setIpAddressNoteArgsForCall[i].id, fake.setIpAddressNoteArgsForCall[i].note
+}
+
+func (fake *FakeClient) SetIpAddressNoteReturns(result1 error) {
+	fake.SetIpAddressNoteStub = nil
+	fake.setIpAddressNoteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) SetIpAddressNoteReturnsOnCall(i int, result1 error) {
+	fake.SetIpAddressNoteStub = nil
+	if fake.setIpAddressNoteReturnsOnCall == nil {
+		fake.setIpAddressNoteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setIpAddressNoteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -3125,12 +5325,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.authorizeHostToVolumeMutex.RUnlock()
 	fake.deauthorizeHostToVolumeMutex.RLock()
 	defer fake.deauthorizeHostToVolumeMutex.RUnlock()
-	fake.createVolumeMutex.RLock()
-	defer fake.createVolumeMutex.RUnlock()
 	fake.orderBlockVolumeMutex.RLock()
 	defer fake.orderBlockVolumeMutex.RUnlock()
-	fake.orderBlockVolume2Mutex.RLock()
-	defer fake.orderBlockVolume2Mutex.RUnlock()
 	fake.cancelBlockVolumeMutex.RLock()
 	defer fake.cancelBlockVolumeMutex.RUnlock()
 	fake.getBlockVolumeDetailsMutex.RLock()
@@ -3175,6 +5371,78 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.deleteSwiftLargeObjectMutex.RUnlock()
 	fake.createImageFromExternalSourceMutex.RLock()
 	defer fake.createImageFromExternalSourceMutex.RUnlock()
+	fake.getVirtualServerPackageMutex.RLock()
+	defer fake.getVirtualServerPackageMutex.RUnlock()
+	fake.modifyVolumeMutex.RLock()
+	defer fake.modifyVolumeMutex.RUnlock()
+	fake.upgradeBlockVolumeMutex.RLock()
+	defer fake.upgradeBlockVolumeMutex.RUnlock()
+	fake.waitVolumeModificationCompletedMutex.RLock()
+	defer fake.waitVolumeModificationCompletedMutex.RUnlock()
+	fake.waitSnapshotSpaceCompletedMutex.RLock()
+	defer fake.waitSnapshotSpaceCompletedMutex.RUnlock()
+	fake.orderSnapshotSpaceMutex.RLock()
+	defer fake.orderSnapshotSpaceMutex.RUnlock()
+	fake.getSubnetByIpAddressMutex.RLock()
+	defer fake.getSubnetByIpAddressMutex.RUnlock()
+	fake.getGlobalIpMutex.RLock()
+	defer fake.getGlobalIpMutex.RUnlock()
+	fake.getGlobalIpsByDestinationMutex.RLock()
+	defer fake.getGlobalIpsByDestinationMutex.RUnlock()
+	fake.routeGlobalIpMutex.RLock()
+	defer fake.routeGlobalIpMutex.RUnlock()
+	fake.unrouteGlobalIpMutex.RLock()
+	defer fake.unrouteGlobalIpMutex.RUnlock()
+	fake.getLoadBalancerMutex.RLock()
+	defer fake.getLoadBalancerMutex.RUnlock()
+	fake.addLoadBalancerMemberMutex.RLock()
+	defer fake.addLoadBalancerMemberMutex.RUnlock()
+	fake.updateLoadBalancerMemberWeightMutex.RLock()
+	defer fake.updateLoadBalancerMemberWeightMutex.RUnlock()
+	fake.deleteLoadBalancerMemberMutex.RLock()
+	defer fake.deleteLoadBalancerMemberMutex.RUnlock()
+	fake.waitLoadBalancerActiveMutex.RLock()
+	defer fake.waitLoadBalancerActiveMutex.RUnlock()
+	fake.getSecurityGroupsMutex.RLock()
+	defer fake.getSecurityGroupsMutex.RUnlock()
+	fake.attachSecurityGroupComponentsMutex.RLock()
+	defer fake.attachSecurityGroupComponentsMutex.RUnlock()
+	fake.detachSecurityGroupComponentsMutex.RLock()
+	defer fake.detachSecurityGroupComponentsMutex.RUnlock()
+	fake.getDedicatedHostsMutex.RLock()
+	defer fake.getDedicatedHostsMutex.RUnlock()
+	fake.getPlacementGroupsMutex.RLock()
+	defer fake.getPlacementGroupsMutex.RUnlock()
+	fake.getPlacementGroupMutex.RLock()
+	defer fake.getPlacementGroupMutex.RUnlock()
+	fake.createPlacementGroupMutex.RLock()
+	defer fake.createPlacementGroupMutex.RUnlock()
+	fake.deletePlacementGroupMutex.RLock()
+	defer fake.deletePlacementGroupMutex.RUnlock()
+	fake.getInstancePlacementGroupIdMutex.RLock()
+	defer fake.getInstancePlacementGroupIdMutex.RUnlock()
+	fake.isInstanceReclaimedMutex.RLock()
+	defer fake.isInstanceReclaimedMutex.RUnlock()
+	fake.verifyVolumeOrderMutex.RLock()
+	defer fake.verifyVolumeOrderMutex.RUnlock()
+	fake.duplicateVolumeMutex.RLock()
+	defer fake.duplicateVolumeMutex.RUnlock()
+	fake.getSnapshotSchedulesMutex.RLock()
+	defer fake.getSnapshotSchedulesMutex.RUnlock()
+	fake.enableSnapshotMutex.RLock()
+	defer fake.enableSnapshotMutex.RUnlock()
+	fake.disableSnapshotsMutex.RLock()
+	defer fake.disableSnapshotsMutex.RUnlock()
+	fake.getSnapshotsMutex.RLock()
+	defer fake.getSnapshotsMutex.RUnlock()
+	fake.orderReplicantVolumeMutex.RLock()
+	defer fake.orderReplicantVolumeMutex.RUnlock()
+	fake.createVolumeMutex.RLock()
+	defer fake.createVolumeMutex.RUnlock()
+	fake.setIpAddressNoteMutex.RLock()
+	defer fake.setIpAddressNoteMutex.RUnlock()
+	fake.orderBlockVolume2Mutex.RLock()
+	defer fake.orderBlockVolume2Mutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -4,6 +4,8 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -266,6 +268,27 @@ var _ = Describe("InstanceHandler", func() {
 		})
 	})
 
+	Describe("GetDedicatedHosts", func() {
+		It("returns the dedicated hosts with their free capacity", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Account_getDedicatedHosts.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			hosts, err := cli.GetDedicatedHosts()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hosts).To(HaveLen(2))
+			Expect(*hosts[0].Name).To(Equal("cf-dh-01"))
+			Expect(*hosts[0].TagReferences[0].Tag.Name).To(Equal("cf-dh"))
+			Expect(*hosts[0].AllocationStatus.CpuAvailable).To(Equal(44))
+			Expect(*hosts[1].AllocationStatus.GuestCount).To(Equal(0))
+		})
+	})
+
 	Describe("GetSubnet", func() {
 		Context("when NetworkSubnetService getObject call successfully", func() {
 			It("get vlan successfully", func() {
@@ -337,6 +360,139 @@ var _ = Describe("InstanceHandler", func() {
 		})
 	})
 
+	Describe("GetSubnetByIpAddress", func() {
+		It("get subnet by ip address successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Subnet_getSubnetForIpAddress.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			subnet, found, err := cli.GetSubnetByIpAddress("169.55.61.218", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(*subnet.AddressSpace).To(Equal("PUBLIC"))
+			Expect(*subnet.NetworkVlanId).To(Equal(1234567))
+		})
+
+		It("return an error", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Subnet_getObject_InternalError.json",
+					"statusCode": http.StatusInternalServerError,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := cli.GetSubnetByIpAddress("169.55.61.218", "")
+			Expect(err).To(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("GetGlobalIp", func() {
+		It("get global ip successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Account_getGlobalIpRecords.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			globalIp, found, err := cli.GetGlobalIp("169.55.61.215")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(*globalIp.Id).To(Equal(52703))
+		})
+
+		It("return not found when the account has no such global ip", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Account_getGlobalIpRecords.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := cli.GetGlobalIp("169.55.61.216")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("GetGlobalIpsByDestination", func() {
+		It("get global ips routed to an ip successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Account_getGlobalIpRecords.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			globalIps, err := cli.GetGlobalIpsByDestination("169.55.61.50")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(globalIps).To(HaveLen(1))
+			Expect(*globalIps[0].Id).To(Equal(52703))
+		})
+	})
+
+	Describe("RouteGlobalIp", func() {
+		It("route global ip successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Subnet_IpAddress_Global_route.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.RouteGlobalIp(52703, "169.55.61.50")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("UnrouteGlobalIp", func() {
+		It("unroute global ip successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Subnet_IpAddress_Global_unroute.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.UnrouteGlobalIp(52703)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("SetIpAddressNote", func() {
+		It("set ip address note successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Subnet_IpAddress_editObject.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.SetIpAddressNote(65633607, "fake-note")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("GetInstanceByPrimaryBackendIpAddress", func() {
 		Context("when AccountService getVirtualGuests call successfully", func() {
 			It("get instance by primary backend ip successfully", func() {
@@ -588,6 +744,27 @@ var _ = Describe("InstanceHandler", func() {
 			})
 		})
 
+		Context("when the API rate limit is exceeded", func() {
+			It("backs off and polls again", func() {
+				respParas = []map[string]interface{}{
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_RateLimitExceeded.json",
+						"statusCode": http.StatusTooManyRequests,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_HasNoneActiveTxn.json",
+						"statusCode": http.StatusOK,
+					},
+				}
+				err = test_helpers.SpecifyServerResps(respParas, server)
+				Expect(err).NotTo(HaveOccurred())
+
+				err := cli.WaitInstanceUntilReady(vgID, time.Now().Add(time.Minute))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
 		Context("when VirtualGuestService getObject call return error", func() {
 			It("return an softlayer-go unhandled error", func() {
 				respParas = []map[string]interface{}{
@@ -865,10 +1042,22 @@ var _ = Describe("InstanceHandler", func() {
 		Context("when VirtualGuestService createObject call successfully", func() {
 			It("create instance successfully", func() {
 				respParas = []map[string]interface{}{
+					{
+						"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+						"statusCode": http.StatusOK,
+					},
 					{
 						"filename":   "SoftLayer_Virtual_Guest_createObject.json",
 						"statusCode": http.StatusOK,
 					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_HasActiveTxn.json",
+						"statusCode": http.StatusOK,
+					},
 					{
 						"filename":   "SoftLayer_Virtual_Guest_setUserMetadata.json",
 						"statusCode": http.StatusOK,
@@ -885,15 +1074,140 @@ var _ = Describe("InstanceHandler", func() {
 				err = test_helpers.SpecifyServerResps(respParas, server)
 				Expect(err).NotTo(HaveOccurred())
 
-				vgs, err := cli.CreateInstance(vgTemplate, userData)
+				vgs, err := cli.CreateInstance(vgTemplate, slClient.InstanceOrderOptions{}, userData)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(*vgs.FullyQualifiedDomainName).To(Equal(*(*vgTemplate).FullyQualifiedDomainName))
 			})
 		})
 
+		Context("when the user data has agent settings", func() {
+			It("orders the instance with the settings in its user data", func() {
+				respParas = []map[string]interface{}{
+					{
+						"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_createObject_InternalError.json",
+						"statusCode": http.StatusInternalServerError,
+					},
+				}
+				err = test_helpers.SpecifyServerResps(respParas, server)
+				Expect(err).NotTo(HaveOccurred())
+
+				userData = &registry.SoftlayerUserData{Settings: &registry.AgentSettings{AgentID: "fake-agent-id"}}
+				_, err := cli.CreateInstance(vgTemplate, slClient.InstanceOrderOptions{}, userData)
+				Expect(err).To(HaveOccurred())
+
+				Expect(vgTemplate.UserData).To(HaveLen(1))
+				contents, err := base64.RawURLEncoding.DecodeString(*vgTemplate.UserData[0].Value)
+				Expect(err).NotTo(HaveOccurred())
+				var orderedUserData registry.SoftlayerUserData
+				Expect(json.Unmarshal(contents, &orderedUserData)).To(Succeed())
+				Expect(orderedUserData.Settings).NotTo(BeNil())
+				Expect(orderedUserData.Settings.AgentID).To(Equal("fake-agent-id"))
+			})
+		})
+
+		Context("when the request has a director request ID", func() {
+			It("sets the request ID in the notes of the instance", func() {
+				logger.SetRequestID("fake-request-id")
+				defer logger.SetRequestID("")
+
+				respParas = []map[string]interface{}{
+					{
+						"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_createObject.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_editObject.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_HasActiveTxn.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_setUserMetadata.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_HasNoneActiveTxn.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_HasNoneActiveTxn.json",
+						"statusCode": http.StatusOK,
+					},
+				}
+				err = test_helpers.SpecifyServerResps(respParas, server)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err := cli.CreateInstance(vgTemplate, slClient.InstanceOrderOptions{}, userData)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(8))
+				Expect(server.ReceivedRequests()[3].Method).To(Equal(http.MethodPut))
+				Expect(server.ReceivedRequests()[3].URL.Path).To(HaveSuffix("SoftLayer_Virtual_Guest/25804753.json"))
+			})
+		})
+
+		Context("when waiting for the ordered instance fails", func() {
+			It("reports the ordered instance before waiting for it", func() {
+				respParas = []map[string]interface{}{
+					{
+						"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_createObject.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_InternalError.json",
+						"statusCode": http.StatusInternalServerError,
+					},
+				}
+				err = test_helpers.SpecifyServerResps(respParas, server)
+				Expect(err).NotTo(HaveOccurred())
+
+				orderedID := 0
+				options := slClient.InstanceOrderOptions{Ordered: func(id int) error {
+					orderedID = id
+					return nil
+				}}
+				_, err := cli.CreateInstance(vgTemplate, options, userData)
+				Expect(err).To(HaveOccurred())
+				Expect(orderedID).To(Equal(25804753))
+			})
+		})
+
 		Context("when VirtualGuestService createObject call return error", func() {
 			It("return an softlayer-go unhandled error", func() {
 				respParas = []map[string]interface{}{
+					{
+						"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+						"statusCode": http.StatusOK,
+					},
 					{
 						"filename":   "SoftLayer_Virtual_Guest_createObject_InternalError.json",
 						"statusCode": http.StatusInternalServerError,
@@ -902,19 +1216,99 @@ var _ = Describe("InstanceHandler", func() {
 				err = test_helpers.SpecifyServerResps(respParas, server)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err := cli.CreateInstance(vgTemplate, userData)
+				_, err := cli.CreateInstance(vgTemplate, slClient.InstanceOrderOptions{}, userData)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("Creating instance"))
 			})
 		})
 
+		Context("when ProductOrderService verifyOrder call return error", func() {
+			It("returns the error without ordering the instance", func() {
+				respParas = []map[string]interface{}{
+					{
+						"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Product_Order_verifyOrder_InvalidDatacenter.json",
+						"statusCode": http.StatusInternalServerError,
+					},
+				}
+				err = test_helpers.SpecifyServerResps(respParas, server)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err := cli.CreateInstance(vgTemplate, slClient.InstanceOrderOptions{}, userData)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Verifying instance order"))
+				Expect(err.Error()).To(ContainSubstring("The requested datacenter is not available"))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		Context("when the API rate limit is exceeded while waiting for provisioning", func() {
+			It("backs off and polls again", func() {
+				respParas = []map[string]interface{}{
+					{
+						"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_createObject.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_RateLimitExceeded.json",
+						"statusCode": http.StatusTooManyRequests,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_HasActiveTxn.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_setUserMetadata.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_HasNoneActiveTxn.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_HasNoneActiveTxn.json",
+						"statusCode": http.StatusOK,
+					},
+				}
+				err = test_helpers.SpecifyServerResps(respParas, server)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err := cli.CreateInstance(vgTemplate, slClient.InstanceOrderOptions{}, userData)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(8))
+			})
+		})
+
 		Context("when Client SetUserDataWithID call return error", func() {
 			It("return an softlayer-go unhandled error", func() {
 				respParas = []map[string]interface{}{
+					{
+						"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+						"statusCode": http.StatusOK,
+					},
 					{
 						"filename":   "SoftLayer_Virtual_Guest_createObject.json",
 						"statusCode": http.StatusOK,
 					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_HasActiveTxn.json",
+						"statusCode": http.StatusOK,
+					},
 					{
 						"filename":   "SoftLayer_Virtual_Guest_setUserMetadata_InternalError.json",
 						"statusCode": http.StatusInternalServerError,
@@ -923,7 +1317,7 @@ var _ = Describe("InstanceHandler", func() {
 				err = test_helpers.SpecifyServerResps(respParas, server)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err := cli.CreateInstance(vgTemplate, userData)
+				_, err := cli.CreateInstance(vgTemplate, slClient.InstanceOrderOptions{}, userData)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("Updating user data contents with instance"))
 			})
@@ -932,10 +1326,22 @@ var _ = Describe("InstanceHandler", func() {
 		Context("when VirtualGuestService WaitInstanceUntilReady call return error", func() {
 			It("return an softlayer-go unhandled error", func() {
 				respParas = []map[string]interface{}{
+					{
+						"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+						"statusCode": http.StatusOK,
+					},
+					{
+						"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+						"statusCode": http.StatusOK,
+					},
 					{
 						"filename":   "SoftLayer_Virtual_Guest_createObject.json",
 						"statusCode": http.StatusOK,
 					},
+					{
+						"filename":   "SoftLayer_Virtual_Guest_getObject_HasActiveTxn.json",
+						"statusCode": http.StatusOK,
+					},
 					{
 						"filename":   "SoftLayer_Virtual_Guest_setUserMetadata.json",
 						"statusCode": http.StatusOK,
@@ -948,7 +1354,7 @@ var _ = Describe("InstanceHandler", func() {
 				err = test_helpers.SpecifyServerResps(respParas, server)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err := cli.CreateInstance(vgTemplate, userData)
+				_, err := cli.CreateInstance(vgTemplate, slClient.InstanceOrderOptions{}, userData)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("Waiting until instance is ready"))
 			})
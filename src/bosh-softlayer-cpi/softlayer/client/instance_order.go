@@ -0,0 +1,135 @@
+package client
+
+import (
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	instancePollInterval      = 5 * time.Second
+	instanceReadyPollInterval = 10 * time.Second
+	instanceMaxPollInterval   = 80 * time.Second
+)
+
+// InstanceOrderOptions are the Virtual_Guest template properties the vendored softlayer-go predates.
+type InstanceOrderOptions struct {
+	PlacementGroupId   int
+	TransientGuestFlag bool
+	ReservedCapacityId int
+
+	// Ordered is called with the ID of the instance as soon as it is ordered, optional. An
+	// error fails the creation, the instance is left to the caller.
+	Ordered func(id int) error
+}
+
+// hasOrderProperties returns true if options set properties of the order template.
+func (o InstanceOrderOptions) hasOrderProperties() bool {
+	return o.PlacementGroupId != 0 || o.TransientGuestFlag || o.ReservedCapacityId != 0
+}
+
+// virtualGuestOrder is a Virtual_Guest template with the properties of InstanceOrderOptions.
+type virtualGuestOrder struct {
+	*datatypes.Virtual_Guest
+	PlacementGroupId   *int  `json:"placementGroupId,omitempty"`
+	TransientGuestFlag *bool `json:"transientGuestFlag,omitempty"`
+	ReservedCapacityId *int  `json:"reservedCapacityId,omitempty"`
+}
+
+func (c *ClientManager) createInstanceObject(template *datatypes.Virtual_Guest, options InstanceOrderOptions) (datatypes.Virtual_Guest, error) {
+	if !options.hasOrderProperties() {
+		return c.VirtualGuestService.CreateObject(template)
+	}
+
+	var virtualGuest datatypes.Virtual_Guest
+	err := c.VirtualGuestService.Session.DoRequest("SoftLayer_Virtual_Guest", "createObject", []interface{}{instanceOrderTemplate(template, options)}, &c.VirtualGuestService.Options, &virtualGuest)
+	return virtualGuest, err
+}
+
+// verifyInstanceOrder dry-runs the order of the instance, SoftLayer rejects a flavor, datacenter or
+// VLAN that can not be ordered before anything is provisioned.
+func (c *ClientManager) verifyInstanceOrder(template *datatypes.Virtual_Guest, options InstanceOrderOptions) error {
+	// The order container is passed on as is, it is a Container_Product_Order_Virtual_Guest
+	var order map[string]interface{}
+	err := c.VirtualGuestService.Session.DoRequest("SoftLayer_Virtual_Guest", "generateOrderTemplate", []interface{}{instanceOrderTemplate(template, options)}, &c.VirtualGuestService.Options, &order)
+	if err != nil {
+		return bosherr.WrapError(err, "Generating order template")
+	}
+
+	var verified datatypes.Container_Product_Order
+	return c.OrderService.Session.DoRequest("SoftLayer_Product_Order", "verifyOrder", []interface{}{order}, &c.OrderService.Options, &verified)
+}
+
+func instanceOrderTemplate(template *datatypes.Virtual_Guest, options InstanceOrderOptions) interface{} {
+	if !options.hasOrderProperties() {
+		return template
+	}
+
+	order := virtualGuestOrder{Virtual_Guest: template}
+	if options.PlacementGroupId != 0 {
+		order.PlacementGroupId = sl.Int(options.PlacementGroupId)
+	}
+	if options.TransientGuestFlag {
+		order.TransientGuestFlag = sl.Bool(true)
+	}
+	if options.ReservedCapacityId != 0 {
+		order.ReservedCapacityId = sl.Int(options.ReservedCapacityId)
+	}
+	return order
+}
+
+// waitInstanceProvisioning polls the ordered instance until SoftLayer runs a provisioning transaction
+// on it or has provisioned it. Polling backs off while the API rate limit is exceeded.
+func (c *ClientManager) waitInstanceProvisioning(id int, until time.Time) error {
+	interval := instancePollInterval
+	for {
+		virtualGuest, found, err := c.GetInstance(id, "id, activeTransaction[id,transactionStatus.name], provisionDate")
+		switch {
+		case isRateLimitExceeded(err):
+			c.logger.Debug(softlayerClientLogTag, "Rate limit exceeded polling instance '%d', retrying in %s", id, interval)
+		case err != nil:
+			return err
+		case found && (virtualGuest.ActiveTransaction != nil || virtualGuest.ProvisionDate != nil):
+			return nil
+		}
+
+		if time.Now().Add(interval).After(until) {
+			return bosherr.Errorf("Waiting instance with id of '%d' is provisioning time out", id)
+		}
+		time.Sleep(interval)
+
+		if isRateLimitExceeded(err) {
+			interval = backOff(interval)
+		} else {
+			interval = instancePollInterval
+		}
+	}
+}
+
+func backOff(interval time.Duration) time.Duration {
+	if interval*2 > instanceMaxPollInterval {
+		return instanceMaxPollInterval
+	}
+	return interval * 2
+}
+
+// IsInstanceReclaimed returns true if the instance with id is a transient guest whose host was reclaimed,
+// it is cancelled and deprovisioned by SoftLayer.
+func (c *ClientManager) IsInstanceReclaimed(id int) (bool, error) {
+	var instance struct {
+		TransientGuestFlag *bool                                 `json:"transientGuestFlag,omitempty"`
+		BillingItem        *datatypes.Billing_Item_Virtual_Guest `json:"billingItem,omitempty"`
+	}
+	options := sl.Options{Id: sl.Int(id), Mask: "mask[id, transientGuestFlag, billingItem.id]"}
+	err := c.VirtualGuestService.Session.DoRequest("SoftLayer_Virtual_Guest", "getObject", nil, &options, &instance)
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.Exception == SOFTLAYER_OBJECTNOTFOUND_EXCEPTION {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return sl.Get(instance.TransientGuestFlag, false).(bool) && instance.BillingItem == nil, nil
+}
@@ -0,0 +1,90 @@
+package client_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"net/http"
+	"strconv"
+	"time"
+
+	boshlogger "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/ncw/swift"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/softlayer/softlayer-go/session"
+
+	cpiLog "bosh-softlayer-cpi/logger"
+	slClient "bosh-softlayer-cpi/softlayer/client"
+	vpsVm "bosh-softlayer-cpi/softlayer/vps_service/client/vm"
+	"bosh-softlayer-cpi/test_helpers"
+)
+
+var _ = Describe("InstanceOrder", func() {
+	var (
+		err error
+
+		logger cpiLog.Logger
+
+		server      *ghttp.Server
+		vps         *vpsVm.Client
+		swiftClient *swift.Connection
+
+		transportHandler *test_helpers.FakeTransportHandler
+		sess             *session.Session
+		cli              *slClient.ClientManager
+
+		respParas []map[string]interface{}
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		transportHandler = &test_helpers.FakeTransportHandler{
+			FakeServer:           server,
+			SoftlayerAPIEndpoint: server.URL(),
+			MaxRetries:           3,
+		}
+
+		vps = &vpsVm.Client{}
+		swiftClient = &swift.Connection{}
+
+		nanos := time.Now().Nanosecond()
+		logger = cpiLog.NewLogger(boshlogger.LevelDebug, strconv.Itoa(nanos))
+		sess = test_helpers.NewFakeSoftlayerSession(transportHandler)
+		cli = slClient.NewSoftLayerClientManager(sess, vps, swiftClient, logger)
+	})
+
+	AfterEach(func() {
+		test_helpers.DestroyServer(server)
+	})
+
+	Describe("IsInstanceReclaimed", func() {
+		It("returns true for a cancelled transient instance", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Virtual_Guest_getObject_Reclaimed.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			reclaimed, err := cli.IsInstanceReclaimed(12345678)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reclaimed).To(BeTrue())
+		})
+
+		It("returns false for a billed transient instance", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Virtual_Guest_getObject_Transient.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			reclaimed, err := cli.IsInstanceReclaimed(12345678)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reclaimed).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,178 @@
+package client_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"net/http"
+	"strconv"
+	"time"
+
+	boshlogger "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/ncw/swift"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/softlayer/softlayer-go/session"
+
+	cpiLog "bosh-softlayer-cpi/logger"
+	slClient "bosh-softlayer-cpi/softlayer/client"
+	vpsVm "bosh-softlayer-cpi/softlayer/vps_service/client/vm"
+	"bosh-softlayer-cpi/test_helpers"
+)
+
+var _ = Describe("LoadBalancerHandler", func() {
+	var (
+		err error
+
+		logger cpiLog.Logger
+
+		server      *ghttp.Server
+		vps         *vpsVm.Client
+		swiftClient *swift.Connection
+
+		transportHandler *test_helpers.FakeTransportHandler
+		sess             *session.Session
+		cli              *slClient.ClientManager
+
+		uuid       string
+		memberUuid string
+
+		respParas []map[string]interface{}
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		transportHandler = &test_helpers.FakeTransportHandler{
+			FakeServer:           server,
+			SoftlayerAPIEndpoint: server.URL(),
+			MaxRetries:           3,
+		}
+
+		vps = &vpsVm.Client{}
+		swiftClient = &swift.Connection{}
+
+		nanos := time.Now().Nanosecond()
+		logger = cpiLog.NewLogger(boshlogger.LevelDebug, strconv.Itoa(nanos))
+		sess = test_helpers.NewFakeSoftlayerSession(transportHandler)
+		cli = slClient.NewSoftLayerClientManager(sess, vps, swiftClient, logger)
+
+		uuid = "0a2da082-4474-4e16-9f02-4e88a4e5a9fe"
+		memberUuid = "5b1d3e7e-8c9a-4d2b-9a0e-2c3f4b5a6d7e"
+	})
+
+	AfterEach(func() {
+		test_helpers.DestroyServer(server)
+	})
+
+	Describe("GetLoadBalancer", func() {
+		It("get load balancer successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_LBaaS_LoadBalancer_getLoadBalancer.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			loadBalancer, found, err := cli.GetLoadBalancer(uuid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(*loadBalancer.Uuid).To(Equal(uuid))
+			Expect(*loadBalancer.Listeners[0].DefaultPool.ProtocolPort).To(Equal(80))
+			Expect(*loadBalancer.Members[0].Address).To(Equal("10.112.10.20"))
+		})
+
+		It("return not found when the load balancer does not exist", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Subnet_getObject_NotFound.json",
+					"statusCode": http.StatusInternalServerError,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := cli.GetLoadBalancer(uuid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("AddLoadBalancerMember", func() {
+		It("add load balancer member successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_LBaaS_LoadBalancer_getLoadBalancer.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.AddLoadBalancerMember(uuid, "10.112.10.20", 50)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("UpdateLoadBalancerMemberWeight", func() {
+		It("update load balancer member weight successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_LBaaS_LoadBalancer_getLoadBalancer.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.UpdateLoadBalancerMemberWeight(uuid, memberUuid, 0)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("DeleteLoadBalancerMember", func() {
+		It("delete load balancer member successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_LBaaS_LoadBalancer_getLoadBalancer.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.DeleteLoadBalancerMember(uuid, memberUuid)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("WaitLoadBalancerActive", func() {
+		It("returns once the load balancer is active", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_LBaaS_LoadBalancer_getLoadBalancer.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.WaitLoadBalancerActive(uuid, time.Now().Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("return an error when waiting times out", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_LBaaS_LoadBalancer_getLoadBalancer_UpdatePending.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err := cli.WaitLoadBalancerActive(uuid, time.Now())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("UPDATE_PENDING"))
+		})
+	})
+})
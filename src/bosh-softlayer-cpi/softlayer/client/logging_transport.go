@@ -0,0 +1,104 @@
+package client
+
+import (
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/logger"
+	"bosh-softlayer-cpi/metrics"
+)
+
+// loggingTransportHandler logs the service, method and duration of every SoftLayer API call
+// and records them in the metrics collector. It retries the calls itself, the way softlayer-go
+// does for sess.Retries, so that every HTTP attempt and the waits before retries are recorded.
+type loggingTransportHandler struct {
+	handler session.TransportHandler
+	logger  logger.Logger
+	metrics *metrics.Collector
+}
+
+func (h loggingTransportHandler) DoRequest(sess *session.Session, service string, method string, args []interface{}, options *sl.Options, pResult interface{}) error {
+	// Like softlayer-go, fewer than 2 retries make a single attempt
+	attempts := sess.Retries
+	if attempts < 2 {
+		attempts = 1
+	}
+	wait := sess.RetryWait
+	if wait == 0 {
+		wait = session.DefaultRetryWait
+	}
+
+	attemptSession := *sess
+	attemptSession.Retries = 0
+
+	var err error
+	var waited time.Duration
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		err = h.handler.DoRequest(&attemptSession, service, method, args, options, pResult)
+		duration := time.Since(start)
+
+		h.logger.SoftLayerCall(service, method, duration, err)
+		if h.metrics != nil {
+			if attempt == 1 {
+				h.metrics.Record(service, method, duration, err)
+			} else {
+				h.metrics.RecordRetry(service, method, waited, duration, err)
+			}
+		}
+
+		if err == nil || !isRetryable(err) || attempt == attempts {
+			return err
+		}
+
+		// Same growing, jittered wait as softlayer-go
+		wait = wait + time.Duration(rand.Int63n(int64(wait)))/2 // #nosec G404
+		h.logger.Debug(softlayerClientLogTag, "Retrying %s::%s in %s after: %s", service, method, wait, err)
+		time.Sleep(wait)
+		waited = wait
+	}
+}
+
+func isRateLimitExceeded(err error) bool {
+	apiErr, ok := err.(sl.Error)
+	return ok && apiErr.Exception == SOFTLAYER_RATELIMITEXCEEDED_EXCEPTION
+}
+
+// isRetryable tells whether softlayer-go would retry a call failing with err: on timeouts and rate limits.
+func isRetryable(err error) bool {
+	if isRateLimitExceeded(err) {
+		return true
+	}
+
+	if slErr, ok := err.(sl.Error); ok {
+		switch slErr.StatusCode {
+		case 408, 504, 599:
+			return true
+		}
+	}
+
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
+
+// withCallLogging returns a copy of sess whose API calls are logged to logger and recorded in collector.
+func withCallLogging(sess *session.Session, logger logger.Logger, collector *metrics.Collector) *session.Session {
+	handler := sess.TransportHandler
+	if handler == nil {
+		// Same choice of transport as softlayer-go makes for a session without one
+		if strings.Contains(sess.Endpoint, "/xmlrpc/") {
+			handler = &session.XmlRpcTransport{}
+		} else {
+			handler = &session.RestTransport{}
+		}
+	}
+
+	loggedSession := *sess
+	loggedSession.TransportHandler = loggingTransportHandler{handler: handler, logger: logger, metrics: collector}
+	return &loggedSession
+}
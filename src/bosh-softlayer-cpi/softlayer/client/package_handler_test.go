@@ -16,6 +16,7 @@ import (
 	"github.com/softlayer/softlayer-go/sl"
 
 	cpiLog "bosh-softlayer-cpi/logger"
+	"bosh-softlayer-cpi/metrics"
 	slClient "bosh-softlayer-cpi/softlayer/client"
 	vpsVm "bosh-softlayer-cpi/softlayer/vps_service/client/vm"
 	"bosh-softlayer-cpi/test_helpers"
@@ -117,6 +118,106 @@ var _ = Describe("packageHandler", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("More than one packages were found for"))
 		})
+
+		It("Reuses the package when the cache is enabled", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Product_Package_getAllObjects_performance.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			cli.EnableCache(time.Hour)
+			firstPackage, err := cli.GetPackage("performance_storage_iscsi")
+			Expect(err).NotTo(HaveOccurred())
+
+			secondPackage, err := cli.WithLogger(logger).GetPackage("performance_storage_iscsi")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secondPackage).To(Equal(firstPackage))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("Records the SoftLayer API calls in the metrics collector", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Product_Package_getAllObjects_performance.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			collector := metrics.NewCollector()
+			_, err := cli.WithMetrics(collector).GetPackage("performance_storage_iscsi")
+			Expect(err).NotTo(HaveOccurred())
+
+			stats := collector.Stats()
+			Expect(stats).To(HaveLen(1))
+			Expect(stats[0].Service).To(Equal("SoftLayer_Product_Package"))
+			Expect(stats[0].Method).To(Equal("getAllObjects"))
+			Expect(stats[0].Count).To(Equal(1))
+			Expect(stats[0].Errors).To(Equal(0))
+		})
+
+		It("Retries rate limited SoftLayer API calls and records every attempt", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Product_Package_getAllObjects_RateLimitExceeded.json",
+					"statusCode": http.StatusTooManyRequests,
+				},
+				{
+					"filename":   "SoftLayer_Product_Package_getAllObjects_performance.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			collector := metrics.NewCollector()
+			retryingSess := sess.SetRetries(3).SetRetryWait(10 * time.Millisecond)
+			retryingCli := slClient.NewSoftLayerClientManager(retryingSess, vps, swiftClient, logger).WithMetrics(collector)
+			_, err := retryingCli.GetPackage("performance_storage_iscsi")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+
+			stats := collector.Stats()
+			Expect(stats).To(HaveLen(1))
+			Expect(stats[0].Count).To(Equal(2))
+			Expect(stats[0].Errors).To(Equal(1))
+			Expect(stats[0].Retries).To(Equal(1))
+			Expect(stats[0].Exceptions).To(Equal(map[string]int{"SoftLayer_Exception_WebService_RateLimitExceeded": 1}))
+			Expect(stats[0].RetryWait).To(BeNumerically(">=", 10*time.Millisecond))
+		})
+
+		It("Returns the error of the last attempt once the retries are exhausted", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Product_Package_getAllObjects_RateLimitExceeded.json",
+					"statusCode": http.StatusTooManyRequests,
+				},
+				{
+					"filename":   "SoftLayer_Product_Package_getAllObjects_RateLimitExceeded.json",
+					"statusCode": http.StatusTooManyRequests,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			collector := metrics.NewCollector()
+			retryingSess := sess.SetRetries(2).SetRetryWait(10 * time.Millisecond)
+			retryingCli := slClient.NewSoftLayerClientManager(retryingSess, vps, swiftClient, logger).WithMetrics(collector)
+			_, err := retryingCli.GetPackage("performance_storage_iscsi")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("SoftLayer_Exception_WebService_RateLimitExceeded"))
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+
+			stats := collector.Stats()
+			Expect(stats[0].Count).To(Equal(2))
+			Expect(stats[0].Errors).To(Equal(2))
+			Expect(stats[0].Retries).To(Equal(1))
+		})
 	})
 
 	Describe("GetPerformanceIscsiPackage", func() {
@@ -592,6 +693,20 @@ var _ = Describe("packageHandler", func() {
 			})
 		})
 
+		Describe("FindSaaSMaximumPerformIops", func() {
+			It("Find successfully", func() {
+				iops, err := slClient.FindSaaSMaximumPerformIops(*productPackage, 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(iops).To(Equal(2000))
+			})
+
+			It("Return error when volume size out of scope", func() {
+				_, err := slClient.FindSaaSMaximumPerformIops(*productPackage, 100)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Unable to find iops for storage space size: 100"))
+			})
+		})
+
 		Describe("FindSaaSPerformIopsPrice", func() {
 			It("Find successfully", func() {
 				_, err := slClient.FindSaaSPerformIopsPrice(*productPackage, 10, 1000)
@@ -885,6 +1000,155 @@ var _ = Describe("packageHandler", func() {
 			})
 		})
 
+		Describe("Endurance price handlers", func() {
+			BeforeEach(func() {
+				productPackage = &datatypes.Product_Package{
+					Id:       sl.Int(759),
+					IsActive: sl.Int(1),
+					Name:     sl.String("Storage As A Service (StaaS)"),
+					Items: []datatypes.Product_Item{
+						{
+							KeyName:  sl.String("WRITEHEAVY_TIER"),
+							Capacity: sl.Float(300),
+							Prices: []datatypes.Product_Item_Price{
+								{
+									Id: sl.Int(45098),
+									Categories: []datatypes.Product_Item_Category{
+										{
+											CategoryCode: sl.String("storage_tier_level"),
+										},
+									},
+								},
+							},
+						},
+						{
+							KeyName:         sl.String("STORAGE_SPACE_FOR_0_25_IOPS_PER_GB"),
+							CapacityMinimum: sl.String("20"),
+							CapacityMaximum: sl.String("12000"),
+							Prices: []datatypes.Product_Item_Price{
+								{
+									Id: sl.Int(45099),
+									Categories: []datatypes.Product_Item_Category{
+										{
+											CategoryCode: sl.String("performance_storage_space"),
+										},
+									},
+								},
+							},
+						},
+						{
+							Capacity: sl.Float(20),
+							Prices: []datatypes.Product_Item_Price{
+								{
+									Id: sl.Int(45100),
+									Categories: []datatypes.Product_Item_Category{
+										{
+											CategoryCode: sl.String("storage_snapshot_space"),
+										},
+									},
+									CapacityRestrictionMinimum: sl.String("200"),
+									CapacityRestrictionMaximum: sl.String("300"),
+									CapacityRestrictionType:    sl.String("STORAGE_TIER_LEVEL"),
+								},
+							},
+						},
+						{
+							KeyName: sl.String("REPLICATION_FOR_TIERBASED_PERFORMANCE"),
+							Prices: []datatypes.Product_Item_Price{
+								{
+									Id: sl.Int(45101),
+									Categories: []datatypes.Product_Item_Category{
+										{
+											CategoryCode: sl.String("performance_storage_replication"),
+										},
+									},
+									CapacityRestrictionMinimum: sl.String("300"),
+									CapacityRestrictionMaximum: sl.String("300"),
+									CapacityRestrictionType:    sl.String("STORAGE_TIER_LEVEL"),
+								},
+							},
+						},
+						{
+							KeyName: sl.String("REPLICATION_FOR_IOPSBASED_PERFORMANCE"),
+							Prices: []datatypes.Product_Item_Price{
+								{
+									Id: sl.Int(45102),
+									Categories: []datatypes.Product_Item_Category{
+										{
+											CategoryCode: sl.String("performance_storage_replication"),
+										},
+									},
+									CapacityRestrictionMinimum: sl.String("100"),
+									CapacityRestrictionMaximum: sl.String("6000"),
+									CapacityRestrictionType:    sl.String("IOPS"),
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("Find tier price successfully", func() {
+				price, err := slClient.FindSaaSEnduranceTierPrice(*productPackage, 4)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*price.Id).To(Equal(45098))
+			})
+
+			It("Return error when tier is invalid", func() {
+				_, err := slClient.FindSaaSEnduranceTierPrice(*productPackage, 3)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Invalid endurance tier"))
+			})
+
+			It("Return error when tier price is not found", func() {
+				_, err := slClient.FindSaaSEnduranceTierPrice(*productPackage, 10)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Unable to find price for endurance tier"))
+			})
+
+			It("Find space price successfully", func() {
+				price, err := slClient.FindSaaSEnduranceSpacePrice(*productPackage, 250, 0.25)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*price.Id).To(Equal(45099))
+			})
+
+			It("Return error when space price is not found", func() {
+				_, err := slClient.FindSaaSEnduranceSpacePrice(*productPackage, 250, 2)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Unable to find price for storage space size"))
+			})
+
+			It("Find snapshot space price successfully", func() {
+				price, err := slClient.FindSaaSEnduranceSnapshotSpacePrice(*productPackage, 20, 4)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*price.Id).To(Equal(45100))
+			})
+
+			It("Return error when snapshot space price is not found", func() {
+				_, err := slClient.FindSaaSEnduranceSnapshotSpacePrice(*productPackage, 20, 10)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Unable to find price snapshot space size"))
+			})
+
+			It("Find replication price of endurance volume successfully", func() {
+				price, err := slClient.FindSaaSReplicationPrice(*productPackage, 0, 4)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*price.Id).To(Equal(45101))
+			})
+
+			It("Find replication price of performance volume successfully", func() {
+				price, err := slClient.FindSaaSReplicationPrice(*productPackage, 1500, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*price.Id).To(Equal(45102))
+			})
+
+			It("Return error when replication price is not found", func() {
+				_, err := slClient.FindSaaSReplicationPrice(*productPackage, 0, 2)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Unable to find price for replication"))
+			})
+		})
+
 		Describe("FindPerformancePrice", func() {
 			It("Find successfully", func() {
 				_, err := slClient.FindPerformancePrice(*productPackage, "performance_storage_iscsi")
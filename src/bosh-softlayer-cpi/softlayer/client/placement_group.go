@@ -0,0 +1,106 @@
+package client
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+// The vendored softlayer-go predates placement groups, their services are called directly.
+const (
+	PLACEMENT_GROUP_DEFAULT_MASK = "mask[id, name, backendRouterId, guests[id, billingItem.cancellationDate, activeTransaction.transactionStatus.name]]"
+
+	placementGroupService       = "SoftLayer_Virtual_PlacementGroup"
+	placementGroupRuleService   = "SoftLayer_Virtual_PlacementGroup_Rule"
+	placementGroupSpreadRuleKey = "SPREAD"
+)
+
+// PlacementGroup is a SoftLayer_Virtual_PlacementGroup, its guests are spread over hypervisors
+// behind one backend router.
+type PlacementGroup struct {
+	Id              *int                      `json:"id,omitempty"`
+	Name            *string                   `json:"name,omitempty"`
+	BackendRouterId *int                      `json:"backendRouterId,omitempty"`
+	RuleId          *int                      `json:"ruleId,omitempty"`
+	Guests          []datatypes.Virtual_Guest `json:"guests,omitempty"`
+}
+
+type placementGroupRule struct {
+	Id      *int    `json:"id,omitempty"`
+	KeyName *string `json:"keyName,omitempty"`
+}
+
+// GetPlacementGroups returns the placement groups of the account with their guests.
+func (c *ClientManager) GetPlacementGroups() ([]PlacementGroup, error) {
+	var placementGroups []PlacementGroup
+	options := sl.Options{Mask: PLACEMENT_GROUP_DEFAULT_MASK}
+	err := c.AccountService.Session.DoRequest("SoftLayer_Account", "getPlacementGroups", nil, &options, &placementGroups)
+	return placementGroups, err
+}
+
+// GetPlacementGroup returns the placement group with id and its guests.
+func (c *ClientManager) GetPlacementGroup(id int) (*PlacementGroup, bool, error) {
+	var placementGroup PlacementGroup
+	options := sl.Options{Id: sl.Int(id), Mask: PLACEMENT_GROUP_DEFAULT_MASK}
+	err := c.AccountService.Session.DoRequest(placementGroupService, "getObject", nil, &options, &placementGroup)
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.Exception == SOFTLAYER_OBJECTNOTFOUND_EXCEPTION {
+			return &PlacementGroup{}, false, nil
+		}
+		return &PlacementGroup{}, false, err
+	}
+
+	return &placementGroup, true, nil
+}
+
+// CreatePlacementGroup creates a placement group spreading its guests behind the backend router.
+func (c *ClientManager) CreatePlacementGroup(name string, backendRouterId int) (*PlacementGroup, error) {
+	var rules []placementGroupRule
+	err := c.AccountService.Session.DoRequest(placementGroupRuleService, "getAllObjects", nil, &sl.Options{}, &rules)
+	if err != nil {
+		return &PlacementGroup{}, bosherr.WrapError(err, "Getting placement group rules")
+	}
+
+	var ruleId *int
+	for _, rule := range rules {
+		if rule.KeyName != nil && *rule.KeyName == placementGroupSpreadRuleKey {
+			ruleId = rule.Id
+		}
+	}
+	if ruleId == nil {
+		return &PlacementGroup{}, bosherr.Errorf("Placement group rule '%s' not found", placementGroupSpreadRuleKey)
+	}
+
+	var placementGroup PlacementGroup
+	template := PlacementGroup{
+		Name:            sl.String(name),
+		BackendRouterId: sl.Int(backendRouterId),
+		RuleId:          ruleId,
+	}
+	err = c.AccountService.Session.DoRequest(placementGroupService, "createObject", []interface{}{template}, &sl.Options{}, &placementGroup)
+	if err != nil {
+		return &PlacementGroup{}, bosherr.WrapErrorf(err, "Creating placement group '%s'", name)
+	}
+
+	return &placementGroup, nil
+}
+
+// DeletePlacementGroup deletes the placement group with id, which must have no guests.
+func (c *ClientManager) DeletePlacementGroup(id int) error {
+	var deleted bool
+	return c.AccountService.Session.DoRequest(placementGroupService, "deleteObject", nil, &sl.Options{Id: sl.Int(id)}, &deleted)
+}
+
+// GetInstancePlacementGroupId returns the ID of the placement group of the instance with id, 0 if it has none.
+func (c *ClientManager) GetInstancePlacementGroupId(id int) (int, error) {
+	var instance struct {
+		PlacementGroupId *int `json:"placementGroupId,omitempty"`
+	}
+	options := sl.Options{Id: sl.Int(id), Mask: "mask[id, placementGroupId]"}
+	err := c.VirtualGuestService.Session.DoRequest("SoftLayer_Virtual_Guest", "getObject", nil, &options, &instance)
+	if err != nil {
+		return 0, err
+	}
+
+	return sl.Get(instance.PlacementGroupId, 0).(int), nil
+}
@@ -0,0 +1,166 @@
+package client_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"net/http"
+	"strconv"
+	"time"
+
+	boshlogger "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/ncw/swift"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/softlayer/softlayer-go/session"
+
+	cpiLog "bosh-softlayer-cpi/logger"
+	slClient "bosh-softlayer-cpi/softlayer/client"
+	vpsVm "bosh-softlayer-cpi/softlayer/vps_service/client/vm"
+	"bosh-softlayer-cpi/test_helpers"
+)
+
+var _ = Describe("PlacementGroupHandler", func() {
+	var (
+		err error
+
+		logger cpiLog.Logger
+
+		server      *ghttp.Server
+		vps         *vpsVm.Client
+		swiftClient *swift.Connection
+
+		transportHandler *test_helpers.FakeTransportHandler
+		sess             *session.Session
+		cli              *slClient.ClientManager
+
+		respParas []map[string]interface{}
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		transportHandler = &test_helpers.FakeTransportHandler{
+			FakeServer:           server,
+			SoftlayerAPIEndpoint: server.URL(),
+			MaxRetries:           3,
+		}
+
+		vps = &vpsVm.Client{}
+		swiftClient = &swift.Connection{}
+
+		nanos := time.Now().Nanosecond()
+		logger = cpiLog.NewLogger(boshlogger.LevelDebug, strconv.Itoa(nanos))
+		sess = test_helpers.NewFakeSoftlayerSession(transportHandler)
+		cli = slClient.NewSoftLayerClientManager(sess, vps, swiftClient, logger)
+	})
+
+	AfterEach(func() {
+		test_helpers.DestroyServer(server)
+	})
+
+	Describe("GetPlacementGroups", func() {
+		It("returns the placement groups of the account with their guests", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Account_getPlacementGroups.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			placementGroups, err := cli.GetPlacementGroups()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(placementGroups).To(HaveLen(2))
+			Expect(*placementGroups[1].Name).To(Equal("bosh-cf-router"))
+			Expect(*placementGroups[1].BackendRouterId).To(Equal(511))
+			Expect(*placementGroups[1].Guests[0].Id).To(Equal(12345678))
+		})
+	})
+
+	Describe("GetPlacementGroup", func() {
+		It("returns the placement group", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Virtual_PlacementGroup_getObject.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			placementGroup, found, err := cli.GetPlacementGroup(1235)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(*placementGroup.Id).To(Equal(1235))
+		})
+
+		It("returns not found when the placement group does not exist", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Virtual_PlacementGroup_getObject_NotFound.json",
+					"statusCode": http.StatusNotFound,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := cli.GetPlacementGroup(1235)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("CreatePlacementGroup", func() {
+		It("creates a placement group with the SPREAD rule", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Virtual_PlacementGroup_Rule_getAllObjects.json",
+					"statusCode": http.StatusOK,
+				},
+				{
+					"filename":   "SoftLayer_Virtual_PlacementGroup_createObject.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			placementGroup, err := cli.CreatePlacementGroup("bosh-cf-diego_cell", 511)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*placementGroup.Id).To(Equal(1236))
+			Expect(*placementGroup.RuleId).To(Equal(1))
+		})
+	})
+
+	Describe("DeletePlacementGroup", func() {
+		It("deletes the placement group successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Virtual_PlacementGroup_deleteObject.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = cli.DeletePlacementGroup(1235)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("GetInstancePlacementGroupId", func() {
+		It("returns the placement group of the instance", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Virtual_Guest_getObject_PlacementGroup.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			placementGroupID, err := cli.GetInstancePlacementGroupId(12345678)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(placementGroupID).To(Equal(1235))
+		})
+	})
+})
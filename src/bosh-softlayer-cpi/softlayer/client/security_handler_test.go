@@ -159,4 +159,70 @@ var _ = Describe("SecurityHandler", func() {
 			})
 		})
 	})
+
+	Describe("GetSecurityGroups", func() {
+		It("returns the security groups of the account", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Account_getSecurityGroups.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			groups, err := cli.GetSecurityGroups()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups).To(HaveLen(2))
+			Expect(*groups[0].Id).To(Equal(21345))
+			Expect(*groups[0].Name).To(Equal("allow_ssh"))
+		})
+
+		It("returns an error when listing the security groups fails", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Account_getSshKeys_InternalError.json",
+					"statusCode": http.StatusInternalServerError,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err := cli.GetSecurityGroups()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+
+	Describe("AttachSecurityGroupComponents", func() {
+		It("attaches the network components successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_SecurityGroup_attachNetworkComponents.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = cli.AttachSecurityGroupComponents(21345, []int{1234567})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("DetachSecurityGroupComponents", func() {
+		It("detaches the network components successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_SecurityGroup_detachNetworkComponents.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = cli.DetachSecurityGroupComponents(21345, []int{1234567})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
 })
@@ -196,6 +196,73 @@ var _ = Describe("SnapshotHandler", func() {
 		})
 	})
 
+	Describe("GetSnapshots", func() {
+		It("GetSnapshots successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Storage_getSnapshots_ManagedByBOSH.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			snapshots, err := cli.GetSnapshots(diskId)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(HaveLen(2))
+			Expect(*snapshots[0].Notes).To(HavePrefix("Snapshot_managed_by_BOSH"))
+		})
+
+		It("Return error when SoftLayerNetworkStorage getSnapshots return an error", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Storage_getSchedules_InternalError.json",
+					"statusCode": http.StatusInternalServerError,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = cli.GetSnapshots(diskId)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+
+	Describe("GetSnapshotSchedules", func() {
+		It("GetSnapshotSchedules successfully", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Storage_getSchedules.json",
+					"statusCode": http.StatusOK,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			schedules, err := cli.GetSnapshotSchedules(diskId)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedules).To(HaveLen(2))
+			Expect(*schedules[0].Type.Keyname).To(Equal("SNAPSHOT_DAILY"))
+			Expect(*schedules[0].RetentionCount).To(Equal("7"))
+		})
+
+		It("Return error when SoftLayerNetworkStorage getSchedules return an error", func() {
+			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Network_Storage_getSchedules_InternalError.json",
+					"statusCode": http.StatusInternalServerError,
+				},
+			}
+			err = test_helpers.SpecifyServerResps(respParas, server)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = cli.GetSnapshotSchedules(diskId)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+
 	Describe("RestoreFromSnapshot", func() {
 		It("DisableSnapshots successfully", func() {
 			respParas = []map[string]interface{}{
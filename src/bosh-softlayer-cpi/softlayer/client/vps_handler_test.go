@@ -142,10 +142,22 @@ var _ = Describe("InstanceHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+					"statusCode": http.StatusOK,
+				},
+				{
+					"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+					"statusCode": http.StatusOK,
+				},
 				{
 					"filename":   "SoftLayer_Virtual_Guest_createObject.json",
 					"statusCode": http.StatusOK,
 				},
+				{
+					"filename":   "SoftLayer_Virtual_Guest_getObject_HasActiveTxn.json",
+					"statusCode": http.StatusOK,
+				},
 				{
 					"filename":   "SoftLayer_Virtual_Guest_setUserMetadata.json",
 					"statusCode": http.StatusOK,
@@ -259,6 +271,14 @@ var _ = Describe("InstanceHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+					"statusCode": http.StatusOK,
+				},
+				{
+					"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+					"statusCode": http.StatusOK,
+				},
 				{
 					"filename":   "SoftLayer_Virtual_Guest_createObject_InternalError.json",
 					"statusCode": http.StatusInternalServerError,
@@ -286,10 +306,22 @@ var _ = Describe("InstanceHandler", func() {
 			err = test_helpers.SpecifyServerResps(respParas, server)
 			Expect(err).NotTo(HaveOccurred())
 			respParas = []map[string]interface{}{
+				{
+					"filename":   "SoftLayer_Virtual_Guest_generateOrderTemplate.json",
+					"statusCode": http.StatusOK,
+				},
+				{
+					"filename":   "SoftLayer_Product_Order_verifyOrder.json",
+					"statusCode": http.StatusOK,
+				},
 				{
 					"filename":   "SoftLayer_Virtual_Guest_createObject.json",
 					"statusCode": http.StatusOK,
 				},
+				{
+					"filename":   "SoftLayer_Virtual_Guest_getObject_HasActiveTxn.json",
+					"statusCode": http.StatusOK,
+				},
 				{
 					"filename":   "SoftLayer_Virtual_Guest_setUserMetadata.json",
 					"statusCode": http.StatusOK,
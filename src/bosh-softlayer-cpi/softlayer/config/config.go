@@ -2,6 +2,9 @@ package config
 
 import (
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+
+	"bosh-softlayer-cpi/logger"
 )
 
 type Config struct {
@@ -18,6 +21,14 @@ type Config struct {
 	SwiftUsername        string `json:"swift_username"`
 	SwiftEndpoint        string `json:"swift_endpoint"`
 	// SWIFT password is also SoftLayer API key
+
+	// Seconds delete_vm waits between draining a VM in its load balancers and removing it
+	LoadBalancerDrainSeconds int `json:"load_balancer_drain_seconds"`
+
+	// Format of the CPI logs, "text" (default) or "json"
+	LogFormat string `json:"log_format"`
+	// Level of the CPI logs, "debug" (default), "info", "warn", "error" or "none"
+	LogLevel string `json:"log_level"`
 }
 
 func (c Config) Validate() error {
@@ -29,5 +40,25 @@ func (c Config) Validate() error {
 		return bosherr.Error("Must provide non-empty ApiKey")
 	}
 
+	if c.LoadBalancerDrainSeconds < 0 {
+		return bosherr.Errorf("LoadBalancerDrainSeconds must not be negative, got %d", c.LoadBalancerDrainSeconds)
+	}
+
+	if c.LogFormat != "" && c.LogFormat != logger.TextFormat && c.LogFormat != logger.JSONFormat {
+		return bosherr.Errorf("Unsupported LogFormat '%s', it must be '%s' or '%s'", c.LogFormat, logger.TextFormat, logger.JSONFormat)
+	}
+
+	if _, err := c.LoggerLevel(); err != nil {
+		return bosherr.WrapError(err, "Validating LogLevel")
+	}
+
 	return nil
 }
+
+// LoggerLevel returns the log level, debug unless configured otherwise.
+func (c Config) LoggerLevel() (boshlog.LogLevel, error) {
+	if c.LogLevel == "" {
+		return boshlog.LevelDebug, nil
+	}
+	return boshlog.Levelify(c.LogLevel)
+}
@@ -4,10 +4,47 @@ import "github.com/softlayer/softlayer-go/datatypes"
 
 //go:generate counterfeiter -o fakes/fake_Disk_Service.go . Service
 type Service interface {
-	Create(size int, iops int, location string, snapshotSpace int) (int, error)
+	Create(size int, diskType string, iops int, tier float64, encrypted bool, location string, snapshotSpace int) (int, error)
+	Duplicate(size int, iops int, tier float64, snapshotSpace int, sourceID int, snapshotID int, location string) (int, error)
 	Delete(id int) error
+	Resize(id int, size int) error
+	Update(id int, size int, diskType string, datacenter string, iops int, tier float64, snapshotSpace int) error
 	SetMetadata(id int, diskMetadata Metadata) error
 	Find(id int) (*datatypes.Network_Storage, error)
+	ValidateOrder(size int, iops int, location string) error
+	SetSnapshotSchedules(id int, schedules []SnapshotSchedule) error
+	SetSnapshotRetention(id int, retention int) error
+	Replicate(id int, replication Replication) (int, error)
+	GetReplicationState(id int) (ReplicationState, error)
 }
 
 type Metadata map[string]interface{}
+
+// SnapshotSchedule takes a snapshot of a disk every hour, day or week, at the given minute, hour and
+// day of the week, and keeps the last retention snapshots.
+type SnapshotSchedule struct {
+	Type      string `json:"type"`
+	Retention int    `json:"retention"`
+	Minute    int    `json:"minute,omitempty"`
+	Hour      int    `json:"hour,omitempty"`
+	DayOfWeek string `json:"day_of_week,omitempty"`
+}
+
+// Replication keeps a replicant of a disk in another datacenter, synchronized whenever the snapshot
+// schedule of the given type takes a snapshot of the disk.
+type Replication struct {
+	Datacenter string `json:"datacenter"`
+	Schedule   string `json:"schedule"`
+}
+
+// ReplicationState is the replication status of a disk as reported by SoftLayer and its replicants.
+type ReplicationState struct {
+	Status     string
+	Replicants []Replicant
+}
+
+type Replicant struct {
+	ID         int
+	Datacenter string
+	Schedule   string
+}
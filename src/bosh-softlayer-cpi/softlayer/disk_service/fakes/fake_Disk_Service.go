@@ -9,22 +9,6 @@ import (
 )
 
 type FakeService struct {
-	CreateStub        func(size int, iops int, location string, snapshotSpace int) (int, error)
-	createMutex       sync.RWMutex
-	createArgsForCall []struct {
-		size          int
-		iops          int
-		location      string
-		snapshotSpace int
-	}
-	createReturns struct {
-		result1 int
-		result2 error
-	}
-	createReturnsOnCall map[int]struct {
-		result1 int
-		result2 error
-	}
 	DeleteStub        func(id int) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -61,62 +45,139 @@ type FakeService struct {
 		result1 *datatypes.Network_Storage
 		result2 error
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
-}
-
-func (fake *FakeService) Create(size int, iops int, location string, snapshotSpace int) (int, error) {
-	fake.createMutex.Lock()
-	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
-	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+	ResizeStub        func(id int, size int) error
+	resizeMutex       sync.RWMutex
+	resizeArgsForCall []struct {
+		id   int
+		size int
+	}
+	resizeReturns struct {
+		result1 error
+	}
+	resizeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateStub        func(id int, size int, diskType string, datacenter string, iops int, tier float64, snapshotSpace int) error
+	updateMutex       sync.RWMutex
+	updateArgsForCall []struct {
+		id            int
 		size          int
+		diskType      string
+		datacenter    string
 		iops          int
-		location      string
+		tier          float64
 		snapshotSpace int
-	}{size, iops, location, snapshotSpace})
-	fake.recordInvocation("Create", []interface{}{size, iops, location, snapshotSpace})
-	fake.createMutex.Unlock()
-	if fake.CreateStub != nil {
-		return fake.CreateStub(size, iops, location, snapshotSpace)
 	}
-	if specificReturn {
-		return ret.result1, ret.result2
+	updateReturns struct {
+		result1 error
 	}
-	return fake.createReturns.result1, fake.createReturns.result2
-}
-
-func (fake *FakeService) CreateCallCount() int {
-	fake.createMutex.RLock()
-	defer fake.createMutex.RUnlock()
-	return len(fake.createArgsForCall)
-}
-
-func (fake *FakeService) CreateArgsForCall(i int) (int, int, string, int) {
-	fake.createMutex.RLock()
-	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].size, fake.createArgsForCall[i].iops, fake.createArgsForCall[i].location, fake.createArgsForCall[i].snapshotSpace
-}
-
-func (fake *FakeService) CreateReturns(result1 int, result2 error) {
-	fake.CreateStub = nil
-	fake.createReturns = struct {
+	updateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ValidateOrderStub        func(size int, iops int, location string) error
+	validateOrderMutex       sync.RWMutex
+	validateOrderArgsForCall []struct {
+		size     int
+		iops     int
+		location string
+	}
+	validateOrderReturns struct {
+		result1 error
+	}
+	validateOrderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DuplicateStub        func(size int, iops int, tier float64, snapshotSpace int, sourceID int, snapshotID int, location string) (int, error)
+	duplicateMutex       sync.RWMutex
+	duplicateArgsForCall []struct {
+		size          int
+		iops          int
+		tier          float64
+		snapshotSpace int
+		sourceID      int
+		snapshotID    int
+		location      string
+	}
+	duplicateReturns struct {
 		result1 int
 		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeService) CreateReturnsOnCall(i int, result1 int, result2 error) {
-	fake.CreateStub = nil
-	if fake.createReturnsOnCall == nil {
-		fake.createReturnsOnCall = make(map[int]struct {
-			result1 int
-			result2 error
-		})
 	}
-	fake.createReturnsOnCall[i] = struct {
+	duplicateReturnsOnCall map[int]struct {
 		result1 int
 		result2 error
-	}{result1, result2}
+	}
+	SetSnapshotSchedulesStub        func(id int, schedules []disk.SnapshotSchedule) error
+	setSnapshotSchedulesMutex       sync.RWMutex
+	setSnapshotSchedulesArgsForCall []struct {
+		id        int
+		schedules []disk.SnapshotSchedule
+	}
+	setSnapshotSchedulesReturns struct {
+		result1 error
+	}
+	setSnapshotSchedulesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetSnapshotRetentionStub        func(id int, retention int) error
+	setSnapshotRetentionMutex       sync.RWMutex
+	setSnapshotRetentionArgsForCall []struct {
+		id        int
+		retention int
+	}
+	setSnapshotRetentionReturns struct {
+		result1 error
+	}
+	setSnapshotRetentionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReplicateStub        func(id int, replication disk.Replication) (int, error)
+	replicateMutex       sync.RWMutex
+	replicateArgsForCall []struct {
+		id          int
+		replication disk.Replication
+	}
+	replicateReturns struct {
+		result1 int
+		result2 error
+	}
+	replicateReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	GetReplicationStateStub        func(id int) (disk.ReplicationState, error)
+	getReplicationStateMutex       sync.RWMutex
+	getReplicationStateArgsForCall []struct {
+		id int
+	}
+	getReplicationStateReturns struct {
+		result1 disk.ReplicationState
+		result2 error
+	}
+	getReplicationStateReturnsOnCall map[int]struct {
+		result1 disk.ReplicationState
+		result2 error
+	}
+	CreateStub        func(size int, diskType string, iops int, tier float64, encrypted bool, location string, snapshotSpace int) (int, error)
+	createMutex       sync.RWMutex
+	createArgsForCall []struct {
+		size          int
+		diskType      string
+		iops          int
+		tier          float64
+		encrypted     bool
+		location      string
+		snapshotSpace int
+	}
+	createReturns struct {
+		result1 int
+		result2 error
+	}
+	createReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
 }
 
 func (fake *FakeService) Delete(id int) error {
@@ -267,17 +328,506 @@ func (fake *FakeService) FindReturnsOnCall(i int, result1 *datatypes.Network_Sto
 	}{result1, result2}
 }
 
+func (fake *FakeService) Resize(id int, size int) error {
+	fake.resizeMutex.Lock()
+	ret, specificReturn := fake.resizeReturnsOnCall[len(fake.resizeArgsForCall)]
+	fake.resizeArgsForCall = append(fake.resizeArgsForCall, struct {
+		id   int
+		size int
+	}{id, size})
+	fake.recordInvocation("Resize", []interface{}{id, size})
+	fake.resizeMutex.Unlock()
+	if fake.ResizeStub != nil {
+		return fake.ResizeStub(id, size)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.resizeReturns.result1
+}
+
+func (fake *FakeService) ResizeCallCount() int {
+	fake.resizeMutex.RLock()
+	defer fake.resizeMutex.RUnlock()
+	return len(fake.resizeArgsForCall)
+}
+
+func (fake *FakeService) ResizeArgsForCall(i int) (int, int) {
+	fake.resizeMutex.RLock()
+	defer fake.resizeMutex.RUnlock()
+	return fake.resizeArgsForCall[i].id, fake.resizeArgsForCall[i].size
+}
+
+func (fake *FakeService) ResizeReturns(result1 error) {
+	fake.ResizeStub = nil
+	fake.resizeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) ResizeReturnsOnCall(i int, result1 error) {
+	fake.ResizeStub = nil
+	if fake.resizeReturnsOnCall == nil {
+		fake.resizeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resizeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) Update(id int, size int, diskType string, datacenter string, iops int, tier float64, snapshotSpace int) error {
+	fake.updateMutex.Lock()
+	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
+	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+		id            int
+		size          int
+		diskType      string
+		datacenter    string
+		iops          int
+		tier          float64
+		snapshotSpace int
+	}{id, size, diskType, datacenter, iops, tier, snapshotSpace})
+	fake.recordInvocation("Update", []interface{}{id, size, diskType, datacenter, iops, tier, snapshotSpace})
+	fake.updateMutex.Unlock()
+	if fake.UpdateStub != nil {
+		return fake.UpdateStub(id, size, diskType, datacenter, iops, tier, snapshotSpace)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateReturns.result1
+}
+
+func (fake *FakeService) UpdateCallCount() int {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return len(fake.updateArgsForCall)
+}
+
+func (fake *FakeService) UpdateArgsForCall(i int) (int, int, string, string, int, float64, int) {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return fake.updateArgsForCall[i].id, fake.updateArgsForCall[i].size, fake.updateArgsForCall[i].diskType, fake.updateArgsForCall[i].datacenter, fake.updateArgsForCall[i].iops, fake.updateArgsForCall[i].tier, fake.updateArgsForCall[i].snapshotSpace
+}
+
+func (fake *FakeService) UpdateReturns(result1 error) {
+	fake.UpdateStub = nil
+	fake.updateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) UpdateReturnsOnCall(i int, result1 error) {
+	fake.UpdateStub = nil
+	if fake.updateReturnsOnCall == nil {
+		fake.updateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) ValidateOrder(size int, iops int, location string) error {
+	fake.validateOrderMutex.Lock()
+	ret, specificReturn := fake.validateOrderReturnsOnCall[len(fake.validateOrderArgsForCall)]
+	fake.validateOrderArgsForCall = append(fake.validateOrderArgsForCall, struct {
+		size     int
+		iops     int
+		location string
+	}{size, iops, location})
+	fake.recordInvocation("ValidateOrder", []interface{}{size, iops, location})
+	fake.validateOrderMutex.Unlock()
+	if fake.ValidateOrderStub != nil {
+		return fake.ValidateOrderStub(size, iops, location)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.validateOrderReturns.result1
+}
+
+func (fake *FakeService) ValidateOrderCallCount() int {
+	fake.validateOrderMutex.RLock()
+	defer fake.validateOrderMutex.RUnlock()
+	return len(fake.validateOrderArgsForCall)
+}
+
+func (fake *FakeService) ValidateOrderArgsForCall(i int) (int, int, string) {
+	fake.validateOrderMutex.RLock()
+	defer fake.validateOrderMutex.RUnlock()
+	return fake.validateOrderArgsForCall[i].size, fake.validateOrderArgsForCall[i].iops, fake.validateOrderArgsForCall[i].location
+}
+
+func (fake *FakeService) ValidateOrderReturns(result1 error) {
+	fake.ValidateOrderStub = nil
+	fake.validateOrderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) ValidateOrderReturnsOnCall(i int, result1 error) {
+	fake.ValidateOrderStub = nil
+	if fake.validateOrderReturnsOnCall == nil {
+		fake.validateOrderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateOrderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) Duplicate(size int, iops int, tier float64, snapshotSpace int, sourceID int, snapshotID int, location string) (int, error) {
+	fake.duplicateMutex.Lock()
+	ret, specificReturn := fake.duplicateReturnsOnCall[len(fake.duplicateArgsForCall)]
+	fake.duplicateArgsForCall = append(fake.duplicateArgsForCall, struct {
+		size          int
+		iops          int
+		tier          float64
+		snapshotSpace int
+		sourceID      int
+		snapshotID    int
+		location      string
+	}{size, iops, tier, snapshotSpace, sourceID, snapshotID, location})
+	fake.recordInvocation("Duplicate", []interface{}{size, iops, tier, snapshotSpace, sourceID, snapshotID, location})
+	fake.duplicateMutex.Unlock()
+	if fake.DuplicateStub != nil {
+		return fake.DuplicateStub(size, iops, tier, snapshotSpace, sourceID, snapshotID, location)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.duplicateReturns.result1, fake.duplicateReturns.result2
+}
+
+func (fake *FakeService) DuplicateCallCount() int {
+	fake.duplicateMutex.RLock()
+	defer fake.duplicateMutex.RUnlock()
+	return len(fake.duplicateArgsForCall)
+}
+
+func (fake *FakeService) DuplicateArgsForCall(i int) (int, int, float64, int, int, int, string) {
+	fake.duplicateMutex.RLock()
+	defer fake.duplicateMutex.RUnlock()
+	return fake.duplicateArgsForCall[i].size, fake.duplicateArgsForCall[i].iops, fake.duplicateArgsForCall[i].tier, fake.duplicateArgsForCall[i].snapshotSpace, fake.duplicateArgsForCall[i].sourceID, fake.duplicateArgsForCall[i].snapshotID, fake.duplicateArgsForCall[i].location
+}
+
+func (fake *FakeService) DuplicateReturns(result1 int, result2 error) {
+	fake.DuplicateStub = nil
+	fake.duplicateReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) DuplicateReturnsOnCall(i int, result1 int, result2 error) {
+	fake.DuplicateStub = nil
+	if fake.duplicateReturnsOnCall == nil {
+		fake.duplicateReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.duplicateReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) SetSnapshotSchedules(id int, schedules []disk.SnapshotSchedule) error {
+	var schedulesCopy []disk.SnapshotSchedule
+	if schedules != nil {
+		schedulesCopy = make([]disk.SnapshotSchedule, len(schedules))
+		copy(schedulesCopy, schedules)
+	}
+	fake.setSnapshotSchedulesMutex.Lock()
+	ret, specificReturn := fake.setSnapshotSchedulesReturnsOnCall[len(fake.setSnapshotSchedulesArgsForCall)]
+	fake.setSnapshotSchedulesArgsForCall = append(fake.setSnapshotSchedulesArgsForCall, struct {
+		id        int
+		schedules []disk.SnapshotSchedule
+	}{id, schedulesCopy})
+	fake.recordInvocation("SetSnapshotSchedules", []interface{}{id, schedulesCopy})
+	fake.setSnapshotSchedulesMutex.Unlock()
+	if fake.SetSnapshotSchedulesStub != nil {
+		return fake.SetSnapshotSchedulesStub(id, schedules)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setSnapshotSchedulesReturns.result1
+}
+
+func (fake *FakeService) SetSnapshotSchedulesCallCount() int {
+	fake.setSnapshotSchedulesMutex.RLock()
+	defer fake.setSnapshotSchedulesMutex.RUnlock()
+	return len(fake.setSnapshotSchedulesArgsForCall)
+}
+
+func (fake *FakeService) SetSnapshotSchedulesArgsForCall(i int) (int, []disk.SnapshotSchedule) {
+	fake.setSnapshotSchedulesMutex.RLock()
+	defer fake.setSnapshotSchedulesMutex.RUnlock()
+	return fake.setSnapshotSchedulesArgsForCall[i].id, fake.setSnapshotSchedulesArgsForCall[i].schedules
+}
+
+func (fake *FakeService) SetSnapshotSchedulesReturns(result1 error) {
+	fake.SetSnapshotSchedulesStub = nil
+	fake.setSnapshotSchedulesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) SetSnapshotSchedulesReturnsOnCall(i int, result1 error) {
+	fake.SetSnapshotSchedulesStub = nil
+	if fake.setSnapshotSchedulesReturnsOnCall == nil {
+		fake.setSnapshotSchedulesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setSnapshotSchedulesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) SetSnapshotRetention(id int, retention int) error {
+	fake.setSnapshotRetentionMutex.Lock()
+	ret, specificReturn := fake.setSnapshotRetentionReturnsOnCall[len(fake.setSnapshotRetentionArgsForCall)]
+	fake.setSnapshotRetentionArgsForCall = append(fake.setSnapshotRetentionArgsForCall, struct {
+		id        int
+		retention int
+	}{id, retention})
+	fake.recordInvocation("SetSnapshotRetention", []interface{}{id, retention})
+	fake.setSnapshotRetentionMutex.Unlock()
+	if fake.SetSnapshotRetentionStub != nil {
+		return fake.SetSnapshotRetentionStub(id, retention)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setSnapshotRetentionReturns.result1
+}
+
+func (fake *FakeService) SetSnapshotRetentionCallCount() int {
+	fake.setSnapshotRetentionMutex.RLock()
+	defer fake.setSnapshotRetentionMutex.RUnlock()
+	return len(fake.setSnapshotRetentionArgsForCall)
+}
+
+func (fake *FakeService) SetSnapshotRetentionArgsForCall(i int) (int, int) {
+	fake.setSnapshotRetentionMutex.RLock()
+	defer fake.setSnapshotRetentionMutex.RUnlock()
+	return fake.setSnapshotRetentionArgsForCall[i].id, fake.setSnapshotRetentionArgsForCall[i].retention
+}
+
+func (fake *FakeService) SetSnapshotRetentionReturns(result1 error) {
+	fake.SetSnapshotRetentionStub = nil
+	fake.setSnapshotRetentionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) SetSnapshotRetentionReturnsOnCall(i int, result1 error) {
+	fake.SetSnapshotRetentionStub = nil
+	if fake.setSnapshotRetentionReturnsOnCall == nil {
+		fake.setSnapshotRetentionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setSnapshotRetentionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) Replicate(id int, replication disk.Replication) (int, error) {
+	fake.replicateMutex.Lock()
+	ret, specificReturn := fake.replicateReturnsOnCall[len(fake.replicateArgsForCall)]
+	fake.replicateArgsForCall = append(fake.replicateArgsForCall, struct {
+		id          int
+		replication disk.Replication
+	}{id, replication})
+	fake.recordInvocation("Replicate", []interface{}{id, replication})
+	fake.replicateMutex.Unlock()
+	if fake.ReplicateStub != nil {
+		return fake.ReplicateStub(id, replication)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.replicateReturns.result1, fake.replicateReturns.result2
+}
+
+func (fake *FakeService) ReplicateCallCount() int {
+	fake.replicateMutex.RLock()
+	defer fake.replicateMutex.RUnlock()
+	return len(fake.replicateArgsForCall)
+}
+
+func (fake *FakeService) ReplicateArgsForCall(i int) (int, disk.Replication) {
+	fake.replicateMutex.RLock()
+	defer fake.replicateMutex.RUnlock()
+	return fake.replicateArgsForCall[i].id, fake.replicateArgsForCall[i].replication
+}
+
+func (fake *FakeService) ReplicateReturns(result1 int, result2 error) {
+	fake.ReplicateStub = nil
+	fake.replicateReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) ReplicateReturnsOnCall(i int, result1 int, result2 error) {
+	fake.ReplicateStub = nil
+	if fake.replicateReturnsOnCall == nil {
+		fake.replicateReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.replicateReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) GetReplicationState(id int) (disk.ReplicationState, error) {
+	fake.getReplicationStateMutex.Lock()
+	ret, specificReturn := fake.getReplicationStateReturnsOnCall[len(fake.getReplicationStateArgsForCall)]
+	fake.getReplicationStateArgsForCall = append(fake.getReplicationStateArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("GetReplicationState", []interface{}{id})
+	fake.getReplicationStateMutex.Unlock()
+	if fake.GetReplicationStateStub != nil {
+		return fake.GetReplicationStateStub(id)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getReplicationStateReturns.result1, fake.getReplicationStateReturns.result2
+}
+
+func (fake *FakeService) GetReplicationStateCallCount() int {
+	fake.getReplicationStateMutex.RLock()
+	defer fake.getReplicationStateMutex.RUnlock()
+	return len(fake.getReplicationStateArgsForCall)
+}
+
+func (fake *FakeService) GetReplicationStateArgsForCall(i int) int {
+	fake.getReplicationStateMutex.RLock()
+	defer fake.getReplicationStateMutex.RUnlock()
+	return fake.getReplicationStateArgsForCall[i].id
+}
+
+func (fake *FakeService) GetReplicationStateReturns(result1 disk.ReplicationState, result2 error) {
+	fake.GetReplicationStateStub = nil
+	fake.getReplicationStateReturns = struct {
+		result1 disk.ReplicationState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) GetReplicationStateReturnsOnCall(i int, result1 disk.ReplicationState, result2 error) {
+	fake.GetReplicationStateStub = nil
+	if fake.getReplicationStateReturnsOnCall == nil {
+		fake.getReplicationStateReturnsOnCall = make(map[int]struct {
+			result1 disk.ReplicationState
+			result2 error
+		})
+	}
+	fake.getReplicationStateReturnsOnCall[i] = struct {
+		result1 disk.ReplicationState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) Create(size int, diskType string, iops int, tier float64, encrypted bool, location string, snapshotSpace int) (int, error) {
+	fake.createMutex.Lock()
+	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
+	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		size          int
+		diskType      string
+		iops          int
+		tier          float64
+		encrypted     bool
+		location      string
+		snapshotSpace int
+	}{size, diskType, iops, tier, encrypted, location, snapshotSpace})
+	fake.recordInvocation("Create", []interface{}{size, diskType, iops, tier, encrypted, location, snapshotSpace})
+	fake.createMutex.Unlock()
+	if fake.CreateStub != nil {
+		return fake.CreateStub(size, diskType, iops, tier, encrypted, location, snapshotSpace)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createReturns.result1, fake.createReturns.result2
+}
+
+func (fake *FakeService) CreateCallCount() int {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	return len(fake.createArgsForCall)
+}
+
+func (fake *FakeService) CreateArgsForCall(i int) (int, string, int, float64, bool, string, int) {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	return fake.createArgsForCall[i].size, fake.createArgsForCall[i].diskType, fake.createArgsForCall[i].iops, fake.createArgsForCall[i].tier, fake.createArgsForCall[i].encrypted, fake.createArgsForCall[i].location, fake.createArgsForCall[i].snapshotSpace
+}
+
+func (fake *FakeService) CreateReturns(result1 int, result2 error) {
+	fake.CreateStub = nil
+	fake.createReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) CreateReturnsOnCall(i int, result1 int, result2 error) {
+	fake.CreateStub = nil
+	if fake.createReturnsOnCall == nil {
+		fake.createReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.createReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.createMutex.RLock()
-	defer fake.createMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.setMetadataMutex.RLock()
 	defer fake.setMetadataMutex.RUnlock()
 	fake.findMutex.RLock()
 	defer fake.findMutex.RUnlock()
+	fake.resizeMutex.RLock()
+	defer fake.resizeMutex.RUnlock()
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	fake.validateOrderMutex.RLock()
+	defer fake.validateOrderMutex.RUnlock()
+	fake.duplicateMutex.RLock()
+	defer fake.duplicateMutex.RUnlock()
+	fake.setSnapshotSchedulesMutex.RLock()
+	defer fake.setSnapshotSchedulesMutex.RUnlock()
+	fake.setSnapshotRetentionMutex.RLock()
+	defer fake.setSnapshotRetentionMutex.RUnlock()
+	fake.replicateMutex.RLock()
+	defer fake.replicateMutex.RUnlock()
+	fake.getReplicationStateMutex.RLock()
+	defer fake.getReplicationStateMutex.RUnlock()
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -2,21 +2,60 @@ package disk
 
 import (
 	"math"
+	"strings"
 
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/sl"
 )
 
-func (d SoftlayerDiskService) Create(size int, iops int, location string, snapshotSpace int) (int, error) {
+const diskEncryptionMask = "id,hasEncryptionAtRest"
+
+// Create orders a performance disk with iops, or an endurance disk with tier IOPS per GB, from the storage as a
+// service package. Encrypted disks are deleted again if SoftLayer did not encrypt them.
+func (d SoftlayerDiskService) Create(size int, diskType string, iops int, tier float64, encrypted bool, location string, snapshotSpace int) (int, error) {
 	d.logger.Debug(softlayerDiskServiceLogTag, "Creating disk of size '%d'", size)
-	volume, err := d.softlayerClient.CreateVolume(location, d.getSoftLayerDiskSize(size), iops, snapshotSpace)
+
+	if strings.ToLower(diskType) == EnduranceDiskType && tier == 0 {
+		return 0, bosherr.Error("Endurance disks require a tier")
+	}
+
+	volume, err := d.softlayerClient.CreateVolume(location, d.getSoftLayerDiskSize(size), iops, tier, snapshotSpace)
 	if err != nil {
-		return 0, bosherr.WrapErrorf(err, "Failed to creating volume with size '%d', iops '%d', location `%s`", d.getSoftLayerDiskSize(size), iops, location)
+		return 0, bosherr.WrapErrorf(err, "Failed to creating volume with size '%d', iops '%d', tier '%v', location `%s`", d.getSoftLayerDiskSize(size), iops, tier, location)
+	}
+
+	if encrypted {
+		details, _, err := d.softlayerClient.GetBlockVolumeDetails(*volume.Id, diskEncryptionMask)
+		if err == nil && !sl.Get(details.HasEncryptionAtRest, false).(bool) {
+			err = bosherr.Errorf("Datacenter '%s' does not support disks encrypted at rest", location)
+		}
+		if err != nil {
+			if deleteErr := d.Delete(*volume.Id); deleteErr != nil {
+				return 0, bosherr.WrapErrorf(err, "Verifying encryption of disk '%d' (deleting disk failed: %s)", *volume.Id, deleteErr)
+			}
+			return 0, bosherr.WrapErrorf(err, "Verifying encryption of disk '%d'", *volume.Id)
+		}
 	}
 
 	return *volume.Id, nil
 }
 
+// ValidateOrder returns an error if a disk of size MB with iops can not be ordered in location.
+func (d SoftlayerDiskService) ValidateOrder(size int, iops int, location string) error {
+	err := d.softlayerClient.VerifyVolumeOrder(location, d.getSoftLayerDiskSize(size), iops)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Verifying volume order with size '%d', iops '%d', location `%s`", d.getSoftLayerDiskSize(size), iops, location)
+	}
+
+	return nil
+}
+
 func (d SoftlayerDiskService) getSoftLayerDiskSize(size int) int {
+	return SoftLayerDiskSize(size)
+}
+
+// SoftLayerDiskSize returns the size in GB of the smallest SoftLayer volume that holds size MB.
+func SoftLayerDiskSize(size int) int {
 	// Sizes and IOPS ranges: http://knowledgelayer.softlayer.com/learning/performance-storage-concepts
 	sizeArray := []int{20, 40, 80, 100, 250, 500, 1000, 2000, 4000, 8000, 12000}
 
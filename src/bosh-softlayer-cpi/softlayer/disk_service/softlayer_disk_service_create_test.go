@@ -47,12 +47,71 @@ var _ = Describe("Disk Service Create", func() {
 					nil,
 				)
 
-				_, err = disk.Create(size, iops, location, 10)
+				_, err = disk.Create(size, "", iops, 0, false, location, 10)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(cli.CreateVolumeCallCount()).To(Equal(1))
 			})
 		})
 
+		It("orders an endurance volume with its tier", func() {
+			cli.CreateVolumeReturns(&datatypes.Network_Storage{Id: sl.Int(12345678)}, nil)
+
+			_, err = disk.Create(size, "endurance", 0, 4, false, location, 0)
+			Expect(err).NotTo(HaveOccurred())
+			actualLocation, actualSize, actualIops, actualTier, actualSnapshotSpace := cli.CreateVolumeArgsForCall(0)
+			Expect(actualLocation).To(Equal("lon02"))
+			Expect(actualSize).To(Equal(20))
+			Expect(actualIops).To(Equal(0))
+			Expect(actualTier).To(Equal(float64(4)))
+			Expect(actualSnapshotSpace).To(Equal(0))
+			Expect(cli.GetBlockVolumeDetailsCallCount()).To(Equal(0))
+		})
+
+		It("returns error when an endurance volume has no tier", func() {
+			_, err = disk.Create(size, "endurance", 0, 0, false, location, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Endurance disks require a tier"))
+			Expect(cli.CreateVolumeCallCount()).To(Equal(0))
+		})
+
+		Context("when the volume is encrypted", func() {
+			BeforeEach(func() {
+				cli.CreateVolumeReturns(&datatypes.Network_Storage{Id: sl.Int(12345678)}, nil)
+				cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{Id: sl.Int(12345678), HasEncryptionAtRest: sl.Bool(true)}, true, nil)
+			})
+
+			It("verifies the encryption of the new volume", func() {
+				id, err := disk.Create(size, "", iops, 0, true, location, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(id).To(Equal(12345678))
+				Expect(cli.CreateVolumeCallCount()).To(Equal(1))
+				actualID, actualMask := cli.GetBlockVolumeDetailsArgsForCall(0)
+				Expect(actualID).To(Equal(12345678))
+				Expect(actualMask).To(Equal("id,hasEncryptionAtRest"))
+				Expect(cli.CancelBlockVolumeCallCount()).To(Equal(0))
+			})
+
+			It("deletes the new volume when it is not encrypted", func() {
+				cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{Id: sl.Int(12345678)}, true, nil)
+
+				_, err = disk.Create(size, "", iops, 0, true, location, 0)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Datacenter 'lon02' does not support disks encrypted at rest"))
+				Expect(cli.CancelBlockVolumeCallCount()).To(Equal(1))
+				actualID, _, _ := cli.CancelBlockVolumeArgsForCall(0)
+				Expect(actualID).To(Equal(12345678))
+			})
+
+			It("deletes the new volume when its encryption can not be verified", func() {
+				cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{}, false, errors.New("fake-client-error"))
+
+				_, err = disk.Create(size, "", iops, 0, true, location, 0)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+				Expect(cli.CancelBlockVolumeCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("return error when softlayerClient CreateVolume call return error", func() {
 			It("failed to create volume", func() {
 				cli.CreateVolumeReturns(
@@ -60,11 +119,31 @@ var _ = Describe("Disk Service Create", func() {
 					errors.New("fake-client-error"),
 				)
 
-				_, err = disk.Create(size, iops, location, 10)
+				_, err = disk.Create(size, "", iops, 0, false, location, 10)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("fake-client-error"))
 				Expect(cli.CreateVolumeCallCount()).To(Equal(1))
 			})
 		})
 	})
+
+	Describe("Call ValidateOrder", func() {
+		It("verifies the order of the volume with the size in GB", func() {
+			err = disk.ValidateOrder(size, iops, location)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.VerifyVolumeOrderCallCount()).To(Equal(1))
+			actualLocation, actualSize, actualIops := cli.VerifyVolumeOrderArgsForCall(0)
+			Expect(actualLocation).To(Equal("lon02"))
+			Expect(actualSize).To(Equal(20))
+			Expect(actualIops).To(Equal(5000))
+		})
+
+		It("returns an error when softlayerClient VerifyVolumeOrder call returns an error", func() {
+			cli.VerifyVolumeOrderReturns(errors.New("fake-client-error"))
+
+			err = disk.ValidateOrder(size, iops, location)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
 })
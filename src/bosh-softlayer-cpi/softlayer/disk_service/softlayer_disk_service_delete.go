@@ -5,8 +5,18 @@ import (
 	"strings"
 )
 
+// Delete cancels the disk, its billing item cancels the replicants of the disk with it.
 func (d SoftlayerDiskService) Delete(id int) error {
-	_, err := d.softlayerClient.CancelBlockVolume(id, "By BOSH !!!", true)
+	replication, err := d.GetReplicationState(id)
+	if err != nil {
+		d.logger.Warn(softlayerDiskServiceLogTag, "Deleting disk '%d' without listing its replicants: %s", id, err)
+	} else {
+		for _, replicant := range replication.Replicants {
+			d.logger.Info(softlayerDiskServiceLogTag, "Deleting replicant '%d' in datacenter '%s' with disk '%d'", replicant.ID, replicant.Datacenter, id)
+		}
+	}
+
+	_, err = d.softlayerClient.CancelBlockVolume(id, "By BOSH !!!", true)
 	if err != nil {
 		if strings.Contains(err.Error(), "No billing item is found to cancel") {
 			return nil
@@ -7,6 +7,8 @@ import (
 	"errors"
 
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
 
 	cpiLog "bosh-softlayer-cpi/logger"
 	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
@@ -43,6 +45,33 @@ var _ = Describe("Disk Service Delete", func() {
 			})
 		})
 
+		Context("when the disk has replicants", func() {
+			It("cancels the disk with its replicants", func() {
+				cli.GetBlockVolumeDetailsReturns(
+					&datatypes.Network_Storage{
+						Id:                  sl.Int(diskID),
+						ReplicationPartners: []datatypes.Network_Storage{{Id: sl.Int(4096)}},
+					},
+					true,
+					nil,
+				)
+
+				err = disk.Delete(diskID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.CancelBlockVolumeCallCount()).To(Equal(1))
+				volumeID, _, _ := cli.CancelBlockVolumeArgsForCall(0)
+				Expect(volumeID).To(Equal(diskID))
+			})
+
+			It("cancels the disk when its replicants can not be listed", func() {
+				cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{}, false, errors.New("fake-client-error"))
+
+				err = disk.Delete(diskID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.CancelBlockVolumeCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("return error when softlayerClient CancelBlockVolume call return error", func() {
 			It("failed to delete volume", func() {
 				cli.CancelBlockVolumeReturns(
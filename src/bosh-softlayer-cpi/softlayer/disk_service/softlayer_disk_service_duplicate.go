@@ -0,0 +1,69 @@
+package disk
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+const snapshotParentVolumeMask = "id,parentVolume.id"
+
+// Duplicate creates a disk from the snapshot snapshotID of the disk sourceID, found from the snapshot if 0.
+// The duplicate has the type and datacenter of the source disk, and its size, iops or tier unless given.
+func (d SoftlayerDiskService) Duplicate(size int, iops int, tier float64, snapshotSpace int, sourceID int, snapshotID int, location string) (int, error) {
+	d.logger.Debug(softlayerDiskServiceLogTag, "Creating disk of size '%d' from snapshot '%d' of disk '%d'", size, snapshotID, sourceID)
+
+	if sourceID == 0 {
+		snapshot, found, err := d.softlayerClient.GetBlockVolumeDetails(snapshotID, snapshotParentVolumeMask)
+		if err != nil {
+			return 0, bosherr.WrapErrorf(err, "Getting details of snapshot '%d'", snapshotID)
+		}
+		if !found || snapshot.ParentVolume == nil || snapshot.ParentVolume.Id == nil {
+			return 0, bosherr.Errorf("Snapshot '%d' not found", snapshotID)
+		}
+		sourceID = *snapshot.ParentVolume.Id
+	}
+
+	source, err := d.findVolumeProperties(sourceID)
+	if err != nil {
+		return 0, err
+	}
+
+	if source.diskType == "" {
+		return 0, bosherr.Errorf("Disk '%d' is not a performance or endurance volume and can not be duplicated", sourceID)
+	}
+	if location != "" && location != source.datacenter {
+		return 0, bosherr.Errorf("Disk '%d' is in datacenter '%s' and can not be duplicated to datacenter '%s'", sourceID, source.datacenter, location)
+	}
+
+	newSize := source.size
+	if size > 0 {
+		newSize = d.getSoftLayerDiskSize(size)
+	}
+	if newSize < source.size {
+		return 0, bosherr.Errorf("Disk of size '%d' can not be smaller than disk '%d' of size '%d'", newSize, sourceID, source.size)
+	}
+
+	newIops, newTier := source.iops, source.tier
+	switch source.diskType {
+	case PerformanceDiskType:
+		if tier != 0 {
+			return 0, bosherr.Errorf("Disk '%d' is a performance volume and has no endurance tier", sourceID)
+		}
+		if iops != 0 {
+			newIops = iops
+		}
+	case EnduranceDiskType:
+		if iops != 0 {
+			return 0, bosherr.Errorf("Disk '%d' is an endurance volume and its iops are set by tier", sourceID)
+		}
+		if tier != 0 {
+			newTier = tier
+		}
+	}
+
+	volume, err := d.softlayerClient.DuplicateVolume(sourceID, snapshotID, source.datacenter, newSize, newIops, newTier, snapshotSpace)
+	if err != nil {
+		return 0, bosherr.WrapErrorf(err, "Duplicating disk '%d' from snapshot '%d' with size '%d', iops '%d', tier '%v'", sourceID, snapshotID, newSize, newIops, newTier)
+	}
+
+	return *volume.Id, nil
+}
@@ -0,0 +1,142 @@
+package disk_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"errors"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	cpiLog "bosh-softlayer-cpi/logger"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	diskService "bosh-softlayer-cpi/softlayer/disk_service"
+)
+
+var _ = Describe("Disk Service Duplicate", func() {
+	var (
+		err error
+
+		sourceID   int
+		snapshotID int
+		source     *datatypes.Network_Storage
+		snapshot   *datatypes.Network_Storage
+
+		cli    *fakeslclient.FakeClient
+		disk   diskService.SoftlayerDiskService
+		logger cpiLog.Logger
+	)
+	BeforeEach(func() {
+		sourceID = 12345678
+		snapshotID = 32345678
+		source = &datatypes.Network_Storage{
+			Id:              sl.Int(sourceID),
+			CapacityGb:      sl.Int(250),
+			ProvisionedIops: sl.String("3000"),
+			StorageType: &datatypes.Network_Storage_Type{
+				KeyName: sl.String("PERFORMANCE_BLOCK_STORAGE"),
+			},
+			ServiceResource: &datatypes.Network_Service_Resource{
+				Datacenter: &datatypes.Location{
+					Name: sl.String("dal10"),
+				},
+			},
+		}
+		snapshot = &datatypes.Network_Storage{
+			Id:           sl.Int(snapshotID),
+			ParentVolume: &datatypes.Network_Storage{Id: sl.Int(sourceID)},
+		}
+
+		cli = &fakeslclient.FakeClient{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		disk = diskService.NewSoftlayerDiskService(cli, logger)
+
+		cli.GetBlockVolumeDetailsStub = func(id int, mask string) (*datatypes.Network_Storage, bool, error) {
+			if id == snapshotID {
+				return snapshot, true, nil
+			}
+			return source, true, nil
+		}
+		cli.DuplicateVolumeReturns(&datatypes.Network_Storage{Id: sl.Int(22345678)}, nil)
+	})
+
+	Describe("Call Duplicate", func() {
+		It("duplicates the source disk with its properties", func() {
+			id, err := disk.Duplicate(0, 0, 0, 0, sourceID, snapshotID, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(22345678))
+			Expect(cli.GetBlockVolumeDetailsCallCount()).To(Equal(1))
+			Expect(cli.DuplicateVolumeCallCount()).To(Equal(1))
+			actualSourceID, actualSnapshotID, actualLocation, actualSize, actualIops, actualTier, actualSnapshotSpace := cli.DuplicateVolumeArgsForCall(0)
+			Expect(actualSourceID).To(Equal(sourceID))
+			Expect(actualSnapshotID).To(Equal(snapshotID))
+			Expect(actualLocation).To(Equal("dal10"))
+			Expect(actualSize).To(Equal(250))
+			Expect(actualIops).To(Equal(3000))
+			Expect(actualTier).To(Equal(float64(0)))
+			Expect(actualSnapshotSpace).To(Equal(0))
+		})
+
+		It("finds the source disk of the snapshot", func() {
+			_, err = disk.Duplicate(500*1024, 5000, 0, 20, 0, snapshotID, "dal10")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.GetBlockVolumeDetailsCallCount()).To(Equal(2))
+			actualSnapshotID, _ := cli.GetBlockVolumeDetailsArgsForCall(0)
+			Expect(actualSnapshotID).To(Equal(snapshotID))
+			actualSourceID, _, _, actualSize, actualIops, _, actualSnapshotSpace := cli.DuplicateVolumeArgsForCall(0)
+			Expect(actualSourceID).To(Equal(sourceID))
+			Expect(actualSize).To(Equal(500))
+			Expect(actualIops).To(Equal(5000))
+			Expect(actualSnapshotSpace).To(Equal(20))
+		})
+
+		It("duplicates an endurance disk with another tier", func() {
+			source.StorageType.KeyName = sl.String("ENDURANCE_BLOCK_STORAGE")
+			source.StorageTierLevel = sl.String("READHEAVY_TIER")
+
+			_, err = disk.Duplicate(0, 0, 4, 0, sourceID, snapshotID, "")
+			Expect(err).NotTo(HaveOccurred())
+			_, _, _, _, actualIops, actualTier, _ := cli.DuplicateVolumeArgsForCall(0)
+			Expect(actualIops).To(Equal(0))
+			Expect(actualTier).To(Equal(float64(4)))
+		})
+
+		It("returns error if the snapshot is not found", func() {
+			cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{}, false, nil)
+			cli.GetBlockVolumeDetailsStub = nil
+
+			_, err = disk.Duplicate(0, 0, 0, 0, 0, snapshotID, "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Snapshot '32345678' not found"))
+			Expect(cli.DuplicateVolumeCallCount()).To(Equal(0))
+		})
+
+		It("returns error if the disk is in another datacenter", func() {
+			_, err = disk.Duplicate(0, 0, 0, 0, sourceID, snapshotID, "lon02")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Disk '12345678' is in datacenter 'dal10' and can not be duplicated to datacenter 'lon02'"))
+		})
+
+		It("returns error if the duplicate is smaller than the source disk", func() {
+			_, err = disk.Duplicate(100*1024, 0, 0, 0, sourceID, snapshotID, "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("can not be smaller than disk '12345678' of size '250'"))
+		})
+
+		It("returns error if a tier is set for a performance disk", func() {
+			_, err = disk.Duplicate(0, 0, 4, 0, sourceID, snapshotID, "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is a performance volume and has no endurance tier"))
+		})
+
+		It("returns error if the duplicate order fails", func() {
+			cli.DuplicateVolumeReturns(&datatypes.Network_Storage{}, errors.New("fake-client-error"))
+
+			_, err = disk.Duplicate(0, 0, 0, 0, sourceID, snapshotID, "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+})
@@ -4,7 +4,17 @@ import (
 	"bosh-softlayer-cpi/api"
 	"bytes"
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
 	"strconv"
+	"strings"
+)
+
+// The snapshot retention of the disk cloud properties is kept in the notes of the disk, since
+// snapshot_disk only gets the disk
+const (
+	snapshotRetentionNote = "snapshot_retention"
+	diskNotesMask         = "id,notes"
 )
 
 func (d SoftlayerDiskService) SetMetadata(id int, vmMetadata Metadata) error {
@@ -13,6 +23,12 @@ func (d SoftlayerDiskService) SetMetadata(id int, vmMetadata Metadata) error {
 		return bosherr.WrapError(err, "generating notes from disk metadata")
 	}
 
+	volume, _, err := d.softlayerClient.GetBlockVolumeDetails(id, diskNotesMask)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Getting notes of network storage '%d'", id)
+	}
+	tags = notesWithSnapshotRetention(tags, SnapshotRetention(volume))
+
 	found, err := d.softlayerClient.SetNotes(id, tags)
 	if err != nil {
 		return bosherr.WrapErrorf(err, "Settings notes on network storage '%d'", id)
@@ -25,6 +41,61 @@ func (d SoftlayerDiskService) SetMetadata(id int, vmMetadata Metadata) error {
 	return nil
 }
 
+// SetSnapshotRetention records how many snapshots snapshot_disk keeps of a disk, 0 for all.
+func (d SoftlayerDiskService) SetSnapshotRetention(id int, retention int) error {
+	volume, found, err := d.softlayerClient.GetBlockVolumeDetails(id, diskNotesMask)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Getting notes of network storage '%d'", id)
+	}
+	if !found {
+		return api.NewDiskNotFoundError(strconv.Itoa(id), false)
+	}
+
+	notes := sl.Get(volume.Notes, "").(string)
+	newNotes := notesWithSnapshotRetention(notes, retention)
+	if newNotes == notes {
+		return nil
+	}
+
+	found, err = d.softlayerClient.SetNotes(id, newNotes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Settings notes on network storage '%d'", id)
+	}
+	if !found {
+		return api.NewDiskNotFoundError(strconv.Itoa(id), false)
+	}
+
+	return nil
+}
+
+// SnapshotRetention returns the snapshot retention recorded in the notes of volume, 0 if there is none.
+func SnapshotRetention(volume *datatypes.Network_Storage) int {
+	if volume == nil {
+		return 0
+	}
+
+	for _, entry := range strings.Split(sl.Get(volume.Notes, "").(string), ",") {
+		if strings.HasPrefix(entry, snapshotRetentionNote+":") {
+			retention, _ := strconv.Atoi(strings.TrimPrefix(entry, snapshotRetentionNote+":"))
+			return retention
+		}
+	}
+	return 0
+}
+
+func notesWithSnapshotRetention(notes string, retention int) string {
+	entries := []string{}
+	for _, entry := range strings.Split(notes, ",") {
+		if entry != "" && !strings.HasPrefix(entry, snapshotRetentionNote+":") {
+			entries = append(entries, entry)
+		}
+	}
+	if retention > 0 {
+		entries = append(entries, snapshotRetentionNote+":"+strconv.Itoa(retention))
+	}
+	return strings.Join(entries, ",")
+}
+
 func (d SoftlayerDiskService) generateNotesFromDiskMetadata(vmMetadata Metadata) (string, error) {
 	var (
 		tagStringBuffer bytes.Buffer
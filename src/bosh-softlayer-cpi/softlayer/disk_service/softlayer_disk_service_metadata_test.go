@@ -9,6 +9,8 @@ import (
 
 	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
 
 	. "bosh-softlayer-cpi/softlayer/disk_service"
 
@@ -81,5 +83,93 @@ var _ = Describe("Virtual Guest Service", func() {
 			Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Disk '%d' not found", diskID)))
 			Expect(cli.SetNotesCallCount()).To(Equal(1))
 		})
+
+		It("Keeps the snapshot retention in the notes", func() {
+			cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{
+				Notes: sl.String("director:old-director,snapshot_retention:7"),
+			}, true, nil)
+			cli.SetNotesReturns(true, nil)
+
+			err := diskService.SetMetadata(diskID, Metadata{"director": "bats-director"})
+			Expect(err).NotTo(HaveOccurred())
+			actualID, actualMask := cli.GetBlockVolumeDetailsArgsForCall(0)
+			Expect(actualID).To(Equal(diskID))
+			Expect(actualMask).To(Equal("id,notes"))
+			_, actualNotes := cli.SetNotesArgsForCall(0)
+			Expect(actualNotes).To(Equal("director:bats-director,snapshot_retention:7"))
+		})
+
+		It("Return error if softLayerClient GetBlockVolumeDetails call returns an error", func() {
+			cli.GetBlockVolumeDetailsReturns(nil, false, errors.New("fake-client-error"))
+
+			err := diskService.SetMetadata(diskID, metaData)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+			Expect(cli.SetNotesCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("Call SetSnapshotRetention", func() {
+		var diskID int
+
+		BeforeEach(func() {
+			diskID = 12345678
+			cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{
+				Notes: sl.String("director:bats-director,deployment:automation-cf"),
+			}, true, nil)
+			cli.SetNotesReturns(true, nil)
+		})
+
+		It("Records the snapshot retention in the notes", func() {
+			err := diskService.SetSnapshotRetention(diskID, 7)
+			Expect(err).NotTo(HaveOccurred())
+			actualID, actualNotes := cli.SetNotesArgsForCall(0)
+			Expect(actualID).To(Equal(diskID))
+			Expect(actualNotes).To(Equal("director:bats-director,deployment:automation-cf,snapshot_retention:7"))
+		})
+
+		It("Removes the snapshot retention from the notes", func() {
+			cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{
+				Notes: sl.String("director:bats-director,snapshot_retention:7,deployment:automation-cf"),
+			}, true, nil)
+
+			err := diskService.SetSnapshotRetention(diskID, 0)
+			Expect(err).NotTo(HaveOccurred())
+			_, actualNotes := cli.SetNotesArgsForCall(0)
+			Expect(actualNotes).To(Equal("director:bats-director,deployment:automation-cf"))
+		})
+
+		It("Does not set the notes if the snapshot retention is unchanged", func() {
+			err := diskService.SetSnapshotRetention(diskID, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.SetNotesCallCount()).To(Equal(0))
+		})
+
+		It("Return error if the disk is not found", func() {
+			cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{}, false, nil)
+
+			err := diskService.SetSnapshotRetention(diskID, 7)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Disk '%d' not found", diskID)))
+		})
+
+		It("Return error if softLayerClient SetNotes call returns an error", func() {
+			cli.SetNotesReturns(false, errors.New("fake-client-error"))
+
+			err := diskService.SetSnapshotRetention(diskID, 7)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+
+	Describe("SnapshotRetention", func() {
+		It("returns the snapshot retention of the notes", func() {
+			Expect(SnapshotRetention(&datatypes.Network_Storage{Notes: sl.String("job:consul,snapshot_retention:7")})).To(Equal(7))
+		})
+
+		It("returns 0 without snapshot retention", func() {
+			Expect(SnapshotRetention(&datatypes.Network_Storage{Notes: sl.String("job:consul")})).To(Equal(0))
+			Expect(SnapshotRetention(&datatypes.Network_Storage{})).To(Equal(0))
+		})
 	})
 })
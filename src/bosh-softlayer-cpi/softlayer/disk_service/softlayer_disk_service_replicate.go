@@ -0,0 +1,94 @@
+package disk
+
+import (
+	"strconv"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/api"
+)
+
+const (
+	replicationScheduleTypePrefix = "REPLICATION_"
+
+	replicationStateMask = "id,replicationStatus,replicationPartners[id,serviceResource.datacenter.name,replicationSchedule.type.keyname]"
+)
+
+// Replicate orders a replicant of a disk in the datacenter of the replication, synchronized at the
+// active snapshot schedule of the disk with the type of the replication schedule.
+func (d SoftlayerDiskService) Replicate(id int, replication Replication) (int, error) {
+	d.logger.Debug(softlayerDiskServiceLogTag, "Replicating disk '%d' to datacenter '%s' %s", id, replication.Datacenter, strings.ToLower(replication.Schedule))
+
+	origin, err := d.findVolumeProperties(id)
+	if err != nil {
+		return 0, err
+	}
+
+	if origin.diskType == "" {
+		return 0, bosherr.Errorf("Disk '%d' is not a performance or endurance volume and can not be replicated", id)
+	}
+	if origin.snapshotSpace == 0 {
+		return 0, bosherr.Errorf("Disk '%d' has no snapshot space and can not be replicated", id)
+	}
+	if replication.Datacenter == origin.datacenter {
+		return 0, bosherr.Errorf("Disk '%d' can not be replicated to its own datacenter '%s'", id, origin.datacenter)
+	}
+
+	schedules, err := d.softlayerClient.GetSnapshotSchedules(id)
+	if err != nil {
+		return 0, bosherr.WrapErrorf(err, "Getting snapshot schedules of disk '%d'", id)
+	}
+
+	scheduleType := snapshotScheduleTypePrefix + strings.ToUpper(replication.Schedule)
+	scheduleID := 0
+	for _, schedule := range schedules {
+		if sl.Get(schedule.Active, 0).(int) == 0 || schedule.Type == nil {
+			continue
+		}
+		if sl.Get(schedule.Type.Keyname, "").(string) == scheduleType {
+			scheduleID = sl.Get(schedule.Id, 0).(int)
+			break
+		}
+	}
+	if scheduleID == 0 {
+		return 0, bosherr.Errorf("Disk '%d' has no %s snapshot schedule to replicate at", id, strings.ToLower(replication.Schedule))
+	}
+
+	replicant, err := d.softlayerClient.OrderReplicantVolume(id, scheduleID, replication.Datacenter, origin.size, origin.iops, origin.tier, origin.snapshotSpace)
+	if err != nil {
+		return 0, bosherr.WrapErrorf(err, "Ordering replicant of disk '%d' in datacenter '%s'", id, replication.Datacenter)
+	}
+
+	return *replicant.Id, nil
+}
+
+// GetReplicationState returns the replication status and the replicants of a disk.
+func (d SoftlayerDiskService) GetReplicationState(id int) (ReplicationState, error) {
+	volume, found, err := d.softlayerClient.GetBlockVolumeDetails(id, replicationStateMask)
+	if err != nil {
+		return ReplicationState{}, bosherr.WrapErrorf(err, "Getting replication state of disk '%d'", id)
+	}
+
+	if !found {
+		return ReplicationState{}, api.NewDiskNotFoundError(strconv.Itoa(id), false)
+	}
+
+	state := ReplicationState{
+		Status:     sl.Get(volume.ReplicationStatus, "").(string),
+		Replicants: []Replicant{},
+	}
+	for _, partner := range volume.ReplicationPartners {
+		replicant := Replicant{ID: sl.Get(partner.Id, 0).(int)}
+		if partner.ServiceResource != nil && partner.ServiceResource.Datacenter != nil {
+			replicant.Datacenter = sl.Get(partner.ServiceResource.Datacenter.Name, "").(string)
+		}
+		if partner.ReplicationSchedule != nil && partner.ReplicationSchedule.Type != nil {
+			replicant.Schedule = strings.TrimPrefix(sl.Get(partner.ReplicationSchedule.Type.Keyname, "").(string), replicationScheduleTypePrefix)
+		}
+		state.Replicants = append(state.Replicants, replicant)
+	}
+
+	return state, nil
+}
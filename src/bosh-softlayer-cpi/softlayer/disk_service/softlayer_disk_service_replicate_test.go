@@ -0,0 +1,181 @@
+package disk_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"errors"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/api"
+	cpiLog "bosh-softlayer-cpi/logger"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	diskService "bosh-softlayer-cpi/softlayer/disk_service"
+)
+
+var _ = Describe("Disk Service Replicate", func() {
+	var (
+		err error
+
+		diskID      int
+		volume      *datatypes.Network_Storage
+		replication diskService.Replication
+
+		cli    *fakeslclient.FakeClient
+		disk   diskService.SoftlayerDiskService
+		logger cpiLog.Logger
+	)
+	BeforeEach(func() {
+		diskID = 12345678
+		volume = &datatypes.Network_Storage{
+			Id:                 sl.Int(diskID),
+			CapacityGb:         sl.Int(250),
+			ProvisionedIops:    sl.String("3000"),
+			SnapshotCapacityGb: sl.String("20"),
+			StorageType: &datatypes.Network_Storage_Type{
+				KeyName: sl.String("PERFORMANCE_BLOCK_STORAGE"),
+			},
+			ServiceResource: &datatypes.Network_Service_Resource{
+				Datacenter: &datatypes.Location{
+					Name: sl.String("dal10"),
+				},
+			},
+		}
+		replication = diskService.Replication{Datacenter: "wdc07", Schedule: "daily"}
+
+		cli = &fakeslclient.FakeClient{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		disk = diskService.NewSoftlayerDiskService(cli, logger)
+
+		cli.GetBlockVolumeDetailsReturns(volume, true, nil)
+		cli.GetSnapshotSchedulesReturns([]datatypes.Network_Storage_Schedule{
+			{
+				Id:     sl.Int(52345678),
+				Active: sl.Int(1),
+				Type:   &datatypes.Network_Storage_Schedule_Type{Keyname: sl.String("SNAPSHOT_HOURLY")},
+			},
+			{
+				Id:     sl.Int(52345679),
+				Active: sl.Int(1),
+				Type:   &datatypes.Network_Storage_Schedule_Type{Keyname: sl.String("SNAPSHOT_DAILY")},
+			},
+		}, nil)
+		cli.OrderReplicantVolumeReturns(&datatypes.Network_Storage{Id: sl.Int(22345678)}, nil)
+	})
+
+	Describe("Call Replicate", func() {
+		It("orders a replicant with the properties of the disk", func() {
+			id, err := disk.Replicate(diskID, replication)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(22345678))
+			Expect(cli.OrderReplicantVolumeCallCount()).To(Equal(1))
+			actualID, actualScheduleID, actualLocation, actualSize, actualIops, actualTier, actualSnapshotSpace := cli.OrderReplicantVolumeArgsForCall(0)
+			Expect(actualID).To(Equal(diskID))
+			Expect(actualScheduleID).To(Equal(52345679))
+			Expect(actualLocation).To(Equal("wdc07"))
+			Expect(actualSize).To(Equal(250))
+			Expect(actualIops).To(Equal(3000))
+			Expect(actualTier).To(Equal(float64(0)))
+			Expect(actualSnapshotSpace).To(Equal(20))
+		})
+
+		It("returns error when the disk has no snapshot schedule of the replication schedule", func() {
+			replication.Schedule = "WEEKLY"
+
+			_, err = disk.Replicate(diskID, replication)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Disk '12345678' has no weekly snapshot schedule to replicate at"))
+			Expect(cli.OrderReplicantVolumeCallCount()).To(Equal(0))
+		})
+
+		It("returns error when the disk has no snapshot space", func() {
+			volume.SnapshotCapacityGb = nil
+
+			_, err = disk.Replicate(diskID, replication)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("has no snapshot space"))
+			Expect(cli.OrderReplicantVolumeCallCount()).To(Equal(0))
+		})
+
+		It("returns error when the replicant would be in the datacenter of the disk", func() {
+			replication.Datacenter = "dal10"
+
+			_, err = disk.Replicate(diskID, replication)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("can not be replicated to its own datacenter 'dal10'"))
+		})
+
+		It("returns error when softlayerClient GetSnapshotSchedules call returns an error", func() {
+			cli.GetSnapshotSchedulesReturns(nil, errors.New("fake-client-error"))
+
+			_, err = disk.Replicate(diskID, replication)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+
+		It("returns error when softlayerClient OrderReplicantVolume call returns an error", func() {
+			cli.OrderReplicantVolumeReturns(&datatypes.Network_Storage{}, errors.New("fake-client-error"))
+
+			_, err = disk.Replicate(diskID, replication)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Ordering replicant of disk '12345678' in datacenter 'wdc07'"))
+		})
+	})
+
+	Describe("Call GetReplicationState", func() {
+		BeforeEach(func() {
+			volume.ReplicationStatus = sl.String("Replicant Volume Provisioning has completed.")
+			volume.ReplicationPartners = []datatypes.Network_Storage{
+				{
+					Id: sl.Int(22345678),
+					ServiceResource: &datatypes.Network_Service_Resource{
+						Datacenter: &datatypes.Location{Name: sl.String("wdc07")},
+					},
+					ReplicationSchedule: &datatypes.Network_Storage_Schedule{
+						Type: &datatypes.Network_Storage_Schedule_Type{Keyname: sl.String("REPLICATION_DAILY")},
+					},
+				},
+			}
+		})
+
+		It("returns the replication status and replicants of the disk", func() {
+			state, err := disk.GetReplicationState(diskID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state).To(Equal(diskService.ReplicationState{
+				Status: "Replicant Volume Provisioning has completed.",
+				Replicants: []diskService.Replicant{
+					{ID: 22345678, Datacenter: "wdc07", Schedule: "DAILY"},
+				},
+			}))
+		})
+
+		It("returns no replicants when the disk is not replicated", func() {
+			volume.ReplicationStatus = nil
+			volume.ReplicationPartners = nil
+
+			state, err := disk.GetReplicationState(diskID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.Replicants).To(BeEmpty())
+		})
+
+		It("returns disk not found error when the disk does not exist", func() {
+			cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{}, false, nil)
+
+			_, err = disk.GetReplicationState(diskID)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(api.CloudError)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("returns error when softlayerClient GetBlockVolumeDetails call returns an error", func() {
+			cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{}, false, errors.New("fake-client-error"))
+
+			_, err = disk.GetReplicationState(diskID)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+})
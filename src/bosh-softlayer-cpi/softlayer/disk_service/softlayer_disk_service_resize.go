@@ -0,0 +1,37 @@
+package disk
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	"bosh-softlayer-cpi/api"
+)
+
+func (d SoftlayerDiskService) Resize(id int, size int) error {
+	d.logger.Debug(softlayerDiskServiceLogTag, "Resizing disk '%d' to size '%d'", id, size)
+	volume, err := d.findVolumeProperties(id)
+	if err != nil {
+		return err
+	}
+
+	if !volume.modifiable {
+		d.logger.Debug(softlayerDiskServiceLogTag, "Disk '%d' of type '%s' in datacenter '%s' can not be resized in place", id, volume.diskType, volume.datacenter)
+		return api.NotSupportedError{}
+	}
+
+	newSize := d.getSoftLayerDiskSize(size)
+	if newSize == volume.size {
+		d.logger.Debug(softlayerDiskServiceLogTag, "Disk '%d' already has size '%d'", id, volume.size)
+		return nil
+	}
+	if newSize < volume.size {
+		d.logger.Debug(softlayerDiskServiceLogTag, "Disk '%d' can not be shrunk from size '%d' to '%d'", id, volume.size, newSize)
+		return api.NotSupportedError{}
+	}
+
+	err = d.softlayerClient.ModifyVolume(id, newSize, volume.iops, volume.tier)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Resizing disk '%d' to size '%d', iops '%d', tier '%v'", id, newSize, volume.iops, volume.tier)
+	}
+
+	return nil
+}
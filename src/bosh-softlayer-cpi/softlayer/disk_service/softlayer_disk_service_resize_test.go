@@ -0,0 +1,139 @@
+package disk_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"errors"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/api"
+	cpiLog "bosh-softlayer-cpi/logger"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	diskService "bosh-softlayer-cpi/softlayer/disk_service"
+)
+
+var _ = Describe("Disk Service Resize", func() {
+	var (
+		err error
+
+		diskID int
+		volume *datatypes.Network_Storage
+
+		cli    *fakeslclient.FakeClient
+		disk   diskService.SoftlayerDiskService
+		logger cpiLog.Logger
+	)
+	BeforeEach(func() {
+		diskID = 12345678
+		volume = &datatypes.Network_Storage{
+			Id:              sl.Int(diskID),
+			CapacityGb:      sl.Int(250),
+			ProvisionedIops: sl.String("3000"),
+			StaasVersion:    sl.String("2"),
+			StorageType: &datatypes.Network_Storage_Type{
+				KeyName: sl.String("PERFORMANCE_BLOCK_STORAGE"),
+			},
+		}
+
+		cli = &fakeslclient.FakeClient{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		disk = diskService.NewSoftlayerDiskService(cli, logger)
+	})
+
+	Describe("Call Resize", func() {
+		BeforeEach(func() {
+			cli.GetBlockVolumeDetailsReturns(volume, true, nil)
+		})
+
+		It("resize volume to the next SoftLayer disk size successfully", func() {
+			err = disk.Resize(diskID, 300*1024)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(1))
+			actualID, actualSize, actualIops, actualTier := cli.ModifyVolumeArgsForCall(0)
+			Expect(actualID).To(Equal(diskID))
+			Expect(actualSize).To(Equal(500))
+			Expect(actualIops).To(Equal(3000))
+			Expect(actualTier).To(Equal(float64(0)))
+		})
+
+		It("resize endurance volume keeping its tier successfully", func() {
+			volume.StorageType.KeyName = sl.String("ENDURANCE_BLOCK_STORAGE")
+			volume.ProvisionedIops = sl.String("1000")
+			volume.StorageTierLevel = sl.String("WRITEHEAVY_TIER")
+
+			err = disk.Resize(diskID, 300*1024)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(1))
+			_, actualSize, actualIops, actualTier := cli.ModifyVolumeArgsForCall(0)
+			Expect(actualSize).To(Equal(500))
+			Expect(actualIops).To(Equal(0))
+			Expect(actualTier).To(Equal(float64(4)))
+		})
+
+		It("does nothing when volume already has the SoftLayer disk size", func() {
+			err = disk.Resize(diskID, 200*1024)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(0))
+		})
+
+		It("return not supported error when volume would be shrunk", func() {
+			err = disk.Resize(diskID, 50*1024)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(0))
+		})
+
+		It("return not supported error when storage type can not be resized", func() {
+			volume.StorageType.KeyName = sl.String("NAS_STORAGE")
+
+			err = disk.Resize(diskID, 300*1024)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(0))
+		})
+
+		It("return not supported error when volume on the first storage platform can not be resized", func() {
+			volume.StaasVersion = sl.String("1")
+
+			err = disk.Resize(diskID, 300*1024)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(0))
+		})
+
+		It("return not supported error when legacy volume has no storage platform version", func() {
+			volume.StaasVersion = nil
+
+			err = disk.Resize(diskID, 300*1024)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(0))
+		})
+
+		It("return disk not found error when volume not found", func() {
+			cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{}, false, nil)
+
+			err = disk.Resize(diskID, 300*1024)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(api.DiskNotFoundError)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("return error when softlayerClient GetBlockVolumeDetails call return error", func() {
+			cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{}, false, errors.New("fake-client-error"))
+
+			err = disk.Resize(diskID, 300*1024)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+
+		It("return error when softlayerClient ModifyVolume call return error", func() {
+			cli.ModifyVolumeReturns(errors.New("fake-client-error"))
+
+			err = disk.Resize(diskID, 300*1024)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,100 @@
+package disk
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	snapshotScheduleTypePrefix = "SNAPSHOT_"
+
+	// SoftLayer requires a day of the week for every schedule
+	defaultSnapshotDayOfWeek = "SUNDAY"
+)
+
+// SetSnapshotSchedules enables the given snapshot schedules of a disk, updating the ones that differ,
+// and disables the schedules of other types.
+func (d SoftlayerDiskService) SetSnapshotSchedules(id int, schedules []SnapshotSchedule) error {
+	d.logger.Debug(softlayerDiskServiceLogTag, "Setting snapshot schedules of disk '%d' to '%+v'", id, schedules)
+	current, err := d.softlayerClient.GetSnapshotSchedules(id)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Getting snapshot schedules of disk '%d'", id)
+	}
+
+	enabled := map[string]datatypes.Network_Storage_Schedule{}
+	for _, schedule := range current {
+		if sl.Get(schedule.Active, 0).(int) == 0 || schedule.Type == nil {
+			continue
+		}
+		keyname := sl.Get(schedule.Type.Keyname, "").(string)
+		if !strings.HasPrefix(keyname, snapshotScheduleTypePrefix) {
+			continue
+		}
+		enabled[strings.TrimPrefix(keyname, snapshotScheduleTypePrefix)] = schedule
+	}
+
+	wanted := map[string]bool{}
+	for _, schedule := range schedules {
+		scheduleType := strings.ToUpper(schedule.Type)
+		dayOfWeek := strings.ToUpper(schedule.DayOfWeek)
+		if dayOfWeek == "" {
+			dayOfWeek = defaultSnapshotDayOfWeek
+		}
+		wanted[scheduleType] = true
+
+		if current, ok := enabled[scheduleType]; ok && snapshotScheduleMatches(current, schedule, dayOfWeek) {
+			continue
+		}
+
+		err = d.softlayerClient.EnableSnapshot(id, scheduleType, schedule.Retention, schedule.Minute, schedule.Hour, dayOfWeek)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Enabling %s snapshots of disk '%d'", strings.ToLower(scheduleType), id)
+		}
+	}
+
+	for scheduleType := range enabled {
+		if wanted[scheduleType] {
+			continue
+		}
+
+		err = d.softlayerClient.DisableSnapshots(id, scheduleType)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Disabling %s snapshots of disk '%d'", strings.ToLower(scheduleType), id)
+		}
+	}
+
+	return nil
+}
+
+// snapshotScheduleMatches compares the properties that apply to the type of schedule, SoftLayer
+// returns the day of the week as number from 0 for Sunday.
+func snapshotScheduleMatches(current datatypes.Network_Storage_Schedule, schedule SnapshotSchedule, dayOfWeek string) bool {
+	if sl.Get(current.RetentionCount, "").(string) != strconv.Itoa(schedule.Retention) ||
+		sl.Get(current.Minute, "").(string) != strconv.Itoa(schedule.Minute) {
+		return false
+	}
+
+	scheduleType := strings.ToUpper(schedule.Type)
+	if scheduleType == "HOURLY" {
+		return true
+	}
+	if sl.Get(current.Hour, "").(string) != strconv.Itoa(schedule.Hour) {
+		return false
+	}
+	if scheduleType == "DAILY" {
+		return true
+	}
+
+	currentDay := strings.ToUpper(sl.Get(current.DayOfWeek, "").(string))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.ToUpper(day.String()) == dayOfWeek {
+			return currentDay == dayOfWeek || currentDay == strconv.Itoa(int(day))
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package disk_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"errors"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	cpiLog "bosh-softlayer-cpi/logger"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	diskService "bosh-softlayer-cpi/softlayer/disk_service"
+)
+
+var _ = Describe("Disk Service SetSnapshotSchedules", func() {
+	var (
+		err error
+
+		diskID    int
+		schedules []diskService.SnapshotSchedule
+
+		cli    *fakeslclient.FakeClient
+		disk   diskService.SoftlayerDiskService
+		logger cpiLog.Logger
+	)
+	BeforeEach(func() {
+		diskID = 12345678
+		schedules = []diskService.SnapshotSchedule{
+			{Type: "daily", Retention: 7, Hour: 2},
+			{Type: "WEEKLY", Retention: 4, Minute: 30, Hour: 3, DayOfWeek: "saturday"},
+		}
+
+		cli = &fakeslclient.FakeClient{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		disk = diskService.NewSoftlayerDiskService(cli, logger)
+
+		cli.GetSnapshotSchedulesReturns([]datatypes.Network_Storage_Schedule{
+			{
+				Active:         sl.Int(1),
+				Type:           &datatypes.Network_Storage_Schedule_Type{Keyname: sl.String("SNAPSHOT_DAILY")},
+				RetentionCount: sl.String("7"),
+				Minute:         sl.String("0"),
+				Hour:           sl.String("2"),
+				DayOfWeek:      sl.String("0"),
+			},
+			{
+				Active:         sl.Int(1),
+				Type:           &datatypes.Network_Storage_Schedule_Type{Keyname: sl.String("SNAPSHOT_HOURLY")},
+				RetentionCount: sl.String("24"),
+				Minute:         sl.String("0"),
+			},
+			{
+				Active:         sl.Int(0),
+				Type:           &datatypes.Network_Storage_Schedule_Type{Keyname: sl.String("SNAPSHOT_WEEKLY")},
+				RetentionCount: sl.String("4"),
+				Minute:         sl.String("30"),
+				Hour:           sl.String("3"),
+				DayOfWeek:      sl.String("6"),
+			},
+		}, nil)
+	})
+
+	Describe("Call SetSnapshotSchedules", func() {
+		It("enables missing schedules and disables the ones of other types", func() {
+			err = disk.SetSnapshotSchedules(diskID, schedules)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cli.GetSnapshotSchedulesArgsForCall(0)).To(Equal(diskID))
+			Expect(cli.EnableSnapshotCallCount()).To(Equal(1))
+			actualID, actualType, actualRetention, actualMinute, actualHour, actualDayOfWeek := cli.EnableSnapshotArgsForCall(0)
+			Expect(actualID).To(Equal(diskID))
+			Expect(actualType).To(Equal("WEEKLY"))
+			Expect(actualRetention).To(Equal(4))
+			Expect(actualMinute).To(Equal(30))
+			Expect(actualHour).To(Equal(3))
+			Expect(actualDayOfWeek).To(Equal("SATURDAY"))
+
+			Expect(cli.DisableSnapshotsCallCount()).To(Equal(1))
+			actualID, actualType = cli.DisableSnapshotsArgsForCall(0)
+			Expect(actualID).To(Equal(diskID))
+			Expect(actualType).To(Equal("HOURLY"))
+		})
+
+		It("updates schedules that differ", func() {
+			schedules[0].Retention = 14
+
+			err = disk.SetSnapshotSchedules(diskID, schedules)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.EnableSnapshotCallCount()).To(Equal(2))
+			_, actualType, actualRetention, _, _, actualDayOfWeek := cli.EnableSnapshotArgsForCall(0)
+			Expect(actualType).To(Equal("DAILY"))
+			Expect(actualRetention).To(Equal(14))
+			Expect(actualDayOfWeek).To(Equal("SUNDAY"))
+		})
+
+		It("does nothing when the schedules are unchanged", func() {
+			cli.GetSnapshotSchedulesReturns([]datatypes.Network_Storage_Schedule{
+				{
+					Active:         sl.Int(1),
+					Type:           &datatypes.Network_Storage_Schedule_Type{Keyname: sl.String("SNAPSHOT_WEEKLY")},
+					RetentionCount: sl.String("4"),
+					Minute:         sl.String("30"),
+					Hour:           sl.String("3"),
+					DayOfWeek:      sl.String("6"),
+				},
+			}, nil)
+
+			err = disk.SetSnapshotSchedules(diskID, schedules[1:])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.EnableSnapshotCallCount()).To(Equal(0))
+			Expect(cli.DisableSnapshotsCallCount()).To(Equal(0))
+		})
+
+		It("disables all schedules if there are none", func() {
+			err = disk.SetSnapshotSchedules(diskID, []diskService.SnapshotSchedule{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.EnableSnapshotCallCount()).To(Equal(0))
+			Expect(cli.DisableSnapshotsCallCount()).To(Equal(2))
+		})
+
+		It("returns error if getting the schedules fails", func() {
+			cli.GetSnapshotSchedulesReturns(nil, errors.New("fake-client-error"))
+
+			err = disk.SetSnapshotSchedules(diskID, schedules)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Getting snapshot schedules of disk '12345678'"))
+		})
+
+		It("returns error if enabling a schedule fails", func() {
+			cli.EnableSnapshotReturns(errors.New("fake-client-error"))
+
+			err = disk.SetSnapshotSchedules(diskID, schedules)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Enabling weekly snapshots of disk '12345678'"))
+		})
+
+		It("returns error if disabling a schedule fails", func() {
+			cli.DisableSnapshotsReturns(errors.New("fake-client-error"))
+
+			err = disk.SetSnapshotSchedules(diskID, schedules)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Disabling hourly snapshots of disk '12345678'"))
+		})
+	})
+})
@@ -0,0 +1,182 @@
+package disk
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/api"
+	boslc "bosh-softlayer-cpi/softlayer/client"
+)
+
+const (
+	PerformanceDiskType = "performance"
+	EnduranceDiskType   = "endurance"
+)
+
+var (
+	diskTypesByStorageType = map[string]string{
+		"PERFORMANCE_BLOCK_STORAGE": PerformanceDiskType,
+		"ENDURANCE_BLOCK_STORAGE":   EnduranceDiskType,
+	}
+)
+
+// minModifiableStaasVersion is the first storage as a service version whose volumes can be modified,
+// legacy volumes have no version.
+const minModifiableStaasVersion = 2
+
+// volumeProperties are the properties of a live volume that can be modified in place
+type volumeProperties struct {
+	diskType      string
+	datacenter    string
+	size          int
+	iops          int
+	tier          float64
+	snapshotSpace int
+
+	// Only volumes provisioned on storage as a service version 2 or later can be modified.
+	modifiable bool
+}
+
+func (d SoftlayerDiskService) Update(id int, size int, diskType string, datacenter string, iops int, tier float64, snapshotSpace int) error {
+	d.logger.Debug(softlayerDiskServiceLogTag, "Updating disk '%d' to size '%d', type '%s', datacenter '%s', iops '%d', tier '%v', snapshot space '%d'", id, size, diskType, datacenter, iops, tier, snapshotSpace)
+	volume, err := d.findVolumeProperties(id)
+	if err != nil {
+		return err
+	}
+
+	if volume.diskType == "" {
+		d.logger.Debug(softlayerDiskServiceLogTag, "Disk '%d' is not a performance or endurance volume", id)
+		return api.NotSupportedError{}
+	}
+
+	if diskType != "" && strings.ToLower(diskType) != volume.diskType {
+		d.logger.Debug(softlayerDiskServiceLogTag, "Disk '%d' can not be changed from type '%s' to '%s'", id, volume.diskType, diskType)
+		return api.NotSupportedError{}
+	}
+
+	if datacenter != "" && !strings.EqualFold(datacenter, volume.datacenter) {
+		d.logger.Debug(softlayerDiskServiceLogTag, "Disk '%d' can not be moved from datacenter '%s' to '%s'", id, volume.datacenter, datacenter)
+		return api.NotSupportedError{}
+	}
+
+	newSize := volume.size
+	if size > 0 {
+		newSize = d.getSoftLayerDiskSize(size)
+	}
+	if newSize < volume.size {
+		d.logger.Debug(softlayerDiskServiceLogTag, "Disk '%d' can not be shrunk from size '%d' to '%d'", id, volume.size, newSize)
+		return api.NotSupportedError{}
+	}
+
+	newIops, newTier := volume.iops, volume.tier
+	switch volume.diskType {
+	case PerformanceDiskType:
+		if tier != 0 {
+			d.logger.Debug(softlayerDiskServiceLogTag, "Disk '%d' is a performance volume and has no endurance tier", id)
+			return api.NotSupportedError{}
+		}
+		if iops != 0 {
+			newIops = iops
+		}
+	case EnduranceDiskType:
+		if iops != 0 {
+			d.logger.Debug(softlayerDiskServiceLogTag, "Disk '%d' is an endurance volume and its iops are set by tier", id)
+			return api.NotSupportedError{}
+		}
+		if tier != 0 {
+			newTier = tier
+		}
+	}
+
+	modifyVolume := newSize != volume.size || newIops != volume.iops || newTier != volume.tier
+	if modifyVolume && !volume.modifiable {
+		d.logger.Debug(softlayerDiskServiceLogTag, "Disk '%d' of type '%s' in datacenter '%s' can not be modified in place", id, volume.diskType, volume.datacenter)
+		return api.NotSupportedError{}
+	}
+
+	orderSnapshotSpace := snapshotSpace != 0 && snapshotSpace != volume.snapshotSpace
+	if orderSnapshotSpace && snapshotSpace < volume.snapshotSpace {
+		d.logger.Debug(softlayerDiskServiceLogTag, "Snapshot space of disk '%d' can not be shrunk from '%d' to '%d'", id, volume.snapshotSpace, snapshotSpace)
+		return api.NotSupportedError{}
+	}
+
+	if modifyVolume {
+		err = d.softlayerClient.ModifyVolume(id, newSize, newIops, newTier)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Modifying disk '%d' to size '%d', iops '%d', tier '%v'", id, newSize, newIops, newTier)
+		}
+	}
+
+	if orderSnapshotSpace {
+		_, err = d.softlayerClient.OrderSnapshotSpace(id, volume.datacenter, snapshotSpace, newIops, newTier, volume.snapshotSpace > 0)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Ordering snapshot space '%d' for disk '%d'", snapshotSpace, id)
+		}
+
+		// A retried update_disk would order the snapshot space again while the order is pending
+		until := time.Now().Add(time.Duration(1) * time.Hour)
+		err = d.softlayerClient.WaitSnapshotSpaceCompleted(id, snapshotSpace, until)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Waiting snapshot space '%d' of disk '%d'", snapshotSpace, id)
+		}
+	}
+
+	return nil
+}
+
+func (d SoftlayerDiskService) findVolumeProperties(id int) (volumeProperties, error) {
+	volume, found, err := d.softlayerClient.GetBlockVolumeDetails(id, boslc.VOLUME_UPGRADE_MASK)
+	if err != nil {
+		return volumeProperties{}, bosherr.WrapErrorf(err, "Getting details of disk '%d'", id)
+	}
+
+	if !found {
+		return volumeProperties{}, api.NewDiskNotFoundError(strconv.Itoa(id), false)
+	}
+
+	properties := volumeProperties{size: sl.Get(volume.CapacityGb, 0).(int)}
+	if staasVersion, err := strconv.Atoi(sl.Get(volume.StaasVersion, "").(string)); err == nil {
+		properties.modifiable = staasVersion >= minModifiableStaasVersion
+	}
+
+	if volume.StorageType != nil {
+		properties.diskType = diskTypesByStorageType[sl.Get(volume.StorageType.KeyName, "").(string)]
+	}
+	if properties.diskType == "" {
+		properties.modifiable = false
+	}
+
+	if volume.ServiceResource != nil && volume.ServiceResource.Datacenter != nil {
+		properties.datacenter = sl.Get(volume.ServiceResource.Datacenter.Name, "").(string)
+	}
+
+	switch properties.diskType {
+	case PerformanceDiskType:
+		if volume.ProvisionedIops != nil {
+			properties.iops, err = strconv.Atoi(*volume.ProvisionedIops)
+			if err != nil {
+				return volumeProperties{}, bosherr.WrapErrorf(err, "Converting provisioned iops '%s' of disk '%d'", *volume.ProvisionedIops, id)
+			}
+		}
+	case EnduranceDiskType:
+		tierLevel := sl.Get(volume.StorageTierLevel, "").(string)
+		tier, ok := boslc.EnduranceTiersByStorageTierLevel[tierLevel]
+		if !ok {
+			return volumeProperties{}, bosherr.Errorf("Unknown endurance tier level '%s' of disk '%d'", tierLevel, id)
+		}
+		properties.tier = tier
+	}
+
+	if volume.SnapshotCapacityGb != nil && *volume.SnapshotCapacityGb != "" {
+		properties.snapshotSpace, err = strconv.Atoi(*volume.SnapshotCapacityGb)
+		if err != nil {
+			return volumeProperties{}, bosherr.WrapErrorf(err, "Converting snapshot capacity '%s' of disk '%d'", *volume.SnapshotCapacityGb, id)
+		}
+	}
+
+	return properties, nil
+}
@@ -0,0 +1,211 @@
+package disk_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"errors"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/api"
+	cpiLog "bosh-softlayer-cpi/logger"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	diskService "bosh-softlayer-cpi/softlayer/disk_service"
+)
+
+var _ = Describe("Disk Service Update", func() {
+	var (
+		err error
+
+		diskID int
+		volume *datatypes.Network_Storage
+
+		cli    *fakeslclient.FakeClient
+		disk   diskService.SoftlayerDiskService
+		logger cpiLog.Logger
+	)
+	BeforeEach(func() {
+		diskID = 12345678
+		volume = &datatypes.Network_Storage{
+			Id:                 sl.Int(diskID),
+			CapacityGb:         sl.Int(250),
+			ProvisionedIops:    sl.String("3000"),
+			SnapshotCapacityGb: sl.String("20"),
+			StaasVersion:       sl.String("2"),
+			StorageType: &datatypes.Network_Storage_Type{
+				KeyName: sl.String("PERFORMANCE_BLOCK_STORAGE"),
+			},
+			ServiceResource: &datatypes.Network_Service_Resource{
+				Datacenter: &datatypes.Location{
+					Name: sl.String("dal10"),
+				},
+			},
+		}
+
+		cli = &fakeslclient.FakeClient{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		disk = diskService.NewSoftlayerDiskService(cli, logger)
+
+		cli.GetBlockVolumeDetailsReturns(volume, true, nil)
+	})
+
+	Describe("Call Update", func() {
+		It("does nothing when properties are unchanged", func() {
+			err = disk.Update(diskID, 250*1024, "performance", "dal10", 3000, 0, 20)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(0))
+			Expect(cli.OrderSnapshotSpaceCallCount()).To(Equal(0))
+		})
+
+		It("modifies iops of performance volume successfully", func() {
+			err = disk.Update(diskID, 250*1024, "", "", 5000, 0, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(1))
+			actualID, actualSize, actualIops, actualTier := cli.ModifyVolumeArgsForCall(0)
+			Expect(actualID).To(Equal(diskID))
+			Expect(actualSize).To(Equal(250))
+			Expect(actualIops).To(Equal(5000))
+			Expect(actualTier).To(Equal(float64(0)))
+			Expect(cli.OrderSnapshotSpaceCallCount()).To(Equal(0))
+		})
+
+		It("modifies tier of endurance volume successfully", func() {
+			volume.StorageType.KeyName = sl.String("ENDURANCE_BLOCK_STORAGE")
+			volume.StorageTierLevel = sl.String("READHEAVY_TIER")
+
+			err = disk.Update(diskID, 250*1024, "endurance", "", 0, 10, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(1))
+			_, actualSize, actualIops, actualTier := cli.ModifyVolumeArgsForCall(0)
+			Expect(actualSize).To(Equal(250))
+			Expect(actualIops).To(Equal(0))
+			Expect(actualTier).To(Equal(float64(10)))
+		})
+
+		It("upgrades snapshot space successfully", func() {
+			err = disk.Update(diskID, 250*1024, "", "", 0, 0, 40)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(0))
+			Expect(cli.OrderSnapshotSpaceCallCount()).To(Equal(1))
+			actualID, actualLocation, actualSize, actualIops, actualTier, actualUpgrade := cli.OrderSnapshotSpaceArgsForCall(0)
+			Expect(actualID).To(Equal(diskID))
+			Expect(actualLocation).To(Equal("dal10"))
+			Expect(actualSize).To(Equal(40))
+			Expect(actualIops).To(Equal(3000))
+			Expect(actualTier).To(Equal(float64(0)))
+			Expect(actualUpgrade).To(BeTrue())
+			Expect(cli.WaitSnapshotSpaceCompletedCallCount()).To(Equal(1))
+			actualID, actualSize, _ = cli.WaitSnapshotSpaceCompletedArgsForCall(0)
+			Expect(actualID).To(Equal(diskID))
+			Expect(actualSize).To(Equal(40))
+		})
+
+		It("orders snapshot space when volume has none", func() {
+			volume.SnapshotCapacityGb = nil
+
+			err = disk.Update(diskID, 250*1024, "", "", 0, 0, 20)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.OrderSnapshotSpaceCallCount()).To(Equal(1))
+			_, _, _, _, _, actualUpgrade := cli.OrderSnapshotSpaceArgsForCall(0)
+			Expect(actualUpgrade).To(BeFalse())
+		})
+
+		It("return not supported error when type changes", func() {
+			err = disk.Update(diskID, 250*1024, "endurance", "", 0, 2, 0)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(0))
+		})
+
+		It("return not supported error when datacenter changes", func() {
+			err = disk.Update(diskID, 250*1024, "", "dal12", 0, 0, 0)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(0))
+			Expect(cli.OrderSnapshotSpaceCallCount()).To(Equal(0))
+		})
+
+		It("return not supported error when tier is set on performance volume", func() {
+			err = disk.Update(diskID, 250*1024, "", "", 0, 2, 0)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+		})
+
+		It("return not supported error when iops are set on endurance volume", func() {
+			volume.StorageType.KeyName = sl.String("ENDURANCE_BLOCK_STORAGE")
+			volume.StorageTierLevel = sl.String("READHEAVY_TIER")
+
+			err = disk.Update(diskID, 250*1024, "", "", 1000, 0, 0)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+		})
+
+		It("return not supported error when volume would be shrunk", func() {
+			err = disk.Update(diskID, 50*1024, "", "", 0, 0, 0)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+		})
+
+		It("return not supported error when snapshot space would be shrunk", func() {
+			err = disk.Update(diskID, 250*1024, "", "", 5000, 0, 10)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(0))
+			Expect(cli.OrderSnapshotSpaceCallCount()).To(Equal(0))
+		})
+
+		It("return not supported error when volume on the first storage platform can not be modified", func() {
+			volume.StaasVersion = sl.String("1")
+
+			err = disk.Update(diskID, 250*1024, "", "", 5000, 0, 0)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+		})
+
+		It("return not supported error when legacy volume has no storage platform version", func() {
+			volume.StaasVersion = nil
+
+			err = disk.Update(diskID, 250*1024, "", "", 5000, 0, 0)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+			Expect(cli.ModifyVolumeCallCount()).To(Equal(0))
+		})
+
+		It("return not supported error when storage type is unknown", func() {
+			volume.StorageType.KeyName = sl.String("NAS_STORAGE")
+
+			err = disk.Update(diskID, 250*1024, "", "", 0, 0, 0)
+			Expect(err).To(Equal(api.NotSupportedError{}))
+		})
+
+		It("return error when softlayerClient GetBlockVolumeDetails call return error", func() {
+			cli.GetBlockVolumeDetailsReturns(&datatypes.Network_Storage{}, false, errors.New("fake-client-error"))
+
+			err = disk.Update(diskID, 250*1024, "", "", 5000, 0, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+
+		It("return error when softlayerClient ModifyVolume call return error", func() {
+			cli.ModifyVolumeReturns(errors.New("fake-client-error"))
+
+			err = disk.Update(diskID, 250*1024, "", "", 5000, 0, 40)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+			Expect(cli.OrderSnapshotSpaceCallCount()).To(Equal(0))
+		})
+
+		It("return error when snapshot space upgrade does not complete", func() {
+			cli.WaitSnapshotSpaceCompletedReturns(errors.New("fake-client-error"))
+
+			err = disk.Update(diskID, 250*1024, "", "", 0, 0, 40)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+			Expect(cli.OrderSnapshotSpaceCallCount()).To(Equal(1))
+		})
+
+		It("return error when softlayerClient OrderSnapshotSpace call return error", func() {
+			cli.OrderSnapshotSpaceReturns(&datatypes.Container_Product_Order_Receipt{}, errors.New("fake-client-error"))
+
+			err = disk.Update(diskID, 250*1024, "", "", 0, 0, 40)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+			Expect(cli.WaitSnapshotSpaceCompletedCallCount()).To(Equal(0))
+		})
+	})
+})
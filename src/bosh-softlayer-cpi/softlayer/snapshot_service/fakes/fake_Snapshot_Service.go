@@ -7,11 +7,11 @@ import (
 )
 
 type FakeService struct {
-	CreateStub        func(diskID int, description string) (int, error)
+	CreateStub        func(diskID int, metadata snapshot.Metadata) (int, error)
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
-		diskID      int
-		description string
+		diskID   int
+		metadata snapshot.Metadata
 	}
 	createReturns struct {
 		result1 int
@@ -32,21 +32,34 @@ type FakeService struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ListStub        func(diskID int) ([]snapshot.Snapshot, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+		diskID int
+	}
+	listReturns struct {
+		result1 []snapshot.Snapshot
+		result2 error
+	}
+	listReturnsOnCall map[int]struct {
+		result1 []snapshot.Snapshot
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeService) Create(diskID int, description string) (int, error) {
+func (fake *FakeService) Create(diskID int, metadata snapshot.Metadata) (int, error) {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
-		diskID      int
-		description string
-	}{diskID, description})
-	fake.recordInvocation("Create", []interface{}{diskID, description})
+		diskID   int
+		metadata snapshot.Metadata
+	}{diskID, metadata})
+	fake.recordInvocation("Create", []interface{}{diskID, metadata})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(diskID, description)
+		return fake.CreateStub(diskID, metadata)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -60,10 +73,10 @@ func (fake *FakeService) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *FakeService) CreateArgsForCall(i int) (int, string) {
+func (fake *FakeService) CreateArgsForCall(i int) (int, snapshot.Metadata) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].diskID, fake.createArgsForCall[i].description
+	return fake.createArgsForCall[i].diskID, fake.createArgsForCall[i].metadata
 }
 
 func (fake *FakeService) CreateReturns(result1 int, result2 error) {
@@ -136,6 +149,57 @@ func (fake *FakeService) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeService) List(diskID int) ([]snapshot.Snapshot, error) {
+	fake.listMutex.Lock()
+	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+		diskID int
+	}{diskID})
+	fake.recordInvocation("List", []interface{}{diskID})
+	fake.listMutex.Unlock()
+	if fake.ListStub != nil {
+		return fake.ListStub(diskID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listReturns.result1, fake.listReturns.result2
+}
+
+func (fake *FakeService) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakeService) ListArgsForCall(i int) int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return fake.listArgsForCall[i].diskID
+}
+
+func (fake *FakeService) ListReturns(result1 []snapshot.Snapshot, result2 error) {
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 []snapshot.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) ListReturnsOnCall(i int, result1 []snapshot.Snapshot, result2 error) {
+	fake.ListStub = nil
+	if fake.listReturnsOnCall == nil {
+		fake.listReturnsOnCall = make(map[int]struct {
+			result1 []snapshot.Snapshot
+			result2 error
+		})
+	}
+	fake.listReturnsOnCall[i] = struct {
+		result1 []snapshot.Snapshot
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -143,6 +207,8 @@ func (fake *FakeService) Invocations() map[string][][]interface{} {
 	defer fake.createMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -1,7 +1,28 @@
 package snapshot
 
+import "time"
+
 //go:generate counterfeiter -o fakes/fake_Snapshot_Service.go . Service
 type Service interface {
-	Create(diskID int, description string) (int, error)
+	Create(diskID int, metadata Metadata) (int, error)
 	Delete(id int) error
+	List(diskID int) ([]Snapshot, error)
+}
+
+// Metadata is the director metadata of a snapshot, recorded in its notes.
+type Metadata struct {
+	Deployment string
+	Job        string
+	Index      string
+	AgentID    string
+	CreatedAt  time.Time
+}
+
+// Snapshot is a snapshot of a disk, ManagedByBOSH if the CPI created it.
+type Snapshot struct {
+	ID            int
+	CreatedAt     time.Time
+	ManagedByBOSH bool
+	Metadata      Metadata
+	Notes         string
 }
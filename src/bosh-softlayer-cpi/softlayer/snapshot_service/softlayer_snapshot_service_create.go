@@ -4,10 +4,8 @@ import (
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 )
 
-func (s SoftlayerSnapshotService) Create(diskID int, note string) (int, error) {
-	if note == "" {
-		note = softlayerSnapshotDescription
-	}
+func (s SoftlayerSnapshotService) Create(diskID int, metadata Metadata) (int, error) {
+	note := generateNotesFromMetadata(metadata)
 
 	s.logger.Debug(softlayerSnapshotServiceLogTag, "Creating Softlayer Snapshot with note: %s", note)
 	snapshot, err := s.softlayerClient.CreateSnapshot(diskID, note)
@@ -2,6 +2,7 @@ package snapshot_test
 
 import (
 	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -45,9 +46,11 @@ var _ = Describe("Snapshot Service", func() {
 				nil,
 			)
 
-			_, err := snapshotService.Create(diskID, "")
+			_, err := snapshotService.Create(diskID, Metadata{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(cli.CreateSnapshotCallCount()).To(Equal(1))
+			_, actualNote := cli.CreateSnapshotArgsForCall(0)
+			Expect(actualNote).To(Equal("Snapshot_managed_by_BOSH"))
 		})
 
 		It("Create successfully", func() {
@@ -58,9 +61,17 @@ var _ = Describe("Snapshot Service", func() {
 				nil,
 			)
 
-			_, err := snapshotService.Create(diskID, "note-string")
+			_, err := snapshotService.Create(diskID, Metadata{
+				Deployment: "fake-deployment",
+				Job:        "fake-job",
+				Index:      "0",
+				AgentID:    "fake-agent-id",
+				CreatedAt:  time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC),
+			})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(cli.CreateSnapshotCallCount()).To(Equal(1))
+			_, actualNote := cli.CreateSnapshotArgsForCall(0)
+			Expect(actualNote).To(Equal("Snapshot_managed_by_BOSH,deployment:fake-deployment,job:fake-job,index:0,agent_id:fake-agent-id,created_at:2026-10-17T02:00:00Z"))
 		})
 
 		It("Return error if softLayerClient CreateSnapshot call returns an error", func() {
@@ -69,7 +80,7 @@ var _ = Describe("Snapshot Service", func() {
 				errors.New("fake-client-error"),
 			)
 
-			_, err := snapshotService.Create(diskID, "")
+			_, err := snapshotService.Create(diskID, Metadata{})
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
 			Expect(cli.CreateSnapshotCallCount()).To(Equal(1))
@@ -0,0 +1,92 @@
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func (s SoftlayerSnapshotService) List(diskID int) ([]Snapshot, error) {
+	volumes, err := s.softlayerClient.GetSnapshots(diskID)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Listing snapshots of disk '%d'", diskID)
+	}
+
+	snapshots := []Snapshot{}
+	for _, volume := range volumes {
+		snapshot := Snapshot{ID: sl.Get(volume.Id, 0).(int), Notes: sl.Get(volume.Notes, "").(string)}
+		snapshot.Metadata, snapshot.ManagedByBOSH = parseMetadataFromNotes(snapshot.Notes)
+
+		snapshot.CreatedAt = snapshot.Metadata.CreatedAt
+		if snapshot.CreatedAt.IsZero() {
+			// Snapshots of other CPI versions only have the timestamp of SoftLayer
+			snapshot.CreatedAt, _ = time.Parse(time.RFC3339, sl.Get(volume.SnapshotCreationTimestamp, "").(string))
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// generateNotesFromMetadata returns the notes of a snapshot, the description of snapshots managed
+// by BOSH followed by the metadata that is set.
+func generateNotesFromMetadata(metadata Metadata) string {
+	entries := []string{softlayerSnapshotDescription}
+	for _, entry := range [][2]string{
+		{"deployment", metadata.Deployment},
+		{"job", metadata.Job},
+		{"index", metadata.Index},
+		{"agent_id", metadata.AgentID},
+	} {
+		if entry[1] != "" {
+			entries = append(entries, entry[0]+":"+entry[1])
+		}
+	}
+	if !metadata.CreatedAt.IsZero() {
+		entries = append(entries, "created_at:"+metadata.CreatedAt.UTC().Format(time.RFC3339))
+	}
+
+	return strings.Join(entries, ",")
+}
+
+// LegacyNotes returns the notes CPI versions before the metadata notes gave the snapshots of the
+// instance of metadata, "<deployment>_<job>_<index>". Snapshots without metadata were described only.
+func LegacyNotes(metadata Metadata) string {
+	if metadata.Deployment == "" || metadata.Job == "" || metadata.Index == "" {
+		return softlayerSnapshotDescription
+	}
+	return fmt.Sprintf("%s_%s_%s", metadata.Deployment, metadata.Job, metadata.Index)
+}
+
+func parseMetadataFromNotes(notes string) (Metadata, bool) {
+	entries := strings.Split(notes, ",")
+	if entries[0] != softlayerSnapshotDescription {
+		return Metadata{}, false
+	}
+
+	var metadata Metadata
+	for _, entry := range entries[1:] {
+		keyValue := strings.SplitN(entry, ":", 2)
+		if len(keyValue) != 2 {
+			continue
+		}
+		switch keyValue[0] {
+		case "deployment":
+			metadata.Deployment = keyValue[1]
+		case "job":
+			metadata.Job = keyValue[1]
+		case "index":
+			metadata.Index = keyValue[1]
+		case "agent_id":
+			metadata.AgentID = keyValue[1]
+		case "created_at":
+			metadata.CreatedAt, _ = time.Parse(time.RFC3339, keyValue[1])
+		}
+	}
+
+	return metadata, true
+}
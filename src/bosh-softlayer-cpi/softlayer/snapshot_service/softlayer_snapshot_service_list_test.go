@@ -0,0 +1,97 @@
+package snapshot_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	cpiLog "bosh-softlayer-cpi/logger"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	. "bosh-softlayer-cpi/softlayer/snapshot_service"
+)
+
+var _ = Describe("Snapshot Service List", func() {
+	var (
+		cli             *fakeslclient.FakeClient
+		logger          cpiLog.Logger
+		snapshotService SoftlayerSnapshotService
+	)
+
+	BeforeEach(func() {
+		cli = &fakeslclient.FakeClient{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		snapshotService = NewSoftlayerSnapshotService(cli, logger)
+	})
+
+	Describe("Call List", func() {
+		It("List successfully", func() {
+			cli.GetSnapshotsReturns([]datatypes.Network_Storage{
+				{
+					Id:                        sl.Int(2345),
+					Notes:                     sl.String("Snapshot_managed_by_BOSH,deployment:fake-deployment,job:fake-job,index:0,agent_id:fake-agent-id,created_at:2026-10-17T02:00:00Z"),
+					SnapshotCreationTimestamp: sl.String("2026-10-16T21:00:05-05:00"),
+				},
+				{
+					Id:                        sl.Int(2346),
+					Notes:                     sl.String("Snapshot_managed_by_BOSH"),
+					SnapshotCreationTimestamp: sl.String("2026-10-15T21:00:05-05:00"),
+				},
+				{
+					Id:                        sl.Int(2347),
+					SnapshotCreationTimestamp: sl.String("2026-10-14T21:00:05-05:00"),
+				},
+			}, nil)
+
+			snapshots, err := snapshotService.List(12345678)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.GetSnapshotsArgsForCall(0)).To(Equal(12345678))
+			Expect(snapshots).To(HaveLen(3))
+
+			Expect(snapshots[0].ID).To(Equal(2345))
+			Expect(snapshots[0].ManagedByBOSH).To(BeTrue())
+			Expect(snapshots[0].Metadata).To(Equal(Metadata{
+				Deployment: "fake-deployment",
+				Job:        "fake-job",
+				Index:      "0",
+				AgentID:    "fake-agent-id",
+				CreatedAt:  time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC),
+			}))
+			Expect(snapshots[0].CreatedAt).To(Equal(snapshots[0].Metadata.CreatedAt))
+
+			Expect(snapshots[1].ManagedByBOSH).To(BeTrue())
+			Expect(snapshots[1].CreatedAt.Equal(time.Date(2026, 10, 16, 2, 0, 5, 0, time.UTC))).To(BeTrue())
+
+			Expect(snapshots[2].ManagedByBOSH).To(BeFalse())
+		})
+
+		It("Keeps the notes of snapshots of older CPI versions", func() {
+			cli.GetSnapshotsReturns([]datatypes.Network_Storage{
+				{
+					Id:                        sl.Int(2345),
+					Notes:                     sl.String("fake-deployment_fake-job_0"),
+					SnapshotCreationTimestamp: sl.String("2026-10-16T21:00:05-05:00"),
+				},
+			}, nil)
+
+			snapshots, err := snapshotService.List(12345678)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots[0].ManagedByBOSH).To(BeFalse())
+			Expect(snapshots[0].Notes).To(Equal(LegacyNotes(Metadata{Deployment: "fake-deployment", Job: "fake-job", Index: "0"})))
+			Expect(snapshots[0].CreatedAt.Equal(time.Date(2026, 10, 17, 2, 0, 5, 0, time.UTC))).To(BeTrue())
+		})
+
+		It("Return error if softLayerClient GetSnapshots call returns an error", func() {
+			cli.GetSnapshotsReturns(nil, errors.New("fake-client-error"))
+
+			_, err := snapshotService.List(12345678)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package fakes
 
 import (
 	"bosh-softlayer-cpi/registry"
+	"bosh-softlayer-cpi/softlayer/client"
 	instance "bosh-softlayer-cpi/softlayer/virtual_guest_service"
 	"sync"
 
@@ -49,7 +50,7 @@ type FakeService struct {
 	attachEphemeralDiskReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CreateStub        func(virtualGuest *datatypes.Virtual_Guest, enableVps bool, stemcellID int, sshKeys []int, userData *registry.SoftlayerUserData) (int, error)
+	CreateStub        func(virtualGuest *datatypes.Virtual_Guest, enableVps bool, stemcellID int, sshKeys []int, userData *registry.SoftlayerUserData, options client.InstanceOrderOptions) (int, error)
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
 		virtualGuest *datatypes.Virtual_Guest
@@ -57,6 +58,7 @@ type FakeService struct {
 		stemcellID   int
 		sshKeys      []int
 		userData     *registry.SoftlayerUserData
+		options      client.InstanceOrderOptions
 	}
 	createReturns struct {
 		result1 int
@@ -107,21 +109,6 @@ type FakeService struct {
 	cleanUpReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CreateSshKeyStub        func(label string, key string, fingerPrint string) (int, error)
-	createSshKeyMutex       sync.RWMutex
-	createSshKeyArgsForCall []struct {
-		label       string
-		key         string
-		fingerPrint string
-	}
-	createSshKeyReturns struct {
-		result1 int
-		result2 error
-	}
-	createSshKeyReturnsOnCall map[int]struct {
-		result1 int
-		result2 error
-	}
 	DeleteStub        func(id int, enableVps bool) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -247,6 +234,33 @@ type FakeService struct {
 	rebootReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RecordNetworksStub        func(id int, record instance.NetworksRecord) error
+	recordNetworksMutex       sync.RWMutex
+	recordNetworksArgsForCall []struct {
+		id     int
+		record instance.NetworksRecord
+	}
+	recordNetworksReturns struct {
+		result1 error
+	}
+	recordNetworksReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RecordedNetworksStub        func(id int) (instance.NetworksRecord, bool, error)
+	recordedNetworksMutex       sync.RWMutex
+	recordedNetworksArgsForCall []struct {
+		id int
+	}
+	recordedNetworksReturns struct {
+		result1 instance.NetworksRecord
+		result2 bool
+		result3 error
+	}
+	recordedNetworksReturnsOnCall map[int]struct {
+		result1 instance.NetworksRecord
+		result2 bool
+		result3 error
+	}
 	ReloadOSStub        func(id int, stemcellID int, sshKeyIds []int, hostname string, domain string, userData *registry.SoftlayerUserData) error
 	reloadOSMutex       sync.RWMutex
 	reloadOSArgsForCall []struct {
@@ -287,6 +301,129 @@ type FakeService struct {
 	updateInstanceUserDataReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CalculateResourcesStub        func(cpu int, memory int, ephemeralDiskSize int) (instance.Resources, error)
+	calculateResourcesMutex       sync.RWMutex
+	calculateResourcesArgsForCall []struct {
+		cpu               int
+		memory            int
+		ephemeralDiskSize int
+	}
+	calculateResourcesReturns struct {
+		result1 instance.Resources
+		result2 error
+	}
+	calculateResourcesReturnsOnCall map[int]struct {
+		result1 instance.Resources
+		result2 error
+	}
+	AddLoadBalancerMemberStub        func(id int, member instance.LoadBalancerMember) error
+	addLoadBalancerMemberMutex       sync.RWMutex
+	addLoadBalancerMemberArgsForCall []struct {
+		id     int
+		member instance.LoadBalancerMember
+	}
+	addLoadBalancerMemberReturns struct {
+		result1 error
+	}
+	addLoadBalancerMemberReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ConfigureSecurityGroupsStub        func(id int, groups instance.SecurityGroups, detachOthers bool) error
+	configureSecurityGroupsMutex       sync.RWMutex
+	configureSecurityGroupsArgsForCall []struct {
+		id           int
+		groups       instance.SecurityGroups
+		detachOthers bool
+	}
+	configureSecurityGroupsReturns struct {
+		result1 error
+	}
+	configureSecurityGroupsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FindSecurityGroupsStub        func(refs []string) ([]int, error)
+	findSecurityGroupsMutex       sync.RWMutex
+	findSecurityGroupsArgsForCall []struct {
+		refs []string
+	}
+	findSecurityGroupsReturns struct {
+		result1 []int
+		result2 error
+	}
+	findSecurityGroupsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
+	SelectDedicatedHostStub        func(placement instance.DedicatedHostPlacement) (int, error)
+	selectDedicatedHostMutex       sync.RWMutex
+	selectDedicatedHostArgsForCall []struct {
+		placement instance.DedicatedHostPlacement
+	}
+	selectDedicatedHostReturns struct {
+		result1 int
+		result2 error
+	}
+	selectDedicatedHostReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	FindPlacementGroupStub        func(ref string) (int, error)
+	findPlacementGroupMutex       sync.RWMutex
+	findPlacementGroupArgsForCall []struct {
+		ref string
+	}
+	findPlacementGroupReturns struct {
+		result1 int
+		result2 error
+	}
+	findPlacementGroupReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	EnsurePlacementGroupStub        func(name string, backendVlanID int) (int, error)
+	ensurePlacementGroupMutex       sync.RWMutex
+	ensurePlacementGroupArgsForCall []struct {
+		name          string
+		backendVlanID int
+	}
+	ensurePlacementGroupReturns struct {
+		result1 int
+		result2 error
+	}
+	ensurePlacementGroupReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	ResolveOrderItemsStub        func(items instance.OrderItems) (instance.OrderItems, error)
+	resolveOrderItemsMutex       sync.RWMutex
+	resolveOrderItemsArgsForCall []struct {
+		items instance.OrderItems
+	}
+	resolveOrderItemsReturns struct {
+		result1 instance.OrderItems
+		result2 error
+	}
+	resolveOrderItemsReturnsOnCall map[int]struct {
+		result1 instance.OrderItems
+		result2 error
+	}
+	CreateSshKeyStub        func(label string, key string, fingerPrint string) (int, bool, error)
+	createSshKeyMutex       sync.RWMutex
+	createSshKeyArgsForCall []struct {
+		label       string
+		key         string
+		fingerPrint string
+	}
+	createSshKeyReturns struct {
+		result1 int
+		result2 bool
+		result3 error
+	}
+	createSshKeyReturnsOnCall map[int]struct {
+		result1 int
+		result2 bool
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -443,7 +580,7 @@ func (fake *FakeService) AttachEphemeralDiskReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeService) Create(virtualGuest *datatypes.Virtual_Guest, enableVps bool, stemcellID int, sshKeys []int, userData *registry.SoftlayerUserData) (int, error) {
+func (fake *FakeService) Create(virtualGuest *datatypes.Virtual_Guest, enableVps bool, stemcellID int, sshKeys []int, userData *registry.SoftlayerUserData, options client.InstanceOrderOptions) (int, error) {
 	var sshKeysCopy []int
 	if sshKeys != nil {
 		sshKeysCopy = make([]int, len(sshKeys))
@@ -457,11 +594,12 @@ func (fake *FakeService) Create(virtualGuest *datatypes.Virtual_Guest, enableVps
 		stemcellID   int
 		sshKeys      []int
 		userData     *registry.SoftlayerUserData
-	}{virtualGuest, enableVps, stemcellID, sshKeysCopy, userData})
-	fake.recordInvocation("Create", []interface{}{virtualGuest, enableVps, stemcellID, sshKeysCopy, userData})
+		options      client.InstanceOrderOptions
+	}{virtualGuest, enableVps, stemcellID, sshKeysCopy, userData, options})
+	fake.recordInvocation("Create", []interface{}{virtualGuest, enableVps, stemcellID, sshKeysCopy, userData, options})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(virtualGuest, enableVps, stemcellID, sshKeys, userData)
+		return fake.CreateStub(virtualGuest, enableVps, stemcellID, sshKeys, userData, options)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -475,10 +613,10 @@ func (fake *FakeService) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *FakeService) CreateArgsForCall(i int) (*datatypes.Virtual_Guest, bool, int, []int, *registry.SoftlayerUserData) {
+func (fake *FakeService) CreateArgsForCall(i int) (*datatypes.Virtual_Guest, bool, int, []int, *registry.SoftlayerUserData, client.InstanceOrderOptions) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].virtualGuest, fake.createArgsForCall[i].enableVps, fake.createArgsForCall[i].stemcellID, fake.createArgsForCall[i].sshKeys, fake.createArgsForCall[i].userData
+	return fake.createArgsForCall[i].virtualGuest, fake.createArgsForCall[i].enableVps, fake.createArgsForCall[i].stemcellID, fake.createArgsForCall[i].sshKeys, fake.createArgsForCall[i].userData, fake.createArgsForCall[i].options
 }
 
 func (fake *FakeService) CreateReturns(result1 int, result2 error) {
@@ -656,59 +794,6 @@ func (fake *FakeService) CleanUpReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeService) CreateSshKey(label string, key string, fingerPrint string) (int, error) {
-	fake.createSshKeyMutex.Lock()
-	ret, specificReturn := fake.createSshKeyReturnsOnCall[len(fake.createSshKeyArgsForCall)]
-	fake.createSshKeyArgsForCall = append(fake.createSshKeyArgsForCall, struct {
-		label       string
-		key         string
-		fingerPrint string
-	}{label, key, fingerPrint})
-	fake.recordInvocation("CreateSshKey", []interface{}{label, key, fingerPrint})
-	fake.createSshKeyMutex.Unlock()
-	if fake.CreateSshKeyStub != nil {
-		return fake.CreateSshKeyStub(label, key, fingerPrint)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fake.createSshKeyReturns.result1, fake.createSshKeyReturns.result2
-}
-
-func (fake *FakeService) CreateSshKeyCallCount() int {
-	fake.createSshKeyMutex.RLock()
-	defer fake.createSshKeyMutex.RUnlock()
-	return len(fake.createSshKeyArgsForCall)
-}
-
-func (fake *FakeService) CreateSshKeyArgsForCall(i int) (string, string, string) {
-	fake.createSshKeyMutex.RLock()
-	defer fake.createSshKeyMutex.RUnlock()
-	return fake.createSshKeyArgsForCall[i].label, fake.createSshKeyArgsForCall[i].key, fake.createSshKeyArgsForCall[i].fingerPrint
-}
-
-func (fake *FakeService) CreateSshKeyReturns(result1 int, result2 error) {
-	fake.CreateSshKeyStub = nil
-	fake.createSshKeyReturns = struct {
-		result1 int
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeService) CreateSshKeyReturnsOnCall(i int, result1 int, result2 error) {
-	fake.CreateSshKeyStub = nil
-	if fake.createSshKeyReturnsOnCall == nil {
-		fake.createSshKeyReturnsOnCall = make(map[int]struct {
-			result1 int
-			result2 error
-		})
-	}
-	fake.createSshKeyReturnsOnCall[i] = struct {
-		result1 int
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *FakeService) Delete(id int, enableVps bool) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -1209,6 +1294,109 @@ func (fake *FakeService) RebootReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeService) RecordNetworks(id int, record instance.NetworksRecord) error {
+	fake.recordNetworksMutex.Lock()
+	ret, specificReturn := fake.recordNetworksReturnsOnCall[len(fake.recordNetworksArgsForCall)]
+	fake.recordNetworksArgsForCall = append(fake.recordNetworksArgsForCall, struct {
+		id     int
+		record instance.NetworksRecord
+	}{id, record})
+	fake.recordInvocation("RecordNetworks", []interface{}{id, record})
+	fake.recordNetworksMutex.Unlock()
+	if fake.RecordNetworksStub != nil {
+		return fake.RecordNetworksStub(id, record)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.recordNetworksReturns.result1
+}
+
+func (fake *FakeService) RecordNetworksCallCount() int {
+	fake.recordNetworksMutex.RLock()
+	defer fake.recordNetworksMutex.RUnlock()
+	return len(fake.recordNetworksArgsForCall)
+}
+
+func (fake *FakeService) RecordNetworksArgsForCall(i int) (int, instance.NetworksRecord) {
+	fake.recordNetworksMutex.RLock()
+	defer fake.recordNetworksMutex.RUnlock()
+	return fake.recordNetworksArgsForCall[i].id, fake.recordNetworksArgsForCall[i].record
+}
+
+func (fake *FakeService) RecordNetworksReturns(result1 error) {
+	fake.RecordNetworksStub = nil
+	fake.recordNetworksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) RecordNetworksReturnsOnCall(i int, result1 error) {
+	fake.RecordNetworksStub = nil
+	if fake.recordNetworksReturnsOnCall == nil {
+		fake.recordNetworksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordNetworksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) RecordedNetworks(id int) (instance.NetworksRecord, bool, error) {
+	fake.recordedNetworksMutex.Lock()
+	ret, specificReturn := fake.recordedNetworksReturnsOnCall[len(fake.recordedNetworksArgsForCall)]
+	fake.recordedNetworksArgsForCall = append(fake.recordedNetworksArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("RecordedNetworks", []interface{}{id})
+	fake.recordedNetworksMutex.Unlock()
+	if fake.RecordedNetworksStub != nil {
+		return fake.RecordedNetworksStub(id)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.recordedNetworksReturns.result1, fake.recordedNetworksReturns.result2, fake.recordedNetworksReturns.result3
+}
+
+func (fake *FakeService) RecordedNetworksCallCount() int {
+	fake.recordedNetworksMutex.RLock()
+	defer fake.recordedNetworksMutex.RUnlock()
+	return len(fake.recordedNetworksArgsForCall)
+}
+
+func (fake *FakeService) RecordedNetworksArgsForCall(i int) int {
+	fake.recordedNetworksMutex.RLock()
+	defer fake.recordedNetworksMutex.RUnlock()
+	return fake.recordedNetworksArgsForCall[i].id
+}
+
+func (fake *FakeService) RecordedNetworksReturns(result1 instance.NetworksRecord, result2 bool, result3 error) {
+	fake.RecordedNetworksStub = nil
+	fake.recordedNetworksReturns = struct {
+		result1 instance.NetworksRecord
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeService) RecordedNetworksReturnsOnCall(i int, result1 instance.NetworksRecord, result2 bool, result3 error) {
+	fake.RecordedNetworksStub = nil
+	if fake.recordedNetworksReturnsOnCall == nil {
+		fake.recordedNetworksReturnsOnCall = make(map[int]struct {
+			result1 instance.NetworksRecord
+			result2 bool
+			result3 error
+		})
+	}
+	fake.recordedNetworksReturnsOnCall[i] = struct {
+		result1 instance.NetworksRecord
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeService) ReloadOS(id int, stemcellID int, sshKeyIds []int, hostname string, domain string, userData *registry.SoftlayerUserData) error {
 	var sshKeyIdsCopy []int
 	if sshKeyIds != nil {
@@ -1365,6 +1553,475 @@ func (fake *FakeService) UpdateInstanceUserDataReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeService) CalculateResources(cpu int, memory int, ephemeralDiskSize int) (instance.Resources, error) {
+	fake.calculateResourcesMutex.Lock()
+	ret, specificReturn := fake.calculateResourcesReturnsOnCall[len(fake.calculateResourcesArgsForCall)]
+	fake.calculateResourcesArgsForCall = append(fake.calculateResourcesArgsForCall, struct {
+		cpu               int
+		memory            int
+		ephemeralDiskSize int
+	}{cpu, memory, ephemeralDiskSize})
+	fake.recordInvocation("CalculateResources", []interface{}{cpu, memory, ephemeralDiskSize})
+	fake.calculateResourcesMutex.Unlock()
+	if fake.CalculateResourcesStub != nil {
+		return fake.CalculateResourcesStub(cpu, memory, ephemeralDiskSize)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.calculateResourcesReturns.result1, fake.calculateResourcesReturns.result2
+}
+
+func (fake *FakeService) CalculateResourcesCallCount() int {
+	fake.calculateResourcesMutex.RLock()
+	defer fake.calculateResourcesMutex.RUnlock()
+	return len(fake.calculateResourcesArgsForCall)
+}
+
+func (fake *FakeService) CalculateResourcesArgsForCall(i int) (int, int, int) {
+	fake.calculateResourcesMutex.RLock()
+	defer fake.calculateResourcesMutex.RUnlock()
+	return fake.calculateResourcesArgsForCall[i].cpu, fake.calculateResourcesArgsForCall[i].memory, fake.calculateResourcesArgsForCall[i].ephemeralDiskSize
+}
+
+func (fake *FakeService) CalculateResourcesReturns(result1 instance.Resources, result2 error) {
+	fake.CalculateResourcesStub = nil
+	fake.calculateResourcesReturns = struct {
+		result1 instance.Resources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) CalculateResourcesReturnsOnCall(i int, result1 instance.Resources, result2 error) {
+	fake.CalculateResourcesStub = nil
+	if fake.calculateResourcesReturnsOnCall == nil {
+		fake.calculateResourcesReturnsOnCall = make(map[int]struct {
+			result1 instance.Resources
+			result2 error
+		})
+	}
+	fake.calculateResourcesReturnsOnCall[i] = struct {
+		result1 instance.Resources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) AddLoadBalancerMember(id int, member instance.LoadBalancerMember) error {
+	fake.addLoadBalancerMemberMutex.Lock()
+	ret, specificReturn := fake.addLoadBalancerMemberReturnsOnCall[len(fake.addLoadBalancerMemberArgsForCall)]
+	fake.addLoadBalancerMemberArgsForCall = append(fake.addLoadBalancerMemberArgsForCall, struct {
+		id     int
+		member instance.LoadBalancerMember
+	}{id, member})
+	fake.recordInvocation("AddLoadBalancerMember", []interface{}{id, member})
+	fake.addLoadBalancerMemberMutex.Unlock()
+	if fake.AddLoadBalancerMemberStub != nil {
+		return fake.AddLoadBalancerMemberStub(id, member)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.addLoadBalancerMemberReturns.result1
+}
+
+func (fake *FakeService) AddLoadBalancerMemberCallCount() int {
+	fake.addLoadBalancerMemberMutex.RLock()
+	defer fake.addLoadBalancerMemberMutex.RUnlock()
+	return len(fake.addLoadBalancerMemberArgsForCall)
+}
+
+func (fake *FakeService) AddLoadBalancerMemberArgsForCall(i int) (int, instance.LoadBalancerMember) {
+	fake.addLoadBalancerMemberMutex.RLock()
+	defer fake.addLoadBalancerMemberMutex.RUnlock()
+	return fake.addLoadBalancerMemberArgsForCall[i].id, fake.addLoadBalancerMemberArgsForCall[i].member
+}
+
+func (fake *FakeService) AddLoadBalancerMemberReturns(result1 error) {
+	fake.AddLoadBalancerMemberStub = nil
+	fake.addLoadBalancerMemberReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) AddLoadBalancerMemberReturnsOnCall(i int, result1 error) {
+	fake.AddLoadBalancerMemberStub = nil
+	if fake.addLoadBalancerMemberReturnsOnCall == nil {
+		fake.addLoadBalancerMemberReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addLoadBalancerMemberReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) ConfigureSecurityGroups(id int, groups instance.SecurityGroups, detachOthers bool) error {
+	fake.configureSecurityGroupsMutex.Lock()
+	ret, specificReturn := fake.configureSecurityGroupsReturnsOnCall[len(fake.configureSecurityGroupsArgsForCall)]
+	fake.configureSecurityGroupsArgsForCall = append(fake.configureSecurityGroupsArgsForCall, struct {
+		id           int
+		groups       instance.SecurityGroups
+		detachOthers bool
+	}{id, groups, detachOthers})
+	fake.recordInvocation("ConfigureSecurityGroups", []interface{}{id, groups, detachOthers})
+	fake.configureSecurityGroupsMutex.Unlock()
+	if fake.ConfigureSecurityGroupsStub != nil {
+		return fake.ConfigureSecurityGroupsStub(id, groups, detachOthers)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.configureSecurityGroupsReturns.result1
+}
+
+func (fake *FakeService) ConfigureSecurityGroupsCallCount() int {
+	fake.configureSecurityGroupsMutex.RLock()
+	defer fake.configureSecurityGroupsMutex.RUnlock()
+	return len(fake.configureSecurityGroupsArgsForCall)
+}
+
+func (fake *FakeService) ConfigureSecurityGroupsArgsForCall(i int) (int, instance.SecurityGroups, bool) {
+	fake.configureSecurityGroupsMutex.RLock()
+	defer fake.configureSecurityGroupsMutex.RUnlock()
+	return fake.configureSecurityGroupsArgsForCall[i].id, fake.configureSecurityGroupsArgsForCall[i].groups, fake.configureSecurityGroupsArgsForCall[i].detachOthers
+}
+
+func (fake *FakeService) ConfigureSecurityGroupsReturns(result1 error) {
+	fake.ConfigureSecurityGroupsStub = nil
+	fake.configureSecurityGroupsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) ConfigureSecurityGroupsReturnsOnCall(i int, result1 error) {
+	fake.ConfigureSecurityGroupsStub = nil
+	if fake.configureSecurityGroupsReturnsOnCall == nil {
+		fake.configureSecurityGroupsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.configureSecurityGroupsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) FindSecurityGroups(refs []string) ([]int, error) {
+	var refsCopy []string
+	if refs != nil {
+		refsCopy = make([]string, len(refs))
+		copy(refsCopy, refs)
+	}
+	fake.findSecurityGroupsMutex.Lock()
+	ret, specificReturn := fake.findSecurityGroupsReturnsOnCall[len(fake.findSecurityGroupsArgsForCall)]
+	fake.findSecurityGroupsArgsForCall = append(fake.findSecurityGroupsArgsForCall, struct {
+		refs []string
+	}{refsCopy})
+	fake.recordInvocation("FindSecurityGroups", []interface{}{refsCopy})
+	fake.findSecurityGroupsMutex.Unlock()
+	if fake.FindSecurityGroupsStub != nil {
+		return fake.FindSecurityGroupsStub(refs)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findSecurityGroupsReturns.result1, fake.findSecurityGroupsReturns.result2
+}
+
+func (fake *FakeService) FindSecurityGroupsCallCount() int {
+	fake.findSecurityGroupsMutex.RLock()
+	defer fake.findSecurityGroupsMutex.RUnlock()
+	return len(fake.findSecurityGroupsArgsForCall)
+}
+
+func (fake *FakeService) FindSecurityGroupsArgsForCall(i int) []string {
+	fake.findSecurityGroupsMutex.RLock()
+	defer fake.findSecurityGroupsMutex.RUnlock()
+	return fake.findSecurityGroupsArgsForCall[i].refs
+}
+
+func (fake *FakeService) FindSecurityGroupsReturns(result1 []int, result2 error) {
+	fake.FindSecurityGroupsStub = nil
+	fake.findSecurityGroupsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) FindSecurityGroupsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.FindSecurityGroupsStub = nil
+	if fake.findSecurityGroupsReturnsOnCall == nil {
+		fake.findSecurityGroupsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.findSecurityGroupsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) SelectDedicatedHost(placement instance.DedicatedHostPlacement) (int, error) {
+	fake.selectDedicatedHostMutex.Lock()
+	ret, specificReturn := fake.selectDedicatedHostReturnsOnCall[len(fake.selectDedicatedHostArgsForCall)]
+	fake.selectDedicatedHostArgsForCall = append(fake.selectDedicatedHostArgsForCall, struct {
+		placement instance.DedicatedHostPlacement
+	}{placement})
+	fake.recordInvocation("SelectDedicatedHost", []interface{}{placement})
+	fake.selectDedicatedHostMutex.Unlock()
+	if fake.SelectDedicatedHostStub != nil {
+		return fake.SelectDedicatedHostStub(placement)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.selectDedicatedHostReturns.result1, fake.selectDedicatedHostReturns.result2
+}
+
+func (fake *FakeService) SelectDedicatedHostCallCount() int {
+	fake.selectDedicatedHostMutex.RLock()
+	defer fake.selectDedicatedHostMutex.RUnlock()
+	return len(fake.selectDedicatedHostArgsForCall)
+}
+
+func (fake *FakeService) SelectDedicatedHostArgsForCall(i int) instance.DedicatedHostPlacement {
+	fake.selectDedicatedHostMutex.RLock()
+	defer fake.selectDedicatedHostMutex.RUnlock()
+	return fake.selectDedicatedHostArgsForCall[i].placement
+}
+
+func (fake *FakeService) SelectDedicatedHostReturns(result1 int, result2 error) {
+	fake.SelectDedicatedHostStub = nil
+	fake.selectDedicatedHostReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) SelectDedicatedHostReturnsOnCall(i int, result1 int, result2 error) {
+	fake.SelectDedicatedHostStub = nil
+	if fake.selectDedicatedHostReturnsOnCall == nil {
+		fake.selectDedicatedHostReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.selectDedicatedHostReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) FindPlacementGroup(ref string) (int, error) {
+	fake.findPlacementGroupMutex.Lock()
+	ret, specificReturn := fake.findPlacementGroupReturnsOnCall[len(fake.findPlacementGroupArgsForCall)]
+	fake.findPlacementGroupArgsForCall = append(fake.findPlacementGroupArgsForCall, struct {
+		ref string
+	}{ref})
+	fake.recordInvocation("FindPlacementGroup", []interface{}{ref})
+	fake.findPlacementGroupMutex.Unlock()
+	if fake.FindPlacementGroupStub != nil {
+		return fake.FindPlacementGroupStub(ref)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findPlacementGroupReturns.result1, fake.findPlacementGroupReturns.result2
+}
+
+func (fake *FakeService) FindPlacementGroupCallCount() int {
+	fake.findPlacementGroupMutex.RLock()
+	defer fake.findPlacementGroupMutex.RUnlock()
+	return len(fake.findPlacementGroupArgsForCall)
+}
+
+func (fake *FakeService) FindPlacementGroupArgsForCall(i int) string {
+	fake.findPlacementGroupMutex.RLock()
+	defer fake.findPlacementGroupMutex.RUnlock()
+	return fake.findPlacementGroupArgsForCall[i].ref
+}
+
+func (fake *FakeService) FindPlacementGroupReturns(result1 int, result2 error) {
+	fake.FindPlacementGroupStub = nil
+	fake.findPlacementGroupReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) FindPlacementGroupReturnsOnCall(i int, result1 int, result2 error) {
+	fake.FindPlacementGroupStub = nil
+	if fake.findPlacementGroupReturnsOnCall == nil {
+		fake.findPlacementGroupReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.findPlacementGroupReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) EnsurePlacementGroup(name string, backendVlanID int) (int, error) {
+	fake.ensurePlacementGroupMutex.Lock()
+	ret, specificReturn := fake.ensurePlacementGroupReturnsOnCall[len(fake.ensurePlacementGroupArgsForCall)]
+	fake.ensurePlacementGroupArgsForCall = append(fake.ensurePlacementGroupArgsForCall, struct {
+		name          string
+		backendVlanID int
+	}{name, backendVlanID})
+	fake.recordInvocation("EnsurePlacementGroup", []interface{}{name, backendVlanID})
+	fake.ensurePlacementGroupMutex.Unlock()
+	if fake.EnsurePlacementGroupStub != nil {
+		return fake.EnsurePlacementGroupStub(name, backendVlanID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.ensurePlacementGroupReturns.result1, fake.ensurePlacementGroupReturns.result2
+}
+
+func (fake *FakeService) EnsurePlacementGroupCallCount() int {
+	fake.ensurePlacementGroupMutex.RLock()
+	defer fake.ensurePlacementGroupMutex.RUnlock()
+	return len(fake.ensurePlacementGroupArgsForCall)
+}
+
+func (fake *FakeService) EnsurePlacementGroupArgsForCall(i int) (string, int) {
+	fake.ensurePlacementGroupMutex.RLock()
+	defer fake.ensurePlacementGroupMutex.RUnlock()
+	return fake.ensurePlacementGroupArgsForCall[i].name, fake.ensurePlacementGroupArgsForCall[i].backendVlanID
+}
+
+func (fake *FakeService) EnsurePlacementGroupReturns(result1 int, result2 error) {
+	fake.EnsurePlacementGroupStub = nil
+	fake.ensurePlacementGroupReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) EnsurePlacementGroupReturnsOnCall(i int, result1 int, result2 error) {
+	fake.EnsurePlacementGroupStub = nil
+	if fake.ensurePlacementGroupReturnsOnCall == nil {
+		fake.ensurePlacementGroupReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.ensurePlacementGroupReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) ResolveOrderItems(items instance.OrderItems) (instance.OrderItems, error) {
+	fake.resolveOrderItemsMutex.Lock()
+	ret, specificReturn := fake.resolveOrderItemsReturnsOnCall[len(fake.resolveOrderItemsArgsForCall)]
+	fake.resolveOrderItemsArgsForCall = append(fake.resolveOrderItemsArgsForCall, struct {
+		items instance.OrderItems
+	}{items})
+	fake.recordInvocation("ResolveOrderItems", []interface{}{items})
+	fake.resolveOrderItemsMutex.Unlock()
+	if fake.ResolveOrderItemsStub != nil {
+		return fake.ResolveOrderItemsStub(items)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.resolveOrderItemsReturns.result1, fake.resolveOrderItemsReturns.result2
+}
+
+func (fake *FakeService) ResolveOrderItemsCallCount() int {
+	fake.resolveOrderItemsMutex.RLock()
+	defer fake.resolveOrderItemsMutex.RUnlock()
+	return len(fake.resolveOrderItemsArgsForCall)
+}
+
+func (fake *FakeService) ResolveOrderItemsArgsForCall(i int) instance.OrderItems {
+	fake.resolveOrderItemsMutex.RLock()
+	defer fake.resolveOrderItemsMutex.RUnlock()
+	return fake.resolveOrderItemsArgsForCall[i].items
+}
+
+func (fake *FakeService) ResolveOrderItemsReturns(result1 instance.OrderItems, result2 error) {
+	fake.ResolveOrderItemsStub = nil
+	fake.resolveOrderItemsReturns = struct {
+		result1 instance.OrderItems
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) ResolveOrderItemsReturnsOnCall(i int, result1 instance.OrderItems, result2 error) {
+	fake.ResolveOrderItemsStub = nil
+	if fake.resolveOrderItemsReturnsOnCall == nil {
+		fake.resolveOrderItemsReturnsOnCall = make(map[int]struct {
+			result1 instance.OrderItems
+			result2 error
+		})
+	}
+	fake.resolveOrderItemsReturnsOnCall[i] = struct {
+		result1 instance.OrderItems
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) CreateSshKey(label string, key string, fingerPrint string) (int, bool, error) {
+	fake.createSshKeyMutex.Lock()
+	ret, specificReturn := fake.createSshKeyReturnsOnCall[len(fake.createSshKeyArgsForCall)]
+	fake.createSshKeyArgsForCall = append(fake.createSshKeyArgsForCall, struct {
+		label       string
+		key         string
+		fingerPrint string
+	}{label, key, fingerPrint})
+	fake.recordInvocation("CreateSshKey", []interface{}{label, key, fingerPrint})
+	fake.createSshKeyMutex.Unlock()
+	if fake.CreateSshKeyStub != nil {
+		return fake.CreateSshKeyStub(label, key, fingerPrint)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.createSshKeyReturns.result1, fake.createSshKeyReturns.result2, fake.createSshKeyReturns.result3
+}
+
+func (fake *FakeService) CreateSshKeyCallCount() int {
+	fake.createSshKeyMutex.RLock()
+	defer fake.createSshKeyMutex.RUnlock()
+	return len(fake.createSshKeyArgsForCall)
+}
+
+func (fake *FakeService) CreateSshKeyArgsForCall(i int) (string, string, string) {
+	fake.createSshKeyMutex.RLock()
+	defer fake.createSshKeyMutex.RUnlock()
+	return fake.createSshKeyArgsForCall[i].label, fake.createSshKeyArgsForCall[i].key, fake.createSshKeyArgsForCall[i].fingerPrint
+}
+
+func (fake *FakeService) CreateSshKeyReturns(result1 int, result2 bool, result3 error) {
+	fake.CreateSshKeyStub = nil
+	fake.createSshKeyReturns = struct {
+		result1 int
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeService) CreateSshKeyReturnsOnCall(i int, result1 int, result2 bool, result3 error) {
+	fake.CreateSshKeyStub = nil
+	if fake.createSshKeyReturnsOnCall == nil {
+		fake.createSshKeyReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 bool
+			result3 error
+		})
+	}
+	fake.createSshKeyReturnsOnCall[i] = struct {
+		result1 int
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1382,8 +2039,6 @@ func (fake *FakeService) Invocations() map[string][][]interface{} {
 	defer fake.configureNetworksMutex.RUnlock()
 	fake.cleanUpMutex.RLock()
 	defer fake.cleanUpMutex.RUnlock()
-	fake.createSshKeyMutex.RLock()
-	defer fake.createSshKeyMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.detachDiskMutex.RLock()
@@ -1404,12 +2059,34 @@ func (fake *FakeService) Invocations() map[string][][]interface{} {
 	defer fake.getSubnetMutex.RUnlock()
 	fake.rebootMutex.RLock()
 	defer fake.rebootMutex.RUnlock()
+	fake.recordNetworksMutex.RLock()
+	defer fake.recordNetworksMutex.RUnlock()
+	fake.recordedNetworksMutex.RLock()
+	defer fake.recordedNetworksMutex.RUnlock()
 	fake.reloadOSMutex.RLock()
 	defer fake.reloadOSMutex.RUnlock()
 	fake.setMetadataMutex.RLock()
 	defer fake.setMetadataMutex.RUnlock()
 	fake.updateInstanceUserDataMutex.RLock()
 	defer fake.updateInstanceUserDataMutex.RUnlock()
+	fake.calculateResourcesMutex.RLock()
+	defer fake.calculateResourcesMutex.RUnlock()
+	fake.addLoadBalancerMemberMutex.RLock()
+	defer fake.addLoadBalancerMemberMutex.RUnlock()
+	fake.configureSecurityGroupsMutex.RLock()
+	defer fake.configureSecurityGroupsMutex.RUnlock()
+	fake.findSecurityGroupsMutex.RLock()
+	defer fake.findSecurityGroupsMutex.RUnlock()
+	fake.selectDedicatedHostMutex.RLock()
+	defer fake.selectDedicatedHostMutex.RUnlock()
+	fake.findPlacementGroupMutex.RLock()
+	defer fake.findPlacementGroupMutex.RUnlock()
+	fake.ensurePlacementGroupMutex.RLock()
+	defer fake.ensurePlacementGroupMutex.RUnlock()
+	fake.resolveOrderItemsMutex.RLock()
+	defer fake.resolveOrderItemsMutex.RUnlock()
+	fake.createSshKeyMutex.RLock()
+	defer fake.createSshKeyMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -4,18 +4,22 @@ import (
 	"github.com/softlayer/softlayer-go/datatypes"
 
 	"bosh-softlayer-cpi/registry"
+	bosl "bosh-softlayer-cpi/softlayer/client"
 )
 
 //go:generate counterfeiter -o fakes/fake_Instance_Service.go . Service
 type Service interface {
+	AddLoadBalancerMember(id int, member LoadBalancerMember) error
 	AttachDisk(id int, diskID int) ([]byte, error)
 	AttachedDisks(id int) ([]string, error)
 	AttachEphemeralDisk(id int, diskSize int) error
-	Create(virtualGuest *datatypes.Virtual_Guest, enableVps bool, stemcellID int, sshKeys []int, userData *registry.SoftlayerUserData) (int, error)
+	CalculateResources(cpu int, memory int, ephemeralDiskSize int) (Resources, error)
+	Create(virtualGuest *datatypes.Virtual_Guest, enableVps bool, stemcellID int, sshKeys []int, userData *registry.SoftlayerUserData, options bosl.InstanceOrderOptions) (int, error)
 	UpgradeInstance(id int, cpu int, memory int, network int, privateCPU bool, dedicatedHost bool) error
 	ConfigureNetworks(id int, networks Networks) (Networks, error)
+	ConfigureSecurityGroups(id int, groups SecurityGroups, detachOthers bool) error
 	CleanUp(id int) error
-	CreateSshKey(label string, key string, fingerPrint string) (int, error)
+	CreateSshKey(label string, key string, fingerPrint string) (int, bool, error)
 	Delete(id int, enableVps bool) error
 	DetachDisk(id int, diskID int) error
 	DeleteSshKey(id int) error
@@ -23,14 +27,71 @@ type Service interface {
 	Find(id int) (*datatypes.Virtual_Guest, error)
 	FindByPrimaryBackendIp(ip string) (*datatypes.Virtual_Guest, error)
 	FindByPrimaryIp(ip string) (*datatypes.Virtual_Guest, error)
+	FindSecurityGroups(refs []string) ([]int, error)
+	FindPlacementGroup(ref string) (int, error)
+	EnsurePlacementGroup(name string, backendVlanID int) (int, error)
 	GetVlan(id int, mask string) (*datatypes.Network_Vlan, error)
 	GetSubnet(id int, mask string) (*datatypes.Network_Subnet, error)
 	Reboot(id int) error
+	ResolveOrderItems(items OrderItems) (OrderItems, error)
+	RecordNetworks(id int, record NetworksRecord) error
+	RecordedNetworks(id int) (NetworksRecord, bool, error)
 	ReloadOS(id int, stemcellID int, sshKeyIds []int, hostname string, domain string, userData *registry.SoftlayerUserData) error
+	SelectDedicatedHost(placement DedicatedHostPlacement) (int, error)
 	SetMetadata(id int, vmMetadata Metadata) error
 	UpdateInstanceUserData(id int, userData *string) error
 }
 
 type Metadata map[string]interface{}
 
+// Resources are VM sizes that can be ordered from SoftLayer.
+type Resources struct {
+	FlavorKeyName     string
+	Cpu               int
+	Memory            int
+	EphemeralDiskSize int
+}
+
+// LoadBalancerMember is the membership of a VM in a SoftLayer/IBM Cloud local load balancer.
+type LoadBalancerMember struct {
+	UUID   string
+	Weight int
+}
+
+// DedicatedHostPlacement is the choice of a dedicated host, listed by ID or in a group, for a VM
+// with Cpu cores, Memory MB of memory and Disk GB of disk, or ordered with a Flavor of its own cores
+// and memory. Spreading counts the guests of the instance group of the VM, tagged with its Deployment
+// and InstanceGroup or, until they are tagged, named with its HostnamePrefix.
+type DedicatedHostPlacement struct {
+	HostIDs        []int
+	Group          string
+	Datacenter     string
+	Strategy       string
+	Cpu            int
+	Memory         int
+	Flavor         string
+	Disk           int
+	Deployment     string
+	InstanceGroup  string
+	HostnamePrefix string
+}
+
+// SecurityGroups are the IDs of the security groups of the public and private network components of a VM.
+type SecurityGroups struct {
+	Public  []int
+	Private []int
+}
+
+// OrderItems are the items of a VM order beyond cpu and memory: a root disk of RootDiskSize GB and
+// an ephemeral disk of EphemeralDiskSize GB, on local or SAN storage, a flavor and a GPU flavor, all
+// available in Datacenter.
+type OrderItems struct {
+	Datacenter        string
+	LocalDisk         bool
+	RootDiskSize      int
+	EphemeralDiskSize int
+	Flavor            string
+	GpuFlavor         string
+}
+
 type DavConfig map[string]interface{}
@@ -93,8 +93,8 @@ func (n Network) isManual() bool { return n.Type == "" || n.Type == "manual" }
 
 func (n Network) validate() error {
 	switch {
-	case n.isVip():
-		return bosherr.Errorf("Network type '%s' not supported", n.Type)
+	case n.isVip() && n.IP == "":
+		return bosherr.Error("VIP network requires an IP address")
 
 	default:
 		return nil
@@ -9,7 +9,6 @@ type Networks map[string]Network
 
 func (n Networks) Validate() error {
 	var networks int
-	var vipNetworks int
 	for _, network := range n {
 		if err := network.validate(); err != nil {
 			return err
@@ -20,19 +19,12 @@ func (n Networks) Validate() error {
 			networks++
 		case network.isManual():
 			networks++
-		case network.isVip():
-			vipNetworks++
 		}
 	}
 
 	// if networks != 1 {
 	// 	return bosherr.Error("Exactly one Dynamic or Manual network must be defined")
 	// }
-	//
-	// // Network type 'vip' not supported currently()
-	// if vipNetworks > 1 {
-	// 	return bosherr.Error("Only one VIP network is allowed")
-	// }
 
 	return nil
 }
@@ -54,16 +46,29 @@ func (n Networks) Network() Network {
 	return Network{}
 }
 
-// func (n Networks) VipNetwork() Network {
-// 	for _, net := range n {
-// 		if net.IsVip() {
-// 			// There can only be 1 vip network
-// 			return net
-// 		}
-// 	}
-//
-// 	return Network{}
-// }
+// VipNetworks returns the vip networks of n.
+func (n Networks) VipNetworks() Networks {
+	vips := Networks{}
+	for name, network := range n {
+		if network.isVip() {
+			vips[name] = network
+		}
+	}
+
+	return vips
+}
+
+// WithoutVipNetworks returns the dynamic and manual networks of n.
+func (n Networks) WithoutVipNetworks() Networks {
+	networks := Networks{}
+	for name, network := range n {
+		if !network.isVip() {
+			networks[name] = network
+		}
+	}
+
+	return networks
+}
 
 func (n Networks) DNS() []string {
 	network := n.Network()
@@ -56,25 +56,29 @@ var _ = Describe("Networks", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("Return error when validate single vip network", func() {
+		It("Validate vip network successfully", func() {
 			networks = Networks{
 				"fake-network-name": Network{
-					Type:    "vip",
-					IP:      "10.10.10.10",
-					Gateway: "fake-network-gateway",
-					Netmask: "fake-network-netmask",
-					DNS:     []string{"fake-network-dns"},
-					Default: []string{"fake-network-default"},
-					CloudProperties: NetworkCloudProperties{
-						VlanID:              42345678,
-						SourcePolicyRouting: true,
-					},
+					Type: "vip",
+					IP:   "169.55.61.215",
+				},
+			}
+
+			err := networks.Validate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Return error when validate vip network without IP", func() {
+			networks = Networks{
+				"fake-network-name": Network{
+					Type: "vip",
+					DNS:  []string{"fake-network-dns"},
 				},
 			}
 
 			err := networks.Validate()
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("Network type 'vip' not supported"))
+			Expect(err.Error()).To(ContainSubstring("VIP network requires an IP address"))
 		})
 	})
 
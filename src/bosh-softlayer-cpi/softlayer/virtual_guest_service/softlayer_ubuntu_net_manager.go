@@ -82,6 +82,35 @@ func (u *Softlayer_Ubuntu_Net) FinalizedNetworkDefinitions(networkComponents dat
 	return finalized, nil
 }
 
+// FinalizedVipNetworkDefinitions binds the VIPs as host addresses aliased on the public interface. The
+// agent does not configure vip networks, so they are handed over as manual networks.
+func (u *Softlayer_Ubuntu_Net) FinalizedVipNetworkDefinitions(networkComponents datatypes.Virtual_Guest, networks Networks) (Networks, error) {
+	finalized := Networks{}
+	for name, nw := range networks {
+		component := networkComponents.PrimaryNetworkComponent
+		if component == nil || component.Name == nil || component.Port == nil {
+			return networks, fmt.Errorf("vip network %q requires a public network", name)
+		}
+
+		alias, err := u.LinkNamer.Name(fmt.Sprintf("%s%d", *component.Name, *component.Port), name)
+		if err != nil {
+			return networks, fmt.Errorf("Linking network with name `%s`: `%s`", name, err.Error())
+		}
+
+		nw.Type = "manual"
+		nw.Netmask = "255.255.255.255"
+		nw.Gateway = ""
+		nw.Alias = alias
+		if component.MacAddress != nil {
+			nw.MAC = *component.MacAddress
+		}
+
+		finalized[name] = nw
+	}
+
+	return finalized, nil
+}
+
 func (u *Softlayer_Ubuntu_Net) NormalizeDynamics(networkComponents datatypes.Virtual_Guest, networks Networks) (Networks, error) {
 	var privateDynamic, publicDynamic *Network
 
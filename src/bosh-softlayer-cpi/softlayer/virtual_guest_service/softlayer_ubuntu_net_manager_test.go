@@ -187,6 +187,61 @@ var _ = Describe("Virtual Guest Service", func() {
 		})
 	})
 
+	Describe("Call FinalizedVipNetworkDefinitions", func() {
+		var (
+			networkComponents datatypes.Virtual_Guest
+			networks          Networks
+			linkNamer         *fakesVirtualGustService.FakeLinkNamer
+		)
+
+		BeforeEach(func() {
+			linkNamer = &fakesVirtualGustService.FakeLinkNamer{}
+			linkNamer.NameReturns("eth1:2", nil)
+			net.LinkNamer = linkNamer
+
+			networkComponents = datatypes.Virtual_Guest{
+				PrimaryNetworkComponent: &datatypes.Virtual_Guest_Network_Component{
+					NetworkVlan: &datatypes.Network_Vlan{
+						Id: sl.Int(1234580),
+					},
+					MacAddress: sl.String("fake-mac-address"),
+					Name:       sl.String("eth"),
+					Port:       sl.Int(1),
+				},
+			}
+			networks = Networks{
+				"fake-vip": Network{
+					Type: "vip",
+					IP:   "169.55.61.215",
+				},
+			}
+		})
+
+		It("Aliases the VIPs on the public interface", func() {
+			finalized, err := net.FinalizedVipNetworkDefinitions(networkComponents, networks)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finalized["fake-vip"]).To(Equal(Network{
+				Type:    "manual",
+				IP:      "169.55.61.215",
+				Netmask: "255.255.255.255",
+				MAC:     "fake-mac-address",
+				Alias:   "eth1:2",
+			}))
+
+			interfaceName, networkName := linkNamer.NameArgsForCall(0)
+			Expect(interfaceName).To(Equal("eth1"))
+			Expect(networkName).To(Equal("fake-vip"))
+		})
+
+		It("Return error when the VM has no public network", func() {
+			networkComponents.PrimaryNetworkComponent = nil
+
+			_, err := net.FinalizedVipNetworkDefinitions(networkComponents, networks)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires a public network"))
+		})
+	})
+
 	Describe("Call NormalizeDynamics", func() {
 		var (
 			networkComponents datatypes.Virtual_Guest
@@ -1,6 +1,8 @@
 package instance
 
 import (
+	"time"
+
 	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
 
 	"bosh-softlayer-cpi/logger"
@@ -14,6 +16,9 @@ type SoftlayerVirtualGuestService struct {
 	softlayerClient bosl.Client
 	uuidGen         boshuuid.Generator
 	logger          logger.Logger
+
+	// Time given to open connections of a drained load balancer member before it is removed
+	loadBalancerDrainPeriod time.Duration
 }
 
 func NewSoftLayerVirtualGuestService(
@@ -28,6 +33,13 @@ func NewSoftLayerVirtualGuestService(
 	}
 }
 
+// WithLoadBalancerDrainPeriod returns a copy of vg waiting period between draining the load balancer
+// members of a deleted instance and removing them.
+func (vg SoftlayerVirtualGuestService) WithLoadBalancerDrainPeriod(period time.Duration) SoftlayerVirtualGuestService {
+	vg.loadBalancerDrainPeriod = period
+	return vg
+}
+
 type Mount struct {
 	PartitionPath string
 	MountPoint    string
@@ -9,15 +9,16 @@ import (
 
 	"bosh-softlayer-cpi/api"
 	"bosh-softlayer-cpi/registry"
+	bosl "bosh-softlayer-cpi/softlayer/client"
 )
 
-func (vg SoftlayerVirtualGuestService) Create(virtualGuest *datatypes.Virtual_Guest, enableVps bool, stemcellID int, sshKeys []int, userData *registry.SoftlayerUserData) (int, error) {
+func (vg SoftlayerVirtualGuestService) Create(virtualGuest *datatypes.Virtual_Guest, enableVps bool, stemcellID int, sshKeys []int, userData *registry.SoftlayerUserData, options bosl.InstanceOrderOptions) (int, error) {
 	var err error
 
 	if enableVps {
 		virtualGuest, err = vg.softlayerClient.CreateInstanceFromVPS(virtualGuest, stemcellID, sshKeys, userData)
 	} else {
-		virtualGuest, err = vg.softlayerClient.CreateInstance(virtualGuest, userData)
+		virtualGuest, err = vg.softlayerClient.CreateInstance(virtualGuest, options, userData)
 	}
 	if err != nil {
 		if strings.Contains(err.Error(), "Time Out") {
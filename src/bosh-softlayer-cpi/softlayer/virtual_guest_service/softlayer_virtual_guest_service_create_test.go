@@ -15,6 +15,7 @@ import (
 
 	cpiLog "bosh-softlayer-cpi/logger"
 	"bosh-softlayer-cpi/registry"
+	"bosh-softlayer-cpi/softlayer/client"
 	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
 )
 
@@ -105,7 +106,7 @@ var _ = Describe("Virtual Guest Service", func() {
 					nil,
 				)
 
-				vmID, err := virtualGuestService.Create(virtualGuest, enableVps, stemcellID, sshKeys, userData)
+				vmID, err := virtualGuestService.Create(virtualGuest, enableVps, stemcellID, sshKeys, userData, client.InstanceOrderOptions{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(cli.CreateInstanceFromVPSCallCount()).To(Equal(1))
 				Expect(cli.CreateInstanceCallCount()).To(Equal(0))
@@ -118,7 +119,7 @@ var _ = Describe("Virtual Guest Service", func() {
 					errors.New("fake-client-error"),
 				)
 
-				vmID, err := virtualGuestService.Create(virtualGuest, enableVps, stemcellID, sshKeys, userData)
+				vmID, err := virtualGuestService.Create(virtualGuest, enableVps, stemcellID, sshKeys, userData, client.InstanceOrderOptions{})
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("fake-client-error"))
 				Expect(cli.CreateInstanceFromVPSCallCount()).To(Equal(1))
@@ -140,20 +141,35 @@ var _ = Describe("Virtual Guest Service", func() {
 					nil,
 				)
 
-				vmID, err := virtualGuestService.Create(virtualGuest, enableVps, stemcellID, sshKeys, userData)
+				vmID, err := virtualGuestService.Create(virtualGuest, enableVps, stemcellID, sshKeys, userData, client.InstanceOrderOptions{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(cli.CreateInstanceFromVPSCallCount()).To(Equal(0))
 				Expect(cli.CreateInstanceCallCount()).To(Equal(1))
 				Expect(vmID).To(Equal(createVmID))
 			})
 
+			It("orders the instance with the order options", func() {
+				cli.CreateInstanceReturns(
+					&datatypes.Virtual_Guest{
+						Id: sl.Int(createVmID),
+					},
+					nil,
+				)
+				options := client.InstanceOrderOptions{PlacementGroupId: 1235, TransientGuestFlag: true}
+
+				_, err := virtualGuestService.Create(virtualGuest, enableVps, stemcellID, sshKeys, userData, options)
+				Expect(err).NotTo(HaveOccurred())
+				_, orderOptions, _ := cli.CreateInstanceArgsForCall(0)
+				Expect(orderOptions).To(Equal(options))
+			})
+
 			It("returns error if softLayerClient create instance from VPS call", func() {
 				cli.CreateInstanceReturns(
 					&datatypes.Virtual_Guest{},
 					errors.New("fake-client-error"),
 				)
 
-				vmID, err := virtualGuestService.Create(virtualGuest, enableVps, stemcellID, sshKeys, userData)
+				vmID, err := virtualGuestService.Create(virtualGuest, enableVps, stemcellID, sshKeys, userData, client.InstanceOrderOptions{})
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("fake-client-error"))
 				Expect(cli.CreateInstanceFromVPSCallCount()).To(Equal(0))
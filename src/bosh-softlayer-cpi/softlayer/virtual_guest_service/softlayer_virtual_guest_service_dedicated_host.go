@@ -0,0 +1,205 @@
+package instance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/api"
+	bosl "bosh-softlayer-cpi/softlayer/client"
+)
+
+const (
+	DedicatedHostStrategySpread = "spread"
+	DedicatedHostStrategyPack   = "pack"
+)
+
+// SelectDedicatedHost returns the ID of the dedicated host the VM of placement is ordered on. Hosts
+// without room for it are skipped, a retryable VMCreationFailedError is returned if none has room.
+// The cores and memory of a VM ordered with a flavor are those of the flavor.
+func (vg SoftlayerVirtualGuestService) SelectDedicatedHost(placement DedicatedHostPlacement) (int, error) {
+	if placement.Flavor != "" {
+		var err error
+		placement.Cpu, placement.Memory, err = vg.flavorSizes(placement.Flavor)
+		if err != nil {
+			return 0, bosherr.WrapErrorf(err, "Getting sizes of flavor '%s'", placement.Flavor)
+		}
+	}
+
+	hosts, err := vg.softlayerClient.GetDedicatedHosts()
+	if err != nil {
+		return 0, bosherr.WrapError(err, "Listing dedicated hosts")
+	}
+
+	candidates := []bosl.DedicatedHost{}
+	for _, host := range hosts {
+		if host.Id == nil || !placement.matches(host) {
+			continue
+		}
+		candidates = append(candidates, host)
+	}
+	if len(candidates) == 0 {
+		return 0, bosherr.Errorf("No dedicated host in datacenter '%s' matches %s", placement.Datacenter, placement)
+	}
+
+	withRoom := []bosl.DedicatedHost{}
+	for _, host := range candidates {
+		if placement.fits(host) {
+			withRoom = append(withRoom, host)
+		} else {
+			vg.logger.Debug(softlayerVirtualGuestServiceLogTag, "Dedicated host '%d' has no room for %d cores, %d MB memory and %d GB disk", *host.Id, placement.Cpu, placement.Memory, placement.Disk)
+		}
+	}
+	if len(withRoom) == 0 {
+		return 0, api.NewVMCreationFailedError(fmt.Sprintf("None of the dedicated hosts matching %s has room for %d cores, %d MB memory and %d GB disk", placement, placement.Cpu, placement.Memory, placement.Disk), true)
+	}
+
+	sort.SliceStable(withRoom, func(i, j int) bool {
+		return placement.prefers(withRoom[i], withRoom[j])
+	})
+
+	hostID := *withRoom[0].Id
+	vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Placing VirtualGuest on dedicated host '%d' (%s strategy)", hostID, placement.strategy())
+	return hostID, nil
+}
+
+func (p DedicatedHostPlacement) String() string {
+	if p.Group != "" {
+		return fmt.Sprintf("group '%s'", p.Group)
+	}
+	return fmt.Sprintf("ids %v", p.HostIDs)
+}
+
+func (p DedicatedHostPlacement) strategy() string {
+	if p.Strategy == "" {
+		return DedicatedHostStrategySpread
+	}
+	return p.Strategy
+}
+
+// matches returns true if host is listed by ID or belongs to the group, and is in the datacenter of
+// the placement. The group is a tag of the host, or the name of the host or its prefix up to a
+// delimiter, group "cf-dh" has the hosts "cf-dh" and "cf-dh-01" but not "cf-dh2-01".
+func (p DedicatedHostPlacement) matches(host bosl.DedicatedHost) bool {
+	if p.Datacenter != "" && host.Datacenter != nil && host.Datacenter.Name != nil && *host.Datacenter.Name != p.Datacenter {
+		return false
+	}
+
+	for _, id := range p.HostIDs {
+		if id == *host.Id {
+			return true
+		}
+	}
+
+	if p.Group == "" {
+		return false
+	}
+	for _, reference := range host.TagReferences {
+		if reference.Tag != nil && reference.Tag.Name != nil && *reference.Tag.Name == p.Group {
+			return true
+		}
+	}
+	return host.Name != nil && inGroup(*host.Name, p.Group)
+}
+
+const dedicatedHostGroupDelimiters = "-_."
+
+func inGroup(name string, group string) bool {
+	if name == group {
+		return true
+	}
+	if !strings.HasPrefix(name, group) {
+		return false
+	}
+
+	// A group may end with its delimiter, e.g. "cf-dh-"
+	if strings.ContainsAny(group[len(group)-1:], dedicatedHostGroupDelimiters) {
+		return true
+	}
+	return strings.ContainsAny(name[len(group):len(group)+1], dedicatedHostGroupDelimiters)
+}
+
+func (p DedicatedHostPlacement) fits(host bosl.DedicatedHost) bool {
+	status := host.AllocationStatus
+	if status == nil {
+		return false
+	}
+
+	// Host memory and disk are in GB
+	memory := (p.Memory + 1023) / 1024
+	return sl.Get(status.CpuAvailable, 0).(int) >= p.Cpu &&
+		sl.Get(status.MemoryAvailable, 0).(int) >= memory &&
+		sl.Get(status.DiskAvailable, 0).(int) >= p.Disk
+}
+
+// prefers returns true if host a is preferred to b. Spreading prefers hosts with fewer guests of the
+// instance group, then with fewer guests, then with more free cores; packing prefers hosts with fewer
+// free cores, then with less free memory.
+func (p DedicatedHostPlacement) prefers(a bosl.DedicatedHost, b bosl.DedicatedHost) bool {
+	cpuA, cpuB := sl.Get(a.AllocationStatus.CpuAvailable, 0).(int), sl.Get(b.AllocationStatus.CpuAvailable, 0).(int)
+
+	if p.strategy() == DedicatedHostStrategyPack {
+		if cpuA != cpuB {
+			return cpuA < cpuB
+		}
+		memoryA, memoryB := sl.Get(a.AllocationStatus.MemoryAvailable, 0).(int), sl.Get(b.AllocationStatus.MemoryAvailable, 0).(int)
+		if memoryA != memoryB {
+			return memoryA < memoryB
+		}
+		return *a.Id < *b.Id
+	}
+
+	groupGuestsA, groupGuestsB := p.groupGuests(a), p.groupGuests(b)
+	if groupGuestsA != groupGuestsB {
+		return groupGuestsA < groupGuestsB
+	}
+	guestsA, guestsB := sl.Get(a.AllocationStatus.GuestCount, 0).(int), sl.Get(b.AllocationStatus.GuestCount, 0).(int)
+	if guestsA != guestsB {
+		return guestsA < guestsB
+	}
+	if cpuA != cpuB {
+		return cpuA > cpuB
+	}
+	return *a.Id < *b.Id
+}
+
+// groupGuests returns the number of guests of host in the instance group of the placement. The tags
+// of a guest are only set by set_vm_metadata, a guest without deployment or job tag is counted by
+// its hostname.
+func (p DedicatedHostPlacement) groupGuests(host bosl.DedicatedHost) int {
+	if p.InstanceGroup == "" {
+		return 0
+	}
+
+	deploymentTag := "deployment:" + cleanTagValue(p.Deployment)
+	jobTag := "job:" + cleanTagValue(p.InstanceGroup)
+	hostnamePrefix := strings.TrimSuffix(p.HostnamePrefix, "-") + "-"
+
+	count := 0
+	for _, guest := range host.Guests {
+		tagged, inDeployment, inJob := false, false, false
+		for _, reference := range guest.TagReferences {
+			if reference.Tag == nil || reference.Tag.Name == nil {
+				continue
+			}
+			name := *reference.Tag.Name
+			if strings.HasPrefix(name, "deployment:") || strings.HasPrefix(name, "job:") {
+				tagged = true
+			}
+			inDeployment = inDeployment || name == deploymentTag
+			inJob = inJob || name == jobTag
+		}
+
+		if tagged {
+			if inDeployment && inJob {
+				count++
+			}
+		} else if p.HostnamePrefix != "" && guest.Hostname != nil && strings.HasPrefix(*guest.Hostname, hostnamePrefix) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,266 @@
+package instance_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/api"
+	cpiLog "bosh-softlayer-cpi/logger"
+	slclient "bosh-softlayer-cpi/softlayer/client"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	. "bosh-softlayer-cpi/softlayer/virtual_guest_service"
+)
+
+var _ = Describe("Virtual Guest Service", func() {
+	var (
+		cli                 *fakeslclient.FakeClient
+		uuidGen             *fakeuuid.FakeGenerator
+		logger              cpiLog.Logger
+		virtualGuestService SoftlayerVirtualGuestService
+	)
+
+	BeforeEach(func() {
+		cli = &fakeslclient.FakeClient{}
+		uuidGen = &fakeuuid.FakeGenerator{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		virtualGuestService = NewSoftLayerVirtualGuestService(cli, uuidGen, logger)
+	})
+
+	Describe("Call SelectDedicatedHost", func() {
+		var (
+			placement DedicatedHostPlacement
+		)
+
+		dedicatedHost := func(id int, name string, datacenter string, guests int, cpu int, memory int, disk int) slclient.DedicatedHost {
+			return slclient.DedicatedHost{
+				Virtual_DedicatedHost: datatypes.Virtual_DedicatedHost{
+					Id:         sl.Int(id),
+					Name:       sl.String(name),
+					Datacenter: &datatypes.Location{Name: sl.String(datacenter)},
+					AllocationStatus: &datatypes.Container_Virtual_DedicatedHost_AllocationStatus{
+						GuestCount:      sl.Int(guests),
+						CpuAvailable:    sl.Int(cpu),
+						MemoryAvailable: sl.Int(memory),
+						DiskAvailable:   sl.Int(disk),
+					},
+				},
+			}
+		}
+
+		guest := func(hostname string, tags ...string) datatypes.Virtual_Guest {
+			references := []datatypes.Tag_Reference{}
+			for _, tag := range tags {
+				references = append(references, datatypes.Tag_Reference{Tag: &datatypes.Tag{Name: sl.String(tag)}})
+			}
+			return datatypes.Virtual_Guest{Hostname: sl.String(hostname), TagReferences: references}
+		}
+
+		BeforeEach(func() {
+			placement = DedicatedHostPlacement{
+				HostIDs:    []int{11111, 22222, 33333},
+				Datacenter: "dal10",
+				Cpu:        4,
+				Memory:     8192,
+				Disk:       125,
+			}
+
+			cli.GetDedicatedHostsReturns(
+				[]slclient.DedicatedHost{
+					dedicatedHost(11111, "cf-dh-01", "dal10", 3, 20, 100, 900),
+					dedicatedHost(22222, "cf-dh-02", "dal10", 1, 8, 40, 900),
+					dedicatedHost(33333, "cf-dh-03", "dal10", 0, 2, 200, 900),
+					dedicatedHost(44444, "cf-dh-04", "dal12", 0, 56, 242, 1200),
+				},
+				nil,
+			)
+		})
+
+		It("Spreads the VMs over the host with the fewest guests that has room", func() {
+			hostID, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostID).To(Equal(22222))
+		})
+
+		It("Spreads the VMs of an instance group over the host with the fewest guests of the group", func() {
+			hosts := []slclient.DedicatedHost{
+				dedicatedHost(11111, "cf-dh-01", "dal10", 3, 20, 100, 900),
+				dedicatedHost(22222, "cf-dh-02", "dal10", 2, 20, 100, 900),
+				dedicatedHost(33333, "cf-dh-03", "dal10", 3, 20, 100, 900),
+			}
+			hosts[0].Guests = []datatypes.Virtual_Guest{
+				guest("router-20260101-010101-001", "deployment:cf-2", "job:router"),
+				guest("router-20260101-010101-002", "deployment:cf", "job:diego-cell"),
+				guest("diego-cell-20260101-010101-003"),
+			}
+			hosts[1].Guests = []datatypes.Virtual_Guest{
+				guest("router-20260101-010101-004", "deployment:cf", "job:router"),
+				guest("router2-20260101-010101-005"),
+			}
+			hosts[2].Guests = []datatypes.Virtual_Guest{
+				guest("router-20260101-010101-006"),
+				guest("router-20260101-010101-007", "deployment:cf", "job:router"),
+				guest("compilation-20260101-010101-008"),
+			}
+			cli.GetDedicatedHostsReturns(hosts, nil)
+			placement.Deployment = "cf"
+			placement.InstanceGroup = "router"
+			placement.HostnamePrefix = "router"
+
+			hostID, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostID).To(Equal(11111))
+
+			hosts[0].Guests = append(hosts[0].Guests, guest("router-20260101-010101-009"), guest("router-20260101-010101-010"))
+			hostID, err = virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostID).To(Equal(22222))
+		})
+
+		It("Packs the VMs on the host with the fewest free cores that has room", func() {
+			placement.Strategy = DedicatedHostStrategyPack
+
+			hostID, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostID).To(Equal(22222))
+
+			placement.Memory = 65536
+			hostID, err = virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostID).To(Equal(11111))
+		})
+
+		It("Selects the hosts of a group in the datacenter", func() {
+			placement.HostIDs = nil
+			placement.Group = "cf-dh"
+			placement.Datacenter = "dal12"
+
+			hostID, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostID).To(Equal(44444))
+		})
+
+		It("Selects only the hosts named after the group or prefixed with it and a delimiter", func() {
+			cli.GetDedicatedHostsReturns(
+				[]slclient.DedicatedHost{
+					dedicatedHost(11111, "cf-dh2-01", "dal10", 0, 56, 242, 1200),
+					dedicatedHost(22222, "cf-dhx", "dal10", 0, 56, 242, 1200),
+					dedicatedHost(33333, "cf-dh_01", "dal10", 1, 56, 242, 1200),
+					dedicatedHost(44444, "cf-dh", "dal10", 2, 56, 242, 1200),
+				},
+				nil,
+			)
+			placement.HostIDs = nil
+			placement.Group = "cf-dh"
+
+			hostID, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostID).To(Equal(33333))
+
+			placement.Group = "cf-dh2"
+			hostID, err = virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostID).To(Equal(11111))
+		})
+
+		It("Selects the hosts tagged with the group", func() {
+			hosts := []slclient.DedicatedHost{
+				dedicatedHost(11111, "dh-a", "dal10", 0, 56, 242, 1200),
+				dedicatedHost(22222, "dh-b", "dal10", 1, 56, 242, 1200),
+				dedicatedHost(33333, "dh-c", "dal10", 2, 56, 242, 1200),
+			}
+			hosts[1].TagReferences = []datatypes.Tag_Reference{{Tag: &datatypes.Tag{Name: sl.String("cf-dh")}}}
+			hosts[2].TagReferences = []datatypes.Tag_Reference{{Tag: &datatypes.Tag{Name: sl.String("cf-dh")}}}
+			cli.GetDedicatedHostsReturns(hosts, nil)
+			placement.HostIDs = nil
+			placement.Group = "cf-dh"
+
+			hostID, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostID).To(Equal(22222))
+		})
+
+		It("Returns an error when no host name or tag matches the group", func() {
+			placement.HostIDs = nil
+			placement.Group = "cf-d"
+
+			_, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("matches group 'cf-d'"))
+		})
+
+		It("Returns a retryable VMCreationFailedError when no host has room", func() {
+			placement.Cpu = 32
+
+			_, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).To(HaveOccurred())
+			creationErr, ok := err.(api.VMCreationFailedError)
+			Expect(ok).To(BeTrue())
+			Expect(creationErr.CanRetry()).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("has room for 32 cores"))
+		})
+
+		It("Returns an error when no host matches", func() {
+			placement.HostIDs = []int{55555}
+
+			_, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("No dedicated host in datacenter 'dal10' matches ids [55555]"))
+		})
+
+		It("Selects a host with room for the cores and memory of the flavor", func() {
+			placement.Cpu = 0
+			placement.Memory = 0
+			placement.Flavor = "B1_16X32X100"
+			cli.GetVirtualServerPackageReturns(
+				datatypes.Product_Package{
+					ActivePresets: []datatypes.Product_Package_Preset{
+						{
+							KeyName:  sl.String("B1_16X32X100"),
+							IsActive: sl.String("1"),
+							Prices: []datatypes.Product_Item_Price{
+								{
+									Item:       &datatypes.Product_Item{KeyName: sl.String("GUEST_CORE_16"), Capacity: sl.Float(16)},
+									Categories: []datatypes.Product_Item_Category{{CategoryCode: sl.String("guest_core")}},
+								},
+								{
+									Item:       &datatypes.Product_Item{KeyName: sl.String("RAM_32_GB"), Capacity: sl.Float(32)},
+									Categories: []datatypes.Product_Item_Category{{CategoryCode: sl.String("ram")}},
+								},
+							},
+						},
+					},
+				},
+				nil,
+			)
+
+			hostID, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostID).To(Equal(11111))
+		})
+
+		It("Returns an error when the flavor does not exist", func() {
+			placement.Flavor = "B1_16X32X100"
+			cli.GetVirtualServerPackageReturns(datatypes.Product_Package{}, nil)
+
+			_, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Flavor 'B1_16X32X100' does not exist"))
+			Expect(cli.GetDedicatedHostsCallCount()).To(Equal(0))
+		})
+
+		It("Returns an error when listing the dedicated hosts fails", func() {
+			cli.GetDedicatedHostsReturns([]slclient.DedicatedHost{}, errors.New("fake-client-error"))
+
+			_, err := virtualGuestService.SelectDedicatedHost(placement)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+})
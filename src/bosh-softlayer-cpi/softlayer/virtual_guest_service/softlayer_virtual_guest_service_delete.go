@@ -3,7 +3,7 @@ package instance
 import bosherr "github.com/cloudfoundry/bosh-utils/errors"
 
 func (vg SoftlayerVirtualGuestService) Delete(id int, enableVps bool) error {
-	_, found, err := vg.softlayerClient.GetInstance(id, "id")
+	instance, found, err := vg.softlayerClient.GetInstance(id, "id, primaryIpAddress, primaryBackendIpAddress, tagReferences.tag.name")
 	if err != nil {
 		return bosherr.WrapErrorf(err, "Failed to find SoftLayer VirtualGuest with id '%d'", id)
 	}
@@ -11,9 +11,34 @@ func (vg SoftlayerVirtualGuestService) Delete(id int, enableVps bool) error {
 		return nil
 	}
 
+	if instance.PrimaryBackendIpAddress != nil {
+		if err = vg.removeLoadBalancerMembers(instance, *instance.PrimaryBackendIpAddress); err != nil {
+			return bosherr.WrapErrorf(err, "Removing SoftLayer VirtualGuest with id '%d' from load balancers", id)
+		}
+	}
+
+	if instance.PrimaryIpAddress != nil {
+		if err = vg.releaseGlobalIps(id, *instance.PrimaryIpAddress); err != nil {
+			vg.logger.Warn(softlayerVirtualGuestServiceLogTag, "Skipping global IPs of SoftLayer VirtualGuest '%d', releasing them: %s", id, err)
+		}
+	}
+
 	if enableVps {
 		return vg.softlayerClient.DeleteInstanceFromVPS(id)
 	}
 
-	return vg.softlayerClient.CancelInstance(id)
+	placementGroupID, err := vg.softlayerClient.GetInstancePlacementGroupId(id)
+	if err != nil {
+		vg.logger.Warn(softlayerVirtualGuestServiceLogTag, "Skipping placement group of SoftLayer VirtualGuest '%d', getting it: %s", id, err)
+	}
+
+	if err = vg.softlayerClient.CancelInstance(id); err != nil {
+		return err
+	}
+
+	if placementGroupID != 0 {
+		vg.releasePlacementGroup(placementGroupID, instance)
+	}
+
+	return nil
 }
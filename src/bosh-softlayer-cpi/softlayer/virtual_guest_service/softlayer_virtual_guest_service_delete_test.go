@@ -2,6 +2,9 @@ package instance_test
 
 import (
 	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -12,6 +15,7 @@ import (
 	"github.com/softlayer/softlayer-go/sl"
 
 	cpiLog "bosh-softlayer-cpi/logger"
+	"bosh-softlayer-cpi/softlayer/client"
 	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
 	. "bosh-softlayer-cpi/softlayer/virtual_guest_service"
 )
@@ -33,12 +37,17 @@ var _ = Describe("Virtual Guest Service", func() {
 
 	Describe("Call Delete", func() {
 		var (
-			vmID      int
-			enableVps bool
+			vmID              int
+			enableVps         bool
+			instanceGroupTags []datatypes.Tag_Reference
 		)
 
 		BeforeEach(func() {
 			vmID = 12345678
+			instanceGroupTags = []datatypes.Tag_Reference{
+				{Tag: &datatypes.Tag{Name: sl.String("deployment:cf")}},
+				{Tag: &datatypes.Tag{Name: sl.String("job:router")}},
+			}
 		})
 
 		Context("Clean up from VPS", func() {
@@ -158,6 +167,454 @@ var _ = Describe("Virtual Guest Service", func() {
 				Expect(cli.DeleteInstanceFromVPSCallCount()).To(Equal(0))
 				Expect(cli.CancelInstanceCallCount()).To(Equal(0))
 			})
+
+			It("Deletes the CPI-created placement group of its last VM", func() {
+				cli.GetInstanceReturns(&datatypes.Virtual_Guest{Id: sl.Int(vmID), TagReferences: instanceGroupTags}, true, nil)
+				cli.GetInstancePlacementGroupIdReturns(1235, nil)
+				cli.GetPlacementGroupReturns(
+					&client.PlacementGroup{
+						Id:     sl.Int(1235),
+						Name:   sl.String("bosh-cf-router"),
+						Guests: []datatypes.Virtual_Guest{{Id: sl.Int(vmID)}},
+					},
+					true,
+					nil,
+				)
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+				Expect(cli.DeletePlacementGroupCallCount()).To(Equal(1))
+				Expect(cli.DeletePlacementGroupArgsForCall(0)).To(Equal(1235))
+			})
+
+			It("Keeps placement groups with other VMs or not created by the CPI", func() {
+				cli.GetInstanceReturns(&datatypes.Virtual_Guest{Id: sl.Int(vmID), TagReferences: instanceGroupTags}, true, nil)
+				cli.GetInstancePlacementGroupIdReturns(1235, nil)
+				cli.GetPlacementGroupReturnsOnCall(0,
+					&client.PlacementGroup{
+						Id:     sl.Int(1235),
+						Name:   sl.String("bosh-cf-router"),
+						Guests: []datatypes.Virtual_Guest{{Id: sl.Int(vmID)}, {Id: sl.Int(22345678)}},
+					},
+					true,
+					nil,
+				)
+				cli.GetPlacementGroupReturnsOnCall(1,
+					&client.PlacementGroup{Id: sl.Int(1235), Name: sl.String("cf-spread")},
+					true,
+					nil,
+				)
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				err = virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.GetPlacementGroupCallCount()).To(Equal(2))
+				Expect(cli.DeletePlacementGroupCallCount()).To(Equal(0))
+			})
+
+			It("Deletes the placement group when its other VMs have a cancellation pending", func() {
+				cli.GetInstanceReturns(&datatypes.Virtual_Guest{Id: sl.Int(vmID), TagReferences: instanceGroupTags}, true, nil)
+				cli.GetInstancePlacementGroupIdReturns(1235, nil)
+				cli.GetPlacementGroupReturns(
+					&client.PlacementGroup{
+						Id:   sl.Int(1235),
+						Name: sl.String("bosh-cf-router"),
+						Guests: []datatypes.Virtual_Guest{
+							{Id: sl.Int(vmID)},
+							{
+								Id:          sl.Int(22345678),
+								BillingItem: &datatypes.Billing_Item_Virtual_Guest{Billing_Item: datatypes.Billing_Item{CancellationDate: &datatypes.Time{Time: time.Now()}}},
+							},
+							{
+								Id: sl.Int(32345678),
+								ActiveTransaction: &datatypes.Provisioning_Version1_Transaction{
+									TransactionStatus: &datatypes.Provisioning_Version1_Transaction_Status{Name: sl.String("RECLAIM_WAIT")},
+								},
+							},
+						},
+					},
+					true,
+					nil,
+				)
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.DeletePlacementGroupCallCount()).To(Equal(1))
+				Expect(cli.DeletePlacementGroupArgsForCall(0)).To(Equal(1235))
+			})
+
+			It("Deletes the placement group when its VMs are deleted concurrently", func() {
+				vmIDs := []int{vmID, 22345678, 32345678}
+				var mutex sync.Mutex
+				cancelled := map[int]bool{}
+
+				cli.GetInstanceStub = func(id int, mask string) (*datatypes.Virtual_Guest, bool, error) {
+					return &datatypes.Virtual_Guest{Id: sl.Int(id), TagReferences: instanceGroupTags}, true, nil
+				}
+				cli.GetInstancePlacementGroupIdReturns(1235, nil)
+				cli.CancelInstanceStub = func(id int) error {
+					mutex.Lock()
+					defer mutex.Unlock()
+					cancelled[id] = true
+					return nil
+				}
+				cli.GetPlacementGroupStub = func(id int) (*client.PlacementGroup, bool, error) {
+					mutex.Lock()
+					defer mutex.Unlock()
+					guests := []datatypes.Virtual_Guest{}
+					for _, guestID := range vmIDs {
+						guest := datatypes.Virtual_Guest{Id: sl.Int(guestID)}
+						if cancelled[guestID] {
+							guest.BillingItem = &datatypes.Billing_Item_Virtual_Guest{Billing_Item: datatypes.Billing_Item{CancellationDate: &datatypes.Time{Time: time.Now()}}}
+						}
+						guests = append(guests, guest)
+					}
+					return &client.PlacementGroup{Id: sl.Int(1235), Name: sl.String("bosh-cf-router"), Guests: guests}, true, nil
+				}
+
+				var wg sync.WaitGroup
+				for _, id := range vmIDs {
+					wg.Add(1)
+					go func(id int) {
+						defer GinkgoRecover()
+						defer wg.Done()
+						Expect(virtualGuestService.Delete(id, enableVps)).To(Succeed())
+					}(id)
+				}
+				wg.Wait()
+
+				Expect(cli.CancelInstanceCallCount()).To(Equal(3))
+				Expect(cli.DeletePlacementGroupCallCount()).To(BeNumerically(">=", 1))
+				Expect(cli.DeletePlacementGroupArgsForCall(0)).To(Equal(1235))
+			})
+
+			It("Never deletes a placement group referenced by the operator", func() {
+				cli.GetInstanceReturns(&datatypes.Virtual_Guest{Id: sl.Int(vmID), TagReferences: instanceGroupTags}, true, nil)
+				cli.GetInstancePlacementGroupIdReturns(1235, nil)
+				cli.GetPlacementGroupReturns(
+					&client.PlacementGroup{
+						Id:     sl.Int(1235),
+						Name:   sl.String("bosh-etcd"),
+						Guests: []datatypes.Virtual_Guest{{Id: sl.Int(vmID)}},
+					},
+					true,
+					nil,
+				)
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+				Expect(cli.DeletePlacementGroupCallCount()).To(Equal(0))
+			})
+
+			It("Keeps the placement group of a VM without deployment and job tags", func() {
+				cli.GetInstanceReturns(&datatypes.Virtual_Guest{Id: sl.Int(vmID)}, true, nil)
+				cli.GetInstancePlacementGroupIdReturns(1235, nil)
+				cli.GetPlacementGroupReturns(
+					&client.PlacementGroup{
+						Id:     sl.Int(1235),
+						Name:   sl.String("bosh-cf-router"),
+						Guests: []datatypes.Virtual_Guest{{Id: sl.Int(vmID)}},
+					},
+					true,
+					nil,
+				)
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+				Expect(cli.DeletePlacementGroupCallCount()).To(Equal(0))
+			})
+
+			It("Cancels the VM when deleting its placement group fails", func() {
+				cli.GetInstanceReturns(&datatypes.Virtual_Guest{Id: sl.Int(vmID), TagReferences: instanceGroupTags}, true, nil)
+				cli.GetInstancePlacementGroupIdReturns(1235, nil)
+				cli.GetPlacementGroupReturns(&client.PlacementGroup{Id: sl.Int(1235), Name: sl.String("bosh-cf-router")}, true, nil)
+				cli.DeletePlacementGroupReturns(errors.New("fake-client-error"))
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+			})
+
+			It("Unroutes the global IPs the CPI routed to the VM before cancelling it", func() {
+				cli.GetInstanceReturns(
+					&datatypes.Virtual_Guest{
+						Id:               sl.Int(vmID),
+						PrimaryIpAddress: sl.String("169.55.61.50"),
+					},
+					true,
+					nil,
+				)
+				cli.GetGlobalIpsByDestinationReturns(
+					[]datatypes.Network_Subnet_IpAddress_Global{
+						{
+							Id:          sl.Int(52703),
+							IpAddressId: sl.Int(65633607),
+							IpAddress: &datatypes.Network_Subnet_IpAddress{
+								IpAddress: sl.String("169.55.61.215"),
+								Note:      sl.String(fmt.Sprintf("bosh-softlayer-cpi: routed to VM %d", vmID)),
+							},
+						},
+						{
+							Id: sl.Int(52704),
+							IpAddress: &datatypes.Network_Subnet_IpAddress{
+								IpAddress: sl.String("169.55.61.216"),
+								Note:      sl.String("fake-operator-note"),
+							},
+						},
+						{
+							Id: sl.Int(52705),
+							IpAddress: &datatypes.Network_Subnet_IpAddress{
+								IpAddress: sl.String("169.55.61.217"),
+							},
+						},
+					},
+					nil,
+				)
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.GetGlobalIpsByDestinationArgsForCall(0)).To(Equal("169.55.61.50"))
+				Expect(cli.UnrouteGlobalIpCallCount()).To(Equal(1))
+				Expect(cli.UnrouteGlobalIpArgsForCall(0)).To(Equal(52703))
+				Expect(cli.SetIpAddressNoteCallCount()).To(Equal(1))
+				ipAddressID, note := cli.SetIpAddressNoteArgsForCall(0)
+				Expect(ipAddressID).To(Equal(65633607))
+				Expect(note).To(BeEmpty())
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+			})
+
+			It("Cancels the VM if softLayerClient unroute global IP call returns an error", func() {
+				cli.GetInstanceReturns(
+					&datatypes.Virtual_Guest{
+						Id:               sl.Int(vmID),
+						PrimaryIpAddress: sl.String("169.55.61.50"),
+					},
+					true,
+					nil,
+				)
+				cli.GetGlobalIpsByDestinationReturns(
+					[]datatypes.Network_Subnet_IpAddress_Global{
+						{
+							Id: sl.Int(52703),
+							IpAddress: &datatypes.Network_Subnet_IpAddress{
+								IpAddress: sl.String("169.55.61.215"),
+								Note:      sl.String(fmt.Sprintf("bosh-softlayer-cpi: routed to VM %d", vmID)),
+							},
+						},
+					},
+					nil,
+				)
+				cli.UnrouteGlobalIpReturns(errors.New("fake-client-error"))
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.UnrouteGlobalIpCallCount()).To(Equal(1))
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+			})
+
+			It("Cancels the VM if finding the global IPs routed to it fails", func() {
+				cli.GetInstanceReturns(
+					&datatypes.Virtual_Guest{
+						Id:               sl.Int(vmID),
+						PrimaryIpAddress: sl.String("169.55.61.50"),
+					},
+					true,
+					nil,
+				)
+				cli.GetGlobalIpsByDestinationReturns(nil, errors.New("fake-client-error"))
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.UnrouteGlobalIpCallCount()).To(Equal(0))
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+			})
+
+			It("Drains and removes the VM from the load balancers it is tagged with before cancelling it", func() {
+				cli.GetInstanceReturns(
+					&datatypes.Virtual_Guest{
+						Id:                      sl.Int(vmID),
+						PrimaryBackendIpAddress: sl.String("10.112.10.20"),
+						TagReferences: []datatypes.Tag_Reference{
+							{Tag: &datatypes.Tag{Name: sl.String("deployment:fake-deployment")}},
+							{Tag: &datatypes.Tag{Name: sl.String("load_balancer:fake-lb-uuid1")}},
+						},
+					},
+					true,
+					nil,
+				)
+				cli.GetLoadBalancerReturns(
+					&datatypes.Network_LBaaS_LoadBalancer{
+						Uuid: sl.String("fake-lb-uuid1"),
+						Members: []datatypes.Network_LBaaS_Member{
+							{Uuid: sl.String("fake-member-uuid1"), Address: sl.String("10.112.10.21"), Weight: sl.Int(50)},
+							{Uuid: sl.String("fake-member-uuid2"), Address: sl.String("10.112.10.20"), Weight: sl.Int(50)},
+						},
+					},
+					true,
+					nil,
+				)
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.GetLoadBalancerCallCount()).To(Equal(1))
+				Expect(cli.GetLoadBalancerArgsForCall(0)).To(Equal("fake-lb-uuid1"))
+				Expect(cli.UpdateLoadBalancerMemberWeightCallCount()).To(Equal(1))
+				uuid, memberUuid, weight := cli.UpdateLoadBalancerMemberWeightArgsForCall(0)
+				Expect(uuid).To(Equal("fake-lb-uuid1"))
+				Expect(memberUuid).To(Equal("fake-member-uuid2"))
+				Expect(weight).To(Equal(0))
+				Expect(cli.DeleteLoadBalancerMemberCallCount()).To(Equal(1))
+				uuid, memberUuid = cli.DeleteLoadBalancerMemberArgsForCall(0)
+				Expect(uuid).To(Equal("fake-lb-uuid1"))
+				Expect(memberUuid).To(Equal("fake-member-uuid2"))
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+			})
+
+			It("Does not touch load balancers of a VM without load balancer tags", func() {
+				cli.GetInstanceReturns(
+					&datatypes.Virtual_Guest{
+						Id:                      sl.Int(vmID),
+						PrimaryBackendIpAddress: sl.String("10.112.10.20"),
+					},
+					true,
+					nil,
+				)
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.GetLoadBalancerCallCount()).To(Equal(0))
+				Expect(cli.UpdateLoadBalancerMemberWeightCallCount()).To(Equal(0))
+				Expect(cli.DeleteLoadBalancerMemberCallCount()).To(Equal(0))
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+			})
+
+			It("Waits the drain period between draining and removing the VM", func() {
+				virtualGuestService = virtualGuestService.WithLoadBalancerDrainPeriod(100 * time.Millisecond)
+				cli.GetInstanceReturns(
+					&datatypes.Virtual_Guest{
+						Id:                      sl.Int(vmID),
+						PrimaryBackendIpAddress: sl.String("10.112.10.20"),
+						TagReferences: []datatypes.Tag_Reference{
+							{Tag: &datatypes.Tag{Name: sl.String("load_balancer:fake-lb-uuid1")}},
+							{Tag: &datatypes.Tag{Name: sl.String("load_balancer:fake-lb-uuid2")}},
+						},
+					},
+					true,
+					nil,
+				)
+				cli.GetLoadBalancerReturnsOnCall(0,
+					&datatypes.Network_LBaaS_LoadBalancer{
+						Uuid: sl.String("fake-lb-uuid1"),
+						Members: []datatypes.Network_LBaaS_Member{
+							{Uuid: sl.String("fake-member-uuid1"), Address: sl.String("10.112.10.20"), Weight: sl.Int(50)},
+						},
+					},
+					true,
+					nil,
+				)
+				cli.GetLoadBalancerReturnsOnCall(1,
+					&datatypes.Network_LBaaS_LoadBalancer{
+						Uuid: sl.String("fake-lb-uuid2"),
+						Members: []datatypes.Network_LBaaS_Member{
+							{Uuid: sl.String("fake-member-uuid2"), Address: sl.String("10.112.10.20"), Weight: sl.Int(50)},
+						},
+					},
+					true,
+					nil,
+				)
+				var drainedAt time.Time
+				cli.UpdateLoadBalancerMemberWeightStub = func(string, string, int) error {
+					drainedAt = time.Now()
+					return nil
+				}
+				var removedAt []time.Time
+				cli.DeleteLoadBalancerMemberStub = func(string, string) error {
+					removedAt = append(removedAt, time.Now())
+					return nil
+				}
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.UpdateLoadBalancerMemberWeightCallCount()).To(Equal(2))
+				Expect(removedAt).To(HaveLen(2))
+				Expect(removedAt[0].Sub(drainedAt)).To(BeNumerically(">=", 100*time.Millisecond))
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+			})
+
+			It("Cancels the VM when a load balancer it is tagged with does not exist", func() {
+				cli.GetInstanceReturns(
+					&datatypes.Virtual_Guest{
+						Id:                      sl.Int(vmID),
+						PrimaryBackendIpAddress: sl.String("10.112.10.20"),
+						TagReferences: []datatypes.Tag_Reference{
+							{Tag: &datatypes.Tag{Name: sl.String("load_balancer:fake-lb-uuid1")}},
+						},
+					},
+					true,
+					nil,
+				)
+				cli.GetLoadBalancerReturns(&datatypes.Network_LBaaS_LoadBalancer{}, false, nil)
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.DeleteLoadBalancerMemberCallCount()).To(Equal(0))
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+			})
+
+			It("Cancels the VM when getting a load balancer it is tagged with fails", func() {
+				cli.GetInstanceReturns(
+					&datatypes.Virtual_Guest{
+						Id:                      sl.Int(vmID),
+						PrimaryBackendIpAddress: sl.String("10.112.10.20"),
+						TagReferences: []datatypes.Tag_Reference{
+							{Tag: &datatypes.Tag{Name: sl.String("load_balancer:fake-lb-uuid1")}},
+						},
+					},
+					true,
+					nil,
+				)
+				cli.GetLoadBalancerReturns(&datatypes.Network_LBaaS_LoadBalancer{}, false, errors.New("fake-client-error"))
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.DeleteLoadBalancerMemberCallCount()).To(Equal(0))
+				Expect(cli.CancelInstanceCallCount()).To(Equal(1))
+			})
+
+			It("Return error if removing the VM from a load balancer fails", func() {
+				cli.GetInstanceReturns(
+					&datatypes.Virtual_Guest{
+						Id:                      sl.Int(vmID),
+						PrimaryBackendIpAddress: sl.String("10.112.10.20"),
+						TagReferences: []datatypes.Tag_Reference{
+							{Tag: &datatypes.Tag{Name: sl.String("load_balancer:fake-lb-uuid1")}},
+						},
+					},
+					true,
+					nil,
+				)
+				cli.GetLoadBalancerReturns(
+					&datatypes.Network_LBaaS_LoadBalancer{
+						Uuid: sl.String("fake-lb-uuid1"),
+						Members: []datatypes.Network_LBaaS_Member{
+							{Uuid: sl.String("fake-member-uuid2"), Address: sl.String("10.112.10.20"), Weight: sl.Int(0)},
+						},
+					},
+					true,
+					nil,
+				)
+				cli.DeleteLoadBalancerMemberReturns(errors.New("fake-client-error"))
+
+				err := virtualGuestService.Delete(vmID, enableVps)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("from load balancers"))
+				Expect(cli.UpdateLoadBalancerMemberWeightCallCount()).To(Equal(0))
+				Expect(cli.CancelInstanceCallCount()).To(Equal(0))
+			})
 		})
 	})
 })
@@ -22,7 +22,7 @@ func (vg SoftlayerVirtualGuestService) Find(id int) (*datatypes.Virtual_Guest, e
 	//@TODO: Need to encapsulate with stemcell find method
 	execStmtRetryable := boshretry.NewRetryable(
 		func() (bool, error) {
-			instance, found, err = vg.softlayerClient.GetInstance(id, "id, datacenter[name], primaryBackendIpAddress, fullyQualifiedDomainName")
+			instance, found, err = vg.softlayerClient.GetInstance(id, "id, datacenter[name], primaryBackendIpAddress, fullyQualifiedDomainName, billingItem[id]")
 			if err != nil {
 				return true, bosherr.WrapErrorf(err, "Failed to find SoftLayer VirtualGuest with id '%d'", id)
 			}
@@ -31,6 +31,17 @@ func (vg SoftlayerVirtualGuestService) Find(id int) (*datatypes.Virtual_Guest, e
 				return false, api.NewVMNotFoundError(strconv.Itoa(id))
 			}
 
+			// A reclaimed transient guest is cancelled, it is gone once deprovisioned
+			if instance.BillingItem == nil {
+				reclaimed, err := vg.softlayerClient.IsInstanceReclaimed(id)
+				if err != nil {
+					return true, bosherr.WrapErrorf(err, "Checking whether SoftLayer VirtualGuest with id '%d' is reclaimed", id)
+				}
+				if reclaimed {
+					return false, api.NewVMNotFoundError(strconv.Itoa(id))
+				}
+			}
+
 			return false, nil
 		})
 	timeService := clock.NewClock()
@@ -12,6 +12,7 @@ import (
 	"github.com/softlayer/softlayer-go/datatypes"
 	"github.com/softlayer/softlayer-go/sl"
 
+	"bosh-softlayer-cpi/api"
 	cpiLog "bosh-softlayer-cpi/logger"
 	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
 	. "bosh-softlayer-cpi/softlayer/virtual_guest_service"
@@ -81,6 +82,38 @@ var _ = Describe("Virtual Guest Service", func() {
 			Expect(err.Error()).To(ContainSubstring("not found"))
 			Expect(softLayerClient.GetInstanceCallCount()).To(Equal(1))
 		})
+
+		It("Return a VMNotFoundError if the transient vm is reclaimed", func() {
+			softLayerClient.GetInstanceReturns(
+				&datatypes.Virtual_Guest{
+					Id: sl.Int(12345678),
+				},
+				true,
+				nil,
+			)
+			softLayerClient.IsInstanceReclaimedReturns(true, nil)
+
+			_, err := virtualGuestService.Find(vmID)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(api.VMNotFoundError)
+			Expect(ok).To(BeTrue())
+			Expect(softLayerClient.IsInstanceReclaimedArgsForCall(0)).To(Equal(vmID))
+		})
+
+		It("Does not check billed vms for reclaim", func() {
+			softLayerClient.GetInstanceReturns(
+				&datatypes.Virtual_Guest{
+					Id:          sl.Int(12345678),
+					BillingItem: &datatypes.Billing_Item_Virtual_Guest{},
+				},
+				true,
+				nil,
+			)
+
+			_, err := virtualGuestService.Find(vmID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(softLayerClient.IsInstanceReclaimedCallCount()).To(Equal(0))
+		})
 	})
 
 	Describe("Call FindByPrimaryBackendIp", func() {
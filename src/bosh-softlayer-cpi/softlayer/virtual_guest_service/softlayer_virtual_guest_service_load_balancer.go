@@ -0,0 +1,194 @@
+package instance
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/datatypes"
+
+	"bosh-softlayer-cpi/api"
+)
+
+const (
+	loadBalancerUpdateTimeout = 10 * time.Minute
+
+	// loadBalancerTagPrefix tags the VM with the UUID of every load balancer the CPI added it to
+	loadBalancerTagPrefix = "load_balancer:"
+)
+
+// AddLoadBalancerMember adds the primary backend IP of the instance to the load balancer of member, a
+// member serves all pools of the load balancer. A member with that IP is kept, only its weight is
+// updated, so retried calls do not add it twice. The VM is tagged with the load balancer before it
+// joins, delete_vm removes it only from the load balancers it is tagged with.
+func (vg SoftlayerVirtualGuestService) AddLoadBalancerMember(id int, member LoadBalancerMember) error {
+	instance, found, err := vg.softlayerClient.GetInstance(id, "id, primaryBackendIpAddress, tagReferences.tag.name")
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Failed to find SoftLayer VirtualGuest with id '%d'", id)
+	}
+	if !found || instance.PrimaryBackendIpAddress == nil {
+		return api.NewVMNotFoundError(strconv.Itoa(id))
+	}
+	ip := *instance.PrimaryBackendIpAddress
+
+	loadBalancer, found, err := vg.softlayerClient.GetLoadBalancer(member.UUID)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Getting load balancer '%s'", member.UUID)
+	}
+	if !found {
+		return bosherr.Errorf("Load balancer '%s' does not exist", member.UUID)
+	}
+
+	if err = vg.tagLoadBalancer(instance, member.UUID); err != nil {
+		return bosherr.WrapErrorf(err, "Tagging VirtualGuest '%d' with load balancer '%s'", id, member.UUID)
+	}
+
+	until := time.Now().Add(loadBalancerUpdateTimeout)
+	for _, existing := range loadBalancer.Members {
+		if existing.Address == nil || *existing.Address != ip {
+			continue
+		}
+
+		if existing.Weight != nil && *existing.Weight == member.Weight {
+			vg.logger.Debug(softlayerVirtualGuestServiceLogTag, "VirtualGuest '%d' is a member of load balancer '%s' already", id, member.UUID)
+			return nil
+		}
+
+		vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Updating weight of VirtualGuest '%d' in load balancer '%s' to '%d'", id, member.UUID, member.Weight)
+		return vg.updateLoadBalancer(member.UUID, until, func() error {
+			return vg.softlayerClient.UpdateLoadBalancerMemberWeight(member.UUID, *existing.Uuid, member.Weight)
+		})
+	}
+
+	vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Adding VirtualGuest '%d' with IP '%s' to load balancer '%s'", id, ip, member.UUID)
+	return vg.updateLoadBalancer(member.UUID, until, func() error {
+		return vg.softlayerClient.AddLoadBalancerMember(member.UUID, ip, member.Weight)
+	})
+}
+
+// tagLoadBalancer adds the tag of the load balancer with uuid to the tags of instance.
+func (vg SoftlayerVirtualGuestService) tagLoadBalancer(instance *datatypes.Virtual_Guest, uuid string) error {
+	tag := loadBalancerTagPrefix + uuid
+	tags := tagNames(instance)
+	for _, name := range tags {
+		if name == tag {
+			return nil
+		}
+	}
+
+	found, err := vg.softlayerClient.SetTags(*instance.Id, strings.Join(append(tags, tag), ","))
+	if err != nil {
+		return err
+	}
+	if !found {
+		return api.NewVMNotFoundError(strconv.Itoa(*instance.Id))
+	}
+
+	return nil
+}
+
+// loadBalancerTags returns the load balancer tags of instance.
+func loadBalancerTags(instance *datatypes.Virtual_Guest) []string {
+	tags := []string{}
+	for _, name := range tagNames(instance) {
+		if strings.HasPrefix(name, loadBalancerTagPrefix) {
+			tags = append(tags, name)
+		}
+	}
+	return tags
+}
+
+func tagNames(instance *datatypes.Virtual_Guest) []string {
+	names := []string{}
+	for _, reference := range instance.TagReferences {
+		if reference.Tag != nil && reference.Tag.Name != nil {
+			names = append(names, *reference.Tag.Name)
+		}
+	}
+	return names
+}
+
+// removeLoadBalancerMembers drains the members with ip of the load balancers the instance is tagged
+// with by setting their weight to 0, waits the drain period for their open connections, then removes
+// them. A load balancer that can not be read is skipped, the VM is cancelled anyway.
+func (vg SoftlayerVirtualGuestService) removeLoadBalancerMembers(instance *datatypes.Virtual_Guest, ip string) error {
+	type loadBalancerMember struct {
+		loadBalancerUuid string
+		uuid             string
+	}
+	var members []loadBalancerMember
+	drained := false
+
+	until := time.Now().Add(loadBalancerUpdateTimeout)
+	for _, tag := range loadBalancerTags(instance) {
+		uuid := strings.TrimPrefix(tag, loadBalancerTagPrefix)
+		loadBalancer, found, err := vg.softlayerClient.GetLoadBalancer(uuid)
+		if err != nil {
+			vg.logger.Warn(softlayerVirtualGuestServiceLogTag, "Skipping member '%s' of load balancer '%s', getting the load balancer: %s", ip, uuid, err)
+			continue
+		}
+		if !found {
+			vg.logger.Debug(softlayerVirtualGuestServiceLogTag, "Skipping member '%s' of load balancer '%s', the load balancer does not exist", ip, uuid)
+			continue
+		}
+
+		for _, member := range loadBalancer.Members {
+			if member.Address == nil || *member.Address != ip || member.Uuid == nil {
+				continue
+			}
+			memberUuid := *member.Uuid
+			members = append(members, loadBalancerMember{loadBalancerUuid: uuid, uuid: memberUuid})
+
+			// A member drained by an earlier try had its drain period
+			if member.Weight != nil && *member.Weight == 0 {
+				continue
+			}
+
+			vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Draining member '%s' of load balancer '%s'", ip, uuid)
+			err = vg.updateLoadBalancer(uuid, until, func() error {
+				return vg.softlayerClient.UpdateLoadBalancerMemberWeight(uuid, memberUuid, 0)
+			})
+			if err != nil {
+				return bosherr.WrapErrorf(err, "Draining member '%s' of load balancer '%s'", ip, uuid)
+			}
+			drained = true
+		}
+	}
+
+	if drained && vg.loadBalancerDrainPeriod > 0 {
+		vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Waiting %s for the connections to drained member '%s' to finish", vg.loadBalancerDrainPeriod, ip)
+		time.Sleep(vg.loadBalancerDrainPeriod)
+	}
+
+	until = time.Now().Add(loadBalancerUpdateTimeout)
+	for _, member := range members {
+		vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Removing member '%s' from load balancer '%s'", ip, member.loadBalancerUuid)
+		err := vg.updateLoadBalancer(member.loadBalancerUuid, until, func() error {
+			return vg.softlayerClient.DeleteLoadBalancerMember(member.loadBalancerUuid, member.uuid)
+		})
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Removing member '%s' from load balancer '%s'", ip, member.loadBalancerUuid)
+		}
+	}
+
+	return nil
+}
+
+// updateLoadBalancer runs update once the load balancer applied its pending updates and waits for it
+// to apply this one.
+func (vg SoftlayerVirtualGuestService) updateLoadBalancer(uuid string, until time.Time, update func() error) error {
+	if err := vg.softlayerClient.WaitLoadBalancerActive(uuid, until); err != nil {
+		return bosherr.WrapErrorf(err, "Waiting load balancer '%s' to be active", uuid)
+	}
+
+	if err := update(); err != nil {
+		return err
+	}
+
+	if err := vg.softlayerClient.WaitLoadBalancerActive(uuid, until); err != nil {
+		return bosherr.WrapErrorf(err, "Waiting load balancer '%s' to be active", uuid)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package instance_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	cpiLog "bosh-softlayer-cpi/logger"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	. "bosh-softlayer-cpi/softlayer/virtual_guest_service"
+)
+
+var _ = Describe("Virtual Guest Service", func() {
+	var (
+		cli                 *fakeslclient.FakeClient
+		uuidGen             *fakeuuid.FakeGenerator
+		logger              cpiLog.Logger
+		virtualGuestService SoftlayerVirtualGuestService
+	)
+
+	BeforeEach(func() {
+		cli = &fakeslclient.FakeClient{}
+		uuidGen = &fakeuuid.FakeGenerator{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		virtualGuestService = NewSoftLayerVirtualGuestService(cli, uuidGen, logger)
+	})
+
+	Describe("Call AddLoadBalancerMember", func() {
+		var (
+			vmID         int
+			member       LoadBalancerMember
+			loadBalancer *datatypes.Network_LBaaS_LoadBalancer
+		)
+
+		BeforeEach(func() {
+			vmID = 12345678
+			member = LoadBalancerMember{UUID: "fake-lb-uuid", Weight: 50}
+			loadBalancer = &datatypes.Network_LBaaS_LoadBalancer{
+				Uuid: sl.String("fake-lb-uuid"),
+			}
+
+			cli.GetInstanceReturns(
+				&datatypes.Virtual_Guest{
+					Id:                      sl.Int(vmID),
+					PrimaryBackendIpAddress: sl.String("10.112.10.20"),
+				},
+				true,
+				nil,
+			)
+			cli.GetLoadBalancerReturns(loadBalancer, true, nil)
+			cli.SetTagsReturns(true, nil)
+		})
+
+		It("Adds the backend IP of the VM as member", func() {
+			err := virtualGuestService.AddLoadBalancerMember(vmID, member)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.GetLoadBalancerArgsForCall(0)).To(Equal("fake-lb-uuid"))
+			Expect(cli.SetTagsCallCount()).To(Equal(1))
+			id, tags := cli.SetTagsArgsForCall(0)
+			Expect(id).To(Equal(vmID))
+			Expect(tags).To(Equal("load_balancer:fake-lb-uuid"))
+			Expect(cli.AddLoadBalancerMemberCallCount()).To(Equal(1))
+			uuid, ip, weight := cli.AddLoadBalancerMemberArgsForCall(0)
+			Expect(uuid).To(Equal("fake-lb-uuid"))
+			Expect(ip).To(Equal("10.112.10.20"))
+			Expect(weight).To(Equal(50))
+			Expect(cli.WaitLoadBalancerActiveCallCount()).To(Equal(2))
+		})
+
+		It("Does not add the VM again when it is a member already", func() {
+			loadBalancer.Members = []datatypes.Network_LBaaS_Member{
+				{Uuid: sl.String("fake-member-uuid"), Address: sl.String("10.112.10.20"), Weight: sl.Int(50)},
+			}
+
+			err := virtualGuestService.AddLoadBalancerMember(vmID, member)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.AddLoadBalancerMemberCallCount()).To(Equal(0))
+			Expect(cli.UpdateLoadBalancerMemberWeightCallCount()).To(Equal(0))
+		})
+
+		It("Keeps the tags of the VM and does not tag it twice", func() {
+			cli.GetInstanceReturnsOnCall(0,
+				&datatypes.Virtual_Guest{
+					Id:                      sl.Int(vmID),
+					PrimaryBackendIpAddress: sl.String("10.112.10.20"),
+					TagReferences: []datatypes.Tag_Reference{
+						{Tag: &datatypes.Tag{Name: sl.String("deployment:fake-deployment")}},
+					},
+				},
+				true,
+				nil,
+			)
+			cli.GetInstanceReturnsOnCall(1,
+				&datatypes.Virtual_Guest{
+					Id:                      sl.Int(vmID),
+					PrimaryBackendIpAddress: sl.String("10.112.10.20"),
+					TagReferences: []datatypes.Tag_Reference{
+						{Tag: &datatypes.Tag{Name: sl.String("deployment:fake-deployment")}},
+						{Tag: &datatypes.Tag{Name: sl.String("load_balancer:fake-lb-uuid")}},
+					},
+				},
+				true,
+				nil,
+			)
+
+			err := virtualGuestService.AddLoadBalancerMember(vmID, member)
+			Expect(err).NotTo(HaveOccurred())
+			err = virtualGuestService.AddLoadBalancerMember(vmID, member)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.SetTagsCallCount()).To(Equal(1))
+			_, tags := cli.SetTagsArgsForCall(0)
+			Expect(tags).To(Equal("deployment:fake-deployment,load_balancer:fake-lb-uuid"))
+		})
+
+		It("Return error when tagging the VM fails", func() {
+			cli.SetTagsReturns(false, errors.New("fake-client-error"))
+
+			err := virtualGuestService.AddLoadBalancerMember(vmID, member)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+			Expect(cli.AddLoadBalancerMemberCallCount()).To(Equal(0))
+		})
+
+		It("Updates the weight when the VM is a member with another weight", func() {
+			loadBalancer.Members = []datatypes.Network_LBaaS_Member{
+				{Uuid: sl.String("fake-member-uuid"), Address: sl.String("10.112.10.20"), Weight: sl.Int(10)},
+			}
+
+			err := virtualGuestService.AddLoadBalancerMember(vmID, member)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.AddLoadBalancerMemberCallCount()).To(Equal(0))
+			uuid, memberUuid, weight := cli.UpdateLoadBalancerMemberWeightArgsForCall(0)
+			Expect(uuid).To(Equal("fake-lb-uuid"))
+			Expect(memberUuid).To(Equal("fake-member-uuid"))
+			Expect(weight).To(Equal(50))
+		})
+
+		It("Return error when the load balancer does not exist", func() {
+			cli.GetLoadBalancerReturns(&datatypes.Network_LBaaS_LoadBalancer{}, false, nil)
+
+			err := virtualGuestService.AddLoadBalancerMember(vmID, member)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Load balancer 'fake-lb-uuid' does not exist"))
+		})
+
+		It("Return error when the load balancer does not become active", func() {
+			cli.WaitLoadBalancerActiveReturns(errors.New("fake-client-error"))
+
+			err := virtualGuestService.AddLoadBalancerMember(vmID, member)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+			Expect(cli.AddLoadBalancerMemberCallCount()).To(Equal(0))
+		})
+
+		It("Return error when the VM does not exist", func() {
+			cli.GetInstanceReturns(&datatypes.Virtual_Guest{}, false, nil)
+
+			err := virtualGuestService.AddLoadBalancerMember(vmID, member)
+			Expect(err).To(HaveOccurred())
+			Expect(cli.GetLoadBalancerCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -4,27 +4,42 @@ import (
 	"bytes"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/clock"
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshretry "github.com/cloudfoundry/bosh-utils/retrystrategy"
+	"github.com/softlayer/softlayer-go/datatypes"
 
 	"bosh-softlayer-cpi/api"
 )
 
+// SetMetadata replaces the tags of the VM with the tags of vmMetadata, the load balancer and networks
+// record tags the CPI set are kept.
 func (vg SoftlayerVirtualGuestService) SetMetadata(id int, vmMetadata Metadata) error {
 	tags, err := vg.extractTagsFromVMMetadata(vmMetadata)
 	if err != nil {
 		return bosherr.WrapError(err, "Extracting tags from vm metadata")
 	}
 
-	var (
-		found bool
-	)
 	execStmtRetryable := boshretry.NewRetryable(
 		func() (bool, error) {
-			found, err = vg.softlayerClient.SetTags(id, tags)
+			instance, found, err := vg.softlayerClient.GetInstance(id, "id, tagReferences.tag.name")
+			if err != nil {
+				return true, bosherr.WrapErrorf(err, "Getting tags of virtualGuest '%d'", id)
+			}
+
+			if !found {
+				return false, api.NewVMNotFoundError(strconv.Itoa(id))
+			}
+
+			allTags := cpiTags(instance)
+			if tags != "" {
+				allTags = append([]string{tags}, allTags...)
+			}
+
+			found, err = vg.softlayerClient.SetTags(id, strings.Join(allTags, ","))
 			if err != nil {
 				return true, bosherr.WrapErrorf(err, "Settings tags on virtualGuest '%d'", id)
 			}
@@ -50,6 +65,17 @@ func (vg SoftlayerVirtualGuestService) SetMetadata(id int, vmMetadata Metadata)
 	return nil
 }
 
+// cpiTags returns the tags the CPI set on instance.
+func cpiTags(instance *datatypes.Virtual_Guest) []string {
+	tags := []string{}
+	for _, name := range tagNames(instance) {
+		if strings.HasPrefix(name, loadBalancerTagPrefix) || strings.HasPrefix(name, networksTagPrefix) || strings.HasPrefix(name, vmSecurityGroupTagPrefix) {
+			tags = append(tags, name)
+		}
+	}
+	return tags
+}
+
 func (vg SoftlayerVirtualGuestService) extractTagsFromVMMetadata(vmMetadata Metadata) (string, error) {
 	var tagStringBuffer bytes.Buffer
 	for tagName, tagValue := range vmMetadata {
@@ -57,21 +83,7 @@ func (vg SoftlayerVirtualGuestService) extractTagsFromVMMetadata(vmMetadata Meta
 			continue
 		}
 
-		// https://softlayer.github.io/reference/services/SoftLayer_Tag/setTags
-		// The characters permitted are A-Z, 0-9, whitespace, _ (underscore), - (hypen), . (period), and : (colon).
-		reg, err := regexp.Compile(`[^\w \-.:]+`)
-		if err != nil {
-			return "", bosherr.WrapError(err, "There is a problem with your regexp: '[^\\w \\-.:]+'. That is used to strips out all invalid characters")
-		}
-		cleanTagString := reg.ReplaceAllString(tagValue.(string), "")
-
-		regColon, err := regexp.Compile(`[:]+`)
-		if err != nil {
-			return "", bosherr.WrapError(err, "There is a problem with your regexp: '[:]+'. That is used to strips out all ':' characters")
-		}
-		convertedTagString := regColon.ReplaceAllString(cleanTagString, "-")
-
-		_, err = tagStringBuffer.WriteString(tagName + ":" + convertedTagString)
+		_, err := tagStringBuffer.WriteString(tagName + ":" + cleanTagValue(tagValue.(string)))
 		if err != nil {
 			return tagStringBuffer.String(), err
 		}
@@ -84,3 +96,16 @@ func (vg SoftlayerVirtualGuestService) extractTagsFromVMMetadata(vmMetadata Meta
 
 	return tagStringBuffer.String(), nil
 }
+
+// https://softlayer.github.io/reference/services/SoftLayer_Tag/setTags
+// The characters permitted are A-Z, 0-9, whitespace, _ (underscore), - (hypen), . (period), and : (colon).
+var (
+	invalidTagCharacters = regexp.MustCompile(`[^\w \-.:]+`)
+	tagColons            = regexp.MustCompile(`[:]+`)
+)
+
+// cleanTagValue strips out the characters not permitted in a tag, and replaces colons, which
+// separate the name of a tag from its value.
+func cleanTagValue(value string) string {
+	return tagColons.ReplaceAllString(invalidTagCharacters.ReplaceAllString(value, ""), "-")
+}
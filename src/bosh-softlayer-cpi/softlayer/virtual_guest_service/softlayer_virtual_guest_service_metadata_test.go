@@ -10,6 +10,8 @@ import (
 
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
 
 	cpiLog "bosh-softlayer-cpi/logger"
 	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
@@ -44,6 +46,8 @@ var _ = Describe("Virtual Guest Service", func() {
 				"director":   "fake-director-uuid",
 				"compiling":  "fake-compiling",
 			}
+
+			cli.GetInstanceReturns(&datatypes.Virtual_Guest{Id: sl.Int(vmID)}, true, nil)
 		})
 
 		It("Set tags successfully", func() {
@@ -117,6 +121,43 @@ var _ = Describe("Virtual Guest Service", func() {
 			Expect(len(matches)).To(Equal(1))
 		})
 
+		It("Keeps the load balancer and networks record tags of the VM", func() {
+			metaData = Metadata{
+				"deployment": "fake-deployment",
+			}
+			cli.GetInstanceReturns(
+				&datatypes.Virtual_Guest{
+					Id: sl.Int(vmID),
+					TagReferences: []datatypes.Tag_Reference{
+						{Tag: &datatypes.Tag{Name: sl.String("deployment:fake-old-deployment")}},
+						{Tag: &datatypes.Tag{Name: sl.String("load_balancer:fake-lb-uuid")}},
+						{Tag: &datatypes.Tag{Name: sl.String("networks:fake-digest")}},
+						{Tag: &datatypes.Tag{Name: sl.String("vm_security_group:private:21345")}},
+					},
+				},
+				true,
+				nil,
+			)
+			cli.SetTagsReturns(
+				true,
+				nil,
+			)
+
+			err := virtualGuestService.SetMetadata(vmID, metaData)
+			Expect(err).NotTo(HaveOccurred())
+			_, tags := cli.SetTagsArgsForCall(0)
+			Expect(tags).To(Equal("deployment:fake-deployment,load_balancer:fake-lb-uuid,networks:fake-digest,vm_security_group:private:21345"))
+		})
+
+		It("Return error if the VM does not exist", func() {
+			cli.GetInstanceReturns(&datatypes.Virtual_Guest{}, false, nil)
+
+			err := virtualGuestService.SetMetadata(vmID, metaData)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("VM '%d' not found", vmID)))
+			Expect(cli.SetTagsCallCount()).To(Equal(0))
+		})
+
 		It("Return error if softLayerClient SetTags call returns an error", func() {
 			cli.SetTagsReturns(
 				false,
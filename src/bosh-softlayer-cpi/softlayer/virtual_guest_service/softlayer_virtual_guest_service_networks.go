@@ -26,6 +26,10 @@ func (vg SoftlayerVirtualGuestService) ConfigureNetworks(id int, networks Networ
 		LinkNamer: NewIndexedNamer(networks),
 	}
 
+	// VIPs are bound as aliases of the public interface, they have no network component of their own
+	vipNetworks := networks.VipNetworks()
+	networks = networks.WithoutVipNetworks()
+
 	componentByNetwork, err := ubuntu.ComponentByNetworkName(*instance, networks)
 	if err != nil {
 		return networks, bosherr.WrapError(err, "Mapping network component and name")
@@ -56,6 +60,22 @@ func (vg SoftlayerVirtualGuestService) ConfigureNetworks(id int, networks Networ
 	}
 	vg.logger.Debug(softlayerVirtualGuestServiceLogTag, "Finalized network definition: %+v", networks)
 
+	for name, network := range vipNetworks {
+		if err = vg.routeVip(id, *instance, name, network); err != nil {
+			return networks, bosherr.WrapErrorf(err, "Routing VIP network '%s'", name)
+		}
+	}
+
+	vipNetworks, err = ubuntu.FinalizedVipNetworkDefinitions(*instance, vipNetworks)
+	if err != nil {
+		return networks, bosherr.WrapError(err, "Finalizing VIP networks definitions")
+	}
+	vg.logger.Debug(softlayerVirtualGuestServiceLogTag, "Finalized VIP network definition: %+v", vipNetworks)
+
+	for name, network := range vipNetworks {
+		networks[name] = network
+	}
+
 	return networks, nil
 }
 
@@ -0,0 +1,110 @@
+package instance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/datatypes"
+
+	"bosh-softlayer-cpi/api"
+)
+
+const (
+	// networksTagPrefix tags the VM with the digest of the networks it was created for
+	networksTagPrefix = "networks:"
+
+	// vmSecurityGroupTagPrefix tags the VM with the security groups of its cloud properties, followed
+	// by the network component, public or private, and the ID of the group
+	vmSecurityGroupTagPrefix = "vm_security_group:"
+)
+
+// NetworksRecord is what create_vm records of the networks of a VM for configure_networks: the
+// digest of the networks the VM was created for and the security groups of its cloud properties.
+type NetworksRecord struct {
+	Digest         string
+	SecurityGroups SecurityGroups
+}
+
+// RecordNetworks tags the VM with record, replacing the record an OS reloaded VM has of its earlier
+// deployment.
+func (vg SoftlayerVirtualGuestService) RecordNetworks(id int, record NetworksRecord) error {
+	instance, found, err := vg.softlayerClient.GetInstance(id, "id, tagReferences.tag.name")
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Getting tags of virtualGuest '%d'", id)
+	}
+	if !found {
+		return api.NewVMNotFoundError(strconv.Itoa(id))
+	}
+
+	tags := []string{}
+	for _, name := range tagNames(instance) {
+		if !strings.HasPrefix(name, networksTagPrefix) && !strings.HasPrefix(name, vmSecurityGroupTagPrefix) {
+			tags = append(tags, name)
+		}
+	}
+	tags = append(tags, networksTagPrefix+record.Digest)
+	for _, groupID := range record.SecurityGroups.Public {
+		tags = append(tags, fmt.Sprintf("%spublic:%d", vmSecurityGroupTagPrefix, groupID))
+	}
+	for _, groupID := range record.SecurityGroups.Private {
+		tags = append(tags, fmt.Sprintf("%sprivate:%d", vmSecurityGroupTagPrefix, groupID))
+	}
+
+	found, err = vg.softlayerClient.SetTags(id, strings.Join(tags, ","))
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Settings tags on virtualGuest '%d'", id)
+	}
+	if !found {
+		return api.NewVMNotFoundError(strconv.Itoa(id))
+	}
+
+	return nil
+}
+
+// RecordedNetworks returns the networks record of the VM. It is not found for VMs created by earlier
+// CPI versions.
+func (vg SoftlayerVirtualGuestService) RecordedNetworks(id int) (NetworksRecord, bool, error) {
+	instance, found, err := vg.softlayerClient.GetInstance(id, "id, tagReferences.tag.name")
+	if err != nil {
+		return NetworksRecord{}, false, bosherr.WrapErrorf(err, "Getting tags of virtualGuest '%d'", id)
+	}
+	if !found {
+		return NetworksRecord{}, false, api.NewVMNotFoundError(strconv.Itoa(id))
+	}
+
+	return networksRecord(instance)
+}
+
+func networksRecord(instance *datatypes.Virtual_Guest) (NetworksRecord, bool, error) {
+	record := NetworksRecord{}
+	for _, name := range tagNames(instance) {
+		if strings.HasPrefix(name, networksTagPrefix) {
+			record.Digest = strings.TrimPrefix(name, networksTagPrefix)
+			continue
+		}
+		if !strings.HasPrefix(name, vmSecurityGroupTagPrefix) {
+			continue
+		}
+
+		fields := strings.Split(strings.TrimPrefix(name, vmSecurityGroupTagPrefix), ":")
+		if len(fields) != 2 {
+			return NetworksRecord{}, false, bosherr.Errorf("Parsing security group tag '%s'", name)
+		}
+		groupID, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return NetworksRecord{}, false, bosherr.WrapErrorf(err, "Parsing security group tag '%s'", name)
+		}
+		switch fields[0] {
+		case "public":
+			record.SecurityGroups.Public = append(record.SecurityGroups.Public, groupID)
+		case "private":
+			record.SecurityGroups.Private = append(record.SecurityGroups.Private, groupID)
+		default:
+			return NetworksRecord{}, false, bosherr.Errorf("Parsing security group tag '%s'", name)
+		}
+	}
+
+	return record, record.Digest != "", nil
+}
@@ -0,0 +1,135 @@
+package instance_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/api"
+	cpiLog "bosh-softlayer-cpi/logger"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	. "bosh-softlayer-cpi/softlayer/virtual_guest_service"
+)
+
+var _ = Describe("Virtual Guest Service", func() {
+	var (
+		cli                 *fakeslclient.FakeClient
+		uuidGen             *fakeuuid.FakeGenerator
+		logger              cpiLog.Logger
+		virtualGuestService SoftlayerVirtualGuestService
+
+		vmID int
+	)
+
+	BeforeEach(func() {
+		cli = &fakeslclient.FakeClient{}
+		uuidGen = &fakeuuid.FakeGenerator{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		virtualGuestService = NewSoftLayerVirtualGuestService(cli, uuidGen, logger)
+
+		vmID = 12345678
+		cli.GetInstanceReturns(
+			&datatypes.Virtual_Guest{
+				Id: sl.Int(vmID),
+				TagReferences: []datatypes.Tag_Reference{
+					{Tag: &datatypes.Tag{Name: sl.String("deployment:fake-deployment")}},
+					{Tag: &datatypes.Tag{Name: sl.String("networks:fake-old-digest")}},
+					{Tag: &datatypes.Tag{Name: sl.String("vm_security_group:private:21344")}},
+				},
+			},
+			true,
+			nil,
+		)
+	})
+
+	Describe("Call RecordNetworks", func() {
+		It("Replaces the networks record tags of the VM", func() {
+			cli.SetTagsReturns(true, nil)
+
+			err := virtualGuestService.RecordNetworks(vmID, NetworksRecord{
+				Digest:         "fake-digest",
+				SecurityGroups: SecurityGroups{Public: []int{21345}, Private: []int{21345, 21346}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.SetTagsCallCount()).To(Equal(1))
+			id, tags := cli.SetTagsArgsForCall(0)
+			Expect(id).To(Equal(vmID))
+			Expect(tags).To(Equal("deployment:fake-deployment,networks:fake-digest,vm_security_group:public:21345,vm_security_group:private:21345,vm_security_group:private:21346"))
+		})
+
+		It("Return error if the VM does not exist", func() {
+			cli.GetInstanceReturns(&datatypes.Virtual_Guest{}, false, nil)
+
+			err := virtualGuestService.RecordNetworks(vmID, NetworksRecord{Digest: "fake-digest"})
+			Expect(err).To(BeAssignableToTypeOf(api.VMNotFoundError{}))
+			Expect(cli.SetTagsCallCount()).To(Equal(0))
+		})
+
+		It("Return error if softLayerClient SetTags call returns an error", func() {
+			cli.SetTagsReturns(false, errors.New("fake-client-error"))
+
+			err := virtualGuestService.RecordNetworks(vmID, NetworksRecord{Digest: "fake-digest"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+
+	Describe("Call RecordedNetworks", func() {
+		It("Returns the networks record of the VM", func() {
+			record, found, err := virtualGuestService.RecordedNetworks(vmID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(record.Digest).To(Equal("fake-old-digest"))
+			Expect(record.SecurityGroups.Public).To(BeEmpty())
+			Expect(record.SecurityGroups.Private).To(Equal([]int{21344}))
+		})
+
+		It("Does not find a record on a VM created by an earlier CPI version", func() {
+			cli.GetInstanceReturns(
+				&datatypes.Virtual_Guest{
+					Id: sl.Int(vmID),
+					TagReferences: []datatypes.Tag_Reference{
+						{Tag: &datatypes.Tag{Name: sl.String("deployment:fake-deployment")}},
+					},
+				},
+				true,
+				nil,
+			)
+
+			_, found, err := virtualGuestService.RecordedNetworks(vmID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("Return error if a security group tag is malformed", func() {
+			cli.GetInstanceReturns(
+				&datatypes.Virtual_Guest{
+					Id: sl.Int(vmID),
+					TagReferences: []datatypes.Tag_Reference{
+						{Tag: &datatypes.Tag{Name: sl.String("networks:fake-digest")}},
+						{Tag: &datatypes.Tag{Name: sl.String("vm_security_group:private:fake-id")}},
+					},
+				},
+				true,
+				nil,
+			)
+
+			_, _, err := virtualGuestService.RecordedNetworks(vmID)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Parsing security group tag 'vm_security_group:private:fake-id'"))
+		})
+
+		It("Return error if the VM does not exist", func() {
+			cli.GetInstanceReturns(&datatypes.Virtual_Guest{}, false, nil)
+
+			_, _, err := virtualGuestService.RecordedNetworks(vmID)
+			Expect(err).To(BeAssignableToTypeOf(api.VMNotFoundError{}))
+		})
+	})
+})
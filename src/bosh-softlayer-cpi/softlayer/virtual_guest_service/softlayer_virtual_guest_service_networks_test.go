@@ -201,6 +201,137 @@ var _ = Describe("Virtual Guest Service", func() {
 			Expect(err.Error()).To(ContainSubstring("Normalizing dynamic networks definitions"))
 			Expect(cli.GetInstanceCallCount()).To(Equal(1))
 		})
+
+		Context("when networks have a vip network", func() {
+			BeforeEach(func() {
+				networks["fake-vip"] = Network{
+					Type: "vip",
+					IP:   "169.55.61.215",
+				}
+			})
+
+			It("Routes the global IP to the public IP of the VM and records the route", func() {
+				cli.GetGlobalIpReturns(
+					&datatypes.Network_Subnet_IpAddress_Global{
+						Id:          sl.Int(52703),
+						IpAddressId: sl.Int(65633607),
+						IpAddress: &datatypes.Network_Subnet_IpAddress{
+							IpAddress: sl.String("169.55.61.215"),
+						},
+					},
+					true,
+					nil,
+				)
+
+				configured, err := virtualGuestService.ConfigureNetworks(vmID, networks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.GetGlobalIpArgsForCall(0)).To(Equal("169.55.61.215"))
+				Expect(cli.RouteGlobalIpCallCount()).To(Equal(1))
+				globalIpID, destination := cli.RouteGlobalIpArgsForCall(0)
+				Expect(globalIpID).To(Equal(52703))
+				Expect(destination).To(Equal("fake-ip-addr1"))
+				Expect(cli.SetIpAddressNoteCallCount()).To(Equal(1))
+				ipAddressID, note := cli.SetIpAddressNoteArgsForCall(0)
+				Expect(ipAddressID).To(Equal(65633607))
+				Expect(note).To(Equal("bosh-softlayer-cpi: routed to VM 12345678"))
+
+				Expect(configured["fake-vip"].Type).To(Equal("manual"))
+				Expect(configured["fake-vip"].IP).To(Equal("169.55.61.215"))
+				Expect(configured["fake-vip"].Netmask).To(Equal("255.255.255.255"))
+				Expect(configured["fake-vip"].MAC).To(Equal("fake-mac-addr1"))
+				Expect(configured["fake-vip"].Alias).To(HavePrefix("fake-network14344:"))
+			})
+
+			It("Does not route the global IP again when it is routed to the VM", func() {
+				cli.GetGlobalIpReturns(
+					&datatypes.Network_Subnet_IpAddress_Global{
+						Id:          sl.Int(52703),
+						IpAddressId: sl.Int(65633607),
+						IpAddress: &datatypes.Network_Subnet_IpAddress{
+							IpAddress: sl.String("169.55.61.215"),
+							Note:      sl.String("bosh-softlayer-cpi: routed to VM 12345678"),
+						},
+						DestinationIpAddress: &datatypes.Network_Subnet_IpAddress{
+							IpAddress: sl.String("fake-ip-addr1"),
+						},
+					},
+					true,
+					nil,
+				)
+
+				_, err := virtualGuestService.ConfigureNetworks(vmID, networks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.RouteGlobalIpCallCount()).To(Equal(0))
+				Expect(cli.SetIpAddressNoteCallCount()).To(Equal(0))
+			})
+
+			It("Return error if client call SetIpAddressNote returns an error", func() {
+				cli.GetGlobalIpReturns(
+					&datatypes.Network_Subnet_IpAddress_Global{
+						Id:          sl.Int(52703),
+						IpAddressId: sl.Int(65633607),
+					},
+					true,
+					nil,
+				)
+				cli.SetIpAddressNoteReturns(errors.New("fake-client-error"))
+
+				_, err := virtualGuestService.ConfigureNetworks(vmID, networks)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Recording the route of global IP '169.55.61.215'"))
+			})
+
+			It("Accepts a portable public IP of the public VLAN of the VM", func() {
+				cli.GetGlobalIpReturns(&datatypes.Network_Subnet_IpAddress_Global{}, false, nil)
+				cli.GetSubnetByIpAddressReturns(
+					&datatypes.Network_Subnet{
+						Id:            sl.Int(882091),
+						AddressSpace:  sl.String("PUBLIC"),
+						NetworkVlanId: sl.Int(22345),
+					},
+					true,
+					nil,
+				)
+
+				configured, err := virtualGuestService.ConfigureNetworks(vmID, networks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.RouteGlobalIpCallCount()).To(Equal(0))
+				Expect(configured).To(HaveKey("fake-vip"))
+			})
+
+			It("Return error if the VIP is neither a global IP nor a portable IP of the VLAN", func() {
+				cli.GetGlobalIpReturns(&datatypes.Network_Subnet_IpAddress_Global{}, false, nil)
+				cli.GetSubnetByIpAddressReturns(
+					&datatypes.Network_Subnet{
+						Id:            sl.Int(882091),
+						AddressSpace:  sl.String("PUBLIC"),
+						NetworkVlanId: sl.Int(99999),
+					},
+					true,
+					nil,
+				)
+
+				_, err := virtualGuestService.ConfigureNetworks(vmID, networks)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("neither a global IP nor a portable public IP"))
+			})
+
+			It("Return error if client call RouteGlobalIp returns an error", func() {
+				cli.GetGlobalIpReturns(
+					&datatypes.Network_Subnet_IpAddress_Global{
+						Id: sl.Int(52703),
+					},
+					true,
+					nil,
+				)
+				cli.RouteGlobalIpReturns(errors.New("fake-client-error"))
+
+				_, err := virtualGuestService.ConfigureNetworks(vmID, networks)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Routing VIP network 'fake-vip'"))
+				Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+			})
+		})
 	})
 
 	Describe("Call GetVlan", func() {
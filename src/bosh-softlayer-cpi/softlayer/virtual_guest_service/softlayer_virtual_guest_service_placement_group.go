@@ -0,0 +1,161 @@
+package instance
+
+import (
+	"strconv"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/datatypes"
+
+	bosl "bosh-softlayer-cpi/softlayer/client"
+)
+
+// placementGroupNamePrefix prefixes the names of the placement groups created by the CPI.
+const placementGroupNamePrefix = "bosh-"
+
+// AutoPlacementGroupName returns the name of the placement group the CPI creates for the VMs of the
+// instance group of deployment.
+func AutoPlacementGroupName(deployment string, instanceGroup string) string {
+	return placementGroupNamePrefix + deployment + "-" + instanceGroup
+}
+
+// FindPlacementGroup returns the ID of the existing placement group referred to by ID or by name.
+func (vg SoftlayerVirtualGuestService) FindPlacementGroup(ref string) (int, error) {
+	placementGroups, err := vg.softlayerClient.GetPlacementGroups()
+	if err != nil {
+		return 0, bosherr.WrapError(err, "Listing placement groups")
+	}
+
+	refID, idErr := strconv.Atoi(ref)
+	for _, placementGroup := range placementGroups {
+		if placementGroup.Id == nil {
+			continue
+		}
+		if (idErr == nil && *placementGroup.Id == refID) || (placementGroup.Name != nil && *placementGroup.Name == ref) {
+			return *placementGroup.Id, nil
+		}
+	}
+
+	return 0, bosherr.Errorf("Placement group '%s' does not exist", ref)
+}
+
+// EnsurePlacementGroup returns the ID of the placement group with name, creating it behind the backend
+// router of the VLAN when it does not exist. Its VMs must be on VLANs behind the same router.
+func (vg SoftlayerVirtualGuestService) EnsurePlacementGroup(name string, backendVlanID int) (int, error) {
+	vlan, found, err := vg.softlayerClient.GetVlan(backendVlanID, "id, primaryRouter.id")
+	if err != nil {
+		return 0, bosherr.WrapErrorf(err, "Getting vlan info with id '%d'", backendVlanID)
+	}
+	if !found || vlan.PrimaryRouter == nil || vlan.PrimaryRouter.Id == nil {
+		return 0, bosherr.Errorf("Backend router of vlan '%d' not found", backendVlanID)
+	}
+	routerID := *vlan.PrimaryRouter.Id
+
+	placementGroups, err := vg.softlayerClient.GetPlacementGroups()
+	if err != nil {
+		return 0, bosherr.WrapError(err, "Listing placement groups")
+	}
+
+	if placementGroup, ok := firstPlacementGroup(placementGroups, name); ok {
+		if placementGroup.BackendRouterId != nil && *placementGroup.BackendRouterId != routerID {
+			return 0, bosherr.Errorf("Placement group '%s' is behind backend router '%d', not behind router '%d' of vlan '%d'", name, *placementGroup.BackendRouterId, routerID, backendVlanID)
+		}
+		return *placementGroup.Id, nil
+	}
+
+	vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Creating placement group '%s' behind backend router '%d'", name, routerID)
+	created, err := vg.softlayerClient.CreatePlacementGroup(name, routerID)
+	if err != nil {
+		return 0, err
+	}
+	createdID := *created.Id
+
+	// VMs of the same instance group are created concurrently, all of them keep the first group
+	// created and delete the groups they created too
+	placementGroups, err = vg.softlayerClient.GetPlacementGroups()
+	if err != nil {
+		vg.logger.Warn(softlayerVirtualGuestServiceLogTag, "Using placement group '%d', listing placement groups again: %s", createdID, err)
+		return createdID, nil
+	}
+
+	placementGroup, ok := firstPlacementGroup(placementGroups, name)
+	if !ok || *placementGroup.Id >= createdID {
+		return createdID, nil
+	}
+
+	vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Using placement group '%d' created concurrently, deleting placement group '%d'", *placementGroup.Id, createdID)
+	if err = vg.softlayerClient.DeletePlacementGroup(createdID); err != nil {
+		vg.logger.Warn(softlayerVirtualGuestServiceLogTag, "Deleting placement group '%d': %s", createdID, err)
+	}
+
+	return *placementGroup.Id, nil
+}
+
+// releasePlacementGroup deletes the placement group the CPI created for the deployment and instance
+// group of instance, which is cancelled, once it was its last guest. Guests with a cancellation
+// pending do not count, VMs deleted in parallel are cancelled before they look at the group, so the
+// last of them to look deletes it. The deployment and instance group are taken from the tags
+// set_vm_metadata set, groups with other names are referenced by the operator and kept. Failures are
+// only logged, the group is reused by the next VM.
+func (vg SoftlayerVirtualGuestService) releasePlacementGroup(placementGroupID int, instance *datatypes.Virtual_Guest) {
+	id := *instance.Id
+	deployment, instanceGroup := "", ""
+	for _, name := range tagNames(instance) {
+		if strings.HasPrefix(name, "deployment:") {
+			deployment = strings.TrimPrefix(name, "deployment:")
+		}
+		if strings.HasPrefix(name, "job:") {
+			instanceGroup = strings.TrimPrefix(name, "job:")
+		}
+	}
+	if deployment == "" || instanceGroup == "" {
+		vg.logger.Debug(softlayerVirtualGuestServiceLogTag, "Keeping placement group '%d', VirtualGuest '%d' has no deployment and job tags", placementGroupID, id)
+		return
+	}
+
+	placementGroup, found, err := vg.softlayerClient.GetPlacementGroup(placementGroupID)
+	if err != nil {
+		vg.logger.Warn(softlayerVirtualGuestServiceLogTag, "Skipping placement group '%d', getting it: %s", placementGroupID, err)
+		return
+	}
+	// Tag values are cleaned, so is the name
+	if !found || placementGroup.Name == nil || cleanTagValue(*placementGroup.Name) != AutoPlacementGroupName(deployment, instanceGroup) {
+		return
+	}
+
+	for _, guest := range placementGroup.Guests {
+		if guest.Id != nil && *guest.Id != id && !cancellationPending(guest) {
+			return
+		}
+	}
+
+	vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Deleting placement group '%s' of its last VirtualGuest '%d'", *placementGroup.Name, id)
+	if err = vg.softlayerClient.DeletePlacementGroup(placementGroupID); err != nil {
+		vg.logger.Warn(softlayerVirtualGuestServiceLogTag, "Deleting placement group '%d': %s", placementGroupID, err)
+	}
+}
+
+// cancellationPending returns true if guest is cancelled already, its billing item is cancelled or it
+// waits to be reclaimed.
+func cancellationPending(guest datatypes.Virtual_Guest) bool {
+	if guest.BillingItem != nil && guest.BillingItem.CancellationDate != nil {
+		return true
+	}
+	transaction := guest.ActiveTransaction
+	return transaction != nil && transaction.TransactionStatus != nil && transaction.TransactionStatus.Name != nil &&
+		*transaction.TransactionStatus.Name == "RECLAIM_WAIT"
+}
+
+// firstPlacementGroup returns the placement group with name and the lowest ID.
+func firstPlacementGroup(placementGroups []bosl.PlacementGroup, name string) (bosl.PlacementGroup, bool) {
+	var first bosl.PlacementGroup
+	for _, placementGroup := range placementGroups {
+		if placementGroup.Id == nil || placementGroup.Name == nil || *placementGroup.Name != name {
+			continue
+		}
+		if first.Id == nil || *placementGroup.Id < *first.Id {
+			first = placementGroup
+		}
+	}
+	return first, first.Id != nil
+}
@@ -0,0 +1,158 @@
+package instance_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	cpiLog "bosh-softlayer-cpi/logger"
+	"bosh-softlayer-cpi/softlayer/client"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	. "bosh-softlayer-cpi/softlayer/virtual_guest_service"
+)
+
+var _ = Describe("Virtual Guest Service", func() {
+	var (
+		cli                 *fakeslclient.FakeClient
+		uuidGen             *fakeuuid.FakeGenerator
+		logger              cpiLog.Logger
+		virtualGuestService SoftlayerVirtualGuestService
+	)
+
+	BeforeEach(func() {
+		cli = &fakeslclient.FakeClient{}
+		uuidGen = &fakeuuid.FakeGenerator{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		virtualGuestService = NewSoftLayerVirtualGuestService(cli, uuidGen, logger)
+
+		cli.GetPlacementGroupsReturns(
+			[]client.PlacementGroup{
+				{Id: sl.Int(1234), Name: sl.String("cf-spread"), BackendRouterId: sl.Int(511)},
+				{Id: sl.Int(1235), Name: sl.String("bosh-cf-router"), BackendRouterId: sl.Int(511)},
+			},
+			nil,
+		)
+	})
+
+	Describe("Call FindPlacementGroup", func() {
+		It("Resolves an ID or a name", func() {
+			id, err := virtualGuestService.FindPlacementGroup("1235")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(1235))
+
+			id, err = virtualGuestService.FindPlacementGroup("cf-spread")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(1234))
+		})
+
+		It("Returns an error when the placement group does not exist", func() {
+			_, err := virtualGuestService.FindPlacementGroup("cf-pack")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Placement group 'cf-pack' does not exist"))
+		})
+
+		It("Returns an error when listing the placement groups fails", func() {
+			cli.GetPlacementGroupsReturns([]client.PlacementGroup{}, errors.New("fake-client-error"))
+
+			_, err := virtualGuestService.FindPlacementGroup("cf-spread")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+
+	Describe("Call EnsurePlacementGroup", func() {
+		BeforeEach(func() {
+			cli.GetVlanReturns(
+				&datatypes.Network_Vlan{
+					Id:            sl.Int(42345678),
+					PrimaryRouter: &datatypes.Hardware_Router{Hardware_Switch: datatypes.Hardware_Switch{Hardware: datatypes.Hardware{Id: sl.Int(511)}}},
+				},
+				true,
+				nil,
+			)
+			cli.CreatePlacementGroupReturns(&client.PlacementGroup{Id: sl.Int(1236)}, nil)
+		})
+
+		It("Returns the existing placement group", func() {
+			id, err := virtualGuestService.EnsurePlacementGroup("bosh-cf-router", 42345678)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(1235))
+			Expect(cli.CreatePlacementGroupCallCount()).To(Equal(0))
+		})
+
+		It("Creates the placement group behind the backend router of the vlan", func() {
+			id, err := virtualGuestService.EnsurePlacementGroup("bosh-cf-diego_cell", 42345678)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(1236))
+			Expect(cli.CreatePlacementGroupCallCount()).To(Equal(1))
+			name, routerID := cli.CreatePlacementGroupArgsForCall(0)
+			Expect(name).To(Equal("bosh-cf-diego_cell"))
+			Expect(routerID).To(Equal(511))
+		})
+
+		It("Keeps the first of the placement groups created concurrently", func() {
+			cli.CreatePlacementGroupReturns(&client.PlacementGroup{Id: sl.Int(1237)}, nil)
+			cli.GetPlacementGroupsReturnsOnCall(1,
+				[]client.PlacementGroup{
+					{Id: sl.Int(1237), Name: sl.String("bosh-cf-diego_cell"), BackendRouterId: sl.Int(511)},
+					{Id: sl.Int(1236), Name: sl.String("bosh-cf-diego_cell"), BackendRouterId: sl.Int(511)},
+				},
+				nil,
+			)
+
+			id, err := virtualGuestService.EnsurePlacementGroup("bosh-cf-diego_cell", 42345678)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(1236))
+			Expect(cli.DeletePlacementGroupCallCount()).To(Equal(1))
+			Expect(cli.DeletePlacementGroupArgsForCall(0)).To(Equal(1237))
+		})
+
+		It("Keeps the placement group it created when it is the first", func() {
+			cli.GetPlacementGroupsReturnsOnCall(1,
+				[]client.PlacementGroup{
+					{Id: sl.Int(1237), Name: sl.String("bosh-cf-diego_cell"), BackendRouterId: sl.Int(511)},
+					{Id: sl.Int(1236), Name: sl.String("bosh-cf-diego_cell"), BackendRouterId: sl.Int(511)},
+				},
+				nil,
+			)
+
+			id, err := virtualGuestService.EnsurePlacementGroup("bosh-cf-diego_cell", 42345678)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(1236))
+			Expect(cli.DeletePlacementGroupCallCount()).To(Equal(0))
+		})
+
+		It("Returns an error when the placement group is behind another router", func() {
+			cli.GetPlacementGroupsReturns(
+				[]client.PlacementGroup{{Id: sl.Int(1235), Name: sl.String("bosh-cf-router"), BackendRouterId: sl.Int(512)}},
+				nil,
+			)
+
+			_, err := virtualGuestService.EnsurePlacementGroup("bosh-cf-router", 42345678)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is behind backend router '512'"))
+		})
+
+		It("Returns an error when the vlan does not exist", func() {
+			cli.GetVlanReturns(&datatypes.Network_Vlan{}, false, nil)
+
+			_, err := virtualGuestService.EnsurePlacementGroup("bosh-cf-router", 42345678)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Backend router of vlan '42345678' not found"))
+		})
+
+		It("Returns an error when creating the placement group fails", func() {
+			cli.CreatePlacementGroupReturns(&client.PlacementGroup{}, errors.New("fake-client-error"))
+
+			_, err := virtualGuestService.EnsurePlacementGroup("bosh-cf-diego_cell", 42345678)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+})
@@ -0,0 +1,266 @@
+package instance
+
+import (
+	"sort"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/sl"
+
+	bsl "bosh-softlayer-cpi/softlayer/client"
+)
+
+// CalculateResources maps cpu, memory (MB) and ephemeral disk size (MB) onto the smallest
+// public flavor that fits, or onto cpu/memory/disk sizes the virtual server package can order.
+func (vg SoftlayerVirtualGuestService) CalculateResources(cpu int, memory int, ephemeralDiskSize int) (Resources, error) {
+	vg.logger.Debug(softlayerVirtualGuestServiceLogTag, "Calculating resources for cpu '%d', memory '%d' and ephemeral disk size '%d'", cpu, memory, ephemeralDiskSize)
+
+	productPackage, err := vg.softlayerClient.GetVirtualServerPackage(bsl.VIRTUAL_SERVER_PACKAGE_PRICES_MASK)
+	if err != nil {
+		return Resources{}, bosherr.WrapError(err, "Getting virtual server package")
+	}
+
+	resources := Resources{}
+	memoryGB := ceilDiv(memory, 1024)
+
+	resources.FlavorKeyName = smallestPublicFlavor(productPackage.ActivePresets, cpu, memoryGB)
+	if resources.FlavorKeyName == "" {
+		resources.Cpu, err = smallestCapacity(productPackage.ItemPrices, product.CPUCategoryCode, cpu, isPublicCorePrice)
+		if err != nil {
+			return Resources{}, err
+		}
+
+		ramCapacity, err := smallestCapacity(productPackage.ItemPrices, product.MemoryCategoryCode, memoryGB, isSharedHostPrice)
+		if err != nil {
+			return Resources{}, err
+		}
+		resources.Memory = ramCapacity * 1024
+	}
+
+	if ephemeralDiskSize > 0 {
+		resources.EphemeralDiskSize, err = smallestCapacity(productPackage.ItemPrices, bsl.EPHEMERAL_DISK_CATEGORY_CODE, ceilDiv(ephemeralDiskSize, 1024), isSanDiskPrice)
+		if err != nil {
+			return Resources{}, err
+		}
+	}
+
+	return resources, nil
+}
+
+// ResolveOrderItems returns items with the ephemeral disk size rounded up to the smallest disk the
+// virtual server package can order, or an error if the root disk size, the flavor or the GPU flavor
+// of items can not be ordered from the package in the datacenter.
+func (vg SoftlayerVirtualGuestService) ResolveOrderItems(items OrderItems) (OrderItems, error) {
+	if items.RootDiskSize == 0 && items.EphemeralDiskSize == 0 && items.Flavor == "" && items.GpuFlavor == "" {
+		return items, nil
+	}
+
+	productPackage, err := vg.softlayerClient.GetVirtualServerPackage(bsl.VIRTUAL_SERVER_PACKAGE_PRICES_MASK)
+	if err != nil {
+		return OrderItems{}, bosherr.WrapError(err, "Getting virtual server package")
+	}
+
+	accept := isSanDiskPrice
+	if items.LocalDisk {
+		accept = func(price datatypes.Product_Item_Price) bool { return !isSanDiskPrice(price) }
+	}
+
+	if items.RootDiskSize != 0 {
+		capacities := capacitiesOf(productPackage.ItemPrices, bsl.ROOT_DISK_CATEGORY_CODE, accept)
+		if !containsInt(capacities, items.RootDiskSize) {
+			return OrderItems{}, bosherr.Errorf("Root disk size %d GB can not be ordered, sizes are %v", items.RootDiskSize, capacities)
+		}
+	}
+
+	if items.EphemeralDiskSize != 0 {
+		items.EphemeralDiskSize, err = smallestCapacity(productPackage.ItemPrices, bsl.EPHEMERAL_DISK_CATEGORY_CODE, items.EphemeralDiskSize, accept)
+		if err != nil {
+			return OrderItems{}, err
+		}
+	}
+
+	if items.Flavor != "" {
+		preset, found := activePreset(productPackage.ActivePresets, items.Flavor)
+		if !found {
+			return OrderItems{}, bosherr.Errorf("Flavor '%s' does not exist", items.Flavor)
+		}
+		if !presetInDatacenter(preset, items.Datacenter) {
+			return OrderItems{}, bosherr.Errorf("Flavor '%s' is not available in datacenter '%s'", items.Flavor, items.Datacenter)
+		}
+	}
+
+	if items.GpuFlavor != "" {
+		preset, found := activePreset(productPackage.ActivePresets, items.GpuFlavor)
+		if !found {
+			return OrderItems{}, bosherr.Errorf("Flavor '%s' does not exist", items.GpuFlavor)
+		}
+		if !presetHasCategory(preset, bsl.GPU_CATEGORY_CODE) {
+			return OrderItems{}, bosherr.Errorf("Flavor '%s' has no GPU", items.GpuFlavor)
+		}
+		if !presetInDatacenter(preset, items.Datacenter) {
+			return OrderItems{}, bosherr.Errorf("Flavor '%s' is not available in datacenter '%s'", items.GpuFlavor, items.Datacenter)
+		}
+	}
+
+	return items, nil
+}
+
+// flavorSizes returns the cores and the memory in MB of flavor, from its preset in the virtual
+// server package.
+func (vg SoftlayerVirtualGuestService) flavorSizes(flavor string) (int, int, error) {
+	productPackage, err := vg.softlayerClient.GetVirtualServerPackage(bsl.VIRTUAL_SERVER_PACKAGE_PRICES_MASK)
+	if err != nil {
+		return 0, 0, bosherr.WrapError(err, "Getting virtual server package")
+	}
+
+	preset, found := activePreset(productPackage.ActivePresets, flavor)
+	if !found {
+		return 0, 0, bosherr.Errorf("Flavor '%s' does not exist", flavor)
+	}
+
+	cpu, memoryGB, _ := presetSizes(preset)
+	return cpu, memoryGB * 1024, nil
+}
+
+func activePreset(presets []datatypes.Product_Package_Preset, keyName string) (datatypes.Product_Package_Preset, bool) {
+	for _, preset := range presets {
+		if sl.Get(preset.IsActive, "1").(string) == "1" && sl.Get(preset.KeyName, "").(string) == keyName {
+			return preset, true
+		}
+	}
+	return datatypes.Product_Package_Preset{}, false
+}
+
+func presetHasCategory(preset datatypes.Product_Package_Preset, categoryCode string) bool {
+	for _, price := range preset.Prices {
+		if hasCategoryCode(price, categoryCode) {
+			return true
+		}
+	}
+	return false
+}
+
+// presetInDatacenter returns true if the preset is available in the datacenter, presets without
+// locations are available in every datacenter of the package.
+func presetInDatacenter(preset datatypes.Product_Package_Preset, datacenter string) bool {
+	if len(preset.Locations) == 0 {
+		return true
+	}
+	for _, location := range preset.Locations {
+		if sl.Get(location.Name, "").(string) == datacenter {
+			return true
+		}
+	}
+	return false
+}
+
+func smallestPublicFlavor(presets []datatypes.Product_Package_Preset, cpu int, memoryGB int) string {
+	var (
+		flavor       string
+		flavorCpu    int
+		flavorMemory int
+	)
+
+	for _, preset := range presets {
+		if sl.Get(preset.IsActive, "1").(string) != "1" || preset.KeyName == nil {
+			continue
+		}
+
+		presetCpu, presetMemory, public := presetSizes(preset)
+		if !public || presetCpu < cpu || presetMemory < memoryGB {
+			continue
+		}
+
+		if flavor == "" || presetMemory < flavorMemory || (presetMemory == flavorMemory && presetCpu < flavorCpu) {
+			flavor, flavorCpu, flavorMemory = *preset.KeyName, presetCpu, presetMemory
+		}
+	}
+
+	return flavor
+}
+
+// presetSizes returns the cores and the memory in GB of preset, and whether it is a public flavor
+// without GPU.
+func presetSizes(preset datatypes.Product_Package_Preset) (int, int, bool) {
+	cpu, memoryGB, public := 0, 0, true
+	for _, price := range preset.Prices {
+		if price.Item == nil || price.Item.Capacity == nil {
+			continue
+		}
+		switch {
+		case hasCategoryCode(price, product.CPUCategoryCode):
+			cpu = int(*price.Item.Capacity)
+			public = public && isPublicCorePrice(price)
+		case hasCategoryCode(price, product.MemoryCategoryCode):
+			memoryGB = int(*price.Item.Capacity)
+		case hasCategoryCode(price, bsl.GPU_CATEGORY_CODE):
+			public = false
+		}
+	}
+	return cpu, memoryGB, public
+}
+
+func smallestCapacity(prices []datatypes.Product_Item_Price, categoryCode string, size int, accept func(datatypes.Product_Item_Price) bool) (int, error) {
+	for _, capacity := range capacitiesOf(prices, categoryCode, accept) {
+		if capacity >= size {
+			return capacity, nil
+		}
+	}
+
+	return 0, bosherr.Errorf("No orderable '%s' item fits size %d", categoryCode, size)
+}
+
+// capacitiesOf returns the sorted capacities of the standard prices in the category, which are
+// available in every datacenter.
+func capacitiesOf(prices []datatypes.Product_Item_Price, categoryCode string, accept func(datatypes.Product_Item_Price) bool) []int {
+	capacities := []int{}
+	for _, price := range prices {
+		if price.LocationGroupId != nil || price.Item == nil || price.Item.Capacity == nil {
+			continue
+		}
+		if !hasCategoryCode(price, categoryCode) || !accept(price) {
+			continue
+		}
+		capacities = append(capacities, int(*price.Item.Capacity))
+	}
+
+	sort.Ints(capacities)
+	return capacities
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasCategoryCode(price datatypes.Product_Item_Price, categoryCode string) bool {
+	for _, category := range price.Categories {
+		if sl.Get(category.CategoryCode, "").(string) == categoryCode {
+			return true
+		}
+	}
+	return false
+}
+
+func isPublicCorePrice(price datatypes.Product_Item_Price) bool {
+	keyName := sl.Get(price.Item.KeyName, "").(string)
+	return !strings.Contains(keyName, "PRIVATE") && !strings.Contains(keyName, "DEDICATED")
+}
+
+func isSharedHostPrice(price datatypes.Product_Item_Price) bool {
+	return !strings.Contains(sl.Get(price.Item.KeyName, "").(string), "DEDICATED")
+}
+
+func isSanDiskPrice(price datatypes.Product_Item_Price) bool {
+	return strings.Contains(sl.Get(price.Item.Description, "").(string), "(SAN)")
+}
+
+func ceilDiv(size int, unit int) int {
+	return (size + unit - 1) / unit
+}
@@ -0,0 +1,216 @@
+package instance_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	cpiLog "bosh-softlayer-cpi/logger"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	. "bosh-softlayer-cpi/softlayer/virtual_guest_service"
+)
+
+var _ = Describe("Virtual Guest Service", func() {
+	var (
+		cli                 *fakeslclient.FakeClient
+		uuidGen             *fakeuuid.FakeGenerator
+		logger              cpiLog.Logger
+		virtualGuestService SoftlayerVirtualGuestService
+	)
+
+	itemPrice := func(categoryCode string, keyName string, description string, capacity float64) datatypes.Product_Item_Price {
+		return datatypes.Product_Item_Price{
+			Categories: []datatypes.Product_Item_Category{
+				{CategoryCode: sl.String(categoryCode)},
+			},
+			Item: &datatypes.Product_Item{
+				KeyName:     sl.String(keyName),
+				Description: sl.String(description),
+				Capacity:    sl.Float(capacity),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cli = &fakeslclient.FakeClient{}
+		uuidGen = &fakeuuid.FakeGenerator{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		virtualGuestService = NewSoftLayerVirtualGuestService(cli, uuidGen, logger)
+
+		cli.GetVirtualServerPackageReturns(
+			datatypes.Product_Package{
+				Id: sl.Int(46),
+				ActivePresets: []datatypes.Product_Package_Preset{
+					{
+						KeyName:  sl.String("B1_4X8X25"),
+						IsActive: sl.String("1"),
+						Prices: []datatypes.Product_Item_Price{
+							itemPrice("guest_core", "GUEST_CORES_4", "4 x 2.0 GHz Cores", 4),
+							itemPrice("ram", "RAM_8_GB", "8 GB", 8),
+						},
+					},
+					{
+						KeyName:  sl.String("B1_2X4X25"),
+						IsActive: sl.String("1"),
+						Prices: []datatypes.Product_Item_Price{
+							itemPrice("guest_core", "GUEST_CORES_2", "2 x 2.0 GHz Cores", 2),
+							itemPrice("ram", "RAM_4_GB", "4 GB", 4),
+						},
+					},
+					{
+						KeyName:  sl.String("AC1_8X60X25"),
+						IsActive: sl.String("1"),
+						Prices: []datatypes.Product_Item_Price{
+							itemPrice("guest_core", "GUEST_CORES_8", "8 x 2.0 GHz Cores", 8),
+							itemPrice("ram", "RAM_60_GB", "60 GB", 60),
+							itemPrice("guest_pcie_device0", "GPU_P100", "1 x P100 GPU", 1),
+						},
+						Locations: []datatypes.Location{{Name: sl.String("dal13")}, {Name: sl.String("wdc07")}},
+					},
+				},
+				ItemPrices: []datatypes.Product_Item_Price{
+					itemPrice("guest_core", "GUEST_CORES_16", "16 x 2.0 GHz Cores", 16),
+					itemPrice("guest_core", "GUEST_PRIVATE_CORES_12", "12 x 2.0 GHz Cores (Dedicated)", 12),
+					itemPrice("guest_core", "GUEST_CORES_32", "32 x 2.0 GHz Cores", 32),
+					itemPrice("ram", "RAM_64_GB", "64 GB", 64),
+					itemPrice("ram", "RAM_32_GB", "32 GB", 32),
+					itemPrice("guest_disk1", "GUEST_DISK_100_GB_LOCAL", "100 GB (LOCAL)", 100),
+					itemPrice("guest_disk1", "GUEST_DISK_100_GB_SAN", "100 GB (SAN)", 100),
+					itemPrice("guest_disk1", "GUEST_DISK_25_GB_SAN", "25 GB (SAN)", 25),
+					itemPrice("guest_disk1", "GUEST_DISK_2000_GB_SAN", "2,000 GB (SAN)", 2000),
+					itemPrice("guest_disk0", "GUEST_DISK_25_GB_SAN", "25 GB (SAN)", 25),
+					itemPrice("guest_disk0", "GUEST_DISK_100_GB_SAN", "100 GB (SAN)", 100),
+					itemPrice("guest_disk0", "GUEST_DISK_25_GB_LOCAL", "25 GB (LOCAL)", 25),
+				},
+			},
+			nil,
+		)
+	})
+
+	Describe("Call CalculateResources", func() {
+		It("returns the smallest public flavor that fits", func() {
+			resources, err := virtualGuestService.CalculateResources(2, 3072, 30*1024)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(Equal(Resources{
+				FlavorKeyName:     "B1_2X4X25",
+				EphemeralDiskSize: 100,
+			}))
+			Expect(cli.GetVirtualServerPackageCallCount()).To(Equal(1))
+		})
+
+		It("returns cpu and memory snapped to orderable sizes when no flavor fits", func() {
+			resources, err := virtualGuestService.CalculateResources(10, 20*1024, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(Equal(Resources{
+				Cpu:    16,
+				Memory: 32 * 1024,
+			}))
+		})
+
+		It("Return error if no orderable size fits", func() {
+			_, err := virtualGuestService.CalculateResources(64, 1024, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("No orderable 'guest_core' item fits size 64"))
+		})
+
+		It("Return error if no ephemeral disk fits", func() {
+			_, err := virtualGuestService.CalculateResources(2, 4096, 4000*1024)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("No orderable 'guest_disk1' item fits size 4000"))
+		})
+
+		It("Return error if softLayerClient GetVirtualServerPackage call returns an error", func() {
+			cli.GetVirtualServerPackageReturns(
+				datatypes.Product_Package{},
+				errors.New("fake-client-error"),
+			)
+
+			_, err := virtualGuestService.CalculateResources(2, 4096, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+
+	Describe("Call ResolveOrderItems", func() {
+		It("accepts a root disk size, a flavor and a GPU flavor available in the datacenter", func() {
+			items, err := virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "dal13", RootDiskSize: 100, GpuFlavor: "AC1_8X60X25"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(items).To(Equal(OrderItems{Datacenter: "dal13", RootDiskSize: 100, GpuFlavor: "AC1_8X60X25"}))
+
+			items, err = virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "dal13", Flavor: "B1_2X4X25"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(items).To(Equal(OrderItems{Datacenter: "dal13", Flavor: "B1_2X4X25"}))
+		})
+
+		It("does not get the package without root disk size, ephemeral disk size and flavors", func() {
+			items, err := virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "dal13"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(items).To(Equal(OrderItems{Datacenter: "dal13"}))
+			Expect(cli.GetVirtualServerPackageCallCount()).To(Equal(0))
+		})
+
+		It("rounds the ephemeral disk size up to the smallest disk of the storage", func() {
+			items, err := virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "dal13", EphemeralDiskSize: 30})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(items.EphemeralDiskSize).To(Equal(100))
+
+			items, err = virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "dal13", EphemeralDiskSize: 20})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(items.EphemeralDiskSize).To(Equal(25))
+
+			items, err = virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "dal13", LocalDisk: true, EphemeralDiskSize: 20})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(items.EphemeralDiskSize).To(Equal(100))
+		})
+
+		It("Return error if no ephemeral disk of the storage fits", func() {
+			_, err := virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "dal13", LocalDisk: true, EphemeralDiskSize: 200})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("No orderable 'guest_disk1' item fits size 200"))
+		})
+
+		It("Return error if the root disk size can not be ordered on the storage", func() {
+			_, err := virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "dal13", LocalDisk: true, RootDiskSize: 100})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Root disk size 100 GB can not be ordered, sizes are [25]"))
+		})
+
+		It("Return error if the flavor does not exist", func() {
+			_, err := virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "dal13", Flavor: "B1_64X64X25"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Flavor 'B1_64X64X25' does not exist"))
+		})
+
+		It("Return error if the flavor is not available in the datacenter", func() {
+			_, err := virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "lon02", Flavor: "AC1_8X60X25"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Flavor 'AC1_8X60X25' is not available in datacenter 'lon02'"))
+		})
+
+		It("Return error if the flavor has no GPU", func() {
+			_, err := virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "dal13", GpuFlavor: "B1_4X8X25"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Flavor 'B1_4X8X25' has no GPU"))
+		})
+
+		It("Return error if the GPU flavor is not available in the datacenter", func() {
+			_, err := virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "lon02", GpuFlavor: "AC1_8X60X25"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Flavor 'AC1_8X60X25' is not available in datacenter 'lon02'"))
+		})
+
+		It("Return error if getting the package fails", func() {
+			cli.GetVirtualServerPackageReturns(datatypes.Product_Package{}, errors.New("fake-client-error"))
+
+			_, err := virtualGuestService.ResolveOrderItems(OrderItems{Datacenter: "dal13", RootDiskSize: 100})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+})
@@ -0,0 +1,127 @@
+package instance
+
+import (
+	"strconv"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/datatypes"
+
+	"bosh-softlayer-cpi/api"
+)
+
+const securityGroupsInstanceMask = "id, primaryNetworkComponent[id, securityGroupBindings.securityGroupId], " +
+	"primaryBackendNetworkComponent[id, securityGroupBindings.securityGroupId]"
+
+// FindSecurityGroups returns the IDs of the security groups referred to by ID or by name. A name
+// must match exactly one security group of the account.
+func (vg SoftlayerVirtualGuestService) FindSecurityGroups(refs []string) ([]int, error) {
+	if len(refs) == 0 {
+		return []int{}, nil
+	}
+
+	securityGroups, err := vg.softlayerClient.GetSecurityGroups()
+	if err != nil {
+		return []int{}, bosherr.WrapError(err, "Listing security groups")
+	}
+
+	ids := []int{}
+	for _, ref := range refs {
+		var matches []int
+		refID, err := strconv.Atoi(ref)
+		for _, securityGroup := range securityGroups {
+			if securityGroup.Id == nil {
+				continue
+			}
+			if (err == nil && *securityGroup.Id == refID) || (securityGroup.Name != nil && *securityGroup.Name == ref) {
+				matches = append(matches, *securityGroup.Id)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return []int{}, bosherr.Errorf("Security group '%s' does not exist", ref)
+		case 1:
+			ids = appendMissing(ids, matches[0])
+		default:
+			return []int{}, bosherr.Errorf("Security group name '%s' is ambiguous, use one of the IDs %v", ref, matches)
+		}
+	}
+
+	return ids, nil
+}
+
+// ConfigureSecurityGroups attaches the security groups to the primary and backend network components of
+// the instance. With detachOthers, the groups bound to a component but not in groups are detached.
+func (vg SoftlayerVirtualGuestService) ConfigureSecurityGroups(id int, groups SecurityGroups, detachOthers bool) error {
+	instance, found, err := vg.softlayerClient.GetInstance(id, securityGroupsInstanceMask)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Failed to find SoftLayer VirtualGuest with id '%d'", id)
+	}
+	if !found {
+		return api.NewVMNotFoundError(strconv.Itoa(id))
+	}
+
+	if instance.PrimaryNetworkComponent == nil && len(groups.Public) > 0 {
+		return bosherr.Errorf("Security groups %v of the public network component require a public network", groups.Public)
+	}
+
+	if err = vg.configureComponentSecurityGroups(id, instance.PrimaryNetworkComponent, groups.Public, detachOthers); err != nil {
+		return err
+	}
+
+	return vg.configureComponentSecurityGroups(id, instance.PrimaryBackendNetworkComponent, groups.Private, detachOthers)
+}
+
+func (vg SoftlayerVirtualGuestService) configureComponentSecurityGroups(id int, component *datatypes.Virtual_Guest_Network_Component, groupIDs []int, detachOthers bool) error {
+	if component == nil || component.Id == nil {
+		return nil
+	}
+	componentID := *component.Id
+
+	bound := map[int]bool{}
+	for _, binding := range component.SecurityGroupBindings {
+		if binding.SecurityGroupId != nil {
+			bound[*binding.SecurityGroupId] = true
+		}
+	}
+
+	desired := map[int]bool{}
+	for _, groupID := range groupIDs {
+		desired[groupID] = true
+		if bound[groupID] {
+			continue
+		}
+
+		vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Attaching security group '%d' to network component '%d' of VirtualGuest '%d'", groupID, componentID, id)
+		if err := vg.softlayerClient.AttachSecurityGroupComponents(groupID, []int{componentID}); err != nil {
+			return bosherr.WrapErrorf(err, "Attaching security group '%d' to network component '%d'", groupID, componentID)
+		}
+	}
+
+	if !detachOthers {
+		return nil
+	}
+
+	for _, binding := range component.SecurityGroupBindings {
+		if binding.SecurityGroupId == nil || desired[*binding.SecurityGroupId] {
+			continue
+		}
+		groupID := *binding.SecurityGroupId
+
+		vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Detaching security group '%d' from network component '%d' of VirtualGuest '%d'", groupID, componentID, id)
+		if err := vg.softlayerClient.DetachSecurityGroupComponents(groupID, []int{componentID}); err != nil {
+			return bosherr.WrapErrorf(err, "Detaching security group '%d' from network component '%d'", groupID, componentID)
+		}
+	}
+
+	return nil
+}
+
+func appendMissing(ids []int, id int) []int {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
@@ -0,0 +1,163 @@
+package instance_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"bosh-softlayer-cpi/api"
+	cpiLog "bosh-softlayer-cpi/logger"
+	fakeslclient "bosh-softlayer-cpi/softlayer/client/fakes"
+	. "bosh-softlayer-cpi/softlayer/virtual_guest_service"
+)
+
+var _ = Describe("Virtual Guest Service", func() {
+	var (
+		cli                 *fakeslclient.FakeClient
+		uuidGen             *fakeuuid.FakeGenerator
+		logger              cpiLog.Logger
+		virtualGuestService SoftlayerVirtualGuestService
+	)
+
+	BeforeEach(func() {
+		cli = &fakeslclient.FakeClient{}
+		uuidGen = &fakeuuid.FakeGenerator{}
+		logger = cpiLog.NewLogger(boshlog.LevelDebug, "")
+		virtualGuestService = NewSoftLayerVirtualGuestService(cli, uuidGen, logger)
+	})
+
+	Describe("Call FindSecurityGroups", func() {
+		BeforeEach(func() {
+			cli.GetSecurityGroupsReturns(
+				[]datatypes.Network_SecurityGroup{
+					{Id: sl.Int(21345), Name: sl.String("allow_ssh")},
+					{Id: sl.Int(21346), Name: sl.String("allow_outbound")},
+					{Id: sl.Int(21347), Name: sl.String("duplicated")},
+					{Id: sl.Int(21348), Name: sl.String("duplicated")},
+				},
+				nil,
+			)
+		})
+
+		It("Resolves IDs and names", func() {
+			ids, err := virtualGuestService.FindSecurityGroups([]string{"21346", "allow_ssh", "allow_outbound"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([]int{21346, 21345}))
+		})
+
+		It("Does not list the security groups without references", func() {
+			ids, err := virtualGuestService.FindSecurityGroups([]string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(BeEmpty())
+			Expect(cli.GetSecurityGroupsCallCount()).To(Equal(0))
+		})
+
+		It("Returns an error when a security group does not exist", func() {
+			_, err := virtualGuestService.FindSecurityGroups([]string{"allow_http"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Security group 'allow_http' does not exist"))
+		})
+
+		It("Returns an error when a name matches several security groups", func() {
+			_, err := virtualGuestService.FindSecurityGroups([]string{"duplicated"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Security group name 'duplicated' is ambiguous"))
+		})
+
+		It("Returns an error when listing the security groups fails", func() {
+			cli.GetSecurityGroupsReturns([]datatypes.Network_SecurityGroup{}, errors.New("fake-client-error"))
+
+			_, err := virtualGuestService.FindSecurityGroups([]string{"allow_ssh"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
+		})
+	})
+
+	Describe("Call ConfigureSecurityGroups", func() {
+		var (
+			vmID   int
+			groups SecurityGroups
+		)
+
+		BeforeEach(func() {
+			vmID = 12345678
+			groups = SecurityGroups{Public: []int{21345}, Private: []int{21346}}
+
+			cli.GetInstanceReturns(
+				&datatypes.Virtual_Guest{
+					Id: sl.Int(vmID),
+					PrimaryNetworkComponent: &datatypes.Virtual_Guest_Network_Component{
+						Id: sl.Int(1001),
+						SecurityGroupBindings: []datatypes.Virtual_Network_SecurityGroup_NetworkComponentBinding{
+							{SecurityGroupId: sl.Int(21345)},
+							{SecurityGroupId: sl.Int(21349)},
+						},
+					},
+					PrimaryBackendNetworkComponent: &datatypes.Virtual_Guest_Network_Component{
+						Id: sl.Int(1002),
+					},
+				},
+				true,
+				nil,
+			)
+		})
+
+		It("Attaches the missing security groups only", func() {
+			err := virtualGuestService.ConfigureSecurityGroups(vmID, groups, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.AttachSecurityGroupComponentsCallCount()).To(Equal(1))
+			groupID, componentIDs := cli.AttachSecurityGroupComponentsArgsForCall(0)
+			Expect(groupID).To(Equal(21346))
+			Expect(componentIDs).To(Equal([]int{1002}))
+			Expect(cli.DetachSecurityGroupComponentsCallCount()).To(Equal(0))
+		})
+
+		It("Detaches the other security groups with detachOthers", func() {
+			err := virtualGuestService.ConfigureSecurityGroups(vmID, groups, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cli.AttachSecurityGroupComponentsCallCount()).To(Equal(1))
+			Expect(cli.DetachSecurityGroupComponentsCallCount()).To(Equal(1))
+			groupID, componentIDs := cli.DetachSecurityGroupComponentsArgsForCall(0)
+			Expect(groupID).To(Equal(21349))
+			Expect(componentIDs).To(Equal([]int{1001}))
+		})
+
+		It("Returns an error when public security groups are set for a VM without public network", func() {
+			cli.GetInstanceReturns(
+				&datatypes.Virtual_Guest{
+					Id:                             sl.Int(vmID),
+					PrimaryBackendNetworkComponent: &datatypes.Virtual_Guest_Network_Component{Id: sl.Int(1002)},
+				},
+				true,
+				nil,
+			)
+
+			err := virtualGuestService.ConfigureSecurityGroups(vmID, groups, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("require a public network"))
+		})
+
+		It("Returns a VMNotFoundError when the VM does not exist", func() {
+			cli.GetInstanceReturns(&datatypes.Virtual_Guest{}, false, nil)
+
+			err := virtualGuestService.ConfigureSecurityGroups(vmID, groups, false)
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(api.VMNotFoundError)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("Returns an error when attaching a security group fails", func() {
+			cli.AttachSecurityGroupComponentsReturns(errors.New("fake-client-error"))
+
+			err := virtualGuestService.ConfigureSecurityGroups(vmID, groups, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Attaching security group '21346' to network component '1002'"))
+		})
+	})
+})
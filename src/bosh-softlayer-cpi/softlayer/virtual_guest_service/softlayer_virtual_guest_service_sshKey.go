@@ -7,18 +7,24 @@ import (
 	"github.com/softlayer/softlayer-go/sl"
 )
 
-func (vg SoftlayerVirtualGuestService) CreateSshKey(label string, key string, fingerPrint string) (int, error) {
+// CreateSshKey returns the ID of the SSH key with key, and if it was created by this call. An existing
+// key of the account is returned as is, it may be used by other VMs.
+func (vg SoftlayerVirtualGuestService) CreateSshKey(label string, key string, fingerPrint string) (int, bool, error) {
 	vg.logger.Debug(softlayerVirtualGuestServiceLogTag, "Creating Ssh Public Key with label prefix '%s' ", label)
 	uuidStr, err := vg.uuidGen.Generate()
 	if err != nil {
-		return 0, bosherr.WrapErrorf(err, "Generating random SoftLayer ssh public key label")
+		return 0, false, bosherr.WrapErrorf(err, "Generating random SoftLayer ssh public key label")
 	}
-	sshKey, err := vg.softlayerClient.CreateSshKey(sl.String(fmt.Sprintf("%s_%s", label, uuidStr)), sl.String(key), sl.String(fingerPrint))
+	uniqueLabel := fmt.Sprintf("%s_%s", label, uuidStr)
+	sshKey, err := vg.softlayerClient.CreateSshKey(sl.String(uniqueLabel), sl.String(key), sl.String(fingerPrint))
 	if err != nil {
-		return 0, bosherr.WrapErrorf(err, "Creating Ssh Public Key with label '%s', key '%s'", label, key)
+		return 0, false, bosherr.WrapErrorf(err, "Creating Ssh Public Key with label '%s', key '%s'", label, key)
 	}
 
-	return *sshKey.Id, nil
+	// Only the key created by this call has its unique label
+	created := sl.Get(sshKey.Label, "").(string) == uniqueLabel
+
+	return *sshKey.Id, created, nil
 }
 
 func (vg SoftlayerVirtualGuestService) DeleteSshKey(id int) error {
@@ -43,18 +43,39 @@ var _ = Describe("Virtual Guest Service", func() {
 			sshKey = "cdj33ndDf&4"
 			fingerPrint = "6530389635564f6464e8e3a47d593e19"
 
+			uuidGen.GeneratedUUID = "fake-uuid"
 			cli.CreateSshKeyReturns(
 				&datatypes.Security_Ssh_Key{
-					Id: sl.Int(32345678),
+					Id:    sl.Int(32345678),
+					Label: sl.String("fake-sshkey_fake-uuid"),
 				},
 				nil,
 			)
 		})
 
 		It("Configure networks successfully", func() {
-			_, err := virtualGuestService.CreateSshKey(label, sshKey, fingerPrint)
+			id, created, err := virtualGuestService.CreateSshKey(label, sshKey, fingerPrint)
 			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(32345678))
+			Expect(created).To(BeTrue())
 			Expect(cli.CreateSshKeyCallCount()).To(Equal(1))
+			actualLabel, _, _ := cli.CreateSshKeyArgsForCall(0)
+			Expect(*actualLabel).To(Equal("fake-sshkey_fake-uuid"))
+		})
+
+		It("returns an existing key of the account as not created", func() {
+			cli.CreateSshKeyReturns(
+				&datatypes.Security_Ssh_Key{
+					Id:    sl.Int(32345679),
+					Label: sl.String("fake-sshkey_other-uuid"),
+				},
+				nil,
+			)
+
+			id, created, err := virtualGuestService.CreateSshKey(label, sshKey, fingerPrint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(32345679))
+			Expect(created).To(BeFalse())
 		})
 
 		It("Return error if softLayerClient ConfigureNetworks call returns an error", func() {
@@ -63,7 +84,7 @@ var _ = Describe("Virtual Guest Service", func() {
 				errors.New("fake-client-error"),
 			)
 
-			_, err := virtualGuestService.CreateSshKey(label, sshKey, fingerPrint)
+			_, _, err := virtualGuestService.CreateSshKey(label, sshKey, fingerPrint)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-client-error"))
 			Expect(cli.CreateSshKeyCallCount()).To(Equal(1))
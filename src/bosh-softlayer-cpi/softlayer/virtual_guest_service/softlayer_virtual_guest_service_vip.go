@@ -0,0 +1,87 @@
+package instance
+
+import (
+	"fmt"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/softlayer/softlayer-go/datatypes"
+)
+
+// globalIpNoteFormat is the note of the address of a global IP routed to a VM by the CPI, so
+// deleting the VM releases only the global IPs it routed.
+const globalIpNoteFormat = "bosh-softlayer-cpi: routed to VM %d"
+
+// routeVip routes the global IP of a vip network to the primary public IP of the instance with id. Portable
+// public IPs need no route, they only have to belong to the public VLAN of the instance.
+func (vg SoftlayerVirtualGuestService) routeVip(id int, instance datatypes.Virtual_Guest, name string, network Network) error {
+	publicComponent := instance.PrimaryNetworkComponent
+	if publicComponent == nil || publicComponent.PrimaryIpAddress == nil || publicComponent.NetworkVlan == nil {
+		return bosherr.Errorf("VIP '%s' requires a public network", network.IP)
+	}
+	destination := *publicComponent.PrimaryIpAddress
+
+	globalIp, found, err := vg.softlayerClient.GetGlobalIp(network.IP)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Finding global IP '%s'", network.IP)
+	}
+
+	if found {
+		if globalIp.DestinationIpAddress != nil && globalIp.DestinationIpAddress.IpAddress != nil && *globalIp.DestinationIpAddress.IpAddress == destination {
+			vg.logger.Debug(softlayerVirtualGuestServiceLogTag, "Global IP '%s' of network '%s' is routed to '%s' already", network.IP, name, destination)
+		} else {
+			vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Routing global IP '%s' of network '%s' to '%s'", network.IP, name, destination)
+			if err = vg.softlayerClient.RouteGlobalIp(*globalIp.Id, destination); err != nil {
+				return bosherr.WrapErrorf(err, "Routing global IP '%s' to '%s'", network.IP, destination)
+			}
+		}
+
+		note := fmt.Sprintf(globalIpNoteFormat, id)
+		if globalIp.IpAddressId != nil && (globalIp.IpAddress == nil || globalIp.IpAddress.Note == nil || *globalIp.IpAddress.Note != note) {
+			if err = vg.softlayerClient.SetIpAddressNote(*globalIp.IpAddressId, note); err != nil {
+				return bosherr.WrapErrorf(err, "Recording the route of global IP '%s'", network.IP)
+			}
+		}
+		return nil
+	}
+
+	subnet, found, err := vg.softlayerClient.GetSubnetByIpAddress(network.IP, "id,networkVlanId,addressSpace")
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Finding subnet of IP '%s'", network.IP)
+	}
+
+	if !found || subnet.AddressSpace == nil || *subnet.AddressSpace != "PUBLIC" ||
+		subnet.NetworkVlanId == nil || publicComponent.NetworkVlan.Id == nil || *subnet.NetworkVlanId != *publicComponent.NetworkVlan.Id {
+		return bosherr.Errorf("VIP '%s' is neither a global IP nor a portable public IP of the public VLAN of the VM", network.IP)
+	}
+
+	return nil
+}
+
+// releaseGlobalIps unroutes the global IPs routed to the VM with id by the CPI, they stay on the
+// account for the next VM. Global IPs routed to ip by others are left alone.
+func (vg SoftlayerVirtualGuestService) releaseGlobalIps(id int, ip string) error {
+	globalIps, err := vg.softlayerClient.GetGlobalIpsByDestination(ip)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Finding global IPs routed to '%s'", ip)
+	}
+
+	note := fmt.Sprintf(globalIpNoteFormat, id)
+	for _, globalIp := range globalIps {
+		if globalIp.IpAddress == nil || globalIp.IpAddress.Note == nil || *globalIp.IpAddress.Note != note {
+			continue
+		}
+
+		vg.logger.Info(softlayerVirtualGuestServiceLogTag, "Unrouting global IP '%s' from '%s'", *globalIp.IpAddress.IpAddress, ip)
+		if err = vg.softlayerClient.UnrouteGlobalIp(*globalIp.Id); err != nil {
+			return bosherr.WrapErrorf(err, "Unrouting global IP '%s'", *globalIp.IpAddress.IpAddress)
+		}
+
+		if globalIp.IpAddressId != nil {
+			if err = vg.softlayerClient.SetIpAddressNote(*globalIp.IpAddressId, ""); err != nil {
+				return bosherr.WrapErrorf(err, "Removing the route record of global IP '%s'", *globalIp.IpAddress.IpAddress)
+			}
+		}
+	}
+
+	return nil
+}